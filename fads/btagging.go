@@ -6,9 +6,7 @@ package fads
 
 import (
 	"math"
-	"math/rand/v2"
 	"reflect"
-	"sync"
 
 	"go-hep.org/x/hep/fmom"
 	"go-hep.org/x/hep/fwk"
@@ -49,11 +47,7 @@ type BTagging struct {
 	btag btagclassifier
 	eff  map[int]func(pt, eta float64) float64
 
-	seed uint64
-	src  *rand.Rand
-
-	flat   distuv.Uniform
-	flatmu sync.Mutex
+	rnd randgen
 }
 
 func (tsk *BTagging) Configure(ctx fwk.Context) error {
@@ -74,15 +68,11 @@ func (tsk *BTagging) Configure(ctx fwk.Context) error {
 		return err
 	}
 
-	tsk.src = rand.New(rand.NewPCG(tsk.seed, tsk.seed))
-	tsk.flat = distuv.Uniform{Min: 0, Max: 1, Src: tsk.src}
 	return err
 }
 
 func (tsk *BTagging) StartTask(ctx fwk.Context) error {
-	var err error
-
-	return err
+	return tsk.rnd.start(ctx)
 }
 
 func (tsk *BTagging) StopTask(ctx fwk.Context) error {
@@ -96,6 +86,11 @@ func (tsk *BTagging) Process(ctx fwk.Context) error {
 
 	store := ctx.Store()
 	msg := ctx.Msg()
+	src, err := tsk.rnd.rand(ctx, tsk.Name())
+	if err != nil {
+		return err
+	}
+	flat := distuv.Uniform{Min: 0, Max: 1, Src: src}
 
 	v, err := store.Get(tsk.partons)
 	if err != nil {
@@ -163,11 +158,9 @@ func (tsk *BTagging) Process(ctx fwk.Context) error {
 
 		// apply efficiency
 		tag := uint32(0)
-		tsk.flatmu.Lock()
-		if tsk.flat.Rand() <= eff(pt, eta) {
+		if flat.Rand() <= eff(pt, eta) {
 			tag = 1
 		}
-		tsk.flatmu.Unlock()
 		jet.BTag |= tag << tsk.bit
 
 		output = append(output, *jet)
@@ -196,7 +189,7 @@ func newBTagging(typ, name string, mgr fwk.App) (fwk.Component, error) {
 			0: func(pt, eta float64) float64 { return 0 },
 		},
 
-		seed: 1234,
+		rnd: randgen{seed: 1234},
 	}
 
 	err = tsk.DeclProp("Partons", &tsk.partons)
@@ -239,7 +232,12 @@ func newBTagging(typ, name string, mgr fwk.App) (fwk.Component, error) {
 		return nil, err
 	}
 
-	err = tsk.DeclProp("Seed", &tsk.seed)
+	err = tsk.DeclProp("Seed", &tsk.rnd.seed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("RandSvc", &tsk.rnd.name)
 	if err != nil {
 		return nil, err
 	}
@@ -10,7 +10,6 @@ import (
 	"math/rand/v2"
 	"reflect"
 	"sort"
-	"sync"
 
 	"go-hep.org/x/hep/fwk"
 	"gonum.org/v1/gonum/stat/distuv"
@@ -166,11 +165,7 @@ type Calorimeter struct {
 	eflowtracks string
 	eflowtowers string
 
-	seed uint64
-	src  *rand.Rand
-
-	gauss distuv.Normal
-	dmu   sync.Mutex
+	rnd randgen
 }
 
 func (tsk *Calorimeter) Configure(ctx fwk.Context) error {
@@ -206,15 +201,11 @@ func (tsk *Calorimeter) Configure(ctx fwk.Context) error {
 		return err
 	}
 
-	tsk.src = rand.New(rand.NewPCG(tsk.seed, tsk.seed))
-	tsk.gauss = distuv.Normal{Mu: 0, Sigma: 1, Src: tsk.src}
 	return err
 }
 
 func (tsk *Calorimeter) StartTask(ctx fwk.Context) error {
-	var err error
-
-	return err
+	return tsk.rnd.start(ctx)
 }
 
 func (tsk *Calorimeter) StopTask(ctx fwk.Context) error {
@@ -228,6 +219,10 @@ func (tsk *Calorimeter) Process(ctx fwk.Context) error {
 
 	store := ctx.Store()
 	msg := ctx.Msg()
+	src, err := tsk.rnd.rand(ctx, tsk.Name())
+	if err != nil {
+		return err
+	}
 
 	v, err := store.Get(tsk.particles)
 	if err != nil {
@@ -396,7 +391,7 @@ func (tsk *Calorimeter) Process(ctx fwk.Context) error {
 
 		var (
 			calotrk = caloTrack{}
-			tower   Candidate
+			tower   = Candidate{ID: newCandidateID()}
 			//twrtrks = make([]Candidate, 0, len(tracks)) // FIXME(sbinet)
 		)
 
@@ -435,14 +430,14 @@ func (tsk *Calorimeter) Process(ctx fwk.Context) error {
 		}
 
 		ecalSigma := tsk.ecalres(calotower.Eta, calotower.ECal.Ene)
-		ecalEne := tsk.lognormal(calotower.ECal.Ene, ecalSigma)
+		ecalEne := tsk.lognormal(src, calotower.ECal.Ene, ecalSigma)
 		ecalTime := 0.0
 		if calotower.ECal.WeightTime >= 1e-9 {
 			ecalTime = calotower.ECal.Time / calotower.ECal.WeightTime
 		}
 
 		hcalSigma := tsk.hcalres(calotower.Eta, calotower.HCal.Ene)
-		hcalEne := tsk.lognormal(calotower.HCal.Ene, hcalSigma)
+		hcalEne := tsk.lognormal(src, calotower.HCal.Ene, hcalSigma)
 		hcalTime := 0.0
 		if calotower.HCal.WeightTime >= 1e-9 {
 			hcalTime = calotower.HCal.Time / calotower.HCal.WeightTime
@@ -453,8 +448,8 @@ func (tsk *Calorimeter) Process(ctx fwk.Context) error {
 		hsqrt := math.Sqrt(hcalEne)
 		time := (esqrt*ecalTime + hsqrt*hcalTime) / (esqrt + hsqrt)
 
-		eta = rand.Float64()*(calotower.Edges[1]-calotower.Edges[0]) + calotower.Edges[0]
-		phi = rand.Float64()*(calotower.Edges[3]-calotower.Edges[2]) + calotower.Edges[2]
+		eta = src.Float64()*(calotower.Edges[1]-calotower.Edges[0]) + calotower.Edges[0]
+		phi = src.Float64()*(calotower.Edges[3]-calotower.Edges[2]) + calotower.Edges[2]
 
 		pt := ene / math.Cosh(eta)
 
@@ -476,7 +471,7 @@ func (tsk *Calorimeter) Process(ctx fwk.Context) error {
 	return err
 }
 
-func (tsk *Calorimeter) lognormal(mean, sigma float64) float64 {
+func (tsk *Calorimeter) lognormal(src *rand.Rand, mean, sigma float64) float64 {
 	if mean <= 0 {
 		return 0
 	}
@@ -484,9 +479,8 @@ func (tsk *Calorimeter) lognormal(mean, sigma float64) float64 {
 	b := math.Sqrt(math.Log(1 + (sigma*sigma)/(mean*mean)))
 	a := math.Log(mean) - 0.5*b*b
 
-	tsk.dmu.Lock()
-	bgauss := tsk.gauss.Rand()
-	tsk.dmu.Unlock()
+	gauss := distuv.Normal{Mu: 0, Sigma: 1, Src: src}
+	bgauss := gauss.Rand()
 	return math.Exp(a + bgauss)
 }
 
@@ -508,7 +502,7 @@ func newCalorimeter(typ, name string, mgr fwk.App) (fwk.Component, error) {
 		eflowtracks: "/fads/eflowtracks",
 		eflowtowers: "/fads/eflowtowers",
 
-		seed: 1234,
+		rnd: randgen{seed: 1234},
 	}
 
 	// --
@@ -565,7 +559,12 @@ func newCalorimeter(typ, name string, mgr fwk.App) (fwk.Component, error) {
 		return nil, err
 	}
 
-	err = tsk.DeclProp("Seed", &tsk.seed)
+	err = tsk.DeclProp("Seed", &tsk.rnd.seed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("RandSvc", &tsk.rnd.name)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,681 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fwk"
+)
+
+// Card is a detector card, describing a chain of fads modules and their
+// parameters.
+//
+// Cards are written in the subset of Tcl used by Delphes data-cards:
+//
+//	set ExecutionPath {
+//	  ParticlePropagator
+//	  ChargedHadronTrackingEfficiency
+//	}
+//
+//	module ParticlePropagator ParticlePropagator {
+//	  set InputArray Delphes/stableParticles
+//	  set OutputArray stableParticles
+//	  set Radius 1.15
+//	}
+//
+//	module Efficiency ChargedHadronTrackingEfficiency {
+//	  set InputArray ParticlePropagator/chargedHadrons
+//	  set OutputArray chargedHadrons
+//	  set EfficiencyFormula {(pt > 1.0) * (abs(eta) <= 2.5) * 0.95}
+//	}
+//
+// The supported commands are set, add, module, source, expr, incr, list,
+// for and foreach.
+//
+// The modules of the ExecutionPath are created in order, with the fads
+// task corresponding to their Delphes module type.
+// The array "Module/name" is stored under "/fads/Module/name" in the event
+// store, and the Delphes/allParticles, Delphes/stableParticles and
+// Delphes/partons arrays are the outputs of the HepMcReader.
+// Formulas are evaluated with the pt, eta and energy variables and the
+// usual math functions (abs, sqrt, pow, exp, log, tanh, ...).
+//
+// The PileUpFile of PileUpMerger modules is a HepMC file of minimum-bias
+// events.
+//
+// The EnergyFlow module type is a fads extension: it builds the eflow tracks,
+// photons and neutral hadrons from the tracks and the towers of a Calorimeter,
+// and accepts the energy-flow parameters of the Delphes Calorimeter.
+//
+// Modules modifying their input array in place in Delphes (BTagging and
+// TauTagging) store a new array "/fads/Module/jets" which replaces their
+// input array for the subsequent modules.
+//
+// The branches of TreeWriter modules are not created as tasks: they are
+// made available by Card.Branches to configure the output of the application.
+type Card struct {
+	path []string
+	mods map[string]*cardModule
+}
+
+// CardBranch describes an output branch of a card TreeWriter module.
+type CardBranch struct {
+	Input string // event store key of the stored collection
+	Name  string // name of the branch
+	Class string // Delphes class of the branch elements
+}
+
+// Port returns the port of the collection stored by the branch.
+// As in Delphes, MissingET and ScalarHT branches store the momentum and
+// energy Candidate of a Merger, the other branches store a []Candidate.
+func (br CardBranch) Port() fwk.Port {
+	typ := reflect.TypeOf([]Candidate{})
+	switch br.Class {
+	case "MissingET", "ScalarHT":
+		typ = reflect.TypeOf(Candidate{})
+	}
+	return fwk.Port{Name: br.Input, Type: typ}
+}
+
+type cardModule struct {
+	typ    string
+	name   string
+	params map[string]string
+	vars   map[string]bool // parameters read as Tcl variables, e.g. loop indices
+}
+
+// ReadCard reads the named detector card.
+func ReadCard(fname string) (*Card, error) {
+	card := &Card{mods: make(map[string]*cardModule)}
+	tcl := newTclInterp(card)
+	err := tcl.source(fname)
+	if err != nil {
+		return nil, err
+	}
+
+	path, ok := tcl.vars["ExecutionPath"]
+	if !ok {
+		return nil, fmt.Errorf("fads: card %q has no ExecutionPath", fname)
+	}
+	card.path = tclSplitList(path)
+	for _, name := range card.path {
+		if _, ok := card.mods[name]; !ok {
+			return nil, fmt.Errorf("fads: card %q has no module %q", fname, name)
+		}
+	}
+
+	return card, nil
+}
+
+// Modules returns the names of the modules of the card execution path.
+func (card *Card) Modules() []string {
+	return append([]string(nil), card.path...)
+}
+
+// Branches returns the output branches of the TreeWriter modules of
+// the card execution path.
+func (card *Card) Branches() []CardBranch {
+	var (
+		branches []CardBranch
+		aliases  = make(map[string]string)
+	)
+	for _, name := range card.path {
+		mod := card.mods[name]
+		switch mod.typ {
+		case "BTagging", "TauTagging":
+			p := cardParams{mod: mod, aliases: aliases}
+			aliases[p.str("JetInputArray", "FastJetFinder/jets")] = p.output("", "jets")
+		case "TreeWriter":
+			elems := tclSplitList(mod.params["Branch"])
+			for i := 0; i+2 < len(elems); i += 3 {
+				p := cardParams{mod: mod, aliases: aliases}
+				branches = append(branches, CardBranch{
+					Input: p.array(elems[i]),
+					Name:  elems[i+1],
+					Class: elems[i+2],
+				})
+			}
+		}
+	}
+	return branches
+}
+
+// Apply creates and configures the fads tasks of the card execution path.
+// Parameters of the card which are not supported by the fads tasks are
+// reported through the message stream of the application.
+func (card *Card) Apply(app fwk.App) error {
+	aliases := make(map[string]string)
+	for _, name := range card.path {
+		mod := card.mods[name]
+		if mod.typ == "TreeWriter" {
+			continue
+		}
+
+		conv, ok := cardModules[mod.typ]
+		if !ok {
+			return fmt.Errorf("fads: module %q has unsupported type %q", mod.name, mod.typ)
+		}
+
+		p := &cardParams{
+			mod:     mod,
+			used:    make(map[string]bool),
+			aliases: aliases,
+		}
+		typ, props := conv(p)
+		if p.err != nil {
+			return p.err
+		}
+
+		c, err := app.New("go-hep.org/x/hep/fads."+typ, mod.name)
+		if err != nil {
+			return fmt.Errorf("fads: could not create module %q: %w", mod.name, err)
+		}
+
+		keys := make([]string, 0, len(props))
+		for k := range props {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			err = app.SetProp(c, k, props[k])
+			if err != nil {
+				return fmt.Errorf("fads: could not configure module %q: %w", mod.name, err)
+			}
+		}
+
+		for _, k := range p.unused() {
+			app.Msg().Warnf("card module %q: parameter %q is not supported\n", mod.name, k)
+		}
+	}
+	return nil
+}
+
+// cardModules converts the parameters of a Delphes module into the type
+// and properties of the corresponding fads task.
+var cardModules = map[string]func(p *cardParams) (string, map[string]any){
+	"PileUpMerger": func(p *cardParams) (string, map[string]any) {
+		if v := p.int("PileUpDistribution", 0); v != 0 {
+			p.errorf("PileUpDistribution", "unsupported distribution %d (only Poisson (0) is supported)", v)
+		}
+		return "PileUpMerger", map[string]any{
+			"Input":         p.input("InputArray", "Delphes/stableParticles"),
+			"Output":        p.output("OutputArray", "stableParticles"),
+			"PileUpFile":    p.str("PileUpFile", "MinBias.hepmc"),
+			"MeanPileUp":    p.float("MeanPileUp", 10),
+			"ZVertexSpread": p.float("ZVertexSpread", 0.053),
+			"TVertexSpread": p.float("TVertexSpread", 1.5e-9),
+		}
+	},
+
+	"ParticlePropagator": func(p *cardParams) (string, map[string]any) {
+		return "Propagator", map[string]any{
+			"Input":          p.input("InputArray", "Delphes/stableParticles"),
+			"Output":         p.output("OutputArray", "stableParticles"),
+			"ChargedHadrons": p.output("ChargedHadronOutputArray", "chargedHadrons"),
+			"Electrons":      p.output("ElectronOutputArray", "electrons"),
+			"Muons":          p.output("MuonOutputArray", "muons"),
+			"Radius":         p.float("Radius", 1.0),
+			"HalfLength":     p.float("HalfLength", 3.0),
+			"Bz":             p.float("Bz", 0.0),
+		}
+	},
+
+	"Efficiency": func(p *cardParams) (string, map[string]any) {
+		eff := p.formula("EfficiencyFormula", "1.0", "pt", "eta")
+		return "Efficiency", map[string]any{
+			"Input":  p.input("InputArray", "ParticlePropagator/stableParticles"),
+			"Output": p.output("OutputArray", "stableParticles"),
+			"Eff":    (func(pt, eta float64) float64)(eff),
+		}
+	},
+
+	"MomentumSmearing": func(p *cardParams) (string, map[string]any) {
+		res := p.formula("ResolutionFormula", "0.0", "pt", "eta")
+		return "MomentumSmearing", map[string]any{
+			"Input":      p.input("InputArray", "ParticlePropagator/stableParticles"),
+			"Output":     p.output("OutputArray", "stableParticles"),
+			"Resolution": (func(pt, eta float64) float64)(res),
+		}
+	},
+
+	"EnergySmearing": func(p *cardParams) (string, map[string]any) {
+		res := p.formula("ResolutionFormula", "0.0", "eta", "energy")
+		return "EnergySmearing", map[string]any{
+			"Input":      p.input("InputArray", "ParticlePropagator/stableParticles"),
+			"Output":     p.output("OutputArray", "stableParticles"),
+			"Resolution": (func(eta, ene float64) float64)(res),
+		}
+	},
+
+	"EnergyScale": func(p *cardParams) (string, map[string]any) {
+		scale := p.formula("ScaleFormula", "1.0", "pt", "eta")
+		return "EnergyScale", map[string]any{
+			"Input":  p.input("InputArray", "FastJetFinder/jets"),
+			"Output": p.output("OutputArray", "jets"),
+			"Scale":  (func(pt, eta float64) float64)(scale),
+		}
+	},
+
+	"Merger": func(p *cardParams) (string, map[string]any) {
+		return "Merger", map[string]any{
+			"Inputs":         p.inputs("InputArray"),
+			"Output":         p.output("OutputArray", "candidates"),
+			"MomentumOutput": p.output("MomentumOutputArray", "momentum"),
+			"EnergyOutput":   p.output("EnergyOutputArray", "energy"),
+		}
+	},
+
+	"Calorimeter": func(p *cardParams) (string, map[string]any) {
+		ecal := p.formula("ECalResolutionFormula", "0.0", "eta", "energy")
+		hcal := p.formula("HCalResolutionFormula", "0.0", "eta", "energy")
+		return "Calorimeter", map[string]any{
+			"Particles":      p.input("ParticleInputArray", "ParticlePropagator/stableParticles"),
+			"Tracks":         p.input("TrackInputArray", "ParticlePropagator/tracks"),
+			"Towers":         p.output("TowerOutputArray", "towers"),
+			"Photons":        p.output("PhotonOutputArray", "photons"),
+			"EFlowTracks":    p.output("EFlowTrackOutputArray", "eflowTracks"),
+			"EFlowTowers":    p.output("EFlowTowerOutputArray", "eflowTowers"),
+			"EtaPhiBins":     p.etaPhiGrid("EtaPhiBins"),
+			"EnergyFraction": p.energyFractions("EnergyFraction"),
+			"ECalResolution": (func(eta, ene float64) float64)(ecal),
+			"HCalResolution": (func(eta, ene float64) float64)(hcal),
+		}
+	},
+
+	"EnergyFlow": func(p *cardParams) (string, map[string]any) {
+		ecal := p.formula("ECalResolutionFormula", "0.0", "eta", "energy")
+		hcal := p.formula("HCalResolutionFormula", "0.0", "eta", "energy")
+		return "EnergyFlow", map[string]any{
+			"Tracks":                    p.input("TrackInputArray", "ParticlePropagator/tracks"),
+			"Towers":                    p.input("TowerInputArray", "Calorimeter/towers"),
+			"EFlowTracks":               p.output("EFlowTrackOutputArray", "eflowTracks"),
+			"EFlowPhotons":              p.output("EFlowPhotonOutputArray", "eflowPhotons"),
+			"EFlowNeutralHadrons":       p.output("EFlowNeutralHadronOutputArray", "eflowNeutralHadrons"),
+			"EnergyFraction":            p.energyFractions("EnergyFraction"),
+			"ECalResolution":            (func(eta, ene float64) float64)(ecal),
+			"HCalResolution":            (func(eta, ene float64) float64)(hcal),
+			"ECalEnergyMin":             p.float("ECalEnergyMin", 0),
+			"HCalEnergyMin":             p.float("HCalEnergyMin", 0),
+			"ECalEnergySignificanceMin": p.float("ECalEnergySignificanceMin", 0),
+			"HCalEnergySignificanceMin": p.float("HCalEnergySignificanceMin", 0),
+		}
+	},
+
+	"Isolation": func(p *cardParams) (string, map[string]any) {
+		props := map[string]any{
+			"Candidates": p.input("CandidateInputArray", "Calorimeter/electrons"),
+			"Isolations": p.input("IsolationInputArray", "Delphes/partons"),
+			"Output":     p.output("OutputArray", "electrons"),
+			"DeltaRMax":  p.float("DeltaRMax", 0.5),
+			"PtMin":      p.float("PTMin", 0.5),
+			"PtRatioMax": p.float("PTRatioMax", 0.1),
+			"PtSumMax":   p.float("PTSumMax", 5.0),
+			"UsePtSum":   p.bool("UsePTSum", false),
+		}
+		if _, ok := p.get("RhoInputArray"); ok {
+			props["Rhos"] = p.input("RhoInputArray", "")
+		}
+		return "Isolation", props
+	},
+
+	"FastJetFinder": func(p *cardParams) (string, map[string]any) {
+		return "FastJetFinder", map[string]any{
+			"Input":            p.input("InputArray", "Calorimeter/towers"),
+			"Output":           p.output("OutputArray", "jets"),
+			"Rho":              p.output("RhoOutputArray", "rho"),
+			"JetAlgorithm":     p.jetAlgorithm("JetAlgorithm", 6),
+			"ParameterR":       p.float("ParameterR", 0.5),
+			"JetPtMin":         p.float("JetPTMin", 10.0),
+			"ConeRadius":       p.float("ConeRadius", 0.5),
+			"SeedThreshold":    p.float("SeedThreshold", 1.0),
+			"ConeAreaFraction": p.float("ConeAreaFraction", 1.0),
+			"MaxIterations":    p.int("MaxIterations", 100),
+			"MaxPairSize":      p.int("MaxPairSize", 2),
+			"Iratch":           p.int("Iratch", 1),
+			"AdjacencyCut":     p.int("AdjacencyCut", 2),
+			"OverlapThreshold": p.float("OverlapThreshold", 0.75),
+			"AreaAlgorithm":    p.int("AreaAlgorithm", 0),
+			"ComputeRho":       p.bool("ComputeRho", false),
+			"GhostEtaMax":      p.float("GhostEtaMax", 5.0),
+			"Repeat":           p.int("Repeat", 1),
+			"GhostArea":        p.float("GhostArea", 0.01),
+			"GridScatter":      p.float("GridScatter", 1.0),
+			"PtScatter":        p.float("PtScatter", 0.1),
+			"MeanGhostPt":      p.float("MeanGhostPt", 1e-100),
+			"EffectiveRfact":   p.float("EffectiveRfact", 1.0),
+			"RhoEtaRange":      p.floatMap("RhoEtaRange"),
+		}
+	},
+
+	"JetPileUpSubtractor": func(p *cardParams) (string, map[string]any) {
+		return "JetPileUpSubtractor", map[string]any{
+			"Jets":     p.input("JetInputArray", "FastJetFinder/jets"),
+			"Rhos":     p.input("RhoInputArray", "FastJetFinder/rho"),
+			"Output":   p.output("OutputArray", "jets"),
+			"JetPtMin": p.float("JetPTMin", 20.0),
+		}
+	},
+
+	"BTagging": func(p *cardParams) (string, map[string]any) {
+		jets := p.input("JetInputArray", "FastJetFinder/jets")
+		props := map[string]any{
+			"Partons":      p.input("PartonInputArray", "Delphes/partons"),
+			"Jets":         jets,
+			"Output":       p.inPlace("JetInputArray", "FastJetFinder/jets", "jets"),
+			"BitNumber":    uint(p.int("BitNumber", 0)),
+			"DeltaR":       p.float("DeltaR", 0.5),
+			"PartonPtMin":  p.float("PartonPTMin", 1.0),
+			"PartonEtaMax": p.float("PartonEtaMax", 2.5),
+			"Eff":          p.formulaMap("EfficiencyFormula", "pt", "eta"),
+		}
+		return "BTagging", props
+	},
+
+	"TauTagging": func(p *cardParams) (string, map[string]any) {
+		jets := p.input("JetInputArray", "FastJetFinder/jets")
+		return "TauTagging", map[string]any{
+			"Particles": p.input("ParticleInputArray", "Delphes/allParticles"),
+			"Partons":   p.input("PartonInputArray", "Delphes/partons"),
+			"Jets":      jets,
+			"Output":    p.inPlace("JetInputArray", "FastJetFinder/jets", "jets"),
+			"DeltaR":    p.float("DeltaR", 0.5),
+			"TauPtMin":  p.float("TauPTMin", 1.0),
+			"TauEtaMax": p.float("TauEtaMax", 2.5),
+			"Eff":       p.formulaMap("EfficiencyFormula", "pt", "eta"),
+		}
+	},
+
+	"UniqueObjectFinder": func(p *cardParams) (string, map[string]any) {
+		elems := p.list("InputArray")
+		if len(elems)%2 != 0 {
+			p.errorf("InputArray", "invalid number of elements (%d)", len(elems))
+		}
+		keys := make([]ObjPair, 0, len(elems)/2)
+		for i := 0; i+1 < len(elems); i += 2 {
+			keys = append(keys, ObjPair{
+				In:  p.array(elems[i]),
+				Out: p.array(p.mod.name + "/" + elems[i+1]),
+			})
+		}
+		return "UniqueObjectFinder", map[string]any{
+			"Keys": keys,
+		}
+	},
+}
+
+// cardParams gives typed access to the parameters of a card module.
+// The first conversion error is recorded in err.
+type cardParams struct {
+	mod     *cardModule
+	used    map[string]bool
+	aliases map[string]string // arrays replaced by modules modifying them in place
+	err     error
+}
+
+func (p *cardParams) errorf(name, format string, args ...any) {
+	if p.err != nil {
+		return
+	}
+	p.err = fmt.Errorf("fads: module %q: parameter %q: %s", p.mod.name, name, fmt.Sprintf(format, args...))
+}
+
+func (p *cardParams) get(name string) (string, bool) {
+	if p.used != nil {
+		p.used[name] = true
+	}
+	v, ok := p.mod.params[name]
+	return v, ok
+}
+
+// unused returns the sorted names of the parameters which were neither
+// converted nor read as Tcl variables by the card.
+func (p *cardParams) unused() []string {
+	var names []string
+	for k := range p.mod.params {
+		if !p.used[k] && !p.mod.vars[k] {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *cardParams) str(name, def string) string {
+	v, ok := p.get(name)
+	if !ok {
+		return def
+	}
+	return strings.TrimSpace(v)
+}
+
+func (p *cardParams) list(name string) []string {
+	v, _ := p.get(name)
+	return tclSplitList(v)
+}
+
+func (p *cardParams) float(name string, def float64) float64 {
+	v, ok := p.get(name)
+	if !ok {
+		return def
+	}
+	f, err := evalExpr(v)
+	if err != nil {
+		p.errorf(name, "%v", err)
+	}
+	return f
+}
+
+func (p *cardParams) int(name string, def int) int {
+	f := p.float(name, float64(def))
+	if f != math.Trunc(f) {
+		p.errorf(name, "expected integer, got %v", f)
+	}
+	return int(f)
+}
+
+func (p *cardParams) bool(name string, def bool) bool {
+	v, ok := p.get(name)
+	if !ok {
+		return def
+	}
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "1", "true", "yes", "on":
+		return true
+	case "0", "false", "no", "off":
+		return false
+	}
+	p.errorf(name, "expected boolean, got %q", v)
+	return def
+}
+
+func (p *cardParams) formula(name, def, x, y string) formula {
+	src, ok := p.get(name)
+	if !ok {
+		src = def
+	}
+	f, err := newFormula(src, x, y)
+	if err != nil {
+		p.errorf(name, "%v", err)
+		return func(x, y float64) float64 { return 0 }
+	}
+	return f
+}
+
+// formulaMap returns the formulas of a list of PDG code and formula pairs.
+func (p *cardParams) formulaMap(name, x, y string) map[int]func(x, y float64) float64 {
+	elems := p.list(name)
+	if len(elems)%2 != 0 {
+		p.errorf(name, "invalid number of elements (%d)", len(elems))
+	}
+	fcts := map[int]func(x, y float64) float64{
+		0: func(x, y float64) float64 { return 0 },
+	}
+	for i := 0; i+1 < len(elems); i += 2 {
+		pid, err := strconv.Atoi(strings.TrimSpace(elems[i]))
+		if err != nil {
+			p.errorf(name, "invalid PDG code %q", elems[i])
+			continue
+		}
+		f, err := newFormula(elems[i+1], x, y)
+		if err != nil {
+			p.errorf(name, "%v", err)
+			continue
+		}
+		fcts[pid] = f
+	}
+	return fcts
+}
+
+// floatMap returns the map of a list of key and value pairs.
+func (p *cardParams) floatMap(name string) map[float64]float64 {
+	elems := p.list(name)
+	if len(elems)%2 != 0 {
+		p.errorf(name, "invalid number of elements (%d)", len(elems))
+	}
+	m := make(map[float64]float64, len(elems)/2)
+	for i := 0; i+1 < len(elems); i += 2 {
+		k, err1 := evalExpr(elems[i])
+		v, err2 := evalExpr(elems[i+1])
+		if err1 != nil || err2 != nil {
+			p.errorf(name, "invalid pair {%s %s}", elems[i], elems[i+1])
+			continue
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// etaPhiGrid returns the calorimeter grid of a list of eta edge and
+// phi edges pairs.
+func (p *cardParams) etaPhiGrid(name string) EtaPhiGrid {
+	elems := p.list(name)
+	if len(elems)%2 != 0 {
+		p.errorf(name, "invalid number of elements (%d)", len(elems))
+	}
+	bins := make([]EtaPhiBin, 0, len(elems)/2)
+	for i := 0; i+1 < len(elems); i += 2 {
+		eta, err := evalExpr(elems[i])
+		if err != nil {
+			p.errorf(name, "invalid eta edge %q", elems[i])
+			continue
+		}
+		phis := tclSplitList(elems[i+1])
+		bin := EtaPhiBin{
+			EtaBins: []float64{eta},
+			PhiBins: make([]float64, 0, len(phis)),
+		}
+		for _, v := range phis {
+			phi, err := evalExpr(v)
+			if err != nil {
+				p.errorf(name, "invalid phi edge %q", v)
+				continue
+			}
+			bin.PhiBins = append(bin.PhiBins, phi)
+		}
+		bins = append(bins, bin)
+	}
+	return NewEtaPhiGrid(bins)
+}
+
+// energyFractions returns the calorimeter energy fractions of a list of
+// PDG code and {ECal HCal} fractions pairs.
+func (p *cardParams) energyFractions(name string) map[int]EneFrac {
+	elems := p.list(name)
+	if len(elems)%2 != 0 {
+		p.errorf(name, "invalid number of elements (%d)", len(elems))
+	}
+	fracs := map[int]EneFrac{
+		0: {ECal: 0, HCal: 1},
+	}
+	for i := 0; i+1 < len(elems); i += 2 {
+		pid, err := strconv.Atoi(strings.TrimSpace(elems[i]))
+		if err != nil {
+			p.errorf(name, "invalid PDG code %q", elems[i])
+			continue
+		}
+		vs := tclSplitList(elems[i+1])
+		if len(vs) != 2 {
+			p.errorf(name, "invalid energy fractions %q", elems[i+1])
+			continue
+		}
+		ecal, err1 := evalExpr(vs[0])
+		hcal, err2 := evalExpr(vs[1])
+		if err1 != nil || err2 != nil {
+			p.errorf(name, "invalid energy fractions %q", elems[i+1])
+			continue
+		}
+		fracs[pid] = EneFrac{ECal: ecal, HCal: hcal}
+	}
+	return fracs
+}
+
+// jetAlgorithm returns the jet algorithm with the Delphes numbering scheme.
+func (p *cardParams) jetAlgorithm(name string, def int) fastjet.JetAlgorithm {
+	switch v := p.int(name, def); v {
+	case 4:
+		return fastjet.KtAlgorithm
+	case 5:
+		return fastjet.CambridgeAachenAlgorithm
+	case 6:
+		return fastjet.AntiKtAlgorithm
+	default:
+		p.errorf(name, "unsupported jet algorithm %d", v)
+		return fastjet.UndefinedJetAlgorithm
+	}
+}
+
+// array returns the event store key of the Delphes array "Module/name".
+func (p *cardParams) array(v string) string {
+	v = strings.TrimSpace(v)
+	if alias, ok := p.aliases[v]; ok {
+		return alias
+	}
+	switch v {
+	case "Delphes/allParticles":
+		return "/fads/AllParticles"
+	case "Delphes/stableParticles":
+		return "/fads/StableParticles"
+	case "Delphes/partons":
+		return "/fads/Partons"
+	}
+	return "/fads/" + v
+}
+
+func (p *cardParams) input(name, def string) string {
+	return p.array(p.str(name, def))
+}
+
+func (p *cardParams) inputs(name string) []string {
+	elems := p.list(name)
+	keys := make([]string, len(elems))
+	for i, v := range elems {
+		keys[i] = p.array(v)
+	}
+	return keys
+}
+
+func (p *cardParams) output(name, def string) string {
+	v := def
+	if name != "" {
+		v = p.str(name, def)
+	}
+	return p.array(p.mod.name + "/" + v)
+}
+
+// inPlace returns the output array of a module modifying the named input
+// array in place, and replaces that input array for the subsequent modules.
+func (p *cardParams) inPlace(name, def, out string) string {
+	in := p.str(name, def)
+	key := p.output("", out)
+	p.aliases[in] = key
+	return key
+}
@@ -0,0 +1,266 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+	"go-hep.org/x/hep/hepmc"
+)
+
+func TestCardATLAS(t *testing.T) {
+	const fname = "testdata/atlas.tcl"
+
+	card, err := ReadCard(fname)
+	if err != nil {
+		t.Fatalf("could not read card: %+v", err)
+	}
+
+	mods := card.Modules()
+	if got, want := len(mods), 26; got != want {
+		t.Fatalf("invalid number of modules: got=%d, want=%d", got, want)
+	}
+	if got, want := mods[0], "ParticlePropagator"; got != want {
+		t.Fatalf("invalid first module: got=%q, want=%q", got, want)
+	}
+	if got, want := mods[len(mods)-1], "TreeWriter"; got != want {
+		t.Fatalf("invalid last module: got=%q, want=%q", got, want)
+	}
+
+	want := []CardBranch{
+		{Input: "/fads/AllParticles", Name: "Particle", Class: "GenParticle"},
+		{Input: "/fads/GenJetFinder/jets", Name: "GenJet", Class: "Jet"},
+		{Input: "/fads/UniqueObjectFinder/jets", Name: "Jet", Class: "Jet"},
+		{Input: "/fads/UniqueObjectFinder/electrons", Name: "Electron", Class: "Electron"},
+		{Input: "/fads/UniqueObjectFinder/photons", Name: "Photon", Class: "Photon"},
+		{Input: "/fads/UniqueObjectFinder/muons", Name: "Muon", Class: "Muon"},
+		{Input: "/fads/MissingET/momentum", Name: "MissingET", Class: "MissingET"},
+		{Input: "/fads/ScalarHT/energy", Name: "ScalarHT", Class: "ScalarHT"},
+	}
+	if got := card.Branches(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid branches:\ngot= %+v\nwant=%+v", got, want)
+	}
+
+	// the events of testdata/hepmc.data are soft: no jet passes the
+	// 20 GeV threshold of the card. Lower it to also exercise the jets.
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(raw, []byte("set JetPTMin 20.0")) {
+		t.Fatalf("card has no JetPTMin parameter")
+	}
+	soft := filepath.Join(t.TempDir(), "atlas-soft.tcl")
+	err = os.WriteFile(soft, bytes.ReplaceAll(raw, []byte("set JetPTMin 20.0"), []byte("set JetPTMin 2.0")), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		card    string
+		ptmin   float64
+		genjets bool
+	}{
+		{name: "nominal", card: fname, ptmin: 20, genjets: false},
+		{name: "soft", card: soft, ptmin: 2, genjets: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			card, err := ReadCard(tc.card)
+			if err != nil {
+				t.Fatalf("could not read card: %+v", err)
+			}
+			testCard(t, card, tc.ptmin, tc.genjets)
+		})
+	}
+}
+
+// testCard runs card on testdata/hepmc.data and checks the collections
+// of its branches.
+// genjets reports whether generator-level jets are expected above ptmin.
+func testCard(t *testing.T, card *Card, ptmin float64, genjets bool) {
+	t.Helper()
+
+	const input = "testdata/hepmc.data"
+
+	app := job.New(job.P{
+		"EvtMax":   int64(-1),
+		"NProcs":   0,
+		"MsgLevel": job.MsgLevel("ERROR"),
+	})
+
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk.InputStream",
+		Name: "hepmc-streamer",
+		Props: job.P{
+			"Ports": []fwk.Port{
+				{Name: "/fads/McEvent", Type: reflect.TypeOf(hepmc.Event{})},
+			},
+			"Streamer": &HepMcStreamer{Name: input},
+		},
+	})
+
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fads.HepMcReader",
+		Name: "hepmcreader",
+		Props: job.P{
+			"Input": "/fads/McEvent",
+		},
+	})
+
+	err := card.Apply(app.App())
+	if err != nil {
+		t.Fatalf("could not apply card: %+v", err)
+	}
+
+	ports := []fwk.Port{
+		{Name: "/fads/EFlowMerger/eflow", Type: reflect.TypeOf([]Candidate{})},
+		{Name: "/fads/TauTagging/jets", Type: reflect.TypeOf([]Candidate{})},
+	}
+	for _, br := range card.Branches() {
+		ports = append(ports, br.Port())
+	}
+	out := &collector{}
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk.OutputStream",
+		Name: "collector",
+		Props: job.P{
+			"Ports":    ports,
+			"Streamer": out,
+		},
+	})
+
+	err = app.App().Run()
+	if err != nil {
+		t.Fatalf("could not run card: %+v", err)
+	}
+
+	nparts := hepmcParticles(t, input)
+	if got, want := len(out.evts), len(nparts); got != want {
+		t.Fatalf("invalid number of events: got=%d, want=%d", got, want)
+	}
+
+	const eps = 1e-9
+	var ngen, njets, nunique int
+	for i, evt := range out.evts {
+		parts := evt["/fads/AllParticles"].([]Candidate)
+		if got, want := len(parts), nparts[i]; got != want {
+			t.Fatalf("evt[%d]: invalid number of particles: got=%d, want=%d", i, got, want)
+		}
+
+		for _, name := range []string{
+			"/fads/GenJetFinder/jets",
+			"/fads/TauTagging/jets",
+			"/fads/UniqueObjectFinder/jets",
+		} {
+			jets := evt[name].([]Candidate)
+			for j := range jets {
+				if pt := jets[j].Mom.Pt(); pt < ptmin {
+					t.Fatalf("evt[%d]: %s[%d]: pt=%v below JetPTMin", i, name, j, pt)
+				}
+			}
+		}
+		ngen += len(evt["/fads/GenJetFinder/jets"].([]Candidate))
+
+		// the unique jets are a subset of the tagged jets.
+		tagged := evt["/fads/TauTagging/jets"].([]Candidate)
+		unique := evt["/fads/UniqueObjectFinder/jets"].([]Candidate)
+		njets += len(tagged)
+		nunique += len(unique)
+		for _, jet := range unique {
+			found := false
+			for j := range tagged {
+				if reflect.DeepEqual(jet, tagged[j]) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Fatalf("evt[%d]: unique jet %v not in tagged jets", i, jet.Mom)
+			}
+		}
+
+		var sumpt float64
+		for _, name := range []string{
+			"/fads/UniqueObjectFinder/jets",
+			"/fads/UniqueObjectFinder/electrons",
+			"/fads/UniqueObjectFinder/photons",
+			"/fads/UniqueObjectFinder/muons",
+		} {
+			objs := evt[name].([]Candidate)
+			for j := range objs {
+				sumpt += objs[j].Mom.Pt()
+			}
+		}
+		ht := evt["/fads/ScalarHT/energy"].(Candidate)
+		if got, want := ht.Mom.Pt(), sumpt; math.Abs(got-want) > eps*(1+want) {
+			t.Fatalf("evt[%d]: invalid scalar HT: got=%v, want=%v", i, got, want)
+		}
+
+		var px, py float64
+		eflow := evt["/fads/EFlowMerger/eflow"].([]Candidate)
+		if len(eflow) == 0 {
+			t.Fatalf("evt[%d]: no energy flow candidates", i)
+		}
+		for j := range eflow {
+			px += eflow[j].Mom.Px()
+			py += eflow[j].Mom.Py()
+		}
+		met := evt["/fads/MissingET/momentum"].(Candidate)
+		if got, want := met.Mom.Px(), px; math.Abs(got-want) > eps*(1+math.Abs(want)) {
+			t.Fatalf("evt[%d]: invalid missing ET px: got=%v, want=%v", i, got, want)
+		}
+		if got, want := met.Mom.Py(), py; math.Abs(got-want) > eps*(1+math.Abs(want)) {
+			t.Fatalf("evt[%d]: invalid missing ET py: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	if got, want := ngen != 0, genjets; got != want {
+		t.Fatalf("invalid generator-level jets: got=%d jets, want jets=%v", ngen, want)
+	}
+	if njets == 0 {
+		t.Fatalf("no reconstructed jets found")
+	}
+	if nunique == 0 {
+		t.Fatalf("no unique reconstructed jets found")
+	}
+}
+
+// hepmcParticles returns the number of particles of each event of the
+// named HepMC file.
+func hepmcParticles(t *testing.T, fname string) []int {
+	t.Helper()
+
+	f, err := os.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open HepMC file: %+v", err)
+	}
+	defer f.Close()
+
+	var (
+		n   []int
+		dec = hepmc.NewDecoder(bufio.NewReader(f))
+	)
+	for {
+		var evt hepmc.Event
+		err := dec.Decode(&evt)
+		if err == io.EOF {
+			return n
+		}
+		if err != nil {
+			t.Fatalf("could not decode HepMC event: %+v", err)
+		}
+		n = append(n, len(evt.Particles))
+	}
+}
@@ -5,6 +5,8 @@
 package fads
 
 import (
+	"sync/atomic"
+
 	"go-hep.org/x/hep/fmom"
 	"go-hep.org/x/hep/hepmc"
 )
@@ -168,6 +170,7 @@ func (tower *Tower) P4() fmom.P4 {
 }
 
 type Candidate struct {
+	ID             uint64  // candidate identifier, shared by the copies of a candidate
 	Pid            int32   // HEP ID number
 	Status         int32   // particle status
 	M1, M2, D1, D2 int32   // particle mothers and daughters
@@ -216,8 +219,13 @@ func (cand *Candidate) Add(c *Candidate) {
 	cand.Candidates = append(cand.Candidates, *c)
 }
 
+// Overlaps returns whether the two candidates, or any of their constituents,
+// are the same candidate.
+// Candidates are identified by their ID, so that copies of a candidate (as
+// made when a candidate goes through several tasks) overlap with the
+// original. Candidates without an ID only overlap with themselves.
 func (cand *Candidate) Overlaps(o *Candidate) bool {
-	if cand == o {
+	if cand == o || (cand.ID != 0 && cand.ID == o.ID) {
 		return true
 	}
 
@@ -238,6 +246,13 @@ func (cand *Candidate) Overlaps(o *Candidate) bool {
 	return false
 }
 
+var candIDs atomic.Uint64
+
+// newCandidateID returns a new, non-zero, candidate identifier.
+func newCandidateID() uint64 {
+	return candIDs.Add(1)
+}
+
 // Classifier classifies candidates into categories
 type Classifier interface {
 	Category(cand *Candidate) int
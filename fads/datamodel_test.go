@@ -0,0 +1,70 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/fmom"
+)
+
+func TestCandidateOverlaps(t *testing.T) {
+	var (
+		trk1 = Candidate{ID: 1, Pid: 211, Status: 1, CandCharge: +1, Mom: fmom.NewPxPyPzE(10, 0, 5, 11.2)}
+		trk2 = Candidate{ID: 2, Pid: -211, Status: 1, CandCharge: -1, Mom: fmom.NewPxPyPzE(0, 8, 1, 8.1)}
+		twr  = Candidate{ID: 3, Mom: fmom.NewPxPyPzE(3, 3, 0, 4.3), Edges: [4]float64{0, 0.1, 0.7, 0.8}}
+		ele  = Candidate{ID: 4, Pid: 11, Status: 1, CandCharge: -1, Mom: fmom.NewPxPyPzE(0, 8, 1, 8.1)}
+
+		// a distinct particle, identical to trk1.
+		trk3 = Candidate{ID: 5, Pid: 211, Status: 1, CandCharge: +1, Mom: fmom.NewPxPyPzE(10, 0, 5, 11.2)}
+
+		// candidates without identifiers.
+		anon1 = Candidate{Pid: 22, Status: 1, Mom: fmom.NewPxPyPzE(1, 2, 3, 3.7)}
+		anon2 = anon1
+	)
+
+	jet := Candidate{ID: 6, Mom: fmom.NewPxPyPzE(13, 3, 5, 15.5)}
+	jet.Add(&trk1)
+	jet.Add(&twr)
+
+	// copies of the candidates, as stored by subsequent tasks.
+	var (
+		trk1c = trk1
+		twrc  = twr
+		jetc  = *jet.Clone()
+	)
+	jetc.BTag = 1
+	trk1c.IsConstituent = 1
+
+	for _, tc := range []struct {
+		name string
+		a, b *Candidate
+		want bool
+	}{
+		{name: "same", a: &trk1, b: &trk1, want: true},
+		{name: "copy", a: &trk1, b: &trk1c, want: true},
+		{name: "different", a: &trk1, b: &trk2, want: false},
+		{name: "same-momentum", a: &trk2, b: &ele, want: false},
+		{name: "identical", a: &trk1, b: &trk3, want: false},
+		{name: "identical-constituent", a: &jet, b: &trk3, want: false},
+		{name: "no-id", a: &anon1, b: &anon1, want: true},
+		{name: "no-id-copy", a: &anon1, b: &anon2, want: false},
+		{name: "constituent", a: &jet, b: &trk1, want: true},
+		{name: "constituent-copy", a: &jet, b: &trk1c, want: true},
+		{name: "constituent-copy-rev", a: &twrc, b: &jet, want: true},
+		{name: "not-constituent", a: &jet, b: &trk2, want: false},
+		{name: "cloned-jet", a: &jetc, b: &jet, want: true},
+		{name: "cloned-jet-constituent", a: &jetc, b: &trk1c, want: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := tc.a.Overlaps(tc.b), tc.want; got != want {
+				t.Fatalf("invalid a.Overlaps(b): got=%v, want=%v", got, want)
+			}
+			if got, want := tc.b.Overlaps(tc.a), tc.want; got != want {
+				t.Fatalf("invalid b.Overlaps(a): got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
@@ -5,9 +5,7 @@
 package fads
 
 import (
-	"math/rand/v2"
 	"reflect"
-	"sync"
 
 	"go-hep.org/x/hep/fwk"
 	"gonum.org/v1/gonum/stat/distuv"
@@ -19,10 +17,8 @@ type Efficiency struct {
 	input  string
 	output string
 
-	eff  func(pt, eta float64) float64
-	seed uint64
-	dist distuv.Uniform
-	dmu  sync.Mutex
+	eff func(pt, eta float64) float64
+	rnd randgen
 }
 
 func (tsk *Efficiency) Configure(ctx fwk.Context) error {
@@ -41,10 +37,7 @@ func (tsk *Efficiency) Configure(ctx fwk.Context) error {
 }
 
 func (tsk *Efficiency) StartTask(ctx fwk.Context) error {
-	var err error
-	src := rand.New(rand.NewPCG(tsk.seed, tsk.seed))
-	tsk.dist = distuv.Uniform{Min: 0, Max: 1, Src: src}
-	return err
+	return tsk.rnd.start(ctx)
 }
 
 func (tsk *Efficiency) StopTask(ctx fwk.Context) error {
@@ -57,6 +50,11 @@ func (tsk *Efficiency) Process(ctx fwk.Context) error {
 	var err error
 	store := ctx.Store()
 	msg := ctx.Msg()
+	src, err := tsk.rnd.rand(ctx, tsk.Name())
+	if err != nil {
+		return err
+	}
+	dist := distuv.Uniform{Min: 0, Max: 1, Src: src}
 
 	v, err := store.Get(tsk.input)
 	if err != nil {
@@ -77,9 +75,7 @@ func (tsk *Efficiency) Process(ctx fwk.Context) error {
 		pt := cand.Mom.Pt()
 
 		// apply efficiency
-		tsk.dmu.Lock()
-		eff := tsk.dist.Rand()
-		tsk.dmu.Unlock()
+		eff := dist.Rand()
 		max := tsk.eff(pt, eta)
 		if eff > max {
 			continue
@@ -100,7 +96,7 @@ func newEfficiency(typ, name string, mgr fwk.App) (fwk.Component, error) {
 		input:    "InputParticles",
 		output:   "OutputParticles",
 		eff:      func(x, y float64) float64 { return 1 },
-		seed:     1234,
+		rnd:      randgen{seed: 1234},
 	}
 	err = tsk.DeclProp("Input", &tsk.input)
 	if err != nil {
@@ -117,7 +113,12 @@ func newEfficiency(typ, name string, mgr fwk.App) (fwk.Component, error) {
 		return nil, err
 	}
 
-	err = tsk.DeclProp("Seed", &tsk.seed)
+	err = tsk.DeclProp("Seed", &tsk.rnd.seed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("RandSvc", &tsk.rnd.name)
 	if err != nil {
 		return nil, err
 	}
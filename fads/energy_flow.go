@@ -0,0 +1,294 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/fwk"
+)
+
+// EnergyFlow combines the tracks reconstructed by the tracking system with
+// the towers of a Calorimeter into particle-flow objects.
+//
+// The expected calorimeter deposits of the charged tracks pointing to a tower
+// are subtracted from the ECal and HCal energies of that tower.
+// The remaining ECal (resp. HCal) energy is turned into an eflow photon
+// (resp. eflow neutral hadron) when it is above ECalEnergyMin
+// (resp. HCalEnergyMin) and its significance with respect to the resolution
+// on the subtracted track energy is above ECalEnergySignificanceMin
+// (resp. HCalEnergySignificanceMin).
+// The eflow neutral objects carry their tower as constituent.
+//
+// All the tracks are forwarded as eflow tracks.
+// The three eflow collections can be merged (with a Merger) and given to
+// FastJetFinder, Isolation or to a missing transverse energy computation.
+type EnergyFlow struct {
+	fwk.TaskBase
+
+	efrac   map[int]EneFrac
+	ecalres func(eta, ene float64) float64
+	hcalres func(eta, ene float64) float64
+
+	ecalmin float64 // minimal neutral ECal energy
+	hcalmin float64 // minimal neutral HCal energy
+	ecalsig float64 // minimal neutral ECal energy significance
+	hcalsig float64 // minimal neutral HCal energy significance
+
+	tracks   string
+	towers   string
+	etracks  string
+	ephotons string
+	eneutral string
+}
+
+func (tsk *EnergyFlow) Configure(ctx fwk.Context) error {
+	var err error
+
+	err = tsk.DeclInPort(tsk.tracks, reflect.TypeOf([]Candidate{}))
+	if err != nil {
+		return err
+	}
+
+	err = tsk.DeclInPort(tsk.towers, reflect.TypeOf([]Candidate{}))
+	if err != nil {
+		return err
+	}
+
+	err = tsk.DeclOutPort(tsk.etracks, reflect.TypeOf([]Candidate{}))
+	if err != nil {
+		return err
+	}
+
+	err = tsk.DeclOutPort(tsk.ephotons, reflect.TypeOf([]Candidate{}))
+	if err != nil {
+		return err
+	}
+
+	err = tsk.DeclOutPort(tsk.eneutral, reflect.TypeOf([]Candidate{}))
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+func (tsk *EnergyFlow) StartTask(ctx fwk.Context) error {
+	var err error
+
+	return err
+}
+
+func (tsk *EnergyFlow) StopTask(ctx fwk.Context) error {
+	var err error
+
+	return err
+}
+
+func (tsk *EnergyFlow) Process(ctx fwk.Context) error {
+	var err error
+	store := ctx.Store()
+	msg := ctx.Msg()
+
+	v, err := store.Get(tsk.tracks)
+	if err != nil {
+		return err
+	}
+	tracks := v.([]Candidate)
+
+	v, err = store.Get(tsk.towers)
+	if err != nil {
+		return err
+	}
+	towers := v.([]Candidate)
+
+	msg.Debugf(">>> tracks: %v, towers: %v\n", len(tracks), len(towers))
+
+	// expected calorimeter deposits of each track
+	type deposit struct {
+		eta, phi   float64
+		ecal, hcal float64
+	}
+	deps := make([]deposit, len(tracks))
+	etracks := make([]Candidate, 0, len(tracks))
+	for i := range tracks {
+		track := &tracks[i]
+		abspid := track.Pid
+		if abspid < 0 {
+			abspid = -abspid
+		}
+		frac, ok := tsk.efrac[int(abspid)]
+		if !ok {
+			frac = tsk.efrac[0]
+		}
+		ene := track.Mom.E()
+		deps[i] = deposit{
+			eta:  track.Pos.Eta(),
+			phi:  track.Pos.Phi(),
+			ecal: ene * frac.ECal,
+			hcal: ene * frac.HCal,
+		}
+		etracks = append(etracks, *track)
+	}
+
+	var (
+		ephotons = make([]Candidate, 0, len(towers))
+		eneutral = make([]Candidate, 0, len(towers))
+	)
+	for i := range towers {
+		tower := &towers[i]
+		var (
+			eta  = tower.Mom.Eta()
+			phi  = tower.Mom.Phi()
+			ecal = 0.0 // ECal energy of the tracks hitting this tower
+			hcal = 0.0 // HCal energy of the tracks hitting this tower
+		)
+		for j := range deps {
+			dep := &deps[j]
+			if dep.eta < tower.Edges[0] || dep.eta >= tower.Edges[1] ||
+				dep.phi < tower.Edges[2] || dep.phi >= tower.Edges[3] {
+				continue
+			}
+			ecal += dep.ecal
+			hcal += dep.hcal
+		}
+
+		if ene := tower.Eem - ecal; ene > tsk.ecalmin && ene > tsk.ecalsig*tsk.ecalres(eta, ecal) {
+			ephotons = append(ephotons, tsk.neutral(tower, eta, phi, ene, 0))
+		}
+
+		if ene := tower.Ehad - hcal; ene > tsk.hcalmin && ene > tsk.hcalsig*tsk.hcalres(eta, hcal) {
+			eneutral = append(eneutral, tsk.neutral(tower, eta, phi, 0, ene))
+		}
+	}
+
+	msg.Debugf(">>> eflow-tracks: %v, eflow-photons: %v, eflow-neutrals: %v\n",
+		len(etracks), len(ephotons), len(eneutral),
+	)
+
+	err = store.Put(tsk.etracks, etracks)
+	if err != nil {
+		return err
+	}
+
+	err = store.Put(tsk.ephotons, ephotons)
+	if err != nil {
+		return err
+	}
+
+	err = store.Put(tsk.eneutral, eneutral)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// neutral returns an eflow neutral object built from the given tower,
+// with the given ECal and HCal energies.
+func (tsk *EnergyFlow) neutral(tower *Candidate, eta, phi, eem, ehad float64) Candidate {
+	ene := eem + ehad
+	cand := Candidate{
+		ID:    newCandidateID(),
+		Pos:   tower.Pos,
+		Mom:   newPtEtaPhiE(ene/math.Cosh(eta), eta, phi, ene),
+		Eem:   eem,
+		Ehad:  ehad,
+		Edges: tower.Edges,
+	}
+	cand.Add(tower)
+	return cand
+}
+
+func newEnergyFlow(typ, name string, mgr fwk.App) (fwk.Component, error) {
+	var err error
+
+	tsk := &EnergyFlow{
+		TaskBase: fwk.NewTask(typ, name, mgr),
+
+		efrac: map[int]EneFrac{
+			0:  {ECal: 0, HCal: 1},
+			11: {ECal: 1, HCal: 0},
+			13: {ECal: 0, HCal: 0},
+		},
+		ecalres: func(eta, ene float64) float64 { return 0 },
+		hcalres: func(eta, ene float64) float64 { return 0 },
+
+		tracks:   "/fads/tracks",
+		towers:   "/fads/towers",
+		etracks:  "/fads/eflowtracks",
+		ephotons: "/fads/eflowphotons",
+		eneutral: "/fads/eflowneutralhadrons",
+	}
+
+	err = tsk.DeclProp("EnergyFraction", &tsk.efrac)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("ECalResolution", &tsk.ecalres)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("HCalResolution", &tsk.hcalres)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("ECalEnergyMin", &tsk.ecalmin)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("HCalEnergyMin", &tsk.hcalmin)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("ECalEnergySignificanceMin", &tsk.ecalsig)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("HCalEnergySignificanceMin", &tsk.hcalsig)
+	if err != nil {
+		return nil, err
+	}
+
+	// --
+
+	err = tsk.DeclProp("Tracks", &tsk.tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("Towers", &tsk.towers)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("EFlowTracks", &tsk.etracks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("EFlowPhotons", &tsk.ephotons)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("EFlowNeutralHadrons", &tsk.eneutral)
+	if err != nil {
+		return nil, err
+	}
+
+	return tsk, err
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(EnergyFlow{}), newEnergyFlow)
+}
@@ -0,0 +1,134 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fmom"
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+)
+
+func TestEnergyFlow(t *testing.T) {
+	tower := func(eta, phi, eem, ehad float64) Candidate {
+		ene := eem + ehad
+		return Candidate{
+			Mom:   newPtEtaPhiE(ene/math.Cosh(eta+0.05), eta+0.05, phi+0.05, ene),
+			Eem:   eem,
+			Ehad:  ehad,
+			Edges: [4]float64{eta, eta + 0.1, phi, phi + 0.1},
+		}
+	}
+	track := func(pid int32, ene, eta, phi float64) Candidate {
+		c := newCand(pid, ene/math.Cosh(eta), eta, phi)
+		pos := fmom.NewPtEtaPhiM(1000, eta, phi, 0)
+		c.Pos.Set(&pos)
+		return c
+	}
+
+	var (
+		twrA = tower(0, 0, 10, 20)   // pion and muon: photon and neutral hadron
+		twrB = tower(1, 1, 8, 0.5)   // electron: remaining energies below thresholds
+		twrC = tower(2, -1, 3, 0)    // significant photon
+		twrD = tower(-2, 2, 1.5, 0)  // photon below significance
+		twrE = tower(-1, -2, 0, 1.5) // neutral hadron
+
+		pion = track(211, 15, 0.05, 0.05)
+		muon = track(-13, 50, 0.02, 0.08)
+		elec = track(11, 7.9, 1.05, 1.05)
+
+		tracks = []Candidate{pion, muon, elec}
+		towers = []Candidate{twrA, twrB, twrC, twrD, twrE}
+	)
+
+	evts := runTasks(t,
+		[]map[string]any{{
+			"/fads/tracks": tracks,
+			"/fads/towers": towers,
+		}},
+		[]fwk.Port{
+			{Name: "/fads/eflow/tracks", Type: reflect.TypeOf([]Candidate{})},
+			{Name: "/fads/eflow/photons", Type: reflect.TypeOf([]Candidate{})},
+			{Name: "/fads/eflow/neutrals", Type: reflect.TypeOf([]Candidate{})},
+		},
+		job.C{
+			Type: "go-hep.org/x/hep/fads.EnergyFlow",
+			Name: "eflow",
+			Props: job.P{
+				"Tracks":                    "/fads/tracks",
+				"Towers":                    "/fads/towers",
+				"EFlowTracks":               "/fads/eflow/tracks",
+				"EFlowPhotons":              "/fads/eflow/photons",
+				"EFlowNeutralHadrons":       "/fads/eflow/neutrals",
+				"ECalResolution":            func(eta, ene float64) float64 { return 1 },
+				"HCalResolution":            func(eta, ene float64) float64 { return 0.5 },
+				"ECalEnergyMin":             0.5,
+				"HCalEnergyMin":             1.0,
+				"ECalEnergySignificanceMin": 2.0,
+				"HCalEnergySignificanceMin": 2.0,
+			},
+		},
+	)
+	evt := evts[0]
+
+	if got, want := evt["/fads/eflow/tracks"].([]Candidate), tracks; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid eflow tracks:\ngot= %v\nwant=%v", got, want)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		towers []Candidate
+		eem    []float64
+		ehad   []float64
+	}{
+		{
+			name:   "/fads/eflow/photons",
+			towers: []Candidate{twrA, twrC},
+			eem:    []float64{10, 3},
+			ehad:   []float64{0, 0},
+		},
+		{
+			name:   "/fads/eflow/neutrals",
+			towers: []Candidate{twrA, twrE},
+			eem:    []float64{0, 0},
+			ehad:   []float64{5, 1.5},
+		},
+	} {
+		got := evt[tc.name].([]Candidate)
+		if len(got) != len(tc.towers) {
+			t.Fatalf("%s: invalid number of candidates: got=%d, want=%d", tc.name, len(got), len(tc.towers))
+		}
+		for i := range got {
+			var (
+				cand = &got[i]
+				twr  = &tc.towers[i]
+				ene  = tc.eem[i] + tc.ehad[i]
+			)
+			if cand.Eem != tc.eem[i] || cand.Ehad != tc.ehad[i] {
+				t.Fatalf("%s[%d]: invalid energies: got=(%v, %v), want=(%v, %v)",
+					tc.name, i, cand.Eem, cand.Ehad, tc.eem[i], tc.ehad[i],
+				)
+			}
+			if got, want := cand.Mom.E(), ene; math.Abs(got-want) > 1e-12 {
+				t.Fatalf("%s[%d]: invalid energy: got=%v, want=%v", tc.name, i, got, want)
+			}
+			if got, want := cand.Mom.Eta(), twr.Mom.Eta(); math.Abs(got-want) > 1e-12 {
+				t.Fatalf("%s[%d]: invalid eta: got=%v, want=%v", tc.name, i, got, want)
+			}
+			if got, want := cand.Mom.Phi(), twr.Mom.Phi(); math.Abs(got-want) > 1e-12 {
+				t.Fatalf("%s[%d]: invalid phi: got=%v, want=%v", tc.name, i, got, want)
+			}
+			if cand.Edges != twr.Edges {
+				t.Fatalf("%s[%d]: invalid edges: got=%v, want=%v", tc.name, i, cand.Edges, twr.Edges)
+			}
+			if len(cand.Candidates) != 1 || !reflect.DeepEqual(cand.Candidates[0], *twr) {
+				t.Fatalf("%s[%d]: invalid constituents: %v", tc.name, i, cand.Candidates)
+			}
+		}
+	}
+}
@@ -6,9 +6,7 @@ package fads
 
 import (
 	"math"
-	"math/rand/v2"
 	"reflect"
-	"sync"
 
 	"go-hep.org/x/hep/fmom"
 	"go-hep.org/x/hep/fwk"
@@ -22,9 +20,7 @@ type EnergySmearing struct {
 	output string
 
 	smear func(eta, ene float64) float64
-	seed  uint64
-	src   *rand.Rand
-	srcmu sync.Mutex
+	rnd   randgen
 }
 
 func (tsk *EnergySmearing) Configure(ctx fwk.Context) error {
@@ -44,9 +40,7 @@ func (tsk *EnergySmearing) Configure(ctx fwk.Context) error {
 }
 
 func (tsk *EnergySmearing) StartTask(ctx fwk.Context) error {
-	var err error
-	tsk.src = rand.New(rand.NewPCG(tsk.seed, tsk.seed))
-	return err
+	return tsk.rnd.start(ctx)
 }
 
 func (tsk *EnergySmearing) StopTask(ctx fwk.Context) error {
@@ -59,6 +53,10 @@ func (tsk *EnergySmearing) Process(ctx fwk.Context) error {
 	var err error
 	store := ctx.Store()
 	msg := ctx.Msg()
+	src, err := tsk.rnd.rand(ctx, tsk.Name())
+	if err != nil {
+		return err
+	}
 
 	v, err := store.Get(tsk.input)
 	if err != nil {
@@ -79,10 +77,8 @@ func (tsk *EnergySmearing) Process(ctx fwk.Context) error {
 		ene := cand.Mom.E()
 
 		// apply smearing
-		tsk.srcmu.Lock()
-		smearEne := distuv.Normal{Mu: ene, Sigma: tsk.smear(eta, ene), Src: tsk.src}
+		smearEne := distuv.Normal{Mu: ene, Sigma: tsk.smear(eta, ene), Src: src}
 		ene = smearEne.Rand()
-		tsk.srcmu.Unlock()
 
 		if ene <= 0 {
 			continue
@@ -118,7 +114,7 @@ func init() {
 				input:    "InputParticles",
 				output:   "OutputParticles",
 				smear:    func(x, y float64) float64 { return 0 },
-				seed:     1234,
+				rnd:      randgen{seed: 1234},
 			}
 
 			err = tsk.DeclProp("Input", &tsk.input)
@@ -136,7 +132,12 @@ func init() {
 				return nil, err
 			}
 
-			err = tsk.DeclProp("Seed", &tsk.seed)
+			err = tsk.DeclProp("Seed", &tsk.rnd.seed)
+			if err != nil {
+				return nil, err
+			}
+
+			err = tsk.DeclProp("RandSvc", &tsk.rnd.name)
 			if err != nil {
 				return nil, err
 			}
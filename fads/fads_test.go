@@ -0,0 +1,137 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"io"
+	"reflect"
+	"sort"
+	"testing"
+
+	"go-hep.org/x/hep/fmom"
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+)
+
+// newCand returns a candidate with the provided PDG ID and massless momentum.
+func newCand(pid int32, pt, eta, phi float64) Candidate {
+	c := Candidate{ID: newCandidateID(), Pid: pid, Status: 1}
+	p4 := fmom.NewPtEtaPhiM(pt, eta, phi, 0)
+	c.Mom.Set(&p4)
+	return c
+}
+
+// source is an input streamer putting the provided collections of each
+// event into the store.
+type source struct {
+	ports []fwk.Port
+	evts  []map[string]any
+	ievt  int
+}
+
+func (src *source) Connect(ports []fwk.Port) error {
+	src.ports = ports
+	return nil
+}
+
+func (src *source) Read(ctx fwk.Context) error {
+	if src.ievt >= len(src.evts) {
+		return io.EOF
+	}
+	evt := src.evts[src.ievt]
+	src.ievt++
+	for _, port := range src.ports {
+		err := ctx.Store().Put(port.Name, evt[port.Name])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (src *source) Disconnect() error { return nil }
+
+// collector is an output streamer keeping the collections of each event.
+type collector struct {
+	ports []fwk.Port
+	evts  []map[string]any
+}
+
+func (c *collector) Connect(ports []fwk.Port) error {
+	c.ports = ports
+	return nil
+}
+
+func (c *collector) Write(ctx fwk.Context) error {
+	evt := make(map[string]any, len(c.ports))
+	for _, port := range c.ports {
+		v, err := ctx.Store().Get(port.Name)
+		if err != nil {
+			return err
+		}
+		evt[port.Name] = v
+	}
+	c.evts = append(c.evts, evt)
+	return nil
+}
+
+func (c *collector) Disconnect() error { return nil }
+
+// runTasks runs the provided tasks sequentially over the input events and
+// returns the requested output collections of each event, if any.
+func runTasks(t *testing.T, evts []map[string]any, outputs []fwk.Port, tasks ...job.C) []map[string]any {
+	t.Helper()
+
+	app := job.New(job.P{
+		"EvtMax":   int64(-1),
+		"NProcs":   0,
+		"MsgLevel": job.MsgLevel("ERROR"),
+	})
+
+	var inputs []fwk.Port
+	for k, v := range evts[0] {
+		inputs = append(inputs, fwk.Port{Name: k, Type: reflect.TypeOf(v)})
+	}
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].Name < inputs[j].Name })
+
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk.InputStream",
+		Name: "source",
+		Props: job.P{
+			"Ports":    inputs,
+			"Streamer": &source{evts: evts},
+		},
+	})
+
+	for _, tsk := range tasks {
+		app.Create(tsk)
+	}
+
+	out := &collector{}
+	if len(outputs) > 0 {
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk.OutputStream",
+			Name: "collector",
+			Props: job.P{
+				"Ports":    outputs,
+				"Streamer": out,
+			},
+		})
+	}
+
+	err := app.App().Run()
+	if err != nil {
+		t.Fatalf("could not run tasks: %+v", err)
+	}
+
+	if len(outputs) == 0 {
+		return nil
+	}
+	if got, want := len(out.evts), len(evts); got != want {
+		t.Fatalf("invalid number of events: got=%d, want=%d", got, want)
+	}
+
+	return out.evts
+}
@@ -37,7 +37,7 @@ type FastJetFinder struct {
 	overlapThreshold float64
 
 	// fastjet area method ---
-	areaDef    any
+	areaDef    *fastjet.AreaDefinition
 	areaAlg    int
 	computeRho bool
 
@@ -72,16 +72,48 @@ func (tsk *FastJetFinder) Configure(ctx fwk.Context) error {
 		return err
 	}
 
-	if tsk.jetAlg != fastjet.AntiKtAlgorithm {
-		return fmt.Errorf("fastjet-finder: only implemented for AntiKt")
-	}
-
-	if tsk.areaAlg != 0 {
-		return fmt.Errorf("fastjet-finder: only implemented with *NO* area-definition")
+	switch tsk.jetAlg {
+	case fastjet.KtAlgorithm, fastjet.CambridgeAlgorithm, fastjet.AntiKtAlgorithm:
+	default:
+		return fmt.Errorf("fastjet-finder: only implemented for Kt, Cambridge and AntiKt")
 	}
 
 	tsk.jetDef = fastjet.NewJetDefinition(tsk.jetAlg, tsk.paramR, fastjet.EScheme, fastjet.BestStrategy)
 
+	ghosts := fastjet.GhostedAreaSpec{
+		MaxRap:      tsk.ghostEtaMax,
+		Area:        tsk.ghostArea,
+		Repeat:      tsk.repeat,
+		GridScatter: tsk.gridScatter,
+		PtScatter:   tsk.ptScatter,
+		MeanGhostPt: tsk.meanGhostPt,
+	}
+
+	// area algorithms, as numbered in Delphes.
+	var areaDef fastjet.AreaDefinition
+	switch tsk.areaAlg {
+	case 0:
+		tsk.areaDef = nil
+	case 1, 2:
+		areaDef = fastjet.NewAreaDefinition(fastjet.ActiveArea, ghosts)
+		tsk.areaDef = &areaDef
+	case 3:
+		areaDef = fastjet.NewAreaDefinition(fastjet.OneGhostPassiveArea, ghosts)
+		tsk.areaDef = &areaDef
+	case 4:
+		areaDef = fastjet.NewAreaDefinition(fastjet.PassiveArea, ghosts)
+		tsk.areaDef = &areaDef
+	case 5:
+		areaDef = fastjet.NewVoronoiAreaDefinition(fastjet.VoronoiAreaSpec{Rfact: tsk.effectiveRfact})
+		tsk.areaDef = &areaDef
+	default:
+		return fmt.Errorf("fastjet-finder: invalid area algorithm (%d)", tsk.areaAlg)
+	}
+
+	if tsk.computeRho && tsk.areaDef == nil {
+		return fmt.Errorf("fastjet-finder: rho computation needs an area algorithm")
+	}
+
 	return err
 }
 
@@ -108,10 +140,7 @@ func (tsk *FastJetFinder) Process(ctx fwk.Context) error {
 	}
 	input := v.([]Candidate)
 
-	output := make([]Candidate, 0)
-	defer func() {
-		err = store.Put(tsk.output, output)
-	}()
+	rhos := make([]Candidate, 0, len(tsk.etaRangeMap))
 
 	injets := make([]fastjet.Jet, 0, len(input))
 	for i := range input {
@@ -122,10 +151,16 @@ func (tsk *FastJetFinder) Process(ctx fwk.Context) error {
 	}
 
 	// construct jets
-	var bldr fastjet.Builder
+	var (
+		bldr jetBuilder
+		csa  *fastjet.ClusterSequenceArea
+	)
 	if tsk.areaDef != nil {
-		// FIXME
-		panic("not implemented")
+		csa, err = fastjet.NewClusterSequenceArea(injets, tsk.jetDef, *tsk.areaDef)
+		if err != nil {
+			return err
+		}
+		bldr = csa
 	} else {
 		bldr, err = fastjet.NewClusterSequence(injets, tsk.jetDef)
 		if err != nil {
@@ -135,8 +170,32 @@ func (tsk *FastJetFinder) Process(ctx fwk.Context) error {
 
 	// compute rho and store it
 	if tsk.computeRho {
-		// FIXME
-		panic("not implemented")
+		etamins := make([]float64, 0, len(tsk.etaRangeMap))
+		for etamin := range tsk.etaRangeMap {
+			etamins = append(etamins, etamin)
+		}
+		sort.Float64s(etamins)
+
+		for _, etamin := range etamins {
+			etamax := tsk.etaRangeMap[etamin]
+			bge := fastjet.JetMedianBackgroundEstimator{
+				RapMin: etamin,
+				RapMax: etamax,
+				AbsRap: true,
+			}
+			bkg, err := bge.Estimate(csa)
+			if err != nil {
+				return err
+			}
+
+			rho := Candidate{
+				ID:  newCandidateID(),
+				Mom: newPtEtaPhiE(bkg.Rho, 0, 0, bkg.Rho),
+			}
+			rho.Edges[0] = etamin
+			rho.Edges[1] = etamax
+			rhos = append(rhos, rho)
+		}
 	}
 
 	outjets, err := bldr.InclusiveJets(tsk.jetPtMin)
@@ -147,19 +206,18 @@ func (tsk *FastJetFinder) Process(ctx fwk.Context) error {
 
 	detaMax := 0.0
 	dphiMax := 0.0
-	output = make([]Candidate, 0, len(outjets))
+	output := make([]Candidate, 0, len(outjets))
 	for i := range outjets {
 		var (
 			jet  = &outjets[i]
 			area fmom.PxPyPzE
 		)
-		if tsk.areaDef != nil {
-			// FIXME
-			panic("not implemented")
-			// area = jet.Area()
+		if csa != nil {
+			area = csa.AreaFourVector(jet)
 		}
 
 		cand := Candidate{
+			ID:  newCandidateID(),
 			Mom: jet.PxPyPzE,
 		}
 
@@ -198,9 +256,27 @@ func (tsk *FastJetFinder) Process(ctx fwk.Context) error {
 	}
 
 	// fmt.Printf("%s: input=%02d outjets=%02d\n", tsk.Name(), len(input), len(output))
+
+	err = store.Put(tsk.output, output)
+	if err != nil {
+		return err
+	}
+
+	err = store.Put(tsk.rho, rhos)
+	if err != nil {
+		return err
+	}
+
 	return err
 }
 
+// jetBuilder is the subset of the fastjet cluster sequences API used
+// by FastJetFinder.
+type jetBuilder interface {
+	InclusiveJets(ptmin float64) ([]fastjet.Jet, error)
+	Constituents(jet *fastjet.Jet) ([]fastjet.Jet, error)
+}
+
 func newFastJetFinder(typ, name string, mgr fwk.App) (fwk.Component, error) {
 	var err error
 
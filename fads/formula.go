@@ -0,0 +1,392 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// formula is a compiled arithmetic expression of (at most) two variables.
+type formula func(x, y float64) float64
+
+// newFormula compiles the expression src, as found in Delphes data-cards
+// (e.g. "(abs(eta) <= 1.5) * (pt > 1.0) * 0.95") or in Tcl 'expr' commands.
+//
+// The variables named x and y are bound to the first and second arguments
+// of the returned formula.
+// Comparison and logical operators evaluate to 1 or 0.
+func newFormula(src, x, y string) (formula, error) {
+	p := formulaParser{src: src, x: x, y: y}
+	err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	f, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %q", p.tok.str)
+	}
+	return f, nil
+}
+
+// evalExpr evaluates the constant expression src.
+func evalExpr(src string) (float64, error) {
+	f, err := newFormula(src, "", "")
+	if err != nil {
+		return 0, err
+	}
+	return f(0, 0), nil
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNum
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	str  string
+	num  float64
+}
+
+type formulaParser struct {
+	src  string
+	pos  int
+	tok  token
+	x, y string
+}
+
+func (p *formulaParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid formula %q: %s", strings.TrimSpace(p.src), fmt.Sprintf(format, args...))
+}
+
+var formulaOps = []string{
+	"**", "<=", ">=", "==", "!=", "&&", "||",
+	"+", "-", "*", "/", "%", "^", "<", ">", "!", "(", ")", ",",
+}
+
+func (p *formulaParser) next() error {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokEOF, str: "EOF"}
+		return nil
+	}
+
+	beg := p.pos
+	c := p.src[p.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '.':
+		for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+			end := p.pos + 1
+			if end < len(p.src) && (p.src[end] == '+' || p.src[end] == '-') {
+				end++
+			}
+			if end < len(p.src) && isDigit(p.src[end]) {
+				for end < len(p.src) && isDigit(p.src[end]) {
+					end++
+				}
+				p.pos = end
+			}
+		}
+		str := p.src[beg:p.pos]
+		v, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return p.errorf("invalid number %q", str)
+		}
+		p.tok = token{kind: tokNum, str: str, num: v}
+		return nil
+
+	case c == '_' || unicode.IsLetter(rune(c)):
+		for p.pos < len(p.src) {
+			c := p.src[p.pos]
+			switch {
+			case c == '_' || isDigit(c) || unicode.IsLetter(rune(c)):
+				p.pos++
+			case strings.HasPrefix(p.src[p.pos:], "::"):
+				p.pos += 2
+			default:
+				p.tok = token{kind: tokIdent, str: p.src[beg:p.pos]}
+				return nil
+			}
+		}
+		p.tok = token{kind: tokIdent, str: p.src[beg:p.pos]}
+		return nil
+	}
+
+	for _, op := range formulaOps {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			p.pos += len(op)
+			p.tok = token{kind: tokOp, str: op}
+			return nil
+		}
+	}
+	return p.errorf("invalid character %q", c)
+}
+
+func (p *formulaParser) accept(ops ...string) (string, bool, error) {
+	if p.tok.kind != tokOp {
+		return "", false, nil
+	}
+	for _, op := range ops {
+		if p.tok.str == op {
+			return op, true, p.next()
+		}
+	}
+	return "", false, nil
+}
+
+func (p *formulaParser) expect(op string) error {
+	if p.tok.kind != tokOp || p.tok.str != op {
+		return p.errorf("expected %q, got %q", op, p.tok.str)
+	}
+	return p.next()
+}
+
+// binary parses a left-associative sequence of operands separated by ops.
+func (p *formulaParser) binary(operand func() (formula, error), ops ...string) (formula, error) {
+	lhs, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok, err := p.accept(ops...)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return lhs, nil
+		}
+		rhs, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		lhs = binop(op, lhs, rhs)
+	}
+}
+
+func (p *formulaParser) or() (formula, error)  { return p.binary(p.and, "||") }
+func (p *formulaParser) and() (formula, error) { return p.binary(p.eq, "&&") }
+func (p *formulaParser) eq() (formula, error)  { return p.binary(p.rel, "==", "!=") }
+func (p *formulaParser) rel() (formula, error) { return p.binary(p.add, "<=", ">=", "<", ">") }
+func (p *formulaParser) add() (formula, error) { return p.binary(p.mul, "+", "-") }
+func (p *formulaParser) mul() (formula, error) { return p.binary(p.unary, "*", "/", "%") }
+
+func (p *formulaParser) unary() (formula, error) {
+	op, ok, err := p.accept("-", "+", "!")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return p.pow()
+	}
+	f, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "-":
+		return func(x, y float64) float64 { return -f(x, y) }, nil
+	case "!":
+		return func(x, y float64) float64 { return b2f(f(x, y) == 0) }, nil
+	}
+	return f, nil
+}
+
+func (p *formulaParser) pow() (formula, error) {
+	lhs, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	_, ok, err := p.accept("^", "**")
+	if err != nil || !ok {
+		return lhs, err
+	}
+	rhs, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return func(x, y float64) float64 { return math.Pow(lhs(x, y), rhs(x, y)) }, nil
+}
+
+func (p *formulaParser) primary() (formula, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokNum:
+		v := tok.num
+		return func(x, y float64) float64 { return v }, p.next()
+
+	case tokOp:
+		if tok.str != "(" {
+			break
+		}
+		err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		f, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return f, p.expect(")")
+
+	case tokIdent:
+		err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok, err := p.accept("("); err != nil || ok {
+			if err != nil {
+				return nil, err
+			}
+			return p.call(tok.str)
+		}
+		switch tok.str {
+		case p.x:
+			return func(x, y float64) float64 { return x }, nil
+		case p.y:
+			return func(x, y float64) float64 { return y }, nil
+		case "pi":
+			return func(x, y float64) float64 { return math.Pi }, nil
+		}
+		return nil, p.errorf("unknown variable %q", tok.str)
+	}
+	return nil, p.errorf("unexpected %q", tok.str)
+}
+
+func (p *formulaParser) call(name string) (formula, error) {
+	var args []formula
+	if _, ok, err := p.accept(")"); err != nil || ok {
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for {
+			arg, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok, err := p.accept(","); err != nil || !ok {
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+		err := p.expect(")")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fname := strings.ToLower(strings.TrimPrefix(name, "TMath::"))
+	if fct, ok := formulaFuncs0[fname]; ok && len(args) == 0 {
+		return func(x, y float64) float64 { return fct() }, nil
+	}
+	if fct, ok := formulaFuncs1[fname]; ok && len(args) == 1 {
+		a := args[0]
+		return func(x, y float64) float64 { return fct(a(x, y)) }, nil
+	}
+	if fct, ok := formulaFuncs2[fname]; ok && len(args) == 2 {
+		a, b := args[0], args[1]
+		return func(x, y float64) float64 { return fct(a(x, y), b(x, y)) }, nil
+	}
+	return nil, p.errorf("unknown function %s with %d argument(s)", name, len(args))
+}
+
+var (
+	formulaFuncs0 = map[string]func() float64{
+		"pi": func() float64 { return math.Pi },
+	}
+
+	formulaFuncs1 = map[string]func(float64) float64{
+		"abs":    math.Abs,
+		"fabs":   math.Abs,
+		"sqrt":   math.Sqrt,
+		"exp":    math.Exp,
+		"log":    math.Log,
+		"log10":  math.Log10,
+		"sin":    math.Sin,
+		"cos":    math.Cos,
+		"tan":    math.Tan,
+		"asin":   math.Asin,
+		"acos":   math.Acos,
+		"atan":   math.Atan,
+		"sinh":   math.Sinh,
+		"cosh":   math.Cosh,
+		"tanh":   math.Tanh,
+		"erf":    math.Erf,
+		"floor":  math.Floor,
+		"ceil":   math.Ceil,
+		"round":  math.Round,
+		"int":    math.Trunc,
+		"double": func(x float64) float64 { return x },
+	}
+
+	formulaFuncs2 = map[string]func(float64, float64) float64{
+		"pow":   math.Pow,
+		"power": math.Pow,
+		"atan2": math.Atan2,
+		"fmod":  math.Mod,
+		"min":   math.Min,
+		"max":   math.Max,
+	}
+)
+
+func binop(op string, lhs, rhs formula) formula {
+	switch op {
+	case "||":
+		return func(x, y float64) float64 { return b2f(lhs(x, y) != 0 || rhs(x, y) != 0) }
+	case "&&":
+		return func(x, y float64) float64 { return b2f(lhs(x, y) != 0 && rhs(x, y) != 0) }
+	case "==":
+		return func(x, y float64) float64 { return b2f(lhs(x, y) == rhs(x, y)) }
+	case "!=":
+		return func(x, y float64) float64 { return b2f(lhs(x, y) != rhs(x, y)) }
+	case "<":
+		return func(x, y float64) float64 { return b2f(lhs(x, y) < rhs(x, y)) }
+	case "<=":
+		return func(x, y float64) float64 { return b2f(lhs(x, y) <= rhs(x, y)) }
+	case ">":
+		return func(x, y float64) float64 { return b2f(lhs(x, y) > rhs(x, y)) }
+	case ">=":
+		return func(x, y float64) float64 { return b2f(lhs(x, y) >= rhs(x, y)) }
+	case "+":
+		return func(x, y float64) float64 { return lhs(x, y) + rhs(x, y) }
+	case "-":
+		return func(x, y float64) float64 { return lhs(x, y) - rhs(x, y) }
+	case "*":
+		return func(x, y float64) float64 { return lhs(x, y) * rhs(x, y) }
+	case "/":
+		return func(x, y float64) float64 { return lhs(x, y) / rhs(x, y) }
+	case "%":
+		return func(x, y float64) float64 { return math.Mod(lhs(x, y), rhs(x, y)) }
+	}
+	panic("fads: invalid formula operator " + op)
+}
+
+func b2f(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
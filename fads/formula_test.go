@@ -0,0 +1,112 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFormula(t *testing.T) {
+	for _, tc := range []struct {
+		src  string
+		x, y float64
+		want float64
+	}{
+		// precedence and associativity.
+		{src: "1 + 2 * 3", want: 7},
+		{src: "(1 + 2) * 3", want: 9},
+		{src: "10 - 4 - 3", want: 3},
+		{src: "12 / 3 / 2", want: 2},
+		{src: "7 % 4 + 1", want: 4},
+		{src: "1 + 2 < 4", want: 1},
+		{src: "1 < 2 == 2 < 3", want: 1},
+		{src: "0 || 1 && 0", want: 0},
+		{src: "1 || 0 && 0", want: 1},
+		{src: "!0 + 1", want: 2},
+		{src: "-3 + 5", want: 2},
+		{src: "--3", want: 3},
+
+		// power operators.
+		{src: "2 ^ 3", want: 8},
+		{src: "2 ** 3", want: 8},
+		{src: "2 ^ 3 ^ 2", want: 512},
+		{src: "2 ** 3 ** 2", want: 512},
+		{src: "(2 ^ 3) ^ 2", want: 64},
+		{src: "2 * 3 ^ 2", want: 18},
+		{src: "-2 ^ 2", want: -4},
+		{src: "2 ^ -1", want: 0.5},
+
+		// functions.
+		{src: "pi", want: math.Pi},
+		{src: "pi()", want: math.Pi},
+		{src: "sqrt(16) + pow(2, 3)", want: 12},
+		{src: "TMath::Sqrt(16) + TMath::Power(2, 3)", want: 12},
+		{src: "TMath::Abs(-2.5)", want: 2.5},
+		{src: "TMath::Min(3, 1) + TMath::Max(3, 1)", want: 4},
+		{src: "TMath::ATan2(1, 1)", want: math.Pi / 4},
+		{src: "TMath::Exp(0) + TMath::Log(1)", want: 1},
+
+		// variables.
+		{src: "x + 2 * y", x: 1, y: 2, want: 5},
+		{src: "(abs(y) <= 1.5) * (x > 1.0) * 0.95", x: 2, y: -1, want: 0.95},
+		{src: "(abs(y) <= 1.5) * (x > 1.0) * 0.95", x: 2, y: 2, want: 0},
+		{src: "(abs(y) <= 1.5) * (x > 1.0) * 0.95", x: 0.5, y: 1, want: 0},
+		{src: "sqrt(x^2*0.01^2 + x*0.02^2)", x: 100, y: 0, want: math.Sqrt(1 + 100*0.0004)},
+	} {
+		t.Run(tc.src, func(t *testing.T) {
+			f, err := newFormula(tc.src, "x", "y")
+			if err != nil {
+				t.Fatalf("could not compile formula: %+v", err)
+			}
+			got := f(tc.x, tc.y)
+			if math.Abs(got-tc.want) > 1e-12 {
+				t.Fatalf("invalid value: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormulaErrors(t *testing.T) {
+	for _, tc := range []struct {
+		src string
+		err string
+	}{
+		{src: "", err: "unexpected"},
+		{src: "1 +", err: "unexpected"},
+		{src: "(1 + 2", err: ")"},
+		{src: "1 2", err: `unexpected "2"`},
+		{src: "z + 1", err: "z"},
+		{src: "foo(1)", err: "unknown function foo"},
+		{src: "TMath::Abs(1, 2)", err: "unknown function TMath::Abs with 2 argument(s)"},
+		{src: "sqrt()", err: "unknown function sqrt with 0 argument(s)"},
+	} {
+		t.Run(tc.src, func(t *testing.T) {
+			_, err := newFormula(tc.src, "x", "y")
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("invalid error: got=%q, want=%q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestEvalExpr(t *testing.T) {
+	got, err := evalExpr("2 * pi")
+	if err != nil {
+		t.Fatalf("could not evaluate expression: %+v", err)
+	}
+	if got != 2*math.Pi {
+		t.Fatalf("invalid value: got=%v, want=%v", got, 2*math.Pi)
+	}
+
+	_, err = evalExpr("x + 1")
+	if err == nil {
+		t.Fatalf("expected an error for an unbound variable")
+	}
+}
@@ -64,6 +64,10 @@ func (s *HepMcStreamer) Disconnect() error {
 	return s.r.Close()
 }
 
+// HepMcReader converts the particles of a HepMC event into candidates.
+//
+// When its 'EventNumber' property is set, HepMcReader also puts the number of
+// the HepMC event, an int64, in the event store under that key.
 type HepMcReader struct {
 	fwk.TaskBase
 
@@ -71,6 +75,7 @@ type HepMcReader struct {
 	allparts    string // all particles
 	stableparts string // all stable particles
 	partons     string // all partons
+	evtnum      string // event number, if any
 }
 
 func (tsk *HepMcReader) Configure(ctx fwk.Context) error {
@@ -96,6 +101,13 @@ func (tsk *HepMcReader) Configure(ctx fwk.Context) error {
 		return err
 	}
 
+	if tsk.evtnum != "" {
+		err = tsk.DeclOutPort(tsk.evtnum, reflect.TypeOf(int64(0)))
+		if err != nil {
+			return err
+		}
+	}
+
 	return err
 }
 
@@ -126,12 +138,49 @@ func (tsk *HepMcReader) Process(ctx fwk.Context) error {
 		len(evt.Particles), len(evt.Vertices),
 	)
 
-	allparts := make([]Candidate, 0, len(evt.Particles)/2)
-	stableparts := make([]Candidate, 0)
-	partons := make([]Candidate, 0)
+	allparts, stableparts, partons := newMcCandidates(&evt)
+
+	err = store.Put(tsk.allparts, allparts)
+	if err != nil {
+		return err
+	}
+
+	err = store.Put(tsk.stableparts, stableparts)
+	if err != nil {
+		return err
+	}
+
+	err = store.Put(tsk.partons, partons)
+	if err != nil {
+		return err
+	}
+
+	if tsk.evtnum != "" {
+		err = store.Put(tsk.evtnum, int64(evt.EventNumber))
+		if err != nil {
+			return err
+		}
+	}
+
+	msg.Debugf("allparts: %d\nstables: %d\npartons: %d\n",
+		len(allparts),
+		len(stableparts),
+		len(partons),
+	)
+
+	return err
+}
+
+// newMcCandidates converts the particles of a HepMC event into all, stable
+// and parton candidates, sorted by descending Pt.
+func newMcCandidates(evt *hepmc.Event) (allparts, stableparts, partons []Candidate) {
+	allparts = make([]Candidate, 0, len(evt.Particles)/2)
+	stableparts = make([]Candidate, 0)
+	partons = make([]Candidate, 0)
 
 	for _, p := range evt.Particles {
 		allparts = append(allparts, Candidate{
+			ID:         newCandidateID(),
 			Pid:        int32(p.PdgID),
 			Status:     int32(p.Status),
 			M2:         1,
@@ -176,30 +225,10 @@ func (tsk *HepMcReader) Process(ctx fwk.Context) error {
 	}
 
 	sort.Sort(ByPt(allparts))
-	err = store.Put(tsk.allparts, allparts)
-	if err != nil {
-		return err
-	}
-
 	sort.Sort(ByPt(stableparts))
-	err = store.Put(tsk.stableparts, stableparts)
-	if err != nil {
-		return err
-	}
-
 	sort.Sort(ByPt(partons))
-	err = store.Put(tsk.partons, partons)
-	if err != nil {
-		return err
-	}
-
-	msg.Debugf("allparts: %d\nstables: %d\npartons: %d\n",
-		len(allparts),
-		len(stableparts),
-		len(partons),
-	)
 
-	return err
+	return allparts, stableparts, partons
 }
 
 func init() {
@@ -220,6 +249,11 @@ func init() {
 				return nil, err
 			}
 
+			err = tsk.DeclProp("EventNumber", &tsk.evtnum)
+			if err != nil {
+				return nil, err
+			}
+
 			return tsk, err
 		},
 	)
@@ -128,7 +128,7 @@ func (tsk *Isolation) Process(ctx fwk.Context) error {
 	}
 
 	for i := range candidates {
-		cand := &input[i]
+		cand := &candidates[i]
 		eta := math.Abs(cand.Mom.Eta())
 		sum := 0.0
 
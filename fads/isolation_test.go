@@ -0,0 +1,62 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+)
+
+func TestIsolation(t *testing.T) {
+	var (
+		ele1 = newCand(11, 50, 0, 0)
+		ele2 = newCand(-11, 40, 1, 2)
+		ele3 = newCand(11, 20, -1, -2)
+		had1 = newCand(211, 1, 0.2, 0.2)  // close to ele1, below ratio
+		had5 = newCand(211, 5, 1.1, 2.1)  // close to ele2, above ratio
+		soft = newCand(22, 0.3, 0, 0.1)   // below PtMin
+		far  = newCand(211, 30, -3, -0.5) // isolated hadron
+	)
+
+	evts := runTasks(t,
+		[]map[string]any{
+			{
+				"/fads/electrons": []Candidate{ele1, ele2},
+				"/fads/particles": []Candidate{far, ele2, had5, ele1, had1, soft},
+			},
+			{
+				// more candidates than isolation particles.
+				"/fads/electrons": []Candidate{ele1, ele3},
+				"/fads/particles": []Candidate{ele1},
+			},
+		},
+		[]fwk.Port{{Name: "/fads/isolated", Type: reflect.TypeOf([]Candidate{})}},
+		job.C{
+			Type: "go-hep.org/x/hep/fads.Isolation",
+			Name: "isolation",
+			Props: job.P{
+				"Candidates": "/fads/electrons",
+				"Isolations": "/fads/particles",
+				"Output":     "/fads/isolated",
+				"DeltaRMax":  0.5,
+				"PtRatioMax": 0.1,
+				"PtMin":      0.5,
+			},
+		},
+	)
+
+	for i, want := range [][]Candidate{
+		{ele1},
+		{ele1, ele3},
+	} {
+		got := evts[i]["/fads/isolated"].([]Candidate)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("evt[%d]: invalid isolated candidates:\ngot= %v\nwant=%v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/fmom"
+	"go-hep.org/x/hep/fwk"
+)
+
+// JetPileUpSubtractor corrects the momentum of jets for the pile-up
+// contamination, by subtracting rho times the area of each jet.
+//
+// The jets must have been clustered by a FastJetFinder with an area
+// algorithm, and the rho values are the ones computed by a FastJetFinder
+// with ComputeRho, for the absolute eta range of each jet.
+// The corrected jets with a transverse momentum below JetPtMin are dropped.
+type JetPileUpSubtractor struct {
+	fwk.TaskBase
+
+	jets   string
+	rhos   string
+	output string
+
+	jetPtMin float64
+}
+
+func (tsk *JetPileUpSubtractor) Configure(ctx fwk.Context) error {
+	var err error
+
+	err = tsk.DeclInPort(tsk.jets, reflect.TypeOf([]Candidate{}))
+	if err != nil {
+		return err
+	}
+
+	err = tsk.DeclInPort(tsk.rhos, reflect.TypeOf([]Candidate{}))
+	if err != nil {
+		return err
+	}
+
+	err = tsk.DeclOutPort(tsk.output, reflect.TypeOf([]Candidate{}))
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+func (tsk *JetPileUpSubtractor) StartTask(ctx fwk.Context) error {
+	var err error
+
+	return err
+}
+
+func (tsk *JetPileUpSubtractor) StopTask(ctx fwk.Context) error {
+	var err error
+
+	return err
+}
+
+func (tsk *JetPileUpSubtractor) Process(ctx fwk.Context) error {
+	var err error
+
+	store := ctx.Store()
+	msg := ctx.Msg()
+
+	v, err := store.Get(tsk.jets)
+	if err != nil {
+		return err
+	}
+	jets := v.([]Candidate)
+
+	v, err = store.Get(tsk.rhos)
+	if err != nil {
+		return err
+	}
+	rhos := v.([]Candidate)
+
+	output := make([]Candidate, 0, len(jets))
+	for i := range jets {
+		jet := jets[i].Clone()
+		eta := math.Abs(jet.Mom.Eta())
+
+		// find rho
+		rho := 0.0
+		for j := range rhos {
+			obj := &rhos[j]
+			if eta >= obj.Edges[0] && eta < obj.Edges[1] {
+				rho = obj.Mom.Pt()
+			}
+		}
+
+		// subtract pile-up contribution
+		jet.Mom = fmom.NewPxPyPzE(
+			jet.Mom.Px()-rho*jet.Area.Px(),
+			jet.Mom.Py()-rho*jet.Area.Py(),
+			jet.Mom.Pz()-rho*jet.Area.Pz(),
+			jet.Mom.E()-rho*jet.Area.E(),
+		)
+
+		if jet.Mom.Pt() < tsk.jetPtMin {
+			continue
+		}
+		output = append(output, *jet)
+	}
+
+	msg.Debugf(">>> jets: %v -> %v\n", len(jets), len(output))
+
+	err = store.Put(tsk.output, output)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+func newJetPileUpSubtractor(typ, name string, mgr fwk.App) (fwk.Component, error) {
+	var err error
+
+	tsk := &JetPileUpSubtractor{
+		TaskBase: fwk.NewTask(typ, name, mgr),
+		jets:     "/fads/jets",
+		rhos:     "/fads/rho",
+		output:   "/fads/JetPileUpSubtractor/jets",
+		jetPtMin: 10.0,
+	}
+
+	err = tsk.DeclProp("Jets", &tsk.jets)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("Rhos", &tsk.rhos)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("Output", &tsk.output)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("JetPtMin", &tsk.jetPtMin)
+	if err != nil {
+		return nil, err
+	}
+
+	return tsk, err
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(JetPileUpSubtractor{}), newJetPileUpSubtractor)
+}
@@ -0,0 +1,99 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fmom"
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+)
+
+func TestJetPileUpSubtractor(t *testing.T) {
+	jet := func(pt, eta, phi, area float64) Candidate {
+		c := newCand(0, pt, eta, phi)
+		c.Area = fmom.NewPxPyPzE(
+			c.Mom.Px()*area/pt,
+			c.Mom.Py()*area/pt,
+			c.Mom.Pz()*area/pt,
+			c.Mom.E()*area/pt,
+		)
+		c.Add(&Candidate{Pid: 211, Mom: c.Mom})
+		return c
+	}
+	rho := func(etamin, etamax, rho float64) Candidate {
+		return Candidate{
+			Mom:   fmom.NewPxPyPzE(rho, 0, 0, rho),
+			Edges: [4]float64{etamin, etamax},
+		}
+	}
+
+	var (
+		jets = []Candidate{
+			jet(50, 0.5, 0, 0.5),  // central: 50-4*0.5=48
+			jet(30, -3, 1, 0.4),   // forward: 30-2*0.4=29.2
+			jet(21, 1.5, -2, 0.5), // central: 21-4*0.5=19 < JetPtMin
+			jet(25, 6, 2, 0.5),    // no rho: 25
+		}
+		rhos = []Candidate{rho(0, 2.5, 4), rho(2.5, 5, 2)}
+	)
+
+	evts := runTasks(t,
+		[]map[string]any{{
+			"/fads/jets": jets,
+			"/fads/rhos": rhos,
+		}},
+		[]fwk.Port{{Name: "/fads/subtracted", Type: reflect.TypeOf([]Candidate{})}},
+		job.C{
+			Type: "go-hep.org/x/hep/fads.JetPileUpSubtractor",
+			Name: "subtractor",
+			Props: job.P{
+				"Jets":     "/fads/jets",
+				"Rhos":     "/fads/rhos",
+				"Output":   "/fads/subtracted",
+				"JetPtMin": 20.0,
+			},
+		},
+	)
+
+	got := evts[0]["/fads/subtracted"].([]Candidate)
+	want := []struct {
+		jet *Candidate
+		pt  float64
+	}{
+		{&jets[0], 48},
+		{&jets[1], 29.2},
+		{&jets[3], 25},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("invalid number of jets: got=%d, want=%d", len(got), len(want))
+	}
+	for i := range got {
+		var (
+			jet = &got[i]
+			ref = want[i].jet
+		)
+		if got, want := jet.Mom.Pt(), want[i].pt; math.Abs(got-want) > 1e-9 {
+			t.Fatalf("jet[%d]: invalid pt: got=%v, want=%v", i, got, want)
+		}
+		if got, want := jet.Mom.Eta(), ref.Mom.Eta(); math.Abs(got-want) > 1e-9 {
+			t.Fatalf("jet[%d]: invalid eta: got=%v, want=%v", i, got, want)
+		}
+		if got, want := jet.Mom.Phi(), ref.Mom.Phi(); math.Abs(got-want) > 1e-9 {
+			t.Fatalf("jet[%d]: invalid phi: got=%v, want=%v", i, got, want)
+		}
+		if !reflect.DeepEqual(jet.Candidates, ref.Candidates) {
+			t.Fatalf("jet[%d]: invalid constituents", i)
+		}
+	}
+
+	// input jets are left untouched.
+	if got, want := jets[0].Mom.Pt(), 50.0; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("input jet modified: pt=%v", got)
+	}
+}
@@ -96,7 +96,7 @@ func (tsk *Merger) Process(ctx fwk.Context) error {
 		}
 	}
 
-	var cmom Candidate
+	cmom := Candidate{ID: newCandidateID()}
 	cmom.Mom.Set(mom)
 
 	err = store.Put(tsk.outmom, cmom)
@@ -104,7 +104,7 @@ func (tsk *Merger) Process(ctx fwk.Context) error {
 		return err
 	}
 
-	var cene Candidate
+	cene := Candidate{ID: newCandidateID()}
 
 	eta := 0.0
 	phi := 0.0
@@ -0,0 +1,118 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/fwk"
+)
+
+// MissingEtCalculator computes the missing transverse energy of an event,
+// as the opposite of the vector sum of the transverse momenta of the
+// candidates of its input collections.
+//
+// The input collections are typically the eflow tracks, photons and neutral
+// hadrons of an EnergyFlow task, or the towers of a Calorimeter.
+type MissingEtCalculator struct {
+	fwk.TaskBase
+
+	inputs []string
+	output string
+}
+
+func (tsk *MissingEtCalculator) Configure(ctx fwk.Context) error {
+	var err error
+
+	for _, input := range tsk.inputs {
+		err = tsk.DeclInPort(input, reflect.TypeOf([]Candidate{}))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tsk.DeclOutPort(tsk.output, reflect.TypeOf(MissingEt{}))
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+func (tsk *MissingEtCalculator) StartTask(ctx fwk.Context) error {
+	var err error
+
+	return err
+}
+
+func (tsk *MissingEtCalculator) StopTask(ctx fwk.Context) error {
+	var err error
+
+	return err
+}
+
+func (tsk *MissingEtCalculator) Process(ctx fwk.Context) error {
+	var err error
+
+	store := ctx.Store()
+	msg := ctx.Msg()
+
+	px := 0.0
+	py := 0.0
+	for _, k := range tsk.inputs {
+		v, err := store.Get(k)
+		if err != nil {
+			return err
+		}
+		input := v.([]Candidate)
+		msg.Debugf(">>> input[%s]: %v\n", k, len(input))
+
+		for i := range input {
+			cand := &input[i]
+			px += cand.Mom.Px()
+			py += cand.Mom.Py()
+		}
+	}
+
+	met := MissingEt{
+		MET: math.Hypot(px, py),
+		Phi: math.Atan2(-py, -px),
+	}
+	msg.Debugf(">>> met: %v\n", met)
+
+	err = store.Put(tsk.output, met)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+func newMissingEtCalculator(typ, name string, mgr fwk.App) (fwk.Component, error) {
+	var err error
+
+	tsk := &MissingEtCalculator{
+		TaskBase: fwk.NewTask(typ, name, mgr),
+		inputs:   []string{},
+		output:   "/fads/MissingEt",
+	}
+
+	err = tsk.DeclProp("Inputs", &tsk.inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("Output", &tsk.output)
+	if err != nil {
+		return nil, err
+	}
+
+	return tsk, err
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(MissingEtCalculator{}), newMissingEtCalculator)
+}
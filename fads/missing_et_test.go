@@ -0,0 +1,61 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+)
+
+func TestMissingEtCalculator(t *testing.T) {
+	evts := runTasks(t,
+		[]map[string]any{
+			{
+				"/fads/tracks": []Candidate{newCand(211, 10, 0, 0), newCand(-211, 5, 1, math.Pi/2)},
+				"/fads/towers": []Candidate{newCand(22, 20, -1, math.Pi)},
+			},
+			{
+				// balanced event.
+				"/fads/tracks": []Candidate{newCand(211, 10, 0, 1), newCand(-211, 10, 2, 1-math.Pi)},
+				"/fads/towers": []Candidate{},
+			},
+			{
+				"/fads/tracks": []Candidate{},
+				"/fads/towers": []Candidate{newCand(22, 3, 0.5, -math.Pi/2)},
+			},
+		},
+		[]fwk.Port{{Name: "/fads/met", Type: reflect.TypeOf(MissingEt{})}},
+		job.C{
+			Type: "go-hep.org/x/hep/fads.MissingEtCalculator",
+			Name: "met",
+			Props: job.P{
+				"Inputs": []string{"/fads/tracks", "/fads/towers"},
+				"Output": "/fads/met",
+			},
+		},
+	)
+
+	for i, want := range []MissingEt{
+		// sum: px=10-20=-10, py=5.
+		{MET: math.Hypot(10, 5), Phi: math.Atan2(-5, 10)},
+		{MET: 0},
+		{MET: 3, Phi: math.Pi / 2},
+	} {
+		got := evts[i]["/fads/met"].(MissingEt)
+		if math.Abs(got.MET-want.MET) > 1e-12 {
+			t.Fatalf("evt[%d]: invalid MET: got=%v, want=%v", i, got.MET, want.MET)
+		}
+		if want.MET == 0 {
+			continue
+		}
+		if math.Abs(got.Phi-want.Phi) > 1e-12 {
+			t.Fatalf("evt[%d]: invalid phi: got=%v, want=%v", i, got.Phi, want.Phi)
+		}
+	}
+}
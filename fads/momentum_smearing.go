@@ -6,9 +6,7 @@ package fads
 
 import (
 	"math"
-	"math/rand/v2"
 	"reflect"
-	"sync"
 
 	"go-hep.org/x/hep/fmom"
 	"go-hep.org/x/hep/fwk"
@@ -22,9 +20,7 @@ type MomentumSmearing struct {
 	output string
 
 	smear func(x, y float64) float64
-	seed  uint64
-	src   *rand.Rand
-	srcmu sync.Mutex
+	rnd   randgen
 }
 
 func (tsk *MomentumSmearing) Configure(ctx fwk.Context) error {
@@ -44,9 +40,7 @@ func (tsk *MomentumSmearing) Configure(ctx fwk.Context) error {
 }
 
 func (tsk *MomentumSmearing) StartTask(ctx fwk.Context) error {
-	var err error
-	tsk.src = rand.New(rand.NewPCG(tsk.seed, tsk.seed))
-	return err
+	return tsk.rnd.start(ctx)
 }
 
 func (tsk *MomentumSmearing) StopTask(ctx fwk.Context) error {
@@ -59,6 +53,10 @@ func (tsk *MomentumSmearing) Process(ctx fwk.Context) error {
 	var err error
 	store := ctx.Store()
 	msg := ctx.Msg()
+	src, err := tsk.rnd.rand(ctx, tsk.Name())
+	if err != nil {
+		return err
+	}
 
 	v, err := store.Get(tsk.input)
 	if err != nil {
@@ -79,10 +77,8 @@ func (tsk *MomentumSmearing) Process(ctx fwk.Context) error {
 		pt := cand.Mom.Pt()
 
 		// apply smearing
-		tsk.srcmu.Lock()
-		smearPt := distuv.Normal{Mu: pt, Sigma: tsk.smear(pt, eta) * pt, Src: tsk.src}
+		smearPt := distuv.Normal{Mu: pt, Sigma: tsk.smear(pt, eta) * pt, Src: src}
 		pt = smearPt.Rand()
-		tsk.srcmu.Unlock()
 
 		if pt <= 0 {
 			continue
@@ -117,7 +113,7 @@ func init() {
 				input:    "InputParticles",
 				output:   "OutputParticles",
 				smear:    func(x, y float64) float64 { return 0 },
-				seed:     1234,
+				rnd:      randgen{seed: 1234},
 			}
 
 			err = tsk.DeclProp("Input", &tsk.input)
@@ -135,7 +131,12 @@ func init() {
 				return nil, err
 			}
 
-			err = tsk.DeclProp("Seed", &tsk.seed)
+			err = tsk.DeclProp("Seed", &tsk.rnd.seed)
+			if err != nil {
+				return nil, err
+			}
+
+			err = tsk.DeclProp("RandSvc", &tsk.rnd.name)
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,434 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"bufio"
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"go-hep.org/x/hep/fmom"
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/hepmc"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// cLight is the speed of light, in mm/s.
+const cLight = 2.99792458e11
+
+// PileUpMerger overlays minimum-bias events onto each hard-scatter event.
+//
+// The number of overlaid events is drawn from a Poisson distribution with
+// mean MeanPileUp.
+// Minimum-bias events are picked at random among the events of the HepMC
+// file PileUpFile.
+// The events of that file are indexed when the task starts and decoded on
+// demand: at most CacheSize decoded events are kept in memory.
+// Each overlaid event is rotated by a random angle in phi and its vertex is
+// smeared in z and time with Gaussian distributions of widths ZVertexSpread
+// (in meters) and TVertexSpread (in seconds).
+// The vertex of the hard-scatter event is smeared in the same way.
+//
+// The stable particles of the overlaid events are tagged with IsPU=1.
+// Their mother and daughter indices (M1, M2, D1, D2) are set to -1, as they
+// do not refer to the particles of the hard-scatter event.
+type PileUpMerger struct {
+	fwk.TaskBase
+
+	input  string
+	output string
+	npu    string
+
+	fname   string
+	mean    float64
+	zspread float64 // in meters
+	tspread float64 // in seconds
+	ncache  int     // maximum number of decoded minimum-bias events in memory
+
+	pileup *pileUpReader
+
+	rnd randgen
+}
+
+func (tsk *PileUpMerger) Configure(ctx fwk.Context) error {
+	var err error
+
+	if tsk.mean < 0 {
+		return fmt.Errorf("%s: invalid mean pile-up (%v)", tsk.Name(), tsk.mean)
+	}
+
+	if tsk.ncache <= 0 {
+		return fmt.Errorf("%s: invalid cache size (%d)", tsk.Name(), tsk.ncache)
+	}
+
+	err = tsk.DeclInPort(tsk.input, reflect.TypeOf([]Candidate{}))
+	if err != nil {
+		return err
+	}
+
+	err = tsk.DeclOutPort(tsk.output, reflect.TypeOf([]Candidate{}))
+	if err != nil {
+		return err
+	}
+
+	if tsk.npu != "" {
+		err = tsk.DeclOutPort(tsk.npu, reflect.TypeOf(int(0)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (tsk *PileUpMerger) StartTask(ctx fwk.Context) error {
+	err := tsk.rnd.start(ctx)
+	if err != nil {
+		return err
+	}
+
+	tsk.pileup, err = openPileUp(tsk.fname, tsk.ncache)
+	if err != nil {
+		return fmt.Errorf("%s: %w", tsk.Name(), err)
+	}
+	if tsk.pileup.len() == 0 && tsk.mean > 0 {
+		_ = tsk.pileup.close()
+		tsk.pileup = nil
+		return fmt.Errorf("%s: no minimum-bias event in %q", tsk.Name(), tsk.fname)
+	}
+	ctx.Msg().Infof("indexed %d minimum-bias events from %q\n", tsk.pileup.len(), tsk.fname)
+
+	return nil
+}
+
+func (tsk *PileUpMerger) StopTask(ctx fwk.Context) error {
+	if tsk.pileup == nil {
+		return nil
+	}
+	err := tsk.pileup.close()
+	tsk.pileup = nil
+	return err
+}
+
+func (tsk *PileUpMerger) Process(ctx fwk.Context) error {
+	var err error
+	store := ctx.Store()
+	msg := ctx.Msg()
+	src, err := tsk.rnd.rand(ctx, tsk.Name())
+	if err != nil {
+		return err
+	}
+
+	v, err := store.Get(tsk.input)
+	if err != nil {
+		return err
+	}
+
+	input := v.([]Candidate)
+	msg.Debugf(">>> input: %v\n", len(input))
+
+	var (
+		zdist = distuv.Normal{Mu: 0, Sigma: tsk.zspread * 1e3, Src: src}
+		tdist = distuv.Normal{Mu: 0, Sigma: tsk.tspread * cLight, Src: src}
+		npu   = 0
+	)
+	if tsk.mean > 0 {
+		npu = int(distuv.Poisson{Lambda: tsk.mean, Src: src}.Rand())
+	}
+
+	output := make([]Candidate, 0, len(input))
+
+	// hard-scatter vertex
+	dz, dt := zdist.Rand(), tdist.Rand()
+	for i := range input {
+		c := input[i]
+		c.Pos = fmom.NewPxPyPzE(c.Pos.X(), c.Pos.Y(), c.Pos.Z()+dz, c.Pos.T()+dt)
+		output = append(output, c)
+	}
+
+	for range npu {
+		evt, err := tsk.pileup.event(src.IntN(tsk.pileup.len()))
+		if err != nil {
+			return fmt.Errorf("%s: %w", tsk.Name(), err)
+		}
+		dphi := (2*src.Float64() - 1) * math.Pi
+		sin, cos := math.Sincos(dphi)
+		dz, dt := zdist.Rand(), tdist.Rand()
+
+		for i := range evt {
+			c := evt[i]
+			c.ID = newCandidateID() // pile-up events may be overlaid more than once.
+			c.IsPU = 1
+			c.Mom = fmom.NewPxPyPzE(
+				cos*c.Mom.Px()-sin*c.Mom.Py(),
+				sin*c.Mom.Px()+cos*c.Mom.Py(),
+				c.Mom.Pz(),
+				c.Mom.E(),
+			)
+			c.Pos = fmom.NewPxPyPzE(
+				cos*c.Pos.X()-sin*c.Pos.Y(),
+				sin*c.Pos.X()+cos*c.Pos.Y(),
+				c.Pos.Z()+dz,
+				c.Pos.T()+dt,
+			)
+			output = append(output, c)
+		}
+	}
+
+	sort.Sort(ByPt(output))
+	msg.Debugf(">>> pile-up: %v (output: %v)\n", npu, len(output))
+
+	err = store.Put(tsk.output, output)
+	if err != nil {
+		return err
+	}
+
+	if tsk.npu != "" {
+		err = store.Put(tsk.npu, npu)
+		if err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// pileUpReader gives random access to the stable particles of the events
+// of a HepMC file.
+//
+// The offsets of the events are indexed when the file is opened, and events
+// are decoded when requested. The most recently used events are cached, up
+// to a maximum number of events.
+type pileUpReader struct {
+	f    *os.File
+	hdr  string  // event listing start key of the file
+	offs []int64 // offsets of the events, followed by the end of the last event
+
+	mu    sync.Mutex
+	max   int                   // maximum number of cached events
+	lru   *list.List            // cached events, most recently used first
+	cache map[int]*list.Element // cached events, by index
+}
+
+type pileUpEvent struct {
+	idx   int
+	parts []Candidate
+}
+
+func openPileUp(fname string, max int) (*pileUpReader, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return nil, fmt.Errorf("could not open pile-up file: %w", err)
+	}
+
+	r := &pileUpReader{
+		f:     f,
+		max:   max,
+		lru:   list.New(),
+		cache: make(map[int]*list.Element),
+	}
+
+	err = r.index()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("could not index pile-up file: %w", err)
+	}
+
+	return r, nil
+}
+
+// index records the offsets of the events of the HepMC file.
+func (r *pileUpReader) index() error {
+	var (
+		br  = bufio.NewReader(r.f)
+		off int64
+	)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if line == "" && err != nil {
+			break
+		}
+
+		switch {
+		case r.hdr == "":
+			if key := strings.TrimSpace(line); strings.HasPrefix(key, "HepMC::") && strings.HasSuffix(key, "-START_EVENT_LISTING") {
+				r.hdr = key
+			}
+		case strings.HasPrefix(line, "E "):
+			r.offs = append(r.offs, off)
+		case strings.HasPrefix(line, "HepMC::"):
+			// end of the event listing.
+			r.offs = append(r.offs, off)
+			return nil
+		}
+		off += int64(len(line))
+	}
+
+	if r.hdr == "" {
+		return fmt.Errorf("no HepMC event listing")
+	}
+	r.offs = append(r.offs, off)
+	return nil
+}
+
+// len returns the number of events of the file.
+func (r *pileUpReader) len() int {
+	if len(r.offs) == 0 {
+		return 0
+	}
+	return len(r.offs) - 1
+}
+
+// event returns the stable particles of the i-th event of the file.
+// The returned slice must not be modified.
+func (r *pileUpReader) event(i int) ([]Candidate, error) {
+	r.mu.Lock()
+	if elem, ok := r.cache[i]; ok {
+		r.lru.MoveToFront(elem)
+		r.mu.Unlock()
+		return elem.Value.(*pileUpEvent).parts, nil
+	}
+	r.mu.Unlock()
+
+	parts, err := r.read(i)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if elem, ok := r.cache[i]; ok {
+		// event concurrently read by another worker.
+		r.lru.MoveToFront(elem)
+		return elem.Value.(*pileUpEvent).parts, nil
+	}
+	r.cache[i] = r.lru.PushFront(&pileUpEvent{idx: i, parts: parts})
+	for r.lru.Len() > r.max {
+		elem := r.lru.Back()
+		r.lru.Remove(elem)
+		delete(r.cache, elem.Value.(*pileUpEvent).idx)
+	}
+	return parts, nil
+}
+
+// read decodes the stable particles of the i-th event of the file.
+func (r *pileUpReader) read(i int) ([]Candidate, error) {
+	var (
+		sec = io.NewSectionReader(r.f, r.offs[i], r.offs[i+1]-r.offs[i])
+		dec = hepmc.NewDecoder(io.MultiReader(strings.NewReader(r.hdr+"\n"), sec))
+		evt hepmc.Event
+	)
+	err := dec.Decode(&evt)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode pile-up event %d: %w", i, err)
+	}
+
+	_, parts, _ := newMcCandidates(&evt)
+	for j := range parts {
+		c := &parts[j]
+		c.M1, c.M2, c.D1, c.D2 = -1, -1, -1, -1
+	}
+
+	err = hepmc.Delete(&evt)
+	if err != nil {
+		return nil, fmt.Errorf("could not release pile-up event %d: %w", i, err)
+	}
+
+	// reach the end of the event, so the decoder can release its resources.
+	var tail hepmc.Event
+	err = dec.Decode(&tail)
+	if !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("could not decode pile-up event %d: unexpected data after event", i)
+	}
+
+	return parts, nil
+}
+
+func (r *pileUpReader) close() error {
+	return r.f.Close()
+}
+
+func newPileUpMerger(typ, name string, mgr fwk.App) (fwk.Component, error) {
+	var err error
+	tsk := &PileUpMerger{
+		TaskBase: fwk.NewTask(typ, name, mgr),
+		input:    "/fads/StableParticles",
+		output:   "StableParticles",
+		npu:      "",
+		fname:    "MinBias.hepmc",
+		mean:     10,
+		zspread:  0.053,
+		tspread:  1.5e-9,
+		ncache:   100,
+		rnd:      randgen{seed: 1234},
+	}
+
+	err = tsk.DeclProp("Input", &tsk.input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("Output", &tsk.output)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("NPileUp", &tsk.npu)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("PileUpFile", &tsk.fname)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("MeanPileUp", &tsk.mean)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("ZVertexSpread", &tsk.zspread)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("TVertexSpread", &tsk.tspread)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("CacheSize", &tsk.ncache)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("Seed", &tsk.rnd.seed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("RandSvc", &tsk.rnd.name)
+	if err != nil {
+		return nil, err
+	}
+
+	return tsk, err
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(PileUpMerger{}), newPileUpMerger)
+}
@@ -0,0 +1,317 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+	_ "go-hep.org/x/hep/fwk/randsvc"
+	"go-hep.org/x/hep/hepmc"
+)
+
+func TestPileUpReader(t *testing.T) {
+	const fname = "testdata/hepmc.data"
+
+	f, err := os.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var (
+		want [][]Candidate
+		dec  = hepmc.NewDecoder(bufio.NewReader(f))
+	)
+	for {
+		var evt hepmc.Event
+		err := dec.Decode(&evt)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("could not decode event: %+v", err)
+		}
+		_, parts, _ := newMcCandidates(&evt)
+		for i := range parts {
+			c := &parts[i]
+			c.M1, c.M2, c.D1, c.D2 = -1, -1, -1, -1
+			c.ID = 0
+		}
+		want = append(want, parts)
+	}
+
+	const ncache = 2
+	r, err := openPileUp(fname, ncache)
+	if err != nil {
+		t.Fatalf("could not open pile-up file: %+v", err)
+	}
+	defer r.close()
+
+	if got, want := r.len(), len(want); got != want {
+		t.Fatalf("invalid number of events: got=%d, want=%d", got, want)
+	}
+
+	for _, i := range []int{4, 0, 2, 0, 3, 1, 4, 4} {
+		got, err := r.event(i)
+		if err != nil {
+			t.Fatalf("could not read event %d: %+v", i, err)
+		}
+		got = slices.Clone(got)
+		for j := range got {
+			got[j].ID = 0
+		}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Fatalf("invalid event %d", i)
+		}
+		if n := r.lru.Len(); n > ncache || len(r.cache) != n {
+			t.Fatalf("invalid cache size: lru=%d, cache=%d, max=%d", n, len(r.cache), ncache)
+		}
+		if front := r.lru.Front().Value.(*pileUpEvent).idx; front != i {
+			t.Fatalf("invalid most recently used event: got=%d, want=%d", front, i)
+		}
+	}
+}
+
+func TestPileUpReaderInvalid(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "invalid.hepmc")
+	err := os.WriteFile(fname, []byte("not a HepMC file\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = openPileUp(fname, 1)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestPileUpMerger(t *testing.T) {
+	const (
+		input = "testdata/hepmc.data"
+		mean  = 3
+	)
+
+	for _, nprocs := range []int{0, 2} {
+		app := job.New(job.P{
+			"EvtMax":   int64(-1),
+			"NProcs":   nprocs,
+			"MsgLevel": job.MsgLevel("ERROR"),
+		})
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk.InputStream",
+			Name: "hepmc-streamer",
+			Props: job.P{
+				"Ports": []fwk.Port{
+					{Name: "/fads/McEvent", Type: reflect.TypeOf(hepmc.Event{})},
+				},
+				"Streamer": &HepMcStreamer{Name: input},
+			},
+		})
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fads.HepMcReader",
+			Name: "hepmcreader",
+			Props: job.P{
+				"Input": "/fads/McEvent",
+			},
+		})
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fads.PileUpMerger",
+			Name: "pileup",
+			Props: job.P{
+				"Input":      "/fads/StableParticles",
+				"Output":     "/fads/PileUp/stableParticles",
+				"NPileUp":    "/fads/PileUp/n",
+				"PileUpFile": input,
+				"MeanPileUp": float64(mean),
+				"CacheSize":  2,
+			},
+		})
+
+		out := &collector{}
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk.OutputStream",
+			Name: "collector",
+			Props: job.P{
+				"Ports": []fwk.Port{
+					{Name: "/fads/StableParticles", Type: reflect.TypeOf([]Candidate{})},
+					{Name: "/fads/PileUp/stableParticles", Type: reflect.TypeOf([]Candidate{})},
+					{Name: "/fads/PileUp/n", Type: reflect.TypeOf(int(0))},
+				},
+				"Streamer": out,
+			},
+		})
+
+		err := app.App().Run()
+		if err != nil {
+			t.Fatalf("nprocs=%d: could not run pile-up merger: %+v", nprocs, err)
+		}
+
+		// the smallest minimum-bias event.
+		nmin := -1
+		r, err := openPileUp(input, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range r.len() {
+			parts, err := r.event(i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if nmin < 0 || len(parts) < nmin {
+				nmin = len(parts)
+			}
+		}
+		r.close()
+
+		if len(out.evts) == 0 {
+			t.Fatalf("nprocs=%d: no event", nprocs)
+		}
+
+		var tot int
+		for i, evt := range out.evts {
+			var (
+				hard   = evt["/fads/StableParticles"].([]Candidate)
+				output = evt["/fads/PileUp/stableParticles"].([]Candidate)
+				npu    = evt["/fads/PileUp/n"].(int)
+			)
+			tot += npu
+
+			var nhard, npart int
+			for j := range output {
+				c := &output[j]
+				if c.IsPU == 0 {
+					nhard++
+					continue
+				}
+				npart++
+				if c.M1 != -1 || c.M2 != -1 || c.D1 != -1 || c.D2 != -1 {
+					t.Fatalf("nprocs=%d: evt[%d]: pile-up candidate %d with mothers/daughters (%d, %d, %d, %d)",
+						nprocs, i, j, c.M1, c.M2, c.D1, c.D2,
+					)
+				}
+			}
+			if nhard != len(hard) {
+				t.Fatalf("nprocs=%d: evt[%d]: invalid number of hard-scatter candidates: got=%d, want=%d",
+					nprocs, i, nhard, len(hard),
+				)
+			}
+			if npart < npu*nmin {
+				t.Fatalf("nprocs=%d: evt[%d]: invalid number of pile-up candidates: got=%d, want>=%d",
+					nprocs, i, npart, npu*nmin,
+				)
+			}
+			if npu == 0 && npart != 0 {
+				t.Fatalf("nprocs=%d: evt[%d]: pile-up candidates without pile-up", nprocs, i)
+			}
+			if !sort.IsSorted(ByPt(output)) {
+				t.Fatalf("nprocs=%d: evt[%d]: candidates not sorted by pt", nprocs, i)
+			}
+		}
+		if tot == 0 {
+			t.Fatalf("nprocs=%d: no pile-up event overlaid", nprocs)
+		}
+	}
+}
+
+func TestPileUpMergerRandSvc(t *testing.T) {
+	const input = "testdata/hepmc.data"
+
+	run := func(nprocs int) map[int64]int {
+		app := job.New(job.P{
+			"EvtMax":   int64(-1),
+			"NProcs":   nprocs,
+			"MsgLevel": job.MsgLevel("ERROR"),
+		})
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk/randsvc.rsvc",
+			Name: "rndsvc",
+			Props: job.P{
+				"Seed":     uint64(42),
+				"EventKey": "/fads/EventNumber",
+			},
+		})
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk.InputStream",
+			Name: "hepmc-streamer",
+			Props: job.P{
+				"Ports": []fwk.Port{
+					{Name: "/fads/McEvent", Type: reflect.TypeOf(hepmc.Event{})},
+				},
+				"Streamer": &HepMcStreamer{Name: input},
+			},
+		})
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fads.HepMcReader",
+			Name: "hepmcreader",
+			Props: job.P{
+				"Input":       "/fads/McEvent",
+				"EventNumber": "/fads/EventNumber",
+			},
+		})
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fads.PileUpMerger",
+			Name: "pileup",
+			Props: job.P{
+				"Input":      "/fads/StableParticles",
+				"Output":     "/fads/PileUp/stableParticles",
+				"NPileUp":    "/fads/PileUp/n",
+				"PileUpFile": input,
+				"MeanPileUp": 3.0,
+				"RandSvc":    "rndsvc",
+			},
+		})
+
+		out := &collector{}
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk.OutputStream",
+			Name: "collector",
+			Props: job.P{
+				"Ports": []fwk.Port{
+					{Name: "/fads/EventNumber", Type: reflect.TypeOf(int64(0))},
+					{Name: "/fads/PileUp/n", Type: reflect.TypeOf(int(0))},
+				},
+				"Streamer": out,
+			},
+		})
+
+		err := app.App().Run()
+		if err != nil {
+			t.Fatalf("nprocs=%d: could not run pile-up merger: %+v", nprocs, err)
+		}
+
+		npus := make(map[int64]int, len(out.evts))
+		for _, evt := range out.evts {
+			npus[evt["/fads/EventNumber"].(int64)] = evt["/fads/PileUp/n"].(int)
+		}
+		return npus
+	}
+
+	ref := run(0)
+	if len(ref) == 0 {
+		t.Fatalf("no event")
+	}
+	for _, nprocs := range []int{2, 4} {
+		got := run(nprocs)
+		if !reflect.DeepEqual(got, ref) {
+			t.Fatalf("nprocs=%d: pile-up differs from sequential run:\ngot= %v\nwant=%v", nprocs, got, ref)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+
+	"go-hep.org/x/hep/fwk"
+)
+
+// randgen provides the random numbers of a task.
+//
+// When the name of a fwk.RandSvc is configured, each event is processed
+// with the stream handed out by that service, making results independent of
+// the number of concurrent workers.
+// The event number used by the service can be published by HepMcReader
+// (see its 'EventNumber' property).
+// Otherwise, each event is processed with a stream derived from a single
+// generator, seeded once with the task seed.
+type randgen struct {
+	seed uint64 // seed of the task generator
+	name string // name of the fwk.RandSvc, if any
+
+	svc fwk.RandSvc
+	mu  sync.Mutex
+	src *rand.Rand
+}
+
+func (gen *randgen) start(ctx fwk.Context) error {
+	if gen.name == "" {
+		gen.src = rand.New(rand.NewPCG(gen.seed, gen.seed))
+		return nil
+	}
+
+	svc, err := ctx.Svc(gen.name)
+	if err != nil {
+		return err
+	}
+
+	rsvc, ok := svc.(fwk.RandSvc)
+	if !ok {
+		return fmt.Errorf("fads: service [%s] is not a fwk.RandSvc (type=%T)", gen.name, svc)
+	}
+	gen.svc = rsvc
+	return nil
+}
+
+// rand returns the random number generator of the task tsk for the current event.
+func (gen *randgen) rand(ctx fwk.Context, tsk string) (*rand.Rand, error) {
+	if gen.svc != nil {
+		return gen.svc.Rand(ctx, tsk)
+	}
+
+	gen.mu.Lock()
+	defer gen.mu.Unlock()
+	return rand.New(rand.NewPCG(gen.src.Uint64(), gen.src.Uint64())), nil
+}
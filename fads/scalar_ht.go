@@ -0,0 +1,120 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"reflect"
+
+	"go-hep.org/x/hep/fwk"
+)
+
+// ScalarHtCalculator computes the scalar sum of the transverse momenta of
+// the candidates of its input collections, with a transverse momentum
+// above PtMin.
+//
+// The input collections are typically the jets, electrons, photons and
+// muons of a UniqueObjectFinder.
+type ScalarHtCalculator struct {
+	fwk.TaskBase
+
+	inputs []string
+	output string
+	ptmin  float64
+}
+
+func (tsk *ScalarHtCalculator) Configure(ctx fwk.Context) error {
+	var err error
+
+	for _, input := range tsk.inputs {
+		err = tsk.DeclInPort(input, reflect.TypeOf([]Candidate{}))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tsk.DeclOutPort(tsk.output, reflect.TypeOf(ScalarHt(0)))
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+func (tsk *ScalarHtCalculator) StartTask(ctx fwk.Context) error {
+	var err error
+
+	return err
+}
+
+func (tsk *ScalarHtCalculator) StopTask(ctx fwk.Context) error {
+	var err error
+
+	return err
+}
+
+func (tsk *ScalarHtCalculator) Process(ctx fwk.Context) error {
+	var err error
+
+	store := ctx.Store()
+	msg := ctx.Msg()
+
+	ht := ScalarHt(0)
+	for _, k := range tsk.inputs {
+		v, err := store.Get(k)
+		if err != nil {
+			return err
+		}
+		input := v.([]Candidate)
+		msg.Debugf(">>> input[%s]: %v\n", k, len(input))
+
+		for i := range input {
+			pt := input[i].Mom.Pt()
+			if pt < tsk.ptmin {
+				continue
+			}
+			ht += ScalarHt(pt)
+		}
+	}
+	msg.Debugf(">>> ht: %v\n", ht)
+
+	err = store.Put(tsk.output, ht)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+func newScalarHtCalculator(typ, name string, mgr fwk.App) (fwk.Component, error) {
+	var err error
+
+	tsk := &ScalarHtCalculator{
+		TaskBase: fwk.NewTask(typ, name, mgr),
+		inputs:   []string{},
+		output:   "/fads/ScalarHt",
+		ptmin:    0,
+	}
+
+	err = tsk.DeclProp("Inputs", &tsk.inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("Output", &tsk.output)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("PtMin", &tsk.ptmin)
+	if err != nil {
+		return nil, err
+	}
+
+	return tsk, err
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(ScalarHtCalculator{}), newScalarHtCalculator)
+}
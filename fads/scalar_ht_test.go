@@ -0,0 +1,46 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+)
+
+func TestScalarHtCalculator(t *testing.T) {
+	evts := runTasks(t,
+		[]map[string]any{
+			{
+				"/fads/jets":      []Candidate{newCand(0, 50, 0, 0), newCand(0, 30, 1, 2), newCand(0, 15, -1, 1)},
+				"/fads/electrons": []Candidate{newCand(11, 25, 0.5, -1)},
+			},
+			{
+				"/fads/jets":      []Candidate{},
+				"/fads/electrons": []Candidate{newCand(11, 19.5, 0.5, -1)},
+			},
+		},
+		[]fwk.Port{{Name: "/fads/ht", Type: reflect.TypeOf(ScalarHt(0))}},
+		job.C{
+			Type: "go-hep.org/x/hep/fads.ScalarHtCalculator",
+			Name: "ht",
+			Props: job.P{
+				"Inputs": []string{"/fads/jets", "/fads/electrons"},
+				"Output": "/fads/ht",
+				"PtMin":  20.0,
+			},
+		},
+	)
+
+	for i, want := range []ScalarHt{50 + 30 + 25, 0} {
+		got := evts[i]["/fads/ht"].(ScalarHt)
+		if math.Abs(float64(got-want)) > 1e-12 {
+			t.Fatalf("evt[%d]: invalid scalar HT: got=%v, want=%v", i, got, want)
+		}
+	}
+}
@@ -6,9 +6,7 @@ package fads
 
 import (
 	"math"
-	"math/rand/v2"
 	"reflect"
-	"sync"
 
 	"go-hep.org/x/hep/fmom"
 	"go-hep.org/x/hep/fwk"
@@ -65,11 +63,7 @@ type TauTagging struct {
 	tag tauclassifier
 	eff map[int]func(pt, eta float64) float64
 
-	seed uint64
-	src  *rand.Rand
-
-	flatmu sync.Mutex
-	flat   distuv.Uniform
+	rnd randgen
 }
 
 func (tsk *TauTagging) Configure(ctx fwk.Context) error {
@@ -90,15 +84,11 @@ func (tsk *TauTagging) Configure(ctx fwk.Context) error {
 		return err
 	}
 
-	tsk.src = rand.New(rand.NewPCG(tsk.seed, tsk.seed))
-	tsk.flat = distuv.Uniform{Min: 0, Max: 1, Src: tsk.src}
 	return err
 }
 
 func (tsk *TauTagging) StartTask(ctx fwk.Context) error {
-	var err error
-
-	return err
+	return tsk.rnd.start(ctx)
 }
 
 func (tsk *TauTagging) StopTask(ctx fwk.Context) error {
@@ -112,6 +102,11 @@ func (tsk *TauTagging) Process(ctx fwk.Context) error {
 
 	store := ctx.Store()
 	msg := ctx.Msg()
+	src, err := tsk.rnd.rand(ctx, tsk.Name())
+	if err != nil {
+		return err
+	}
+	flat := distuv.Uniform{Min: 0, Max: 1, Src: src}
 
 	v, err := store.Get(tsk.particles)
 	if err != nil {
@@ -158,11 +153,9 @@ func (tsk *TauTagging) Process(ctx fwk.Context) error {
 		pt := jet.Mom.Pt()
 
 		charge := int32(-1)
-		tsk.flatmu.Lock()
-		if tsk.flat.Rand() > 0.5 {
+		if flat.Rand() > 0.5 {
 			charge = 1
 		}
-		tsk.flatmu.Unlock()
 
 		for j := range taus {
 			mc := &taus[j]
@@ -193,11 +186,9 @@ func (tsk *TauTagging) Process(ctx fwk.Context) error {
 
 		// apply efficiency
 		tag := uint32(0)
-		tsk.flatmu.Lock()
-		if tsk.flat.Rand() <= eff(pt, eta) {
+		if flat.Rand() <= eff(pt, eta) {
 			tag = 1
 		}
-		tsk.flatmu.Unlock()
 		jet.TauTag = tag
 		jet.CandCharge = charge
 
@@ -227,7 +218,7 @@ func newTauTagging(typ, name string, mgr fwk.App) (fwk.Component, error) {
 			0: func(pt, eta float64) float64 { return 0 },
 		},
 
-		seed: 1234,
+		rnd: randgen{seed: 1234},
 	}
 
 	err = tsk.DeclProp("Particles", &tsk.particles)
@@ -270,7 +261,12 @@ func newTauTagging(typ, name string, mgr fwk.App) (fwk.Component, error) {
 		return nil, err
 	}
 
-	err = tsk.DeclProp("Seed", &tsk.seed)
+	err = tsk.DeclProp("Seed", &tsk.rnd.seed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("RandSvc", &tsk.rnd.name)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,620 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// tclInterp interprets the subset of Tcl used by Delphes data-cards:
+//   - set, add, module and source,
+//   - expr, incr, list, for and foreach,
+//   - variable ($name, ${name}) and command ([cmd]) substitutions.
+//
+// Variables set within the body of a module are the parameters of that module.
+type tclInterp struct {
+	card  *Card
+	fname string // name of the script being evaluated
+	dir   string // directory of the script being evaluated
+
+	vars map[string]string // global variables
+	mod  *cardModule       // module being defined, if any
+}
+
+func newTclInterp(card *Card) *tclInterp {
+	return &tclInterp{
+		card: card,
+		vars: make(map[string]string),
+	}
+}
+
+func (tcl *tclInterp) source(fname string) error {
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		return fmt.Errorf("fads: could not read card: %w", err)
+	}
+
+	fname0, dir0 := tcl.fname, tcl.dir
+	defer func() {
+		tcl.fname, tcl.dir = fname0, dir0
+	}()
+	tcl.fname, tcl.dir = fname, filepath.Dir(fname)
+
+	_, err = tcl.eval(string(raw), 1)
+	return err
+}
+
+func (tcl *tclInterp) errorf(line int, format string, args ...any) error {
+	return fmt.Errorf("fads: %s:%d: %s", tcl.fname, line, fmt.Sprintf(format, args...))
+}
+
+// eval evaluates the script src, starting at the provided line number.
+// eval returns the result of the last command.
+func (tcl *tclInterp) eval(src string, line int) (string, error) {
+	p := tclParser{tcl: tcl, src: src, line: line}
+	var res string
+	for {
+		words, line, err := p.command()
+		if err != nil {
+			return "", err
+		}
+		if words == nil {
+			return res, nil
+		}
+		if len(words) == 0 {
+			continue
+		}
+		res, err = tcl.exec(words, line)
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func (tcl *tclInterp) exec(words []tclWord, line int) (string, error) {
+	args := make([]string, len(words))
+	for i, w := range words {
+		args[i] = w.str
+	}
+
+	switch cmd := args[0]; cmd {
+	case "set":
+		switch len(args) {
+		case 2:
+			v, ok := tcl.get(args[1])
+			if !ok {
+				return "", tcl.errorf(line, "can't read %q: no such variable", args[1])
+			}
+			return v, nil
+		case 3:
+			tcl.set(args[1], args[2])
+			return args[2], nil
+		}
+		return "", tcl.errorf(line, `wrong # args: should be "set varName ?newValue?"`)
+
+	case "add":
+		if len(args) < 2 {
+			return "", tcl.errorf(line, `wrong # args: should be "add varName ?value ...?"`)
+		}
+		v, _ := tcl.get(args[1])
+		elems := append(tclSplitList(v), args[2:]...)
+		v = tclList(elems)
+		tcl.set(args[1], v)
+		return v, nil
+
+	case "module":
+		if len(args) != 3 && len(args) != 4 {
+			return "", tcl.errorf(line, `wrong # args: should be "module type name ?body?"`)
+		}
+		if tcl.mod != nil {
+			return "", tcl.errorf(line, "module %q defined within module %q", args[2], tcl.mod.name)
+		}
+		if _, dup := tcl.card.mods[args[2]]; dup {
+			return "", tcl.errorf(line, "module %q already defined", args[2])
+		}
+		mod := &cardModule{
+			typ:    args[1],
+			name:   args[2],
+			params: make(map[string]string),
+			vars:   make(map[string]bool),
+		}
+		tcl.card.mods[mod.name] = mod
+		if len(args) == 4 {
+			tcl.mod = mod
+			defer func() { tcl.mod = nil }()
+			_, err := tcl.eval(args[3], words[3].line)
+			if err != nil {
+				return "", err
+			}
+		}
+		return "", nil
+
+	case "source":
+		if len(args) != 2 {
+			return "", tcl.errorf(line, `wrong # args: should be "source fileName"`)
+		}
+		fname := args[1]
+		if !filepath.IsAbs(fname) {
+			fname = filepath.Join(tcl.dir, fname)
+		}
+		return "", tcl.source(fname)
+
+	case "expr":
+		if len(args) < 2 {
+			return "", tcl.errorf(line, `wrong # args: should be "expr arg ?arg ...?"`)
+		}
+		v, err := tcl.expr(strings.Join(args[1:], " "), line)
+		if err != nil {
+			return "", err
+		}
+		return tclFormat(v), nil
+
+	case "incr":
+		if len(args) != 2 && len(args) != 3 {
+			return "", tcl.errorf(line, `wrong # args: should be "incr varName ?increment?"`)
+		}
+		v, ok := tcl.get(args[1])
+		if !ok {
+			v = "0"
+		}
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return "", tcl.errorf(line, "expected integer but got %q", v)
+		}
+		n := int64(1)
+		if len(args) == 3 {
+			n, err = strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return "", tcl.errorf(line, "expected integer but got %q", args[2])
+			}
+		}
+		v = strconv.FormatInt(i+n, 10)
+		tcl.set(args[1], v)
+		return v, nil
+
+	case "list":
+		return tclList(args[1:]), nil
+
+	case "for":
+		if len(args) != 5 {
+			return "", tcl.errorf(line, `wrong # args: should be "for start test next command"`)
+		}
+		_, err := tcl.eval(args[1], words[1].line)
+		if err != nil {
+			return "", err
+		}
+		for {
+			ok, err := tcl.expr(args[2], words[2].line)
+			if err != nil {
+				return "", err
+			}
+			if ok == 0 {
+				return "", nil
+			}
+			_, err = tcl.eval(args[4], words[4].line)
+			if err != nil {
+				return "", err
+			}
+			_, err = tcl.eval(args[3], words[3].line)
+			if err != nil {
+				return "", err
+			}
+		}
+
+	case "foreach":
+		if len(args) != 4 {
+			return "", tcl.errorf(line, `wrong # args: should be "foreach varName list command"`)
+		}
+		for _, v := range tclSplitList(args[2]) {
+			tcl.set(args[1], v)
+			_, err := tcl.eval(args[3], words[3].line)
+			if err != nil {
+				return "", err
+			}
+		}
+		return "", nil
+
+	default:
+		return "", tcl.errorf(line, "invalid command name %q", cmd)
+	}
+}
+
+// get returns the value of the named variable, looking first at the
+// parameters of the module being defined.
+func (tcl *tclInterp) get(name string) (string, bool) {
+	if tcl.mod != nil {
+		if v, ok := tcl.mod.params[name]; ok {
+			return v, true
+		}
+	}
+	v, ok := tcl.vars[name]
+	return v, ok
+}
+
+func (tcl *tclInterp) set(name, v string) {
+	if tcl.mod != nil {
+		tcl.mod.params[name] = v
+		return
+	}
+	tcl.vars[name] = v
+}
+
+// expr substitutes variables and commands in src and evaluates the
+// resulting arithmetic expression.
+func (tcl *tclInterp) expr(src string, line int) (float64, error) {
+	p := tclParser{tcl: tcl, src: src, line: line}
+	str, err := p.subst()
+	if err != nil {
+		return 0, err
+	}
+	v, err := evalExpr(str)
+	if err != nil {
+		return 0, tcl.errorf(line, "%v", err)
+	}
+	return v, nil
+}
+
+type tclWord struct {
+	str  string
+	line int
+}
+
+type tclParser struct {
+	tcl  *tclInterp
+	src  string
+	pos  int
+	line int
+}
+
+func (p *tclParser) eof() bool { return p.pos >= len(p.src) }
+
+func (p *tclParser) peek() byte { return p.src[p.pos] }
+
+func (p *tclParser) advance() byte {
+	c := p.src[p.pos]
+	p.pos++
+	if c == '\n' {
+		p.line++
+	}
+	return c
+}
+
+// command parses the next command and returns its words.
+// command returns nil words when the end of the script is reached, and
+// empty words for empty commands.
+func (p *tclParser) command() ([]tclWord, int, error) {
+	// skip blanks, empty commands and comments.
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ';':
+			p.advance()
+			continue
+		case c == '\\' && p.pos+1 < len(p.src) && p.src[p.pos+1] == '\n':
+			p.advance()
+			p.advance()
+			continue
+		case c == '#':
+			for !p.eof() && p.peek() != '\n' {
+				if p.advance() == '\\' && !p.eof() {
+					p.advance()
+				}
+			}
+			continue
+		}
+		break
+	}
+	if p.eof() {
+		return nil, p.line, nil
+	}
+
+	line := p.line
+	words := []tclWord{}
+	for {
+		// skip blanks between words.
+		for !p.eof() {
+			c := p.peek()
+			if c == ' ' || c == '\t' || c == '\r' {
+				p.advance()
+				continue
+			}
+			if c == '\\' && p.pos+1 < len(p.src) && p.src[p.pos+1] == '\n' {
+				p.advance()
+				p.advance()
+				continue
+			}
+			break
+		}
+		if p.eof() {
+			return words, line, nil
+		}
+		if c := p.peek(); c == '\n' || c == ';' {
+			p.advance()
+			return words, line, nil
+		}
+
+		w, err := p.word()
+		if err != nil {
+			return nil, line, err
+		}
+		words = append(words, w)
+	}
+}
+
+func (p *tclParser) word() (tclWord, error) {
+	line := p.line
+	switch p.peek() {
+	case '{':
+		str, err := p.braced()
+		if err != nil {
+			return tclWord{}, err
+		}
+		if !p.eof() && !strings.ContainsRune(" \t\r\n;", rune(p.peek())) {
+			return tclWord{}, p.tcl.errorf(p.line, "extra characters after close-brace")
+		}
+		return tclWord{str: str, line: line}, nil
+
+	case '"':
+		p.advance()
+		str, err := p.substUntil(func(c byte) bool { return c == '"' })
+		if err != nil {
+			return tclWord{}, err
+		}
+		if p.eof() {
+			return tclWord{}, p.tcl.errorf(line, "missing \"")
+		}
+		p.advance()
+		return tclWord{str: str, line: line}, nil
+	}
+
+	str, err := p.substUntil(func(c byte) bool {
+		return strings.ContainsRune(" \t\r\n;", rune(c))
+	})
+	return tclWord{str: str, line: line}, err
+}
+
+// braced returns the content of a braced word, without substitution.
+func (p *tclParser) braced() (string, error) {
+	line := p.line
+	p.advance()
+
+	var (
+		o     strings.Builder
+		depth = 1
+	)
+	for !p.eof() {
+		c := p.advance()
+		switch c {
+		case '\\':
+			if p.eof() {
+				break
+			}
+			if p.peek() == '\n' {
+				// backslash-newline and leading blanks are replaced by a space.
+				p.advance()
+				for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+					p.advance()
+				}
+				o.WriteByte(' ')
+				continue
+			}
+			o.WriteByte(c)
+			c = p.advance()
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return o.String(), nil
+			}
+		}
+		o.WriteByte(c)
+	}
+	return "", p.tcl.errorf(line, "missing close-brace")
+}
+
+// subst performs variable, command and backslash substitutions on the
+// whole source.
+func (p *tclParser) subst() (string, error) {
+	return p.substUntil(func(byte) bool { return false })
+}
+
+func (p *tclParser) substUntil(stop func(c byte) bool) (string, error) {
+	var o strings.Builder
+	for !p.eof() && !stop(p.peek()) {
+		switch c := p.peek(); c {
+		case '$':
+			v, err := p.variable()
+			if err != nil {
+				return "", err
+			}
+			o.WriteString(v)
+
+		case '[':
+			v, err := p.bracket()
+			if err != nil {
+				return "", err
+			}
+			o.WriteString(v)
+
+		case '\\':
+			p.advance()
+			if p.eof() {
+				o.WriteByte('\\')
+				break
+			}
+			switch c := p.advance(); c {
+			case 'n':
+				o.WriteByte('\n')
+			case 't':
+				o.WriteByte('\t')
+			case '\n':
+				for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+					p.advance()
+				}
+				o.WriteByte(' ')
+			default:
+				o.WriteByte(c)
+			}
+
+		default:
+			o.WriteByte(p.advance())
+		}
+	}
+	return o.String(), nil
+}
+
+func (p *tclParser) variable() (string, error) {
+	line := p.line
+	p.advance() // '$'
+
+	var name string
+	switch {
+	case !p.eof() && p.peek() == '{':
+		end := strings.IndexByte(p.src[p.pos:], '}')
+		if end < 0 {
+			return "", p.tcl.errorf(line, "missing close-brace for variable name")
+		}
+		name = p.src[p.pos+1 : p.pos+end]
+		p.pos += end + 1
+	default:
+		beg := p.pos
+		for !p.eof() {
+			c := p.peek()
+			if c == '_' || isDigit(c) || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') {
+				p.advance()
+				continue
+			}
+			if strings.HasPrefix(p.src[p.pos:], "::") {
+				p.pos += 2
+				continue
+			}
+			break
+		}
+		name = p.src[beg:p.pos]
+		if name == "" {
+			return "$", nil
+		}
+	}
+
+	name = strings.TrimPrefix(name, "::")
+	v, ok := p.tcl.get(name)
+	if p.tcl.mod != nil {
+		p.tcl.mod.vars[name] = true
+	}
+	if !ok {
+		return "", p.tcl.errorf(line, "can't read %q: no such variable", name)
+	}
+	return v, nil
+}
+
+// bracket evaluates a command substitution.
+func (p *tclParser) bracket() (string, error) {
+	line := p.line
+	p.advance() // '['
+
+	beg := p.pos
+	depth := 1
+	for !p.eof() {
+		switch p.advance() {
+		case '\\':
+			if !p.eof() {
+				p.advance()
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return p.tcl.eval(p.src[beg:p.pos-1], line)
+			}
+		}
+	}
+	return "", p.tcl.errorf(line, "missing close-bracket")
+}
+
+// tclSplitList splits the Tcl list v into its elements.
+func tclSplitList(v string) []string {
+	var (
+		elems []string
+		i     = 0
+	)
+	isSpace := func(c byte) bool { return c == ' ' || c == '\t' || c == '\r' || c == '\n' }
+	for {
+		for i < len(v) && isSpace(v[i]) {
+			i++
+		}
+		if i >= len(v) {
+			return elems
+		}
+
+		switch v[i] {
+		case '{':
+			depth := 1
+			beg := i + 1
+			for i = beg; i < len(v); i++ {
+				switch v[i] {
+				case '\\':
+					i++
+					continue
+				case '{':
+					depth++
+				case '}':
+					depth--
+				}
+				if depth == 0 {
+					break
+				}
+			}
+			end := min(i, len(v))
+			elems = append(elems, v[beg:end])
+			i = end + 1
+
+		case '"':
+			beg := i + 1
+			for i = beg; i < len(v) && v[i] != '"'; i++ {
+				if v[i] == '\\' {
+					i++
+				}
+			}
+			end := min(i, len(v))
+			elems = append(elems, v[beg:end])
+			i = end + 1
+
+		default:
+			beg := i
+			for i < len(v) && !isSpace(v[i]) {
+				i++
+			}
+			elems = append(elems, v[beg:i])
+		}
+	}
+}
+
+// tclList returns the Tcl list made of the provided elements.
+func tclList(elems []string) string {
+	o := make([]string, len(elems))
+	for i, e := range elems {
+		switch {
+		case e == "", strings.ContainsAny(e, " \t\r\n;\"$[]{}\\"):
+			o[i] = "{" + e + "}"
+		default:
+			o[i] = e
+		}
+	}
+	return strings.Join(o, " ")
+}
+
+// tclFormat formats the result of an 'expr' command.
+func tclFormat(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
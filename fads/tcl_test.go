@@ -0,0 +1,235 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTclEval(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		src  string
+		vars map[string]string
+	}{
+		{
+			name: "set",
+			src:  "set a 1\nset b $a; set c {$a}\nset d \"x $b\"",
+			vars: map[string]string{"a": "1", "b": "1", "c": "$a", "d": "x 1"},
+		},
+		{
+			name: "set-get",
+			src:  "set a 1\nset b [set a]",
+			vars: map[string]string{"a": "1", "b": "1"},
+		},
+		{
+			name: "add",
+			src:  "add l a\nadd l {b c} d\nadd l",
+			vars: map[string]string{"l": "a {b c} d"},
+		},
+		{
+			name: "comments",
+			src:  "# set a 1\nset b 2 ;# comment\n# continued \\\nset a 3",
+			vars: map[string]string{"b": "2"},
+		},
+		{
+			name: "continuation",
+			src:  "set a \\\n  1\nset b {x \\\n    y}\nadd l 1 \\\n 2",
+			vars: map[string]string{"a": "1", "b": "x  y", "l": "1 2"},
+		},
+		{
+			name: "nested-braces",
+			src:  "set a {0.0 {1 2} {3 {4 5}}}",
+			vars: map[string]string{"a": "0.0 {1 2} {3 {4 5}}"},
+		},
+		{
+			name: "expr",
+			src:  "set a 2\nset b [expr $a * 3 + 1]\nset c [expr {$a ^ 2}]",
+			vars: map[string]string{"a": "2", "b": "7", "c": "4"},
+		},
+		{
+			name: "for",
+			src:  "for {set i 0} {$i < 3} {incr i} { add l [expr $i * 0.5] }",
+			vars: map[string]string{"i": "3", "l": "0 0.5 1"},
+		},
+		{
+			name: "foreach",
+			src:  "foreach v {a b} { add l $v$v }",
+			vars: map[string]string{"v": "b", "l": "aa bb"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tcl := newTclInterp(&Card{mods: make(map[string]*cardModule)})
+			_, err := tcl.eval(tc.src, 1)
+			if err != nil {
+				t.Fatalf("could not evaluate script: %+v", err)
+			}
+			if got, want := tcl.vars, tc.vars; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid variables:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+}
+
+func TestTclModule(t *testing.T) {
+	tcl := newTclInterp(&Card{mods: make(map[string]*cardModule)})
+	_, err := tcl.eval(`
+set Radius 1.2
+module Efficiency Eff {
+  set InputArray Propagator/stableParticles
+  set Radius $Radius
+  add Bins 1 \
+    2
+  set EfficiencyFormula {
+    (pt <= 1.0) * (0.00) + \
+    (pt >  1.0) * (0.70)
+  }
+}
+module Merger Empty
+set Radius 2
+`, 1)
+	if err != nil {
+		t.Fatalf("could not evaluate script: %+v", err)
+	}
+
+	if got, want := tcl.vars, map[string]string{"Radius": "2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid global variables:\ngot= %q\nwant=%q", got, want)
+	}
+
+	mod, ok := tcl.card.mods["Eff"]
+	if !ok {
+		t.Fatalf("no module Eff")
+	}
+	if got, want := mod.typ, "Efficiency"; got != want {
+		t.Fatalf("invalid module type: got=%q, want=%q", got, want)
+	}
+	want := map[string]string{
+		"InputArray":        "Propagator/stableParticles",
+		"Radius":            "1.2",
+		"Bins":              "1 2",
+		"EfficiencyFormula": "\n    (pt <= 1.0) * (0.00) +  (pt >  1.0) * (0.70)\n  ",
+	}
+	if got := mod.params; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid module parameters:\ngot= %q\nwant=%q", got, want)
+	}
+
+	eff, err := newFormula(mod.params["EfficiencyFormula"], "pt", "eta")
+	if err != nil {
+		t.Fatalf("could not compile efficiency formula: %+v", err)
+	}
+	if got, want := eff(2, 0), 0.70; got != want {
+		t.Fatalf("invalid efficiency: got=%v, want=%v", got, want)
+	}
+
+	mod, ok = tcl.card.mods["Empty"]
+	if !ok {
+		t.Fatalf("no module Empty")
+	}
+	if len(mod.params) != 0 {
+		t.Fatalf("invalid module parameters: %q", mod.params)
+	}
+}
+
+func TestTclSource(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []struct {
+		name string
+		src  string
+	}{
+		{"main.tcl", "set ExecutionPath {Eff}\nsource sub/common.tcl\nmodule Efficiency Eff {\n  set Radius $Radius\n}\n"},
+		{"sub/common.tcl", "set Radius 1.5\nsource defs.tcl\n"},
+		{"sub/defs.tcl", "set Bz 2.0\n"},
+	} {
+		fname := filepath.Join(dir, f.name)
+		err := os.MkdirAll(filepath.Dir(fname), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(fname, []byte(f.src), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	card, err := ReadCard(filepath.Join(dir, "main.tcl"))
+	if err != nil {
+		t.Fatalf("could not read card: %+v", err)
+	}
+	if got, want := card.Modules(), []string{"Eff"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid modules: got=%q, want=%q", got, want)
+	}
+	if got, want := card.mods["Eff"].params["Radius"], "1.5"; got != want {
+		t.Fatalf("invalid sourced parameter: got=%q, want=%q", got, want)
+	}
+}
+
+func TestTclErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{
+			name: "unknown-command",
+			src:  "set a 1\nputs $a",
+			err:  `:2: invalid command name "puts"`,
+		},
+		{
+			name: "unknown-variable",
+			src:  "set a $b",
+			err:  `:1: can't read "b": no such variable`,
+		},
+		{
+			name: "set-args",
+			src:  "set a 1 2",
+			err:  `wrong # args: should be "set varName ?newValue?"`,
+		},
+		{
+			name: "missing-brace",
+			src:  "set a {1 2\nset b 3",
+			err:  ":1: missing close-brace",
+		},
+		{
+			name: "extra-chars",
+			src:  "set a {1}2",
+			err:  "extra characters after close-brace",
+		},
+		{
+			name: "nested-module",
+			src:  "module Merger A {\n  module Merger B\n}",
+			err:  `:2: module "B" defined within module "A"`,
+		},
+		{
+			name: "duplicate-module",
+			src:  "module Merger A\nmodule Merger A",
+			err:  `:2: module "A" already defined`,
+		},
+		{
+			name: "missing-source",
+			src:  "source not-there.tcl",
+			err:  "could not read card",
+		},
+		{
+			name: "expr",
+			src:  "set a [expr 1 +]",
+			err:  "invalid formula",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tcl := newTclInterp(&Card{mods: make(map[string]*cardModule)})
+			_, err := tcl.eval(tc.src, 1)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("invalid error: got=%q, want=%q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,407 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/fmom"
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// TreeWriter writes fads collections to a ROOT tree, with the branch and
+// leaf names of the Delphes TreeWriter output.
+//
+// Each port is written as a branch of one of the Delphes classes
+// (GenParticle, Track, Tower, Jet, Electron, Photon, Muon, MissingET,
+// ScalarHT or Rho), as described by the Branches of the writer.
+// A branch named Jet is made of a Jet_size count leaf and of one leaf per
+// field of the Delphes class (Jet.PT, Jet.Eta, Jet.Phi, ...), holding the
+// values of all the elements of the collection for the event.
+// Fields of the Delphes classes that have no counterpart in fads (isolation
+// variables, references to other objects, fixed-size arrays, ...) are not
+// written.
+//
+// The leaves are written as flat variable-length arrays, not as the split
+// TClonesArray of Delphes classes written by Delphes, which groot can not
+// write.
+// The output can thus be read by name with TTree::Draw, TTreeReaderArray,
+// uproot or groot (e.g. "Jet.PT" and "Jet_size"), but not with code relying
+// on the Delphes classes, such as ExRootTreeReader::UseBranch or
+// TTree::SetBranchAddress with a TClonesArray: existing Delphes analysis
+// macros can not read TreeWriter output unchanged.
+//
+// MissingET and ScalarHT branches are filled from the momentum and energy
+// Candidate outputs of a Merger, as in Delphes, or from a MissingEt and a
+// ScalarHt value.
+// The other branches are filled from a []Candidate.
+type TreeWriter struct {
+	// FIXME: write the collections as split TClonesArrays of the
+	// Delphes classes, once groot can write split TClonesArray branches.
+
+	Name     string              // output filename
+	Tree     string              // name of the output tree (default: Delphes)
+	Branches []CardBranch        // output branches, one per port
+	Options  []rtree.WriteOption // options of the output tree (compression, basket size, ...)
+
+	f   *riofs.File
+	w   rtree.Writer
+	brs []treeBranch
+}
+
+// treeBranch holds the state of an output branch.
+type treeBranch struct {
+	port   fwk.Port
+	n      int32
+	leaves []func(cands []Candidate)
+}
+
+func (tw *TreeWriter) Connect(ports []fwk.Port) error {
+	var err error
+
+	tree := tw.Tree
+	if tree == "" {
+		tree = "Delphes"
+	}
+
+	brs := make(map[string]CardBranch, len(tw.Branches))
+	for _, br := range tw.Branches {
+		brs[br.Input] = br
+	}
+
+	var wvars []rtree.WriteVar
+	tw.brs = make([]treeBranch, len(ports))
+	for i, port := range ports {
+		br, ok := brs[port.Name]
+		if !ok {
+			return fmt.Errorf("fads: no output branch for port [%s]", port.Name)
+		}
+		if _, ok := treeCandidates(reflect.New(port.Type).Elem().Interface()); !ok {
+			return fmt.Errorf("fads: invalid type %v for port [%s]", port.Type, port.Name)
+		}
+		leaves, ok := delphesClasses[br.Class]
+		if !ok {
+			return fmt.Errorf("fads: branch %q has unsupported class %q", br.Name, br.Class)
+		}
+
+		obr := &tw.brs[i]
+		obr.port = port
+		count := br.Name + "_size"
+		wvars = append(wvars, rtree.WriteVar{Name: count, Value: &obr.n})
+		for _, leaf := range leaves {
+			ptr, fill := leaf.new()
+			wvars = append(wvars, rtree.WriteVar{
+				Name:  br.Name + "." + leaf.name,
+				Value: ptr,
+				Count: count,
+			})
+			obr.leaves = append(obr.leaves, fill)
+		}
+	}
+
+	tw.f, err = riofs.Create(tw.Name)
+	if err != nil {
+		return fmt.Errorf("fads: could not create output file: %w", err)
+	}
+
+	opts := append([]rtree.WriteOption{rtree.WithTitle("Analysis tree")}, tw.Options...)
+	tw.w, err = rtree.NewWriter(tw.f, tree, wvars, opts...)
+	if err != nil {
+		_ = tw.f.Close()
+		return fmt.Errorf("fads: could not create tree writer: %w", err)
+	}
+
+	return err
+}
+
+func (tw *TreeWriter) Write(ctx fwk.Context) error {
+	store := ctx.Store()
+
+	for i := range tw.brs {
+		br := &tw.brs[i]
+		v, err := store.Get(br.port.Name)
+		if err != nil {
+			return err
+		}
+
+		cands, ok := treeCandidates(v)
+		if !ok {
+			return fmt.Errorf("fads: port[%s]: invalid type %T", br.port.Name, v)
+		}
+
+		br.n = int32(len(cands))
+		for _, fill := range br.leaves {
+			fill(cands)
+		}
+	}
+
+	_, err := tw.w.Write()
+	if err != nil {
+		return fmt.Errorf("fads: could not write event: %w", err)
+	}
+
+	return nil
+}
+
+func (tw *TreeWriter) Disconnect() error {
+	// make sure we don't leak filedescriptors
+	defer tw.f.Close()
+
+	err := tw.w.Close()
+	if err != nil {
+		return fmt.Errorf("fads: could not close tree writer: %w", err)
+	}
+
+	err = tw.f.Close()
+	if err != nil {
+		return fmt.Errorf("fads: could not close output file: %w", err)
+	}
+
+	return nil
+}
+
+// treeCandidates returns the candidates of a collection to be written by
+// a TreeWriter.
+func treeCandidates(v any) ([]Candidate, bool) {
+	switch v := v.(type) {
+	case []Candidate:
+		return v, true
+	case Candidate:
+		return []Candidate{v}, true
+	case MissingEt:
+		// Delphes stores the visible momentum, opposite to the missing one.
+		var cand Candidate
+		cand.Mom = fmom.NewPxPyPzE(-v.MET*math.Cos(v.Phi), -v.MET*math.Sin(v.Phi), 0, v.MET)
+		return []Candidate{cand}, true
+	case ScalarHt:
+		var cand Candidate
+		cand.Mom = fmom.NewPxPyPzE(float64(v), 0, 0, float64(v))
+		return []Candidate{cand}, true
+	}
+	return nil, false
+}
+
+// treeLeaf describes a leaf of a Delphes class.
+type treeLeaf struct {
+	name string
+	// new returns a pointer to the storage of the leaf, and a function
+	// filling it from the candidates of an event.
+	new func() (ptr any, fill func(cands []Candidate))
+}
+
+func leafF(name string, f func(cand *Candidate) float64) treeLeaf {
+	return treeLeaf{name: name, new: func() (any, func([]Candidate)) {
+		var vs []float32
+		return &vs, func(cands []Candidate) {
+			vs = vs[:0]
+			for i := range cands {
+				vs = append(vs, float32(f(&cands[i])))
+			}
+		}
+	}}
+}
+
+func leafI(name string, f func(cand *Candidate) int32) treeLeaf {
+	return treeLeaf{name: name, new: func() (any, func([]Candidate)) {
+		var vs []int32
+		return &vs, func(cands []Candidate) {
+			vs = vs[:0]
+			for i := range cands {
+				vs = append(vs, f(&cands[i]))
+			}
+		}
+	}}
+}
+
+func leafU(name string, f func(cand *Candidate) uint32) treeLeaf {
+	return treeLeaf{name: name, new: func() (any, func([]Candidate)) {
+		var vs []uint32
+		return &vs, func(cands []Candidate) {
+			vs = vs[:0]
+			for i := range cands {
+				vs = append(vs, f(&cands[i]))
+			}
+		}
+	}}
+}
+
+// delphesClasses describes the leaves of the supported Delphes classes,
+// following the Delphes TreeWriter.
+var delphesClasses = map[string][]treeLeaf{
+	"GenParticle": {
+		leafI("PID", func(c *Candidate) int32 { return c.Pid }),
+		leafI("Status", func(c *Candidate) int32 { return c.Status }),
+		leafI("IsPU", func(c *Candidate) int32 { return int32(c.IsPU) }),
+		leafI("M1", func(c *Candidate) int32 { return c.M1 }),
+		leafI("M2", func(c *Candidate) int32 { return c.M2 }),
+		leafI("D1", func(c *Candidate) int32 { return c.D1 }),
+		leafI("D2", func(c *Candidate) int32 { return c.D2 }),
+		leafI("Charge", func(c *Candidate) int32 { return c.CandCharge }),
+		leafF("Mass", func(c *Candidate) float64 { return c.Mom.M() }),
+		leafF("E", func(c *Candidate) float64 { return c.Mom.E() }),
+		leafF("Px", func(c *Candidate) float64 { return c.Mom.Px() }),
+		leafF("Py", func(c *Candidate) float64 { return c.Mom.Py() }),
+		leafF("Pz", func(c *Candidate) float64 { return c.Mom.Pz() }),
+		leafF("P", func(c *Candidate) float64 { return c.Mom.P() }),
+		leafF("PT", func(c *Candidate) float64 { return c.Mom.Pt() }),
+		leafF("Eta", func(c *Candidate) float64 { return treeEta(&c.Mom) }),
+		leafF("Phi", func(c *Candidate) float64 { return c.Mom.Phi() }),
+		leafF("Rapidity", func(c *Candidate) float64 { return treeRapidity(&c.Mom) }),
+		leafF("T", func(c *Candidate) float64 { return c.Pos.T() / cLight }),
+		leafF("X", func(c *Candidate) float64 { return c.Pos.X() }),
+		leafF("Y", func(c *Candidate) float64 { return c.Pos.Y() }),
+		leafF("Z", func(c *Candidate) float64 { return c.Pos.Z() }),
+	},
+	"Track": {
+		leafI("PID", func(c *Candidate) int32 { return c.Pid }),
+		leafI("Charge", func(c *Candidate) int32 { return c.CandCharge }),
+		leafF("P", func(c *Candidate) float64 { return c.Mom.P() }),
+		leafF("PT", func(c *Candidate) float64 { return c.Mom.Pt() }),
+		leafF("Eta", func(c *Candidate) float64 { return treeEta(&c.Mom) }),
+		leafF("Phi", func(c *Candidate) float64 { return c.Mom.Phi() }),
+		leafF("CtgTheta", func(c *Candidate) float64 { return c.Mom.CotTh() }),
+		leafF("Mass", func(c *Candidate) float64 { return c.Mom.M() }),
+		leafF("EtaOuter", func(c *Candidate) float64 { return treeEta(&c.Pos) }),
+		leafF("PhiOuter", func(c *Candidate) float64 { return c.Pos.Phi() }),
+		leafF("TOuter", func(c *Candidate) float64 { return c.Pos.T() / cLight }),
+		leafF("XOuter", func(c *Candidate) float64 { return c.Pos.X() }),
+		leafF("YOuter", func(c *Candidate) float64 { return c.Pos.Y() }),
+		leafF("ZOuter", func(c *Candidate) float64 { return c.Pos.Z() }),
+	},
+	"Tower": {
+		leafF("ET", func(c *Candidate) float64 { return c.Mom.Pt() }),
+		leafF("Eta", func(c *Candidate) float64 { return treeEta(&c.Mom) }),
+		leafF("Phi", func(c *Candidate) float64 { return c.Mom.Phi() }),
+		leafF("E", func(c *Candidate) float64 { return c.Mom.E() }),
+		leafF("T", func(c *Candidate) float64 { return c.Pos.T() / cLight }),
+		leafF("Eem", func(c *Candidate) float64 { return c.Eem }),
+		leafF("Ehad", func(c *Candidate) float64 { return c.Ehad }),
+	},
+	"Jet": {
+		leafF("PT", func(c *Candidate) float64 { return c.Mom.Pt() }),
+		leafF("Eta", func(c *Candidate) float64 { return treeEta(&c.Mom) }),
+		leafF("Phi", func(c *Candidate) float64 { return c.Mom.Phi() }),
+		leafF("T", func(c *Candidate) float64 { return c.Pos.T() / cLight }),
+		leafF("Mass", func(c *Candidate) float64 { return c.Mom.M() }),
+		leafF("DeltaEta", func(c *Candidate) float64 { return c.DEta }),
+		leafF("DeltaPhi", func(c *Candidate) float64 { return c.DPhi }),
+		leafU("BTag", func(c *Candidate) uint32 { return c.BTag }),
+		leafU("TauTag", func(c *Candidate) uint32 { return c.TauTag }),
+		leafI("Charge", func(c *Candidate) int32 {
+			charge := int32(0)
+			for i := range c.Candidates {
+				charge += c.Candidates[i].CandCharge
+			}
+			return charge
+		}),
+		leafF("EhadOverEem", func(c *Candidate) float64 {
+			eem, ehad := 0.0, 0.0
+			for i := range c.Candidates {
+				eem += c.Candidates[i].Eem
+				ehad += c.Candidates[i].Ehad
+			}
+			return treeEhadOverEem(eem, ehad)
+		}),
+		leafI("NCharged", func(c *Candidate) int32 {
+			n := int32(0)
+			for i := range c.Candidates {
+				if c.Candidates[i].CandCharge != 0 {
+					n++
+				}
+			}
+			return n
+		}),
+		leafI("NNeutrals", func(c *Candidate) int32 {
+			n := int32(0)
+			for i := range c.Candidates {
+				if c.Candidates[i].CandCharge == 0 {
+					n++
+				}
+			}
+			return n
+		}),
+	},
+	"Electron": {
+		leafF("PT", func(c *Candidate) float64 { return c.Mom.Pt() }),
+		leafF("Eta", func(c *Candidate) float64 { return treeEta(&c.Mom) }),
+		leafF("Phi", func(c *Candidate) float64 { return c.Mom.Phi() }),
+		leafF("T", func(c *Candidate) float64 { return c.Pos.T() / cLight }),
+		leafI("Charge", func(c *Candidate) int32 { return c.CandCharge }),
+		leafF("EhadOverEem", func(c *Candidate) float64 { return treeEhadOverEem(c.Eem, c.Ehad) }),
+	},
+	"Photon": {
+		leafF("PT", func(c *Candidate) float64 { return c.Mom.Pt() }),
+		leafF("Eta", func(c *Candidate) float64 { return treeEta(&c.Mom) }),
+		leafF("Phi", func(c *Candidate) float64 { return c.Mom.Phi() }),
+		leafF("E", func(c *Candidate) float64 { return c.Mom.E() }),
+		leafF("T", func(c *Candidate) float64 { return c.Pos.T() / cLight }),
+		leafF("EhadOverEem", func(c *Candidate) float64 { return treeEhadOverEem(c.Eem, c.Ehad) }),
+	},
+	"Muon": {
+		leafF("PT", func(c *Candidate) float64 { return c.Mom.Pt() }),
+		leafF("Eta", func(c *Candidate) float64 { return treeEta(&c.Mom) }),
+		leafF("Phi", func(c *Candidate) float64 { return c.Mom.Phi() }),
+		leafF("T", func(c *Candidate) float64 { return c.Pos.T() / cLight }),
+		leafI("Charge", func(c *Candidate) int32 { return c.CandCharge }),
+	},
+	"MissingET": {
+		leafF("MET", func(c *Candidate) float64 { return c.Mom.Pt() }),
+		leafF("Eta", func(c *Candidate) float64 {
+			mom := treeMissing(c)
+			return treeEta(&mom)
+		}),
+		leafF("Phi", func(c *Candidate) float64 {
+			mom := treeMissing(c)
+			return mom.Phi()
+		}),
+	},
+	"ScalarHT": {
+		leafF("HT", func(c *Candidate) float64 { return c.Mom.Pt() }),
+	},
+	"Rho": {
+		leafF("Rho", func(c *Candidate) float64 { return c.Mom.Pt() }),
+	},
+}
+
+// treeMissing returns the missing momentum of the visible momentum of cand.
+func treeMissing(cand *Candidate) fmom.PxPyPzE {
+	return fmom.NewPxPyPzE(-cand.Mom.Px(), -cand.Mom.Py(), -cand.Mom.Pz(), cand.Mom.E())
+}
+
+// treeEta returns the pseudo-rapidity of p, as computed by Delphes for
+// momenta along the beam axis.
+func treeEta(p *fmom.PxPyPzE) float64 {
+	if p.Pt() == 0 {
+		return treeBeam(p)
+	}
+	return p.Eta()
+}
+
+// treeRapidity returns the rapidity of p, as computed by Delphes for
+// momenta along the beam axis.
+func treeRapidity(p *fmom.PxPyPzE) float64 {
+	if p.Pt() == 0 {
+		return treeBeam(p)
+	}
+	return p.Rapidity()
+}
+
+func treeBeam(p *fmom.PxPyPzE) float64 {
+	if p.Pz() >= 0 {
+		return +999.9
+	}
+	return -999.9
+}
+
+func treeEhadOverEem(eem, ehad float64) float64 {
+	if eem <= 0 {
+		return 999.9
+	}
+	return ehad / eem
+}
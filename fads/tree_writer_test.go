@@ -0,0 +1,214 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fmom"
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestTreeWriter(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "delphes.root")
+
+	var (
+		jet1 = newCand(0, 50, 0.5, 1)
+		jet2 = newCand(0, 30, -1.5, -2)
+		ele1 = newCand(11, 25, 1, 0.5)
+		vis  Candidate // visible momentum, from a Merger
+	)
+	jet1.BTag = 1
+	jet1.Add(&Candidate{CandCharge: +1, Eem: 1, Ehad: 4})
+	jet1.Add(&Candidate{CandCharge: -1, Eem: 2, Ehad: 3})
+	jet1.Add(&Candidate{CandCharge: 0, Eem: 5})
+	jet2.TauTag = 1
+	ele1.CandCharge = -1
+	vis.Mom = fmom.NewPxPyPzE(3, 4, 10, 20)
+
+	branches := []CardBranch{
+		{Input: "/fads/jets", Name: "Jet", Class: "Jet"},
+		{Input: "/fads/electrons", Name: "Electron", Class: "Electron"},
+		{Input: "/fads/momentum", Name: "MissingET", Class: "MissingET"},
+		{Input: "/fads/ht", Name: "ScalarHT", Class: "ScalarHT"},
+	}
+	var ports []fwk.Port
+	for _, br := range branches {
+		ports = append(ports, br.Port())
+	}
+	ports[len(ports)-1].Type = reflect.TypeOf(ScalarHt(0))
+
+	runTasks(t,
+		[]map[string]any{
+			{
+				"/fads/jets":      []Candidate{jet1, jet2},
+				"/fads/electrons": []Candidate{ele1},
+				"/fads/momentum":  vis,
+				"/fads/ht":        ScalarHt(105),
+			},
+			{
+				"/fads/jets":      []Candidate{},
+				"/fads/electrons": []Candidate{},
+				"/fads/momentum":  Candidate{},
+				"/fads/ht":        ScalarHt(0),
+			},
+			{
+				"/fads/jets":      []Candidate{jet2},
+				"/fads/electrons": []Candidate{},
+				"/fads/momentum":  vis,
+				"/fads/ht":        ScalarHt(30),
+			},
+		},
+		nil,
+		job.C{
+			Type: "go-hep.org/x/hep/fwk.OutputStream",
+			Name: "tree-writer",
+			Props: job.P{
+				"Ports": ports,
+				"Streamer": &TreeWriter{
+					Name:     fname,
+					Branches: branches,
+				},
+			},
+		},
+	)
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("Delphes")
+	if err != nil {
+		t.Fatalf("could not get tree: %+v", err)
+	}
+	tree := obj.(rtree.Tree)
+
+	if got, want := tree.Entries(), int64(3); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	var names []string
+	for _, b := range tree.Branches() {
+		names = append(names, b.Name())
+	}
+	want := []string{
+		"Jet_size", "Jet.PT", "Jet.Eta", "Jet.Phi", "Jet.T", "Jet.Mass",
+		"Jet.DeltaEta", "Jet.DeltaPhi", "Jet.BTag", "Jet.TauTag", "Jet.Charge",
+		"Jet.EhadOverEem", "Jet.NCharged", "Jet.NNeutrals",
+		"Electron_size", "Electron.PT", "Electron.Eta", "Electron.Phi",
+		"Electron.T", "Electron.Charge", "Electron.EhadOverEem",
+		"MissingET_size", "MissingET.MET", "MissingET.Eta", "MissingET.Phi",
+		"ScalarHT_size", "ScalarHT.HT",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("invalid branches:\ngot= %q\nwant=%q", names, want)
+	}
+
+	rvars := rtree.NewReadVars(tree)
+	r, err := rtree.NewReader(tree, rvars)
+	if err != nil {
+		t.Fatalf("could not create tree reader: %+v", err)
+	}
+	defer r.Close()
+
+	var evts []map[string]any
+	err = r.Read(func(ctx rtree.RCtx) error {
+		evt := make(map[string]any, len(rvars))
+		for _, rvar := range rvars {
+			v := reflect.ValueOf(rvar.Value).Elem()
+			if v.Kind() == reflect.Slice {
+				v = reflect.AppendSlice(reflect.MakeSlice(v.Type(), 0, v.Len()), v)
+			}
+			evt[rvar.Name] = v.Interface()
+		}
+		evts = append(evts, evt)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+
+	f32 := func(vs ...float64) []float32 {
+		o := make([]float32, len(vs))
+		for i, v := range vs {
+			o[i] = float32(v)
+		}
+		return o
+	}
+
+	for i, want := range []map[string]any{
+		{
+			"Jet_size":        int32(2),
+			"Jet.PT":          f32(50, 30),
+			"Jet.Eta":         f32(0.5, -1.5),
+			"Jet.Phi":         f32(1, -2),
+			"Jet.BTag":        []uint32{1, 0},
+			"Jet.TauTag":      []uint32{0, 1},
+			"Jet.Charge":      []int32{0, 0},
+			"Jet.EhadOverEem": f32(7.0/8.0, 999.9),
+			"Jet.NCharged":    []int32{2, 0},
+			"Jet.NNeutrals":   []int32{1, 0},
+			"Electron_size":   int32(1),
+			"Electron.PT":     f32(25),
+			"Electron.Eta":    f32(1),
+			"Electron.Charge": []int32{-1},
+			"MissingET_size":  int32(1),
+			"MissingET.MET":   f32(5),
+			"MissingET.Phi":   f32(math.Atan2(-4, -3)),
+			"ScalarHT_size":   int32(1),
+			"ScalarHT.HT":     f32(105),
+		},
+		{
+			"Jet_size":       int32(0),
+			"Jet.PT":         []float32{},
+			"Electron_size":  int32(0),
+			"Electron.PT":    []float32{},
+			"MissingET_size": int32(1),
+			"MissingET.MET":  f32(0),
+			"ScalarHT.HT":    f32(0),
+		},
+		{
+			"Jet_size":    int32(1),
+			"Jet.PT":      f32(30),
+			"Jet.TauTag":  []uint32{1},
+			"ScalarHT.HT": f32(30),
+		},
+	} {
+		for k, want := range want {
+			got := evts[i][k]
+			if !approxEqual(got, want) {
+				t.Errorf("evt[%d]: invalid %s: got=%v, want=%v", i, k, got, want)
+			}
+		}
+	}
+}
+
+func approxEqual(a, b any) bool {
+	switch a := a.(type) {
+	case float32:
+		b, ok := b.(float32)
+		return ok && math.Abs(float64(a-b)) <= 1e-5*math.Max(1, math.Abs(float64(b)))
+	case []float32:
+		b, ok := b.([]float32)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !approxEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
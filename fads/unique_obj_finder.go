@@ -15,6 +15,8 @@ type ObjPair struct {
 	Out string
 }
 
+// UniqueObjectFinder removes from each collection the objects overlapping
+// with the unique objects of the collections listed before it.
 type UniqueObjectFinder struct {
 	fwk.TaskBase
 
@@ -88,14 +90,14 @@ func (tsk *UniqueObjectFinder) Process(ctx fwk.Context) error {
 		output := pair.Out
 		for i := range input {
 			cand := &input[i]
-			unique := false
+			unique := true
 		uniqueloop:
 			for jcol := range icol {
-				jcands := colls[jcol].In
+				jcands := colls[jcol].Out
 				for j := range jcands {
 					jcand := &jcands[j]
 					if cand.Overlaps(jcand) {
-						unique = true
+						unique = false
 						break uniqueloop
 					}
 				}
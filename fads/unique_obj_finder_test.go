@@ -0,0 +1,88 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fads
+
+import (
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+)
+
+func TestUniqueObjectFinder(t *testing.T) {
+	var (
+		twr1 = newCand(0, 30, 0.5, 0.5)
+		twr2 = newCand(0, 40, -1, 2)
+		twr3 = newCand(0, 25, 1.5, -2)
+		trk1 = newCand(11, 29, 0.5, 0.5)
+		trk2 = newCand(-11, 20, 1.5, -2)
+		trk3 = newCand(13, 15, -2, 1)
+
+		pho1 = newCand(22, 30, 0.5, 0.5)
+		ele1 = newCand(11, 29, 0.5, 0.5) // same calorimeter deposit as pho1
+		ele2 = newCand(-11, 20, 1.5, -2)
+		muo1 = newCand(13, 15, -2, 1)
+		jet1 = newCand(0, 31, 0.5, 0.5) // contains pho1
+		jet2 = newCand(0, 40, -1, 2)
+		jet3 = newCand(0, 29, 0.5, 0.4) // contains the track of ele1 only
+		jet4 = newCand(0, 26, 1.5, -2)  // contains ele2
+	)
+	pho1.Add(&twr1)
+	ele1.Add(&trk1)
+	ele1.Add(&twr1)
+	ele2.Add(&trk2)
+	ele2.Add(&twr3)
+	muo1.Add(&trk3)
+	jet1.Add(&twr1)
+	jet2.Add(&twr2)
+	jet3.Add(&trk1)
+	jet4.Add(&twr3)
+
+	evts := runTasks(t,
+		[]map[string]any{{
+			"/fads/photons":   []Candidate{pho1},
+			"/fads/electrons": []Candidate{ele1, ele2},
+			"/fads/muons":     []Candidate{muo1},
+			"/fads/jets":      []Candidate{jet1, jet2, jet3, jet4},
+		}},
+		[]fwk.Port{
+			{Name: "/fads/unique/photons", Type: reflect.TypeOf([]Candidate{})},
+			{Name: "/fads/unique/electrons", Type: reflect.TypeOf([]Candidate{})},
+			{Name: "/fads/unique/muons", Type: reflect.TypeOf([]Candidate{})},
+			{Name: "/fads/unique/jets", Type: reflect.TypeOf([]Candidate{})},
+		},
+		job.C{
+			Type: "go-hep.org/x/hep/fads.UniqueObjectFinder",
+			Name: "unique",
+			Props: job.P{
+				"Keys": []ObjPair{
+					{In: "/fads/photons", Out: "/fads/unique/photons"},
+					{In: "/fads/electrons", Out: "/fads/unique/electrons"},
+					{In: "/fads/muons", Out: "/fads/unique/muons"},
+					{In: "/fads/jets", Out: "/fads/unique/jets"},
+				},
+			},
+		},
+	)
+
+	for _, tc := range []struct {
+		name string
+		want []Candidate
+	}{
+		// earlier collections take precedence over later ones.
+		{name: "/fads/unique/photons", want: []Candidate{pho1}},
+		{name: "/fads/unique/electrons", want: []Candidate{ele2}},
+		{name: "/fads/unique/muons", want: []Candidate{muo1}},
+		// objects are only compared with the unique objects of the
+		// earlier collections: jet3 overlaps with the removed ele1.
+		{name: "/fads/unique/jets", want: []Candidate{jet2, jet3}},
+	} {
+		got := evts[0][tc.name].([]Candidate)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%s: invalid candidates:\ngot= %v\nwant=%v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -4,4 +4,174 @@
 
 package fastjet
 
-type AreaDefinition struct{}
+import (
+	"fmt"
+	"math"
+)
+
+// AreaType describes the type of jet area to compute.
+type AreaType int
+
+const (
+	InvalidArea AreaType = iota
+
+	// ActiveArea is the area measured by adding a dense coverage of
+	// infinitely soft particles (ghosts) to the event and counting
+	// the ghosts clustered in each jet.
+	ActiveArea
+
+	// PassiveArea is the area measured by adding one ghost at a time
+	// to the event.
+	PassiveArea
+
+	// OneGhostPassiveArea is the passive area, always computed by explicitly
+	// adding one ghost at a time to the event, whatever the jet algorithm.
+	OneGhostPassiveArea
+
+	// VoronoiArea is the area of a jet defined as the sum of the areas of
+	// the Voronoi cells of its constituents, each cell being intersected
+	// with a circle of radius Rfact*R.
+	VoronoiArea
+)
+
+func (typ AreaType) String() string {
+	switch typ {
+	case InvalidArea:
+		return "invalid"
+	case ActiveArea:
+		return "active"
+	case PassiveArea:
+		return "passive"
+	case OneGhostPassiveArea:
+		return "1-ghost passive"
+	case VoronoiArea:
+		return "voronoi"
+	default:
+		return fmt.Sprintf("AreaType(%d)", int(typ))
+	}
+}
+
+// GhostedAreaSpec describes how ghosts are laid out on the rapidity-phi
+// cylinder.
+//
+// Ghosts are placed on a grid of cells of area Area, covering the rapidity
+// range [-MaxRap, MaxRap], with random fluctuations of their positions and
+// of their transverse momenta.
+type GhostedAreaSpec struct {
+	MaxRap      float64 // maximal rapidity of the ghosts
+	Area        float64 // area of a single ghost
+	Repeat      int     // number of repetitions of the ghost layout
+	GridScatter float64 // fractional random fluctuations of the ghosts position on the grid
+	PtScatter   float64 // fractional random fluctuations of the ghosts transverse momentum
+	MeanGhostPt float64 // mean transverse momentum of the ghosts
+	Seed        uint64  // seed of the random number generator used to lay out ghosts
+}
+
+// NewGhostedAreaSpec returns a ghosts specification covering the rapidity
+// range [-maxrap, maxrap], with the default values of C++ FastJet for the
+// other parameters.
+func NewGhostedAreaSpec(maxrap float64) GhostedAreaSpec {
+	return GhostedAreaSpec{
+		MaxRap:      maxrap,
+		Area:        0.01,
+		Repeat:      1,
+		GridScatter: 1,
+		PtScatter:   0.1,
+		MeanGhostPt: 1e-100,
+	}
+}
+
+// grid returns the number of ghosts along the rapidity and phi axes and
+// the actual area of a ghost.
+func (spec GhostedAreaSpec) grid() (nrap, nphi int, area float64) {
+	size := math.Sqrt(spec.Area)
+	nrap = int(0.5 + 2*spec.MaxRap/size)
+	nphi = int(0.5 + 2*math.Pi/size)
+	nrap = max(nrap, 1)
+	nphi = max(nphi, 1)
+	drap := 2 * spec.MaxRap / float64(nrap)
+	dphi := 2 * math.Pi / float64(nphi)
+	return nrap, nphi, drap * dphi
+}
+
+func (spec GhostedAreaSpec) validate() error {
+	switch {
+	case spec.MaxRap <= 0:
+		return fmt.Errorf("fastjet: invalid ghosts maximal rapidity (%v)", spec.MaxRap)
+	case spec.Area <= 0:
+		return fmt.Errorf("fastjet: invalid ghost area (%v)", spec.Area)
+	case spec.Repeat <= 0:
+		return fmt.Errorf("fastjet: invalid number of ghosts repetitions (%d)", spec.Repeat)
+	case spec.MeanGhostPt <= 0:
+		return fmt.Errorf("fastjet: invalid mean ghost transverse momentum (%v)", spec.MeanGhostPt)
+	}
+	return nil
+}
+
+// VoronoiAreaSpec describes how Voronoi areas are computed.
+type VoronoiAreaSpec struct {
+	// Rfact is the factor applied to the jet radius R to obtain the
+	// radius of the circle intersecting each Voronoi cell.
+	Rfact float64
+}
+
+// AreaDefinition describes how jet areas are computed.
+type AreaDefinition struct {
+	typ     AreaType
+	ghost   GhostedAreaSpec
+	voronoi VoronoiAreaSpec
+}
+
+// NewAreaDefinition returns a ghosts-based area definition of the given type.
+func NewAreaDefinition(typ AreaType, spec GhostedAreaSpec) AreaDefinition {
+	return AreaDefinition{typ: typ, ghost: spec}
+}
+
+// NewVoronoiAreaDefinition returns a Voronoi-based area definition.
+func NewVoronoiAreaDefinition(spec VoronoiAreaSpec) AreaDefinition {
+	return AreaDefinition{typ: VoronoiArea, voronoi: spec}
+}
+
+// Type returns the type of jet area.
+func (def AreaDefinition) Type() AreaType {
+	return def.typ
+}
+
+// GhostSpec returns the specification of ghosts.
+func (def AreaDefinition) GhostSpec() GhostedAreaSpec {
+	return def.ghost
+}
+
+// VoronoiSpec returns the specification of Voronoi areas.
+func (def AreaDefinition) VoronoiSpec() VoronoiAreaSpec {
+	return def.voronoi
+}
+
+// Description returns a string description of the area definition.
+func (def AreaDefinition) Description() string {
+	switch def.typ {
+	case VoronoiArea:
+		return fmt.Sprintf("Voronoi area with effective_R = %v*R", def.voronoi.Rfact)
+	case ActiveArea, PassiveArea, OneGhostPassiveArea:
+		return fmt.Sprintf(
+			"%v area with ghosts of area %v, placed up to y = %v, scattered with fractional spread %v, with %d repetitions",
+			def.typ, def.ghost.Area, def.ghost.MaxRap, def.ghost.GridScatter, def.ghost.Repeat,
+		)
+	default:
+		return "invalid area definition"
+	}
+}
+
+func (def AreaDefinition) validate() error {
+	switch def.typ {
+	case ActiveArea, PassiveArea, OneGhostPassiveArea:
+		return def.ghost.validate()
+	case VoronoiArea:
+		if def.voronoi.Rfact <= 0 {
+			return fmt.Errorf("fastjet: invalid Voronoi effective radius factor (%v)", def.voronoi.Rfact)
+		}
+		return nil
+	default:
+		return fmt.Errorf("fastjet: invalid area type (%v)", def.typ)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// Background describes the estimated transverse momentum density of the
+// background (pileup or underlying event) of an event.
+type Background struct {
+	Rho      float64 // median of the pt/area distribution of the jets, per unit area
+	Sigma    float64 // fluctuations of the background transverse momentum, per square root of unit area
+	MeanArea float64 // mean area of the jets used in the estimation
+	NJets    int     // number of jets used in the estimation
+	NEmpty   float64 // estimated number of empty jets
+}
+
+// JetMedianBackgroundEstimator estimates the transverse momentum density
+// of the background of an event, from the median of the pt/area
+// distribution of the jets of the event, as in C++ FastJet.
+//
+// The hardest NHardest jets of the event are excluded from the estimation,
+// and only the remaining jets within the rapidity range [RapMin, RapMax]
+// are considered.
+// When AbsRap is set, the rapidity range applies to the absolute rapidity
+// of the jets.
+//
+// For area definitions that do not explicitly cluster all ghosts into jets,
+// the empty area of the rapidity range is accounted for with empty jets of
+// area 0.55*pi*R^2 and null transverse momentum.
+type JetMedianBackgroundEstimator struct {
+	RapMin   float64 // minimal rapidity of the jets used in the estimation
+	RapMax   float64 // maximal rapidity of the jets used in the estimation
+	NHardest int     // number of hardest jets excluded from the estimation
+	AbsRap   bool    // whether the rapidity range applies to the absolute rapidity
+}
+
+// Estimate estimates the background of the event clustered by csa.
+func (bge JetMedianBackgroundEstimator) Estimate(csa *ClusterSequenceArea) (Background, error) {
+	var bkg Background
+	if bge.RapMax <= bge.RapMin {
+		return bkg, fmt.Errorf("fastjet: invalid rapidity range [%v, %v]", bge.RapMin, bge.RapMax)
+	}
+
+	all, err := csa.InclusiveJets(0)
+	if err != nil {
+		return bkg, fmt.Errorf("fastjet: could not retrieve inclusive jets: %w", err)
+	}
+	sort.Sort(ByPt(all))
+
+	var (
+		ymin, ymax = bge.RapMin, bge.RapMax
+		sel        = func(jet *Jet) bool {
+			y := jet.Rapidity()
+			if bge.AbsRap {
+				y = math.Abs(y)
+			}
+			return ymin <= y && y <= ymax
+		}
+		area  float64 // total area of the jets within the rapidity range
+		ptas  = make([]float64, 0, len(all))
+		areas float64 // total area of the jets used in the estimation
+	)
+	for i := range all {
+		jet := &all[i]
+		if !sel(jet) {
+			continue
+		}
+		a := csa.Area(jet)
+		area += a
+		if i < bge.NHardest || a <= 0 {
+			continue
+		}
+		ptas = append(ptas, jet.Pt()/a)
+		areas += a
+	}
+
+	switch csa.area.typ {
+	case ActiveArea, PassiveArea, OneGhostPassiveArea:
+		// the region covered by ghosts.
+		ymin = math.Max(ymin, -csa.area.ghost.MaxRap)
+		ymax = math.Min(ymax, +csa.area.ghost.MaxRap)
+	}
+	width := ymax - ymin
+	if bge.AbsRap {
+		ymin = math.Max(ymin, 0)
+		width = 2 * (ymax - ymin)
+	}
+	if ymin < ymax {
+		empty := width*2*math.Pi - area
+		bkg.NEmpty = math.Max(0, empty/(0.55*math.Pi*csa.cs.r2))
+	}
+
+	if len(ptas) == 0 {
+		return bkg, nil
+	}
+
+	slices.Sort(ptas)
+	bkg.NJets = len(ptas)
+	bkg.MeanArea = areas / float64(len(ptas))
+	bkg.Rho = percentile(ptas, 0.5, bkg.NEmpty)
+
+	const onesigma = 0.158655254 // (1-0.6827)/2
+	bkg.Sigma = (bkg.Rho - percentile(ptas, onesigma, bkg.NEmpty)) * math.Sqrt(bkg.MeanArea)
+
+	return bkg, nil
+}
+
+// percentile returns the p-percentile of the sorted values, extended with
+// nempty values equal to zero.
+func percentile(sorted []float64, p, nempty float64) float64 {
+	var (
+		n   = len(sorted)
+		pos = (float64(n)+nempty)*p - nempty - 0.5
+	)
+	switch {
+	case pos >= 0 && n > 1:
+		lo := int(pos)
+		if lo >= n-1 {
+			return sorted[n-1]
+		}
+		hi := lo + 1
+		return sorted[lo]*(float64(hi)-pos) + sorted[hi]*(pos-float64(lo))
+	case pos > -0.5 && n >= 1:
+		// interpolate between the empty jets and the first jet.
+		return sorted[0] * (pos + 0.5)
+	default:
+		return 0
+	}
+}
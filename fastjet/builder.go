@@ -15,3 +15,22 @@ type Builder interface {
 	// Constituents retrieves the constituents of a jet
 	Constituents(jet *Jet) ([]Jet, error)
 }
+
+// Recorder is a Builder whose clustering sequence can be filled from
+// the outside, as done by plugins.
+type Recorder interface {
+	Builder
+
+	// Jets returns all the jets of the clustering sequence,
+	// the first ones being the input particles.
+	Jets() []Jet
+
+	// RecordIJRecombination records the recombination of the i-th and
+	// j-th jets, at the distance dij, and returns the index of the
+	// resulting jet.
+	RecordIJRecombination(i, j int, dij float64) (int, error)
+
+	// RecordIBRecombination records the recombination of the i-th jet
+	// with the beam, at the distance dib.
+	RecordIBRecombination(i int, dib float64) error
+}
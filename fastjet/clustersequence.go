@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math"
 
+	"go-hep.org/x/hep/fastjet/internal/heap"
 	"go-hep.org/x/hep/fmom"
 )
 
@@ -22,6 +23,10 @@ type history struct {
 	maxdij  float64
 }
 
+// nlnnMinJets is the number of input particles above which the NlnN strategy
+// is chosen by BestStrategy.
+const nlnnMinJets = 200
+
 const (
 	invalidIndex     = -3
 	inexistentParent = -2
@@ -224,16 +229,26 @@ func (cs *ClusterSequence) run() error {
 		return nil
 	}
 
-	run := cs.runN3Dumb
+	if cs.alg == PluginAlgorithm {
+		if cs.def.plugin == nil {
+			return errors.New("fastjet: plugin algorithm without plugin")
+		}
+		return cs.def.plugin.RunClustering(cs)
+	}
+
+	strategy, err := cs.chooseStrategy()
+	if err != nil {
+		return err
+	}
 
-	switch cs.strategy {
-	case NlnNStrategy:
-		run = cs.runNlnN
-	case N3DumbStrategy:
-		run = cs.runN3Dumb
+	run := cs.runN3Dumb
+	switch strategy {
+	case NlnNStrategy, NlnN3piStrategy, NlnN4piStrategy,
+		NlnNCamStrategy, NlnNCam2pi2RStrategy, NlnNCam4piStrategy:
+		run = func() error { return cs.runNlnN(strategy) }
 	}
 
-	err := run()
+	err = run()
 	if err != nil {
 		return err
 	}
@@ -241,11 +256,107 @@ func (cs *ClusterSequence) run() error {
 	return nil
 }
 
+// chooseStrategy returns the strategy used to run the clustering.
+// BestStrategy is resolved from the algorithm, R and the number of jets.
+// The NlnN strategies find nearest neighbours on the rapidity-phi cylinder,
+// so they can not be used with the e+e- algorithms nor with R >= 2pi.
+func (cs *ClusterSequence) chooseStrategy() (Strategy, error) {
+	var (
+		ee    = cs.alg == EeKtAlgorithm || cs.alg == EeGenKtAlgorithm
+		large = cs.r >= 2*math.Pi
+	)
+
+	switch strategy := cs.strategy; strategy {
+	case BestStrategy:
+		if ee || large || len(cs.jets) < nlnnMinJets {
+			return N3DumbStrategy, nil
+		}
+		return NlnNStrategy, nil
+
+	case NlnNCamStrategy, NlnNCam2pi2RStrategy, NlnNCam4piStrategy:
+		if cs.alg != CambridgeAlgorithm {
+			return strategy, fmt.Errorf("fastjet: %v strategy can only be used with the Cambridge/Aachen algorithm", strategy)
+		}
+		fallthrough
+
+	case NlnNStrategy, NlnN3piStrategy, NlnN4piStrategy:
+		switch {
+		case ee:
+			return strategy, fmt.Errorf("fastjet: %v strategy can not be used with e+e- algorithms", strategy)
+		case large:
+			return strategy, fmt.Errorf("fastjet: %v strategy requires R < 2pi (R=%v)", strategy, cs.r)
+		}
+		return strategy, nil
+
+	default:
+		return strategy, nil
+	}
+}
+
 // Constituents retrieves the list of constituents of a given jet
 func (cs *ClusterSequence) Constituents(jet *Jet) ([]Jet, error) {
 	return cs.addConstituents(jet)
 }
 
+// Jets returns all the jets of the clustering sequence, the first ones
+// being the input particles.
+func (cs *ClusterSequence) Jets() []Jet {
+	return cs.jets
+}
+
+// RecordIJRecombination records the recombination of the i-th and j-th
+// jets, at the distance dij, and returns the index of the resulting jet.
+//
+// RecordIJRecombination is meant to be used by plugins.
+func (cs *ClusterSequence) RecordIJRecombination(i, j int, dij float64) (int, error) {
+	if err := cs.checkJetIndex(i); err != nil {
+		return -1, err
+	}
+	if err := cs.checkJetIndex(j); err != nil {
+		return -1, err
+	}
+	if i == j {
+		return -1, fmt.Errorf("fastjet: can not recombine jet %d with itself", i)
+	}
+	return cs.ijRecombinationStep(i, j, dij)
+}
+
+// RecordIBRecombination records the recombination of the i-th jet with the
+// beam, at the distance dib.
+//
+// RecordIBRecombination is meant to be used by plugins.
+func (cs *ClusterSequence) RecordIBRecombination(i int, dib float64) error {
+	if err := cs.checkJetIndex(i); err != nil {
+		return err
+	}
+	return cs.ibRecombinationStep(i, dib)
+}
+
+func (cs *ClusterSequence) checkJetIndex(i int) error {
+	if i < 0 || i >= len(cs.jets) {
+		return fmt.Errorf("fastjet: invalid jet index %d", i)
+	}
+	if child := cs.history[cs.jets[i].hidx].child; child != invalidIndex {
+		return fmt.Errorf("fastjet: jet %d was already recombined", i)
+	}
+	return nil
+}
+
+// Parents returns the two jets that were merged to create the given jet.
+// Parents returns false if the jet was not created by the merging of two jets.
+func (cs *ClusterSequence) Parents(jet *Jet) (p1, p2 Jet, ok bool) {
+	hh := &cs.history[jet.hidx]
+	if hh.parent1 < 0 || hh.parent2 < 0 {
+		return p1, p2, false
+	}
+	p1 = cs.jets[cs.history[hh.parent1].jet]
+	p2 = cs.jets[cs.history[hh.parent2].jet]
+	if p1.Pt2() < p2.Pt2() {
+		p1, p2 = p2, p1
+	}
+	return p1, p2, true
+}
+
 func (cs *ClusterSequence) addConstituents(jet *Jet) ([]Jet, error) {
 	var err error
 	var subjets []Jet
@@ -501,43 +612,149 @@ func (cs *ClusterSequence) runN3Dumb() error {
 	return err
 }
 
-// runNlnN runs the clustering using a Hierarchical Delaunay triangulation
+// runNlnN runs the clustering using a dynamic Delaunay triangulation
 // and a min-heap to achieve O(N*ln N) behaviour.
 //
-// There are internally asserted assumptions about absence of points
-// with coincident eta-phi coordinates.
-func (cs *ClusterSequence) runNlnN() error {
-	panic(fmt.Errorf("fastjet: runNlnN not implemented"))
+// The nearest neighbours on the rapidity-phi cylinder are found in the
+// plane, after mirroring (some of) the jets at phi+2pi, as prescribed
+// by the clustering strategy.
+func (cs *ClusterSequence) runNlnN(strategy Strategy) error {
+	var (
+		n      = len(cs.jets)
+		mirror float64
+	)
+
+	switch strategy {
+	case NlnN4piStrategy, NlnNCam4piStrategy:
+		mirror = 2 * math.Pi
+	case NlnN3piStrategy:
+		mirror = math.Pi
+	default:
+		mirror = math.Min(cs.r, 2*math.Pi)
+	}
+
+	var (
+		dnn    = newDnnCylinder(cs.jets, mirror, 2*n)
+		h      = heap.New()
+		active = make([]bool, n, 2*n)
+		dists  = make([]ktDistance, n, 2*n)
+	)
+
+	for i := range n {
+		active[i] = true
+	}
+	for i := range n {
+		cs.addKtDistance(h, dnn, dists, i)
+	}
+
+	for nactive := n; nactive > 0; nactive-- {
+		var (
+			i, j int
+			dij  float64
+		)
+		for {
+			i, j, dij = h.Pop()
+			if i < 0 {
+				return fmt.Errorf("fastjet: internal error (empty heap with %d active jets)", nactive)
+			}
+			if !active[i] {
+				continue
+			}
+			if d := dists[i]; !d.ok || d.j != j || d.dij != dij {
+				// stale entry.
+				continue
+			}
+			if j != beamJetIndex && !active[j] {
+				continue
+			}
+			break
+		}
+
+		var updated []int
+		switch j {
+		case beamJetIndex:
+			err := cs.ibRecombinationStep(i, dij)
+			if err != nil {
+				return err
+			}
+			active[i] = false
+			updated = dnn.remove(i)
+
+		default:
+			k, err := cs.ijRecombinationStep(i, j, dij)
+			if err != nil {
+				return err
+			}
+			active[i] = false
+			active[j] = false
+			active = append(active, true)
+			dists = append(dists, ktDistance{})
+
+			updated = append(updated, dnn.remove(i)...)
+			updated = append(updated, dnn.remove(j)...)
+			updated = append(updated, dnn.insert(k, &cs.jets[k])...)
+			updated = append(updated, k)
+		}
+
+		for _, u := range updated {
+			if !active[u] {
+				continue
+			}
+			cs.addKtDistance(h, dnn, dists, u)
+		}
+	}
+
+	return nil
+}
+
+// ktDistance is the kt distance of a jet pushed on the heap.
+type ktDistance struct {
+	j   int     // index of the other jet (or beamJetIndex)
+	dij float64 // kt distance
+	ok  bool    // whether a kt distance was pushed on the heap for that jet
 }
 
-// // addKtDistance adds the current kt distance for particle jeti to the heap
-// // using information about the nearest neighbor in the eta-phi plane.
-// // Work as follows:
-// //
-// // . if the kt is zero then its nearest neighbour is taken to be
-// //   the beam jet and the distance is zero.
-// //
-// // . if cylinder distance to nearest neighbour > r^2 then it is
-// //   yiB that is smallest and this is added to the heap.
-// //
-// // . otherwise if the nearest neighbour jj has a larger kt then add
-// //   dij to the heap.
-// //
-// // . otherwise do nothing
-// //
-// func (cs *ClusterSequence) addKtDistance(h *heap.Heap, jeti, jetj int, dist float64) {
-// 	yiB := cs.jetScaleForAlgorithm(&cs.jets[jeti])
-// 	if yiB == 0 {
-// 		h.Push(jeti, beamJetIndex, yiB)
-// 		return
-// 	}
-// 	deltaR2 := dist * cs.invR2
-// 	if deltaR2 > 1 {
-// 		h.Push(jeti, beamJetIndex, yiB)
-// 		return
-// 	}
-// 	if yiB <= cs.jetScaleForAlgorithm(&cs.jets[jetj]) {
-// 		dij := deltaR2 * yiB
-// 		h.Push(jeti, jetj, dij)
-// 	}
-// }
+// addKtDistance adds the current kt distance for particle jeti to the heap
+// using information about the nearest neighbor in the rapidity-phi plane.
+// Work as follows:
+//
+//   - if the kt is zero then its nearest neighbour is taken to be
+//     the beam jet and the distance is zero.
+//   - if cylinder distance to nearest neighbour > r^2 then it is
+//     yiB that is smallest and this is added to the heap.
+//   - otherwise if the nearest neighbour jj has a larger kt then add
+//     dij to the heap.
+//   - otherwise do nothing
+//
+// Entries previously pushed on the heap for jeti become stale.
+func (cs *ClusterSequence) addKtDistance(h *heap.Heap, dnn *dnnCylinder, dists []ktDistance, jeti int) {
+	push := func(j int, dij float64) {
+		dists[jeti] = ktDistance{j: j, dij: dij, ok: true}
+		h.Push(jeti, j, dij)
+	}
+
+	yiB := cs.jetScaleForAlgorithm(&cs.jets[jeti])
+	if yiB == 0 {
+		push(beamJetIndex, yiB)
+		return
+	}
+
+	jetj, _ := dnn.nearest(jeti)
+	if jetj == beamJetIndex {
+		push(beamJetIndex, yiB)
+		return
+	}
+
+	deltaR2 := Distance(&cs.jets[jeti], &cs.jets[jetj]) * cs.invR2
+	if deltaR2 > 1 {
+		push(beamJetIndex, yiB)
+		return
+	}
+
+	if yiB <= cs.jetScaleForAlgorithm(&cs.jets[jetj]) {
+		push(jetj, deltaR2*yiB)
+		return
+	}
+
+	dists[jeti] = ktDistance{}
+}
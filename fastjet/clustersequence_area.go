@@ -4,44 +4,686 @@
 
 package fastjet
 
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"go-hep.org/x/hep/fmom"
+)
+
+// ClusterSequenceArea is a ClusterSequence that also computes the area of
+// the jets.
+//
+// The jets of a ClusterSequenceArea are the ones of the clustering of the
+// input particles alone: ghosts, when used to compute areas, never appear
+// among the jets or their constituents.
 type ClusterSequenceArea struct {
 	cs   *ClusterSequence
 	area AreaDefinition
+
+	// areas, errors and 4-vector areas of the jets, indexed by the history
+	// index of the jets in the cluster sequence.
+	areas []float64
+	errs  []float64
+	a4s   [][4]float64
 }
 
+// NewClusterSequenceArea clusters the provided particles with the def jet
+// definition and computes the area of the resulting jets according to the
+// area definition.
+//
+// As in C++ FastJet, passive areas are computed as Voronoi areas (with
+// Rfact=1) for the kt algorithm and as active areas for the anti-kt
+// algorithm, as both are then equivalent, and with one ghost at a time
+// for the other algorithms.
 func NewClusterSequenceArea(jets []Jet, def JetDefinition, area AreaDefinition) (*ClusterSequenceArea, error) {
+	err := area.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	switch def.Algorithm() {
+	case EeKtAlgorithm, EeGenKtAlgorithm:
+		return nil, fmt.Errorf("fastjet: jet areas are not defined for e+e- algorithms")
+	}
+
 	cs, err := NewClusterSequence(jets, def)
 	if err != nil {
 		return nil, err
 	}
 
 	csa := ClusterSequenceArea{
-		cs:   cs,
-		area: area,
+		cs:    cs,
+		area:  area,
+		areas: make([]float64, len(cs.history)),
+		errs:  make([]float64, len(cs.history)),
+		a4s:   make([][4]float64, len(cs.history)),
+	}
+
+	switch area.typ {
+	case ActiveArea:
+		err = csa.runActive(def)
+	case PassiveArea:
+		switch def.Algorithm() {
+		case KtAlgorithm:
+			csa.runVoronoi(1)
+		case AntiKtAlgorithm:
+			err = csa.runActive(def)
+		case CambridgeAlgorithm:
+			// ghosts are clustered as with the anti-kt algorithm, real
+			// particles as with the Cambridge/Aachen one.
+			tmp := def
+			tmp.alg = CambridgeForPassiveAlgorithm
+			tmp.extra = math.Sqrt(area.ghost.MeanGhostPt)
+			err = csa.runActive(tmp)
+		default:
+			err = csa.run1GhostPassive()
+		}
+	case OneGhostPassiveArea:
+		err = csa.run1GhostPassive()
+	case VoronoiArea:
+		csa.runVoronoi(area.voronoi.Rfact)
+	}
+	if err != nil {
+		return nil, err
 	}
+
 	return &csa, nil
 }
 
+// AreaDefinition returns the area definition used to compute the jet areas.
+func (csa *ClusterSequenceArea) AreaDefinition() AreaDefinition {
+	return csa.area
+}
+
+// Area returns the area of the given jet.
 func (csa *ClusterSequenceArea) Area(jet *Jet) float64 {
-	panic("not implemented")
+	return csa.areas[csa.index(jet)]
 }
 
+// AreaErr returns the uncertainty on the area of the given jet.
+//
+// For ghost-based areas, AreaErr is the standard deviation of the areas
+// obtained with the different repetitions of the ghosts layout.
 func (csa *ClusterSequenceArea) AreaErr(jet *Jet) float64 {
-	panic("not implemented")
+	return csa.errs[csa.index(jet)]
+}
+
+// AreaFourVector returns the 4-vector area of the given jet.
+//
+// The 4-vector area of a jet is the sum of the 4-momenta of its ghosts,
+// each ghost being normalized to a transverse momentum equal to its area.
+// For Voronoi areas, the 4-momentum of each constituent is normalized to a
+// transverse momentum equal to the area of its Voronoi cell.
+func (csa *ClusterSequenceArea) AreaFourVector(jet *Jet) fmom.PxPyPzE {
+	a4 := csa.a4s[csa.index(jet)]
+	return fmom.NewPxPyPzE(a4[0], a4[1], a4[2], a4[3])
+}
+
+func (csa *ClusterSequenceArea) index(jet *Jet) int {
+	i := jet.hidx
+	if i < 0 || i >= len(csa.areas) {
+		panic(fmt.Errorf("fastjet: jet is not part of the cluster sequence (history index: %d)", i))
+	}
+	return i
+}
+
+// SubtractedJet returns the given jet, with the background transverse
+// momentum density rho subtracted using the 4-vector area of the jet:
+//
+//	p_sub = p - rho*A
+//
+// SubtractedJet returns a jet with a null 4-momentum if the transverse
+// momentum of the background exceeds the one of the jet.
+// The subtracted jet keeps the constituents of the original jet.
+func (csa *ClusterSequenceArea) SubtractedJet(jet *Jet, rho float64) Jet {
+	var (
+		a4  = csa.AreaFourVector(jet)
+		sub = NewJet(0, 0, 0, 0)
+	)
+	if rho*a4.Pt() < jet.Pt() {
+		sub = NewJet(
+			jet.Px()-rho*a4.Px(),
+			jet.Py()-rho*a4.Py(),
+			jet.Pz()-rho*a4.Pz(),
+			jet.E()-rho*a4.E(),
+		)
+	}
+	sub.UserInfo = jet.UserInfo
+	sub.hidx = jet.hidx
+	sub.structure = jet.structure
+	return sub
+}
+
+// SubtractedPt returns the transverse momentum of the given jet, with the
+// background transverse momentum density rho subtracted using the scalar
+// area of the jet.
+func (csa *ClusterSequenceArea) SubtractedPt(jet *Jet, rho float64) float64 {
+	return jet.Pt() - rho*csa.Area(jet)
 }
 
+// NumExclusiveJets returns the number of exclusive jets that would have been obtained
+// running the algorithm in exclusive mode with the given dcut
 func (csa *ClusterSequenceArea) NumExclusiveJets(dcut float64) int {
-	panic("not implemented")
+	return csa.cs.NumExclusiveJets(dcut)
 }
 
-func (cs *ClusterSequenceArea) ExclusiveJets(dcut float64) ([]Jet, error) {
-	panic("not implemented")
+func (csa *ClusterSequenceArea) ExclusiveJets(dcut float64) ([]Jet, error) {
+	return csa.cs.ExclusiveJets(dcut)
 }
 
-func (cs *ClusterSequenceArea) ExclusiveJetsUpTo(njets int) ([]Jet, error) {
-	panic("not implemented")
+func (csa *ClusterSequenceArea) ExclusiveJetsUpTo(njets int) ([]Jet, error) {
+	return csa.cs.ExclusiveJetsUpTo(njets)
 }
 
 func (csa *ClusterSequenceArea) InclusiveJets(ptmin float64) ([]Jet, error) {
-	panic("not implemented")
+	return csa.cs.InclusiveJets(ptmin)
+}
+
+// Constituents retrieves the list of constituents of a given jet
+func (csa *ClusterSequenceArea) Constituents(jet *Jet) ([]Jet, error) {
+	return csa.cs.Constituents(jet)
+}
+
+// ghosts returns a new layout of ghosts.
+func (csa *ClusterSequenceArea) ghosts(rnd *rand.Rand, ghosts []Jet) []Jet {
+	var (
+		spec          = csa.area.ghost
+		nrap, nphi, _ = spec.grid()
+		drap          = 2 * spec.MaxRap / float64(nrap)
+		dphi          = 2 * math.Pi / float64(nphi)
+	)
+	ghosts = ghosts[:0]
+	for irap := range nrap {
+		for iphi := range nphi {
+			var (
+				rap = -spec.MaxRap + (float64(irap)+0.5+spec.GridScatter*(rnd.Float64()-0.5))*drap
+				phi = (float64(iphi) + 0.5 + spec.GridScatter*(rnd.Float64()-0.5)) * dphi
+				pt  = spec.MeanGhostPt * (1 + spec.PtScatter*(rnd.Float64()-0.5))
+			)
+			ghosts = append(ghosts, NewJet(
+				pt*math.Cos(phi),
+				pt*math.Sin(phi),
+				pt*math.Sinh(rap),
+				pt*math.Cosh(rap),
+			))
+		}
+	}
+	return ghosts
+}
+
+func (csa *ClusterSequenceArea) rand() *rand.Rand {
+	return rand.New(rand.NewPCG(csa.area.ghost.Seed, 0x9e3779b97f4a7c15))
+}
+
+// ghostArea4 returns the contribution of a ghost to a 4-vector area.
+func ghostArea4(ghost *Jet, area float64) [4]float64 {
+	f := area / ghost.Pt()
+	return [4]float64{f * ghost.Px(), f * ghost.Py(), f * ghost.Pz(), f * ghost.E()}
+}
+
+func addArea4(a, b [4]float64) [4]float64 {
+	return [4]float64{a[0] + b[0], a[1] + b[1], a[2] + b[2], a[3] + b[3]}
+}
+
+// runActive computes active areas, clustering the input particles together
+// with ghosts, using the def jet definition.
+//
+// Jets of the ghosted clustering are matched to the jets of the clustering
+// of the input particles alone through their (real) constituents.
+// As ghosts are infinitely soft, the clustering of the input particles is
+// not modified by the ghosts for infrared safe algorithms.
+func (csa *ClusterSequenceArea) runActive(def JetDefinition) error {
+	var (
+		cs       = csa.cs
+		n        = cs.initn
+		rnd      = csa.rand()
+		keys     = make([]uint64, n)
+		_, _, ga = csa.area.ghost.grid()
+		nrep     = csa.area.ghost.Repeat
+		sum2     = make([]float64, len(cs.history))
+	)
+
+	// identify each input particle with a random key, and each jet with
+	// the sum of the keys of its constituents.
+	for i := range keys {
+		keys[i] = rnd.Uint64()
+	}
+	fkeys := historyKeys(cs, keys)
+
+	type ghostInfo struct {
+		n  int
+		a4 [4]float64
+	}
+
+	var (
+		particles = make([]Jet, n)
+		ghosts    []Jet
+	)
+	for i := range particles {
+		p := &cs.jets[i]
+		particles[i] = NewJet(p.Px(), p.Py(), p.Pz(), p.E())
+	}
+
+	for range nrep {
+		ghosts = csa.ghosts(rnd, ghosts)
+		gcs, err := NewClusterSequence(append(particles[:n:n], ghosts...), def)
+		if err != nil {
+			return fmt.Errorf("fastjet: could not cluster ghosts: %w", err)
+		}
+
+		var (
+			gkeys = historyKeys(gcs, keys)
+			infos = make([]ghostInfo, len(gcs.history))
+			jets  = make(map[uint64]ghostInfo, len(gcs.history))
+		)
+		for i, hh := range gcs.history {
+			switch {
+			case hh.parent1 == inexistentParent:
+				if i >= n {
+					infos[i] = ghostInfo{1, ghostArea4(&gcs.jets[i], ga)}
+				}
+			case hh.jet == invalidIndex:
+				continue
+			default:
+				p1 := infos[hh.parent1]
+				p2 := infos[hh.parent2]
+				infos[i] = ghostInfo{p1.n + p2.n, addArea4(p1.a4, p2.a4)}
+			}
+			if gkeys[i] == 0 {
+				// pure ghost jet.
+				continue
+			}
+			// the last jet with a given set of real constituents
+			// holds all the ghosts attached to these constituents.
+			jets[gkeys[i]] = infos[i]
+		}
+
+		for i, key := range fkeys {
+			if key == 0 {
+				continue
+			}
+			info := jets[key]
+			area := float64(info.n) * ga
+			csa.areas[i] += area
+			sum2[i] += area * area
+			csa.a4s[i] = addArea4(csa.a4s[i], info.a4)
+		}
+	}
+
+	csa.normalize(sum2, nrep)
+	return nil
+}
+
+// normalize turns the sums of areas over nrep repetitions into mean values
+// and standard deviations.
+func (csa *ClusterSequenceArea) normalize(sum2 []float64, nrep int) {
+	inv := 1 / float64(nrep)
+	for i := range csa.areas {
+		mean := csa.areas[i] * inv
+		csa.areas[i] = mean
+		csa.errs[i] = math.Sqrt(math.Max(0, sum2[i]*inv-mean*mean))
+		for j := range csa.a4s[i] {
+			csa.a4s[i][j] *= inv
+		}
+	}
+}
+
+// historyKeys returns, for each step of the history of the cluster sequence,
+// the sum of the keys of the constituents of the created jet.
+// Constituents without a key (ghosts) and beam recombination steps have
+// a null key.
+func historyKeys(cs *ClusterSequence, keys []uint64) []uint64 {
+	o := make([]uint64, len(cs.history))
+	for i, hh := range cs.history {
+		switch {
+		case hh.parent1 == inexistentParent:
+			if i < len(keys) {
+				o[i] = keys[i]
+			}
+		case hh.jet == invalidIndex:
+			// beam recombination.
+		default:
+			o[i] = o[hh.parent1] + o[hh.parent2]
+		}
+	}
+	return o
+}
+
+// cumulate sums the areas attached to each jet of the history with the
+// areas of its parents.
+func (csa *ClusterSequenceArea) cumulate(areas []float64, a4s [][4]float64) {
+	for i, hh := range csa.cs.history {
+		if hh.parent1 == inexistentParent || hh.jet == invalidIndex {
+			continue
+		}
+		areas[i] += areas[hh.parent1] + areas[hh.parent2]
+		a4s[i] = addArea4(a4s[i], addArea4(a4s[hh.parent1], a4s[hh.parent2]))
+	}
+}
+
+// run1GhostPassive computes passive areas, adding one ghost at a time to
+// the event.
+//
+// As ghosts are infinitely soft, the clustering sequence of the input
+// particles is left untouched by a ghost.
+// The fate of a ghost is thus obtained by replaying the clustering sequence
+// and checking, at each step, whether the distance of the ghost to the
+// beam or to one of the current jets is smaller than the distance of the
+// recombination of that step.
+func (csa *ClusterSequenceArea) run1GhostPassive() error {
+	cs := csa.cs
+	if cs.alg == PluginAlgorithm {
+		return fmt.Errorf("fastjet: passive areas are not supported for plugin algorithms")
+	}
+
+	var (
+		rnd      = csa.rand()
+		_, _, ga = csa.area.ghost.grid()
+		nrep     = csa.area.ghost.Repeat
+		sum2     = make([]float64, len(cs.history))
+		scales   = make([]float64, len(cs.jets))
+		areas    = make([]float64, len(cs.history))
+		a4s      = make([][4]float64, len(cs.history))
+		replay   = newGhostReplay(cs)
+		ghosts   []Jet
+	)
+	for i := range cs.jets {
+		scales[i] = cs.jetScaleForAlgorithm(&cs.jets[i])
+	}
+
+	for range nrep {
+		clear(areas)
+		clear(a4s)
+		ghosts = csa.ghosts(rnd, ghosts)
+		for i := range ghosts {
+			ghost := &ghosts[i]
+			h := replay.run(ghost, cs.jetScaleForAlgorithm(ghost), scales)
+			if h < 0 {
+				continue
+			}
+			areas[h] += ga
+			a4s[h] = addArea4(a4s[h], ghostArea4(ghost, ga))
+		}
+		csa.cumulate(areas, a4s)
+
+		for i, hh := range cs.history {
+			if hh.jet == invalidIndex {
+				continue
+			}
+			csa.areas[i] += areas[i]
+			sum2[i] += areas[i] * areas[i]
+			csa.a4s[i] = addArea4(csa.a4s[i], a4s[i])
+		}
+	}
+
+	csa.normalize(sum2, nrep)
+	return nil
+}
+
+// ghostReplay replays a clustering sequence in the presence of a single
+// infinitely soft ghost.
+type ghostReplay struct {
+	cs  *ClusterSequence
+	act []int // indices of the active jets
+	pos []int // position of each jet in the active jets slice, -1 if inactive
+}
+
+func newGhostReplay(cs *ClusterSequence) *ghostReplay {
+	return &ghostReplay{
+		cs:  cs,
+		act: make([]int, 0, cs.initn),
+		pos: make([]int, len(cs.jets)),
+	}
+}
+
+// run returns the history index of the jet the ghost is clustered with, or
+// -1 if the ghost is recombined with the beam.
+func (rp *ghostReplay) run(ghost *Jet, sg float64, scales []float64) int {
+	var (
+		cs    = rp.cs
+		best  = -1
+		bestd = math.Inf(+1)
+	)
+
+	dist := func(j int) float64 {
+		return math.Min(sg, scales[j]) * Distance(ghost, &cs.jets[j]) * cs.invR2
+	}
+	update := func() {
+		best = -1
+		bestd = math.Inf(+1)
+		for _, j := range rp.act {
+			if d := dist(j); d < bestd {
+				best, bestd = j, d
+			}
+		}
+	}
+	remove := func(j int) {
+		i := rp.pos[j]
+		last := rp.act[len(rp.act)-1]
+		rp.act[i] = last
+		rp.pos[last] = i
+		rp.act = rp.act[:len(rp.act)-1]
+		rp.pos[j] = -1
+	}
+
+	for i := range rp.pos {
+		rp.pos[i] = -1
+	}
+	rp.act = rp.act[:0]
+	for i := range cs.initn {
+		rp.pos[i] = len(rp.act)
+		rp.act = append(rp.act, i)
+	}
+	update()
+
+	for _, hh := range cs.history[cs.initn:] {
+		if min(sg, bestd) < hh.dij {
+			if sg <= bestd {
+				return -1
+			}
+			return cs.jets[best].hidx
+		}
+
+		stale := false
+		for _, p := range []int{hh.parent1, hh.parent2} {
+			if p < 0 {
+				continue
+			}
+			j := cs.history[p].jet
+			remove(j)
+			stale = stale || j == best
+		}
+		if hh.jet != invalidIndex {
+			j := hh.jet
+			rp.pos[j] = len(rp.act)
+			rp.act = append(rp.act, j)
+			if d := dist(j); !stale && d < bestd {
+				best, bestd = j, d
+			}
+		}
+		if stale {
+			update()
+		}
+	}
+	return -1
+}
+
+// runVoronoi computes Voronoi areas, the Voronoi cell of each particle on
+// the rapidity-phi cylinder being intersected with a circle of radius
+// rfact*R centered on the particle.
+//
+// Coincident particles share the area of their Voronoi cell.
+func (csa *ClusterSequenceArea) runVoronoi(rfact float64) {
+	var (
+		cs   = csa.cs
+		n    = cs.initn
+		reff = rfact * cs.r
+		dmax = 2 * reff
+		idx  = make([]int, n)
+		raps = make([]float64, n)
+		phis = make([]float64, n)
+		poly = make([][2]float64, 0, 16)
+		tmp  = make([][2]float64, 0, 16)
+		nbrs [][2]float64
+	)
+	for i := range idx {
+		idx[i] = i
+		raps[i] = cs.jets[i].Rapidity()
+		phis[i] = cs.jets[i].Phi()
+	}
+	slices.SortFunc(idx, func(i, j int) int {
+		switch {
+		case raps[i] < raps[j]:
+			return -1
+		case raps[i] > raps[j]:
+			return +1
+		}
+		return 0
+	})
+
+	for ii, i := range idx {
+		nbrs = nbrs[:0]
+		coincident := 0
+		add := func(j int) {
+			drap := raps[j] - raps[i]
+			dphi := math.Remainder(phis[j]-phis[i], 2*math.Pi)
+			for _, dphi := range []float64{dphi - 2*math.Pi, dphi, dphi + 2*math.Pi} {
+				if math.Abs(dphi) >= dmax {
+					continue
+				}
+				switch d2 := drap*drap + dphi*dphi; {
+				case d2 == 0:
+					coincident++
+				case d2 < dmax*dmax:
+					nbrs = append(nbrs, [2]float64{drap, dphi})
+				}
+			}
+		}
+		if 2*math.Pi < dmax {
+			// the particle is its own neighbour across the phi=0 edge.
+			nbrs = append(nbrs, [2]float64{0, -2 * math.Pi}, [2]float64{0, +2 * math.Pi})
+		}
+		for _, j := range idx[ii+1:] {
+			if raps[j]-raps[i] >= dmax {
+				break
+			}
+			add(j)
+		}
+		for k := ii - 1; k >= 0; k-- {
+			j := idx[k]
+			if raps[i]-raps[j] >= dmax {
+				break
+			}
+			add(j)
+		}
+		// clip with the nearest neighbours first, to keep the polygon small.
+		slices.SortFunc(nbrs, func(a, b [2]float64) int {
+			da := a[0]*a[0] + a[1]*a[1]
+			db := b[0]*b[0] + b[1]*b[1]
+			switch {
+			case da < db:
+				return -1
+			case da > db:
+				return +1
+			}
+			return 0
+		})
+
+		poly = append(poly[:0],
+			[2]float64{-reff, -reff}, [2]float64{+reff, -reff},
+			[2]float64{+reff, +reff}, [2]float64{-reff, +reff},
+		)
+		for _, q := range nbrs {
+			poly, tmp = clipHalfPlane(tmp[:0], poly, q), poly
+		}
+
+		area := polyCircleArea(poly, reff) / float64(1+coincident)
+		csa.areas[i] = area
+		if pt := cs.jets[i].Pt(); pt > 0 {
+			f := area / pt
+			p := &cs.jets[i]
+			csa.a4s[i] = [4]float64{f * p.Px(), f * p.Py(), f * p.Pz(), f * p.E()}
+		}
+	}
+
+	csa.cumulate(csa.areas, csa.a4s)
+}
+
+// clipHalfPlane clips the convex polygon poly with the half-plane of the
+// points closer to the origin than to q, and appends the result to dst.
+func clipHalfPlane(dst, poly [][2]float64, q [2]float64) [][2]float64 {
+	var (
+		c    = 0.5 * (q[0]*q[0] + q[1]*q[1])
+		side = func(p [2]float64) float64 { return p[0]*q[0] + p[1]*q[1] - c }
+	)
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		sa := side(a)
+		sb := side(b)
+		if sa <= 0 {
+			dst = append(dst, a)
+		}
+		if (sa < 0 && sb > 0) || (sa > 0 && sb < 0) {
+			t := sa / (sa - sb)
+			dst = append(dst, [2]float64{a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])})
+		}
+	}
+	return dst
+}
+
+// polyCircleArea returns the area of the intersection of the polygon with
+// the disk of radius r centered on the origin.
+func polyCircleArea(poly [][2]float64, r float64) float64 {
+	var area float64
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		area += triCircleArea(a, b, r)
+	}
+	return math.Abs(area)
+}
+
+// triCircleArea returns the signed area of the intersection of the
+// triangle (O, a, b) with the disk of radius r centered on the origin O.
+func triCircleArea(a, b [2]float64, r float64) float64 {
+	var (
+		dx = b[0] - a[0]
+		dy = b[1] - a[1]
+		ts = [4]float64{0}
+		nt = 1
+	)
+	// split the segment [a, b] at its intersections with the circle.
+	qa := dx*dx + dy*dy
+	qb := a[0]*dx + a[1]*dy
+	qc := a[0]*a[0] + a[1]*a[1] - r*r
+	if disc := qb*qb - qa*qc; qa > 0 && disc > 0 {
+		sq := math.Sqrt(disc)
+		for _, t := range []float64{(-qb - sq) / qa, (-qb + sq) / qa} {
+			if 0 < t && t < 1 {
+				ts[nt] = t
+				nt++
+			}
+		}
+	}
+	ts[nt] = 1
+	nt++
+
+	var (
+		area float64
+		pt   = func(t float64) (float64, float64) { return a[0] + t*dx, a[1] + t*dy }
+	)
+	for i := range nt - 1 {
+		px, py := pt(ts[i])
+		qx, qy := pt(ts[i+1])
+		mx, my := pt(0.5 * (ts[i] + ts[i+1]))
+		cross := px*qy - py*qx
+		if mx*mx+my*my < r*r {
+			area += 0.5 * cross
+			continue
+		}
+		area += 0.5 * r * r * math.Atan2(cross, px*qx+py*qy)
+	}
+	return area
 }
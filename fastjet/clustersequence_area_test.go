@@ -4,14 +4,12 @@
 
 package fastjet_test
 
-/* FIXME
-
 import (
 	"bufio"
 	"fmt"
+	"math"
 	"os"
 	"sort"
-	"strings"
 	"testing"
 
 	"go-hep.org/x/hep/fastjet"
@@ -19,112 +17,316 @@ import (
 )
 
 func TestClusterSequenceArea(t *testing.T) {
-	const tol = 1e-6
+	// kinematics and passive kt areas, computed as Voronoi areas, are
+	// deterministic and compared with the C++ FastJet ones within the
+	// precision of the reference files.
+	// ghosts are laid out with a different random number generator than the
+	// one of C++ FastJet: ghosted areas are averaged over several layouts of
+	// the ghosts and compared with the C++ FastJet ones within a few standard
+	// deviations of the area of a jet.
+	const (
+		raptol  = 5e-6
+		pttol   = 5e-4
+		areatol = 5e-4
+		nsigma  = 4
+		seed    = 1234
+	)
 
 	for _, test := range []struct {
-		input string
-		name  string
-		def   fastjet.JetDefinition
-		area  fastjet.AreaDefinition
-		ptmin float64
+		name   string
+		def    fastjet.JetDefinition
+		area   fastjet.AreaType
+		repeat int
 	}{
 		{
-			input: "testdata/single-pp-event.dat",
-			name:  "area_ghost_active_kt_r1.0_escheme_best",
+			name: "area_ghost_active_kt_r1.0_escheme_best",
 			def: fastjet.NewJetDefinition(
 				fastjet.KtAlgorithm, 1.0, fastjet.EScheme, fastjet.BestStrategy,
 			),
-			area:  fastjet.AreaDefinition{}, // ghost-area, active-area
-			ptmin: 5.0,
+			area:   fastjet.ActiveArea,
+			repeat: 10,
 		},
 		{
-			input: "testdata/single-pp-event.dat",
-			name:  "area_ghost_passive_kt_r1.0_escheme_best",
+			name: "area_ghost_passive_kt_r1.0_escheme_best",
 			def: fastjet.NewJetDefinition(
 				fastjet.KtAlgorithm, 1.0, fastjet.EScheme, fastjet.BestStrategy,
 			),
-			area:  fastjet.AreaDefinition{}, // ghost-area, passive-area
-			ptmin: 5.0,
+			area:   fastjet.PassiveArea,
+			repeat: 1,
 		},
 		{
-			input: "testdata/single-pp-event.dat",
-			name:  "area_ghost_active_antikt_r1.0_escheme_best",
+			name: "area_ghost_active_antikt_r1.0_escheme_best",
 			def: fastjet.NewJetDefinition(
 				fastjet.AntiKtAlgorithm, 1.0, fastjet.EScheme, fastjet.BestStrategy,
 			),
-			area:  fastjet.AreaDefinition{}, // ghost-area, active-area
-			ptmin: 5.0,
+			area:   fastjet.ActiveArea,
+			repeat: 10,
 		},
 		{
-			input: "testdata/single-pp-event.dat",
-			name:  "area_ghost_passive_antikt_r1.0_escheme_best",
+			name: "area_ghost_passive_antikt_r1.0_escheme_best",
 			def: fastjet.NewJetDefinition(
 				fastjet.AntiKtAlgorithm, 1.0, fastjet.EScheme, fastjet.BestStrategy,
 			),
-			area:  fastjet.AreaDefinition{}, // ghost-area, passive-area
-			ptmin: 5.0,
+			area:   fastjet.PassiveArea,
+			repeat: 10,
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
 
-			// TODO
-			if strings.Contains(test.name, "passive") {
-				t.Skipf("passive area: not implemented")
-			}
-			// TODO
-			if strings.Contains(test.name, "active") {
-				t.Skipf("active area: not implemented")
-			}
-			test := test
-			particles, err := loadParticles(test.input)
+			particles, err := loadParticles("testdata/single-pp-event.dat")
 			if err != nil {
 				t.Fatal(err)
 			}
 
-			csa, err := fastjet.NewClusterSequenceArea(particles, test.def, test.area)
+			spec := fastjet.NewGhostedAreaSpec(6)
+			spec.Repeat = test.repeat
+			spec.Seed = seed
+			csa, err := fastjet.NewClusterSequenceArea(particles, test.def, fastjet.NewAreaDefinition(test.area, spec))
 			if err != nil {
 				t.Fatalf("error for jet definition: %v", err)
 			}
 
-			jets, err := csa.InclusiveJets(test.ptmin)
+			jets, err := csa.InclusiveJets(5.0)
 			if err != nil {
 				t.Fatalf("incl-jets error: %v", err)
 			}
 
 			sort.Sort(fastjet.ByPt(jets))
 
-			want, err := loadRefAreas("testdata/" + test.name + ".ref")
+			ref, err := loadRefAreas("testdata/" + test.name + ".ref")
 			if err != nil {
 				t.Fatalf("error reading reference file: %v", err)
 			}
 
-			if len(want) != len(jets) {
-				t.Fatalf("got %d jets, want %d", len(jets), len(want))
+			if len(ref) != len(jets) {
+				t.Fatalf("got %d jets, want %d", len(jets), len(ref))
 			}
 
-			n := len(jets)
-			if len(want) < n {
-				n = len(want)
+			for i := range jets {
+				var (
+					jet = &jets[i]
+					got = [5]float64{
+						jet.Rapidity(), angle0to2Pi(jet.Phi()), jet.Pt(),
+						csa.Area(jet), csa.AreaErr(jet),
+					}
+					ref = ref[i]
+				)
+				if !scalar.EqualWithinAbs(got[0], ref[0], raptol) ||
+					!scalar.EqualWithinAbs(got[1], ref[1], raptol) ||
+					!scalar.EqualWithinAbs(got[2], ref[2], pttol) {
+					t.Errorf("#%d\ngot= %v\nwant=%v", i, got[:3], ref[:3])
+				}
+
+				// the reference area is a single layout of the ghosts.
+				tol := areatol + nsigma*got[4]*math.Sqrt(1+1/float64(test.repeat))
+				if !scalar.EqualWithinAbs(got[3], ref[3], tol) {
+					t.Errorf("#%d: invalid area: got=%v +- %v, want=%v (tol=%v)", i, got[3], got[4], ref[3], tol)
+				}
 			}
-			for i := 0; i < n; i++ {
-				ref := want[i][:]
-				jet := &jets[i]
-				rap := jet.Rapidity()
-				phi := angle0to2Pi(jet.Phi())
-				pt := jet.Pt()
-
-				area := csa.Area(jet)
-				areaErr := csa.AreaErr(jet)
-
-				got := []float64{rap, phi, pt, area, areaErr}
-				if !scalar.EqualApprox(got, ref, tol) {
-					t.Errorf("#%d\ngot= %v\nwant=%v", i, got, ref)
+		})
+	}
+}
+
+func TestOneGhostPassiveArea(t *testing.T) {
+	// for the anti-kt algorithm, passive and active areas are the same for
+	// a given layout of the ghosts, up to a few ghosts where jets overlap.
+	const tol = 5 * 0.01
+	particles, err := loadParticles("testdata/single-pp-event.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 1.0, fastjet.EScheme, fastjet.BestStrategy)
+	spec := fastjet.NewGhostedAreaSpec(6)
+	spec.Seed = 1234
+
+	areas := func(typ fastjet.AreaType) []float64 {
+		t.Helper()
+		csa, err := fastjet.NewClusterSequenceArea(particles, def, fastjet.NewAreaDefinition(typ, spec))
+		if err != nil {
+			t.Fatalf("could not create cluster sequence: %+v", err)
+		}
+		jets, err := csa.InclusiveJets(5.0)
+		if err != nil {
+			t.Fatalf("could not retrieve jets: %+v", err)
+		}
+		sort.Sort(fastjet.ByPt(jets))
+		areas := make([]float64, len(jets))
+		for i := range jets {
+			areas[i] = csa.Area(&jets[i])
+		}
+		return areas
+	}
+
+	got := areas(fastjet.OneGhostPassiveArea)
+	want := areas(fastjet.ActiveArea)
+	if len(got) != len(want) {
+		t.Fatalf("invalid number of jets: got=%d, want=%d", len(got), len(want))
+	}
+	for i := range got {
+		if !scalar.EqualWithinAbs(got[i], want[i], tol) {
+			t.Errorf("#%d: invalid area: got=%v, want=%v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestVoronoiArea(t *testing.T) {
+	const r = 0.7
+	def := fastjet.NewJetDefinition(fastjet.KtAlgorithm, r, fastjet.EScheme, fastjet.BestStrategy)
+
+	for _, test := range []struct {
+		name      string
+		particles []fastjet.Jet
+		rfact     float64
+		want      []float64
+	}{
+		{
+			name:      "single",
+			particles: []fastjet.Jet{fastjet.NewJet(10, 0, 0, 10)},
+			rfact:     1,
+			want:      []float64{math.Pi * r * r},
+		},
+		{
+			name: "coincident",
+			particles: []fastjet.Jet{
+				fastjet.NewJet(10, 0, 0, 10),
+				fastjet.NewJet(10, 0, 0, 10),
+			},
+			rfact: 1,
+			want:  []float64{math.Pi * r * r},
+		},
+		{
+			// the Voronoi cell of a particle is the whole cylinder,
+			// intersected with a circle larger than the cylinder.
+			name:      "cylinder",
+			particles: []fastjet.Jet{fastjet.NewJet(10, 0, 0, 10)},
+			rfact:     10,
+			want: func() []float64 {
+				const reff = 10 * r
+				return []float64{2 * (math.Pi*math.Sqrt(reff*reff-math.Pi*math.Pi) + reff*reff*math.Asin(math.Pi/reff))}
+			}(),
+		},
+		{
+			// the particles are separated by r: each circle is cut along
+			// a chord at distance r/2 of its center.
+			name: "pair",
+			particles: []fastjet.Jet{
+				fastjet.NewJet(10, 0, 0, 10),
+				fastjet.NewJet(10*math.Cos(r), 10*math.Sin(r), 0, 10),
+			},
+			rfact: 1,
+			want: func() []float64 {
+				seg := r * r * (math.Acos(0.5) - 0.5*math.Sqrt(1-0.25))
+				a := math.Pi*r*r - seg
+				return []float64{a, a}
+			}(),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			csa, err := fastjet.NewClusterSequenceArea(
+				test.particles, def,
+				fastjet.NewVoronoiAreaDefinition(fastjet.VoronoiAreaSpec{Rfact: test.rfact}),
+			)
+			if err != nil {
+				t.Fatalf("could not create cluster sequence: %+v", err)
+			}
+			jets, err := csa.InclusiveJets(0)
+			if err != nil {
+				t.Fatalf("could not retrieve jets: %+v", err)
+			}
+			sort.Sort(fastjet.ByPt(jets))
+			if got, want := len(jets), len(test.want); got != want {
+				t.Fatalf("invalid number of jets: got=%d, want=%d", got, want)
+			}
+			for i := range jets {
+				if got, want := csa.Area(&jets[i]), test.want[i]; !scalar.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+					t.Errorf("#%d: invalid area: got=%v, want=%v", i, got, want)
 				}
 			}
 		})
 	}
+}
+
+func TestJetMedianBackgroundEstimator(t *testing.T) {
+	const (
+		drap = 0.2
+		nphi = 32
+		dphi = 2 * math.Pi / nphi
+		pt   = 0.5
+		hard = 100.0
+	)
+
+	// uniform background, with a hard particle.
+	var particles []fastjet.Jet
+	for y := -3 + 0.5*drap; y < 3; y += drap {
+		for i := range nphi {
+			phi := (float64(i) + 0.5) * dphi
+			particles = append(particles, fastjet.NewJet(
+				pt*math.Cos(phi), pt*math.Sin(phi), pt*math.Sinh(y), pt*math.Cosh(y),
+			))
+		}
+	}
+	particles = append(particles, fastjet.NewJet(hard, 0, 0, hard))
+
+	csa, err := fastjet.NewClusterSequenceArea(
+		particles,
+		fastjet.NewJetDefinition(fastjet.KtAlgorithm, 0.4, fastjet.EScheme, fastjet.BestStrategy),
+		fastjet.NewVoronoiAreaDefinition(fastjet.VoronoiAreaSpec{Rfact: 1}),
+	)
+	if err != nil {
+		t.Fatalf("could not create cluster sequence: %+v", err)
+	}
+
+	bge := fastjet.JetMedianBackgroundEstimator{RapMin: -2, RapMax: +2, NHardest: 1}
+	bkg, err := bge.Estimate(csa)
+	if err != nil {
+		t.Fatalf("could not estimate background: %+v", err)
+	}
+
+	// the transverse momentum of a jet is slightly smaller than the scalar
+	// sum of the transverse momenta of its constituents.
+	if got, want := bkg.Rho, pt/(drap*dphi); !scalar.EqualWithinRel(got, want, 2e-2) {
+		t.Fatalf("invalid rho: got=%v, want=%v", got, want)
+	}
+	if bkg.NJets == 0 || bkg.MeanArea <= 0 {
+		t.Fatalf("invalid background estimation: %+v", bkg)
+	}
+
+	abs, err := fastjet.JetMedianBackgroundEstimator{RapMin: 0, RapMax: 2, NHardest: 1, AbsRap: true}.Estimate(csa)
+	if err != nil {
+		t.Fatalf("could not estimate background in absolute rapidity range: %+v", err)
+	}
+	if got, want := abs.Rho, bkg.Rho; !scalar.EqualWithinRel(got, want, 1e-12) {
+		t.Fatalf("invalid rho in absolute rapidity range: got=%v, want=%v", got, want)
+	}
+	if got, want := abs.NJets, bkg.NJets; got != want {
+		t.Fatalf("invalid number of jets in absolute rapidity range: got=%d, want=%d", got, want)
+	}
 
+	jets, err := csa.InclusiveJets(10)
+	if err != nil {
+		t.Fatalf("could not retrieve jets: %+v", err)
+	}
+	if got, want := len(jets), 1; got != want {
+		t.Fatalf("invalid number of hard jets: got=%d, want=%d", got, want)
+	}
+
+	sub := csa.SubtractedJet(&jets[0], bkg.Rho)
+	if got, want := sub.Pt(), hard; !scalar.EqualWithinRel(got, want, 1e-2) {
+		t.Fatalf("invalid subtracted jet pt: got=%v, want=%v", got, want)
+	}
+	if got, want := csa.SubtractedPt(&jets[0], bkg.Rho), hard; !scalar.EqualWithinRel(got, want, 1e-2) {
+		t.Fatalf("invalid subtracted pt: got=%v, want=%v", got, want)
+	}
+	if got, want := len(sub.Constituents()), len(jets[0].Constituents()); got != want {
+		t.Fatalf("invalid number of constituents: got=%d, want=%d", got, want)
+	}
+
+	soft := csa.SubtractedJet(&jets[0], 1e6)
+	if got := soft.E(); got != 0 {
+		t.Fatalf("invalid over-subtracted jet: got E=%v, want=0", got)
+	}
 }
 
 func loadRefAreas(name string) ([][5]float64, error) {
@@ -151,4 +353,3 @@ func loadRefAreas(name string) ([][5]float64, error) {
 	}
 	return refs, nil
 }
-*/
@@ -0,0 +1,55 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet
+
+// CompositeJetStructure is the structure of a jet made of the E-scheme
+// combination of several pieces.
+type CompositeJetStructure struct {
+	pieces []Jet
+}
+
+// Constituents returns the constituents of all the pieces of the jet.
+func (cjs CompositeJetStructure) Constituents(jet *Jet) ([]Jet, error) {
+	var o []Jet
+	for i := range cjs.pieces {
+		piece := &cjs.pieces[i]
+		if piece.structure == nil {
+			o = append(o, *piece)
+			continue
+		}
+		sub, err := piece.structure.Constituents(piece)
+		if err != nil {
+			return nil, err
+		}
+		o = append(o, sub...)
+	}
+	return o, nil
+}
+
+// Join returns the E-scheme combination of the provided pieces, as a
+// jet whose constituents are the constituents of the pieces.
+func Join(pieces ...Jet) Jet {
+	var px, py, pz, e float64
+	for i := range pieces {
+		p := &pieces[i]
+		px += p.Px()
+		py += p.Py()
+		pz += p.Pz()
+		e += p.E()
+	}
+	jet := NewJet(px, py, pz, e)
+	jet.structure = CompositeJetStructure{pieces: append([]Jet(nil), pieces...)}
+	return jet
+}
+
+// Pieces returns the pieces a jet created by Join is made of.
+// Pieces returns nil for other jets.
+func (jet *Jet) Pieces() []Jet {
+	cjs, ok := jet.structure.(CompositeJetStructure)
+	if !ok {
+		return nil
+	}
+	return append([]Jet(nil), cjs.pieces...)
+}
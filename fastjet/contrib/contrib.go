@@ -0,0 +1,76 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package contrib provides jet substructure tools, modeled after the ones
+// of C++ FastJet and FastJet contrib: groomers (filtering, trimming,
+// pruning, mass-drop and soft-drop) and substructure observables
+// (N-subjettiness and energy correlation functions).
+package contrib // import "go-hep.org/x/hep/fastjet/contrib"
+
+import (
+	"fmt"
+	"sort"
+
+	"go-hep.org/x/hep/fastjet"
+)
+
+// maxAllowableR is the jet radius used to recluster all the constituents
+// of a jet into a single jet.
+const maxAllowableR = 1000
+
+// Groomer grooms jets, removing the soft and wide-angle radiation
+// they contain.
+type Groomer interface {
+	// Groom returns the groomed version of the provided jet.
+	// Groom returns a jet with a null 4-momentum if no part of the jet
+	// survives the grooming.
+	Groom(jet *fastjet.Jet) (fastjet.Jet, error)
+}
+
+var (
+	_ Groomer = (*Filter)(nil)
+	_ Groomer = (*Trimmer)(nil)
+	_ Groomer = (*Pruner)(nil)
+	_ Groomer = (*MassDropTagger)(nil)
+	_ Groomer = (*SoftDrop)(nil)
+)
+
+// recluster reclusters the constituents of the jet with the provided jet
+// definition, and returns the resulting inclusive jets sorted by decreasing
+// transverse momentum.
+func recluster(jet *fastjet.Jet, def fastjet.JetDefinition) (*fastjet.ClusterSequence, []fastjet.Jet, error) {
+	cs, err := fastjet.NewClusterSequence(jet.Constituents(), def)
+	if err != nil {
+		return nil, nil, fmt.Errorf("contrib: could not recluster jet: %w", err)
+	}
+
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("contrib: could not retrieve reclustered jets: %w", err)
+	}
+	sort.Sort(fastjet.ByPt(jets))
+
+	return cs, jets, nil
+}
+
+// reclusterCA reclusters all the constituents of the jet into a single jet,
+// with the Cambridge/Aachen algorithm.
+func reclusterCA(jet *fastjet.Jet) (*fastjet.ClusterSequence, fastjet.Jet, error) {
+	def := fastjet.NewJetDefinition(
+		fastjet.CambridgeAlgorithm, maxAllowableR,
+		fastjet.EScheme, fastjet.BestStrategy,
+	)
+	cs, jets, err := recluster(jet, def)
+	if err != nil {
+		return nil, fastjet.Jet{}, err
+	}
+	if len(jets) == 0 {
+		return cs, zeroJet(), nil
+	}
+	return cs, jets[0], nil
+}
+
+func zeroJet() fastjet.Jet {
+	return fastjet.NewJet(0, 0, 0, 0)
+}
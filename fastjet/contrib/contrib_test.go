@@ -0,0 +1,62 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contrib_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+)
+
+func particle(pt, y, phi float64) fastjet.Jet {
+	return fastjet.NewJet(pt*math.Cos(phi), pt*math.Sin(phi), pt*math.Sinh(y), pt*math.Cosh(y))
+}
+
+// twoProngs returns the constituents of a boosted 2-prong decay, with a
+// soft and wide-angle contamination.
+func twoProngs() []fastjet.Jet {
+	return []fastjet.Jet{
+		// first prong
+		particle(200, 0.0, 0.0),
+		particle(50, 0.05, 0.02),
+		particle(30, -0.03, 0.04),
+		// second prong
+		particle(150, 0.3, 0.2),
+		particle(40, 0.32, 0.25),
+		// soft, wide-angle radiation
+		particle(2, -0.6, -0.5),
+		particle(1.5, 0.7, -0.6),
+	}
+}
+
+// clusterOne clusters the particles into a single anti-kt jet.
+func clusterOne(t *testing.T, particles []fastjet.Jet) fastjet.Jet {
+	t.Helper()
+
+	cs, err := fastjet.NewClusterSequence(
+		particles,
+		fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 1.5, fastjet.EScheme, fastjet.BestStrategy),
+	)
+	if err != nil {
+		t.Fatalf("could not cluster particles: %+v", err)
+	}
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		t.Fatalf("could not retrieve jets: %+v", err)
+	}
+	if got, want := len(jets), 1; got != want {
+		t.Fatalf("invalid number of jets: got=%d, want=%d", got, want)
+	}
+	return jets[0]
+}
+
+func sumPt(jets []fastjet.Jet) float64 {
+	var sum float64
+	for i := range jets {
+		sum += jets[i].Pt()
+	}
+	return sum
+}
@@ -0,0 +1,142 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contrib
+
+import (
+	"math"
+
+	"go-hep.org/x/hep/fastjet"
+)
+
+// EnergyCorrelator computes the N-point energy correlation function of
+// the constituents of a jet, as defined by Larkoski, Salam and Thaler
+// (arXiv:1305.0007):
+//
+//	ECF(N, Beta) = sum_(i1<i2<...<iN) (prod_a pt_ia) * (prod_(a<b) DeltaR_ia,ib)^Beta
+//
+// where DeltaR is the rapidity-phi distance between two constituents.
+// ECF(0, Beta) is 1.
+type EnergyCorrelator struct {
+	N    int     // number of correlated constituents
+	Beta float64 // angular exponent
+}
+
+// Result returns the energy correlation function of the jet.
+func (ec EnergyCorrelator) Result(jet *fastjet.Jet) float64 {
+	return ecf(jet.Constituents(), ec.N, ec.Beta)
+}
+
+func ecf(parts []fastjet.Jet, n int, beta float64) float64 {
+	switch {
+	case n < 0:
+		panic("contrib: invalid negative number of correlated constituents")
+	case n == 0:
+		return 1
+	case n > len(parts):
+		return 0
+	}
+
+	pts := make([]float64, len(parts))
+	for i := range parts {
+		pts[i] = parts[i].Pt()
+	}
+	if n == 1 {
+		var sum float64
+		for _, pt := range pts {
+			sum += pt
+		}
+		return sum
+	}
+
+	drs := make([][]float64, len(parts))
+	for i := range parts {
+		drs[i] = make([]float64, len(parts))
+		for j := range i {
+			dr := math.Pow(fastjet.Distance(&parts[i], &parts[j]), 0.5*beta)
+			drs[i][j] = dr
+			drs[j][i] = dr
+		}
+	}
+
+	var (
+		idx = make([]int, 0, n)
+		sum func(beg int, w float64) float64
+	)
+	sum = func(beg int, w float64) float64 {
+		var o float64
+		for k := beg; k < len(parts); k++ {
+			wk := w * pts[k]
+			for _, i := range idx {
+				wk *= drs[i][k]
+			}
+			if wk == 0 {
+				continue
+			}
+			if len(idx)+1 == n {
+				o += wk
+				continue
+			}
+			idx = append(idx, k)
+			o += sum(k+1, wk)
+			idx = idx[:len(idx)-1]
+		}
+		return o
+	}
+	return sum(0, 1)
+}
+
+// EnergyCorrelatorRatio computes the ratio of energy correlation functions:
+//
+//	r_N = ECF(N+1, Beta) / ECF(N, Beta)
+type EnergyCorrelatorRatio struct {
+	N    int     // number of correlated constituents of the denominator
+	Beta float64 // angular exponent
+}
+
+// Result returns the ratio of energy correlation functions of the jet.
+func (ecr EnergyCorrelatorRatio) Result(jet *fastjet.Jet) float64 {
+	parts := jet.Constituents()
+	return ecf(parts, ecr.N+1, ecr.Beta) / ecf(parts, ecr.N, ecr.Beta)
+}
+
+// EnergyCorrelatorDoubleRatio computes the double ratio of energy
+// correlation functions:
+//
+//	C_N = ECF(N+1, Beta) * ECF(N-1, Beta) / ECF(N, Beta)^2
+//
+// C_1 and C_2 are suited to discriminate 1-prong and 2-prong jets,
+// respectively.
+type EnergyCorrelatorDoubleRatio struct {
+	N    int     // number of correlated constituents
+	Beta float64 // angular exponent
+}
+
+// Result returns the double ratio of energy correlation functions of the jet.
+func (ecr EnergyCorrelatorDoubleRatio) Result(jet *fastjet.Jet) float64 {
+	var (
+		parts = jet.Constituents()
+		ecfn  = ecf(parts, ecr.N, ecr.Beta)
+	)
+	return ecf(parts, ecr.N+1, ecr.Beta) * ecf(parts, ecr.N-1, ecr.Beta) / (ecfn * ecfn)
+}
+
+// EnergyCorrelatorD2 computes the D2 observable of Larkoski, Moult and
+// Neill (arXiv:1409.6298):
+//
+//	D2 = ECF(3, Beta) * ECF(1, Beta)^3 / ECF(2, Beta)^3
+type EnergyCorrelatorD2 struct {
+	Beta float64 // angular exponent
+}
+
+// Result returns the D2 observable of the jet.
+func (d2 EnergyCorrelatorD2) Result(jet *fastjet.Jet) float64 {
+	var (
+		parts = jet.Constituents()
+		e1    = ecf(parts, 1, d2.Beta)
+		e2    = ecf(parts, 2, d2.Beta)
+		e3    = ecf(parts, 3, d2.Beta)
+	)
+	return e3 * e1 * e1 * e1 / (e2 * e2 * e2)
+}
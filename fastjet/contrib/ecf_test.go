@@ -0,0 +1,82 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contrib_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fastjet/contrib"
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func TestEnergyCorrelator(t *testing.T) {
+	var (
+		parts = []fastjet.Jet{
+			particle(100, 0, 0),
+			particle(50, 0.3, 0.4),
+			particle(20, -0.2, 0.1),
+		}
+		jet = clusterOne(t, parts)
+
+		dr = func(i, j int) float64 {
+			return math.Sqrt(fastjet.Distance(&parts[i], &parts[j]))
+		}
+	)
+
+	for _, beta := range []float64{0.5, 1, 2} {
+		var (
+			d01  = math.Pow(dr(0, 1), beta)
+			d02  = math.Pow(dr(0, 2), beta)
+			d12  = math.Pow(dr(1, 2), beta)
+			ecf0 = 1.0
+			ecf1 = 170.0
+			ecf2 = 100*50*d01 + 100*20*d02 + 50*20*d12
+			ecf3 = 100 * 50 * 20 * d01 * d02 * d12
+		)
+
+		for _, tc := range []struct {
+			n    int
+			want float64
+		}{
+			{0, ecf0},
+			{1, ecf1},
+			{2, ecf2},
+			{3, ecf3},
+			{4, 0},
+		} {
+			got := contrib.EnergyCorrelator{N: tc.n, Beta: beta}.Result(&jet)
+			if !scalar.EqualWithinRel(got, tc.want, 1e-12) {
+				t.Fatalf("beta=%v: invalid ECF(%d): got=%v, want=%v", beta, tc.n, got, tc.want)
+			}
+		}
+
+		for _, tc := range []struct {
+			name string
+			got  float64
+			want float64
+		}{
+			{"r2", contrib.EnergyCorrelatorRatio{N: 2, Beta: beta}.Result(&jet), ecf3 / ecf2},
+			{"C1", contrib.EnergyCorrelatorDoubleRatio{N: 1, Beta: beta}.Result(&jet), ecf2 * ecf0 / (ecf1 * ecf1)},
+			{"C2", contrib.EnergyCorrelatorDoubleRatio{N: 2, Beta: beta}.Result(&jet), ecf3 * ecf1 / (ecf2 * ecf2)},
+			{"D2", contrib.EnergyCorrelatorD2{Beta: beta}.Result(&jet), ecf3 * ecf1 * ecf1 * ecf1 / (ecf2 * ecf2 * ecf2)},
+		} {
+			if !scalar.EqualWithinRel(tc.got, tc.want, 1e-12) {
+				t.Fatalf("beta=%v: invalid %s: got=%v, want=%v", beta, tc.name, tc.got, tc.want)
+			}
+		}
+	}
+
+	// D2 discriminates 2-prong jets from 1-prong jets.
+	var (
+		prongs = clusterOne(t, twoProngs())
+		prong  = clusterOne(t, twoProngs()[:3])
+		d2     = contrib.EnergyCorrelatorD2{Beta: 1}
+	)
+	if !(d2.Result(&prongs) < d2.Result(&prong)) {
+		t.Fatalf("invalid D2: 2-prong=%v, 1-prong=%v", d2.Result(&prongs), d2.Result(&prong))
+	}
+}
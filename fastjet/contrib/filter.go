@@ -0,0 +1,53 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contrib
+
+import (
+	"go-hep.org/x/hep/fastjet"
+)
+
+// Filter reclusters the constituents of a jet into subjets and only keeps
+// the NHardest hardest subjets.
+type Filter struct {
+	Def      fastjet.JetDefinition // definition used to recluster the jet into subjets
+	NHardest int                   // number of subjets to keep
+}
+
+// Groom returns the combination of the NHardest hardest subjets of the jet.
+func (f Filter) Groom(jet *fastjet.Jet) (fastjet.Jet, error) {
+	_, subjets, err := recluster(jet, f.Def)
+	if err != nil {
+		return fastjet.Jet{}, err
+	}
+	if len(subjets) > f.NHardest {
+		subjets = subjets[:f.NHardest]
+	}
+	return fastjet.Join(subjets...), nil
+}
+
+// Trimmer reclusters the constituents of a jet into subjets and only keeps
+// the subjets carrying at least a fraction PtFrac of the transverse
+// momentum of the jet.
+type Trimmer struct {
+	Def    fastjet.JetDefinition // definition used to recluster the jet into subjets
+	PtFrac float64               // minimal transverse momentum fraction of the kept subjets
+}
+
+// Groom returns the combination of the subjets of the jet passing the
+// transverse momentum fraction cut.
+func (tr Trimmer) Groom(jet *fastjet.Jet) (fastjet.Jet, error) {
+	_, subjets, err := recluster(jet, tr.Def)
+	if err != nil {
+		return fastjet.Jet{}, err
+	}
+	ptmin := tr.PtFrac * jet.Pt()
+	kept := subjets[:0]
+	for _, sub := range subjets {
+		if sub.Pt() >= ptmin {
+			kept = append(kept, sub)
+		}
+	}
+	return fastjet.Join(kept...), nil
+}
@@ -0,0 +1,56 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contrib_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fastjet/contrib"
+)
+
+func TestTrimmer(t *testing.T) {
+	jet := clusterOne(t, twoProngs())
+
+	trimmed, err := contrib.Trimmer{
+		Def:    fastjet.NewJetDefinition(fastjet.KtAlgorithm, 0.2, fastjet.EScheme, fastjet.BestStrategy),
+		PtFrac: 0.03,
+	}.Groom(&jet)
+	if err != nil {
+		t.Fatalf("could not trim jet: %+v", err)
+	}
+
+	if got, want := len(trimmed.Constituents()), 5; got != want {
+		t.Fatalf("invalid number of constituents: got=%d, want=%d", got, want)
+	}
+	if got, want := len(trimmed.Pieces()), 2; got != want {
+		t.Fatalf("invalid number of subjets: got=%d, want=%d", got, want)
+	}
+	if got, want := sumPt(trimmed.Constituents()), 470.0; got != want {
+		t.Fatalf("invalid scalar sum of constituents pt: got=%v, want=%v", got, want)
+	}
+	if !(trimmed.M() < jet.M()) {
+		t.Fatalf("trimming did not reduce the jet mass: trimmed=%v, jet=%v", trimmed.M(), jet.M())
+	}
+}
+
+func TestFilter(t *testing.T) {
+	jet := clusterOne(t, twoProngs())
+
+	filtered, err := contrib.Filter{
+		Def:      fastjet.NewJetDefinition(fastjet.CambridgeAlgorithm, 0.1, fastjet.EScheme, fastjet.BestStrategy),
+		NHardest: 1,
+	}.Groom(&jet)
+	if err != nil {
+		t.Fatalf("could not filter jet: %+v", err)
+	}
+
+	if got, want := len(filtered.Constituents()), 3; got != want {
+		t.Fatalf("invalid number of constituents: got=%d, want=%d", got, want)
+	}
+	if got, want := sumPt(filtered.Constituents()), 280.0; got != want {
+		t.Fatalf("invalid scalar sum of constituents pt: got=%v, want=%v", got, want)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contrib
+
+import (
+	"fmt"
+	"math"
+
+	"go-hep.org/x/hep/fastjet"
+)
+
+// AxesDefinition describes how the axes of N-subjettiness are found.
+type AxesDefinition int
+
+const (
+	KtAxes        AxesDefinition = iota // exclusive kt axes
+	CAAxes                              // exclusive Cambridge/Aachen axes
+	OnePassKtAxes                       // exclusive kt axes, refined by a minimization of N-subjettiness
+)
+
+func (axes AxesDefinition) String() string {
+	switch axes {
+	case KtAxes:
+		return "KT"
+	case CAAxes:
+		return "CA"
+	case OnePassKtAxes:
+		return "OnePass_KT"
+	default:
+		return fmt.Sprintf("AxesDefinition(%d)", int(axes))
+	}
+}
+
+// Nsubjettiness computes the N-subjettiness of a jet, as defined by Thaler
+// and Van Tilburg (arXiv:1011.2268):
+//
+//	tau_N = 1/d0 * sum_k pt_k * min(DeltaR_1k^Beta, ..., DeltaR_Nk^Beta)
+//
+// where the sum runs over the constituents of the jet, and DeltaR_ik is the
+// rapidity-phi distance between the constituent k and the axis i.
+//
+// If R0 is strictly positive, N-subjettiness is normalized with:
+//
+//	d0 = sum_k pt_k * R0^Beta
+//
+// and d0=1 otherwise.
+type Nsubjettiness struct {
+	N    int            // number of axes
+	Beta float64        // angular exponent
+	R0   float64        // characteristic jet radius, used for the normalization
+	Axes AxesDefinition // definition of the axes
+}
+
+// Result returns the N-subjettiness of the jet.
+func (ns Nsubjettiness) Result(jet *fastjet.Jet) (float64, error) {
+	parts := jet.Constituents()
+	if len(parts) <= ns.N {
+		return 0, nil
+	}
+
+	axes, err := ns.axes(jet, parts)
+	if err != nil {
+		return 0, err
+	}
+
+	var tau, d0 float64
+	for i := range parts {
+		p := &parts[i]
+		pt := p.Pt()
+		tau += pt * math.Pow(nearestAxis(p, axes), 0.5*ns.Beta)
+		d0 += pt
+	}
+	if ns.R0 > 0 {
+		tau /= d0 * math.Pow(ns.R0, ns.Beta)
+	}
+	return tau, nil
+}
+
+// axes returns the N axes of the jet, as (rapidity, phi) pairs.
+func (ns Nsubjettiness) axes(jet *fastjet.Jet, parts []fastjet.Jet) ([][2]float64, error) {
+	if ns.N <= 0 {
+		return nil, fmt.Errorf("contrib: invalid number of N-subjettiness axes (%d)", ns.N)
+	}
+
+	alg := fastjet.KtAlgorithm
+	switch ns.Axes {
+	case KtAxes, OnePassKtAxes:
+	case CAAxes:
+		alg = fastjet.CambridgeAlgorithm
+	default:
+		return nil, fmt.Errorf("contrib: invalid N-subjettiness axes definition (%v)", ns.Axes)
+	}
+
+	cs, err := fastjet.NewClusterSequence(
+		parts,
+		fastjet.NewJetDefinition(alg, maxAllowableR, fastjet.EScheme, fastjet.BestStrategy),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("contrib: could not recluster jet: %w", err)
+	}
+	subjets, err := cs.ExclusiveJetsUpTo(ns.N)
+	if err != nil {
+		return nil, fmt.Errorf("contrib: could not find N-subjettiness axes: %w", err)
+	}
+
+	axes := make([][2]float64, len(subjets))
+	for i := range subjets {
+		axes[i] = [2]float64{subjets[i].Rapidity(), subjets[i].Phi()}
+	}
+
+	if ns.Axes == OnePassKtAxes {
+		axes = ns.minimize(parts, axes)
+	}
+	return axes, nil
+}
+
+// minimize iteratively moves the axes to minimize N-subjettiness, assigning
+// each constituent to its nearest axis and moving each axis to the
+// (generalized) centroid of its constituents.
+func (ns Nsubjettiness) minimize(parts []fastjet.Jet, axes [][2]float64) [][2]float64 {
+	const (
+		maxIter = 100
+		eps     = 1e-6
+	)
+
+	type centroid struct {
+		w, rap, phi float64
+	}
+	sums := make([]centroid, len(axes))
+
+	for range maxIter {
+		clear(sums)
+		for i := range parts {
+			p := &parts[i]
+			ia, dr2 := nearestAxisIndex(p, axes)
+			// for Beta != 2, the centroid is weighted by DeltaR^(Beta-2).
+			w := p.Pt()
+			if ns.Beta != 2 && dr2 > 0 {
+				w *= math.Pow(dr2, 0.5*ns.Beta-1)
+			}
+			dphi := math.Remainder(p.Phi()-axes[ia][1], 2*math.Pi)
+			sums[ia].w += w
+			sums[ia].rap += w * (p.Rapidity() - axes[ia][0])
+			sums[ia].phi += w * dphi
+		}
+
+		moved := 0.0
+		for i := range axes {
+			if sums[i].w == 0 {
+				continue
+			}
+			drap := sums[i].rap / sums[i].w
+			dphi := sums[i].phi / sums[i].w
+			axes[i][0] += drap
+			axes[i][1] = math.Remainder(axes[i][1]+dphi, 2*math.Pi)
+			moved = math.Max(moved, drap*drap+dphi*dphi)
+		}
+		if moved < eps*eps {
+			break
+		}
+	}
+	return axes
+}
+
+// nearestAxis returns the squared distance of the jet to its nearest axis.
+func nearestAxis(jet *fastjet.Jet, axes [][2]float64) float64 {
+	_, dr2 := nearestAxisIndex(jet, axes)
+	return dr2
+}
+
+func nearestAxisIndex(jet *fastjet.Jet, axes [][2]float64) (int, float64) {
+	var (
+		idx = -1
+		min = math.Inf(+1)
+	)
+	for i, axis := range axes {
+		drap := jet.Rapidity() - axis[0]
+		dphi := math.Remainder(jet.Phi()-axis[1], 2*math.Pi)
+		if dr2 := drap*drap + dphi*dphi; dr2 < min {
+			idx, min = i, dr2
+		}
+	}
+	return idx, min
+}
+
+// NsubjettinessRatio computes the ratio tau_N/tau_(N-1) of N-subjettiness
+// values, with the same angular exponent, normalization and axes
+// definition.
+type NsubjettinessRatio struct {
+	N    int            // number of axes of the numerator
+	Beta float64        // angular exponent
+	R0   float64        // characteristic jet radius, used for the normalization
+	Axes AxesDefinition // definition of the axes
+}
+
+// Result returns the N-subjettiness ratio tau_N/tau_(N-1) of the jet.
+func (nsr NsubjettinessRatio) Result(jet *fastjet.Jet) (float64, error) {
+	num, err := Nsubjettiness{N: nsr.N, Beta: nsr.Beta, R0: nsr.R0, Axes: nsr.Axes}.Result(jet)
+	if err != nil {
+		return 0, err
+	}
+	den, err := Nsubjettiness{N: nsr.N - 1, Beta: nsr.Beta, R0: nsr.R0, Axes: nsr.Axes}.Result(jet)
+	if err != nil {
+		return 0, err
+	}
+	return num / den, nil
+}
@@ -0,0 +1,95 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contrib_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fastjet/contrib"
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func TestNsubjettiness(t *testing.T) {
+	var (
+		pair = clusterOne(t, []fastjet.Jet{
+			particle(100, 0, 0),
+			particle(50, 0.3, 0.4),
+		})
+		prongs = clusterOne(t, twoProngs())
+	)
+
+	for _, axes := range []contrib.AxesDefinition{contrib.KtAxes, contrib.CAAxes, contrib.OnePassKtAxes} {
+		t.Run(axes.String(), func(t *testing.T) {
+			// each constituent is its own axis.
+			tau2, err := contrib.Nsubjettiness{N: 2, Beta: 1, Axes: axes}.Result(&pair)
+			if err != nil {
+				t.Fatalf("could not compute tau2: %+v", err)
+			}
+			if tau2 != 0 {
+				t.Fatalf("invalid tau2: got=%v, want=0", tau2)
+			}
+
+			tau1, err := contrib.Nsubjettiness{N: 1, Beta: 2, R0: 1, Axes: axes}.Result(&pair)
+			if err != nil {
+				t.Fatalf("could not compute tau1: %+v", err)
+			}
+			// with beta=2, the optimal axis is the pt-weighted centroid.
+			want := 100 * 50 * (0.3*0.3 + 0.4*0.4) / (150 * 150)
+			if axes != contrib.OnePassKtAxes {
+				// exclusive axes are along the jet.
+				want = 0
+				for _, p := range pair.Constituents() {
+					want += p.Pt() * fastjet.Distance(&p, &pair) / 150
+				}
+			}
+			if !scalar.EqualWithinRel(tau1, want, 1e-6) {
+				t.Fatalf("invalid tau1: got=%v, want=%v", tau1, want)
+			}
+
+			if axes == contrib.CAAxes {
+				// exclusive C/A axes are sensitive to soft and wide-angle
+				// radiation.
+				return
+			}
+
+			tau21, err := contrib.NsubjettinessRatio{N: 2, Beta: 1, R0: 1, Axes: axes}.Result(&prongs)
+			if err != nil {
+				t.Fatalf("could not compute tau21: %+v", err)
+			}
+			if !(0 < tau21 && tau21 < 0.3) {
+				t.Fatalf("invalid tau21 for a 2-prong jet: %v", tau21)
+			}
+
+			tau32, err := contrib.NsubjettinessRatio{N: 3, Beta: 1, R0: 1, Axes: axes}.Result(&prongs)
+			if err != nil {
+				t.Fatalf("could not compute tau32: %+v", err)
+			}
+			if !(tau21 < tau32 && tau32 < 1) {
+				t.Fatalf("invalid tau32 for a 2-prong jet: tau32=%v, tau21=%v", tau32, tau21)
+			}
+		})
+	}
+
+	// the minimization can only improve on the seed axes.
+	for n := 1; n <= 3; n++ {
+		kt, err := contrib.Nsubjettiness{N: n, Beta: 2, Axes: contrib.KtAxes}.Result(&prongs)
+		if err != nil {
+			t.Fatalf("could not compute tau%d: %+v", n, err)
+		}
+		opt, err := contrib.Nsubjettiness{N: n, Beta: 2, Axes: contrib.OnePassKtAxes}.Result(&prongs)
+		if err != nil {
+			t.Fatalf("could not compute tau%d: %+v", n, err)
+		}
+		if opt > kt*(1+1e-12) {
+			t.Fatalf("tau%d: minimized axes worse than seed axes: %v > %v", n, opt, kt)
+		}
+	}
+
+	_, err := contrib.Nsubjettiness{N: 1, Beta: 1, Axes: contrib.AxesDefinition(42)}.Result(&prongs)
+	if err == nil {
+		t.Fatalf("expected an error for an invalid axes definition")
+	}
+}
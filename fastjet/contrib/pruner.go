@@ -0,0 +1,127 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contrib
+
+import (
+	"fmt"
+	"math"
+
+	"go-hep.org/x/hep/fastjet"
+)
+
+// Pruner reclusters the constituents of a jet, vetoing the recombinations
+// that are both soft and at wide angle:
+//
+//	z = min(pt_i, pt_j)/pt_(i+j) < ZCut  and  DeltaR_ij > Rcut
+//
+// with Rcut = RCutFactor * 2m/pt, m and pt being the mass and transverse
+// momentum of the original jet.
+// The softer jet of a vetoed recombination is discarded.
+type Pruner struct {
+	Def        fastjet.JetDefinition // definition used to recluster the jet, usually Cambridge/Aachen
+	ZCut       float64               // minimal momentum fraction of a recombination
+	RCutFactor float64               // factor applied to 2m/pt to obtain Rcut
+}
+
+// Groom returns the hardest pruned jet.
+func (pr Pruner) Groom(jet *fastjet.Jet) (fastjet.Jet, error) {
+	var (
+		parts = jet.Constituents()
+		rcut  = pr.RCutFactor * 2 * jet.M() / jet.Pt()
+		rec   = &pruningRecombiner{
+			rec:   pr.Def.Recombiner(),
+			zcut:  pr.ZCut,
+			rcut2: rcut * rcut,
+		}
+		def = fastjet.NewJetDefinitionRecombiner(
+			pr.Def.Algorithm(), pr.Def.R(), rec, pr.Def.Strategy(),
+		)
+		inputs = make([]fastjet.Jet, len(parts))
+	)
+
+	// tag each input with the list of the constituents it is made of,
+	// so pruned constituents can be tracked down.
+	for i, p := range parts {
+		inputs[i] = fastjet.NewJet(p.Px(), p.Py(), p.Pz(), p.E())
+		inputs[i].UserInfo = []int{i}
+	}
+
+	cs, err := fastjet.NewClusterSequence(inputs, def)
+	if err != nil {
+		return fastjet.Jet{}, fmt.Errorf("contrib: could not prune jet: %w", err)
+	}
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		return fastjet.Jet{}, fmt.Errorf("contrib: could not retrieve pruned jets: %w", err)
+	}
+	if len(jets) == 0 {
+		return zeroJet(), nil
+	}
+
+	hardest := &jets[0]
+	for i := range jets[1:] {
+		if jets[i+1].Pt2() > hardest.Pt2() {
+			hardest = &jets[i+1]
+		}
+	}
+
+	idx := hardest.UserInfo.([]int)
+	kept := make([]fastjet.Jet, len(idx))
+	for i, j := range idx {
+		kept[i] = parts[j]
+	}
+	return fastjet.Join(kept...), nil
+}
+
+// pruningRecombiner is a recombiner that vetoes soft and wide-angle
+// recombinations, keeping track of the constituents each jet is made of.
+type pruningRecombiner struct {
+	rec   fastjet.Recombiner
+	zcut  float64
+	rcut2 float64
+}
+
+func (rec *pruningRecombiner) Description() string {
+	return fmt.Sprintf(
+		"%s, with pruning (zcut=%v, Rcut=%v)",
+		rec.rec.Description(), rec.zcut, math.Sqrt(rec.rcut2),
+	)
+}
+
+func (rec *pruningRecombiner) Recombine(j1, j2 *fastjet.Jet) (fastjet.Jet, error) {
+	jet, err := rec.rec.Recombine(j1, j2)
+	if err != nil {
+		return jet, err
+	}
+
+	var (
+		c1 = j1.UserInfo.([]int)
+		c2 = j2.UserInfo.([]int)
+		z  = math.Min(j1.Pt(), j2.Pt()) / jet.Pt()
+	)
+	if z < rec.zcut && fastjet.Distance(j1, j2) > rec.rcut2 {
+		hard, cs := j1, c1
+		if j2.Pt2() > j1.Pt2() {
+			hard, cs = j2, c2
+		}
+		jet = fastjet.NewJet(hard.Px(), hard.Py(), hard.Pz(), hard.E())
+		jet.UserInfo = cs
+		return jet, nil
+	}
+
+	cs := make([]int, 0, len(c1)+len(c2))
+	cs = append(cs, c1...)
+	cs = append(cs, c2...)
+	jet.UserInfo = cs
+	return jet, nil
+}
+
+func (rec *pruningRecombiner) Preprocess(jet *fastjet.Jet) error {
+	return rec.rec.Preprocess(jet)
+}
+
+func (rec *pruningRecombiner) Scheme() fastjet.RecombinationScheme {
+	return fastjet.ExternalScheme
+}
@@ -0,0 +1,46 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contrib_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fastjet/contrib"
+)
+
+func TestPruner(t *testing.T) {
+	jet := clusterOne(t, twoProngs())
+
+	pruned, err := contrib.Pruner{
+		Def:        fastjet.NewJetDefinition(fastjet.CambridgeAlgorithm, 1.5, fastjet.EScheme, fastjet.BestStrategy),
+		ZCut:       0.1,
+		RCutFactor: 0.5,
+	}.Groom(&jet)
+	if err != nil {
+		t.Fatalf("could not prune jet: %+v", err)
+	}
+
+	if got, want := len(pruned.Constituents()), 5; got != want {
+		t.Fatalf("invalid number of constituents: got=%d, want=%d", got, want)
+	}
+	if got, want := sumPt(pruned.Constituents()), 470.0; got != want {
+		t.Fatalf("invalid scalar sum of constituents pt: got=%v, want=%v", got, want)
+	}
+
+	// without cut, nothing is pruned.
+	pruned, err = contrib.Pruner{
+		Def: fastjet.NewJetDefinition(fastjet.CambridgeAlgorithm, 1.5, fastjet.EScheme, fastjet.BestStrategy),
+	}.Groom(&jet)
+	if err != nil {
+		t.Fatalf("could not prune jet: %+v", err)
+	}
+	if got, want := len(pruned.Constituents()), len(jet.Constituents()); got != want {
+		t.Fatalf("invalid number of constituents: got=%d, want=%d", got, want)
+	}
+	if got, want := pruned.M(), jet.M(); got != want {
+		t.Fatalf("invalid pruned mass: got=%v, want=%v", got, want)
+	}
+}
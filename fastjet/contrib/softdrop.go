@@ -0,0 +1,107 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contrib
+
+import (
+	"math"
+
+	"go-hep.org/x/hep/fastjet"
+)
+
+// MassDropTagger implements the mass-drop tagger of Butterworth, Davison,
+// Rubin and Salam (arXiv:0802.2470).
+//
+// The jet is reclustered with the Cambridge/Aachen algorithm and
+// declustered, following the heavier branch, until a splitting j -> j1 j2
+// satisfies:
+//
+//	m_j1 < Mu * m_j  and  min(pt_j1^2, pt_j2^2) * DeltaR_12^2 / m_j^2 > YCut
+type MassDropTagger struct {
+	Mu   float64 // maximal mass fraction of the heavier subjet
+	YCut float64 // minimal asymmetry of the splitting
+}
+
+// Groom returns the combination of the two subjets of the first splitting
+// passing the mass-drop conditions.
+// Groom returns a jet with a null 4-momentum if the jet is not tagged.
+func (mdt MassDropTagger) Groom(jet *fastjet.Jet) (fastjet.Jet, error) {
+	cs, j, err := reclusterCA(jet)
+	if err != nil {
+		return fastjet.Jet{}, err
+	}
+
+	for {
+		j1, j2, ok := cs.Parents(&j)
+		if !ok {
+			return zeroJet(), nil
+		}
+		if j1.M() < j2.M() {
+			j1, j2 = j2, j1
+		}
+		var (
+			m2 = j.M() * j.M()
+			y  = math.Min(j1.Pt2(), j2.Pt2()) * fastjet.Distance(&j1, &j2) / m2
+		)
+		if j1.M() < mdt.Mu*j.M() && y > mdt.YCut {
+			return fastjet.Join(j1, j2), nil
+		}
+		j = j1
+	}
+}
+
+// SoftDrop implements the soft-drop groomer of Larkoski, Marzani, Soyez
+// and Thaler (arXiv:1402.2657).
+//
+// The jet is reclustered with the Cambridge/Aachen algorithm and
+// declustered, following the harder branch, until a splitting j -> j1 j2
+// satisfies:
+//
+//	min(pt_j1, pt_j2)/(pt_j1 + pt_j2) > ZCut * (DeltaR_12/R0)^Beta
+//
+// With Beta=0, soft drop is equivalent to the modified mass-drop tagger
+// (without mass-drop condition).
+type SoftDrop struct {
+	Beta float64 // angular exponent
+	ZCut float64 // symmetry cut
+	R0   float64 // characteristic jet radius (1 if zero)
+}
+
+// Groom returns the soft-dropped jet, as the combination of the two
+// subjets of the first splitting passing the soft-drop condition.
+//
+// If no splitting passes the soft-drop condition, Groom returns the
+// remaining single constituent for Beta >= 0 (grooming mode), and a jet
+// with a null 4-momentum for Beta < 0 (tagging mode).
+func (sd SoftDrop) Groom(jet *fastjet.Jet) (fastjet.Jet, error) {
+	cs, j, err := reclusterCA(jet)
+	if err != nil {
+		return fastjet.Jet{}, err
+	}
+
+	r0 := sd.R0
+	if r0 == 0 {
+		r0 = 1
+	}
+
+	for {
+		j1, j2, ok := cs.Parents(&j)
+		if !ok {
+			if sd.Beta < 0 {
+				return zeroJet(), nil
+			}
+			return fastjet.Join(j), nil
+		}
+		var (
+			pt1 = j1.Pt()
+			pt2 = j2.Pt()
+			z   = math.Min(pt1, pt2) / (pt1 + pt2)
+			dr  = math.Sqrt(fastjet.Distance(&j1, &j2))
+		)
+		if z > sd.ZCut*math.Pow(dr/r0, sd.Beta) {
+			return fastjet.Join(j1, j2), nil
+		}
+		j = j1
+	}
+}
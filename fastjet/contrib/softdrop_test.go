@@ -0,0 +1,107 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contrib_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fastjet/contrib"
+)
+
+func TestSoftDrop(t *testing.T) {
+	jet := clusterOne(t, twoProngs())
+
+	for _, tc := range []struct {
+		name   string
+		sd     contrib.SoftDrop
+		n      int
+		sumpt  float64
+		prongs int
+	}{
+		{
+			name:   "mmdt",
+			sd:     contrib.SoftDrop{Beta: 0, ZCut: 0.1},
+			n:      5,
+			sumpt:  470,
+			prongs: 2,
+		},
+		{
+			name:   "beta=2",
+			sd:     contrib.SoftDrop{Beta: 2, ZCut: 0.1, R0: 1},
+			n:      5,
+			sumpt:  470,
+			prongs: 2,
+		},
+		{
+			// no splitting is symmetric enough: only the hardest
+			// constituent remains.
+			name:   "zcut=0.45",
+			sd:     contrib.SoftDrop{Beta: 0, ZCut: 0.45},
+			n:      1,
+			sumpt:  200,
+			prongs: 1,
+		},
+		{
+			name: "no-cut",
+			sd:   contrib.SoftDrop{Beta: 0, ZCut: 0},
+			n:    7,
+			sumpt: func() float64 {
+				return sumPt(jet.Constituents())
+			}(),
+			prongs: 2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			groomed, err := tc.sd.Groom(&jet)
+			if err != nil {
+				t.Fatalf("could not groom jet: %+v", err)
+			}
+			if got, want := len(groomed.Constituents()), tc.n; got != want {
+				t.Fatalf("invalid number of constituents: got=%d, want=%d", got, want)
+			}
+			if got, want := sumPt(groomed.Constituents()), tc.sumpt; got != want {
+				t.Fatalf("invalid scalar sum of constituents pt: got=%v, want=%v", got, want)
+			}
+			if got, want := len(groomed.Pieces()), tc.prongs; got != want {
+				t.Fatalf("invalid number of prongs: got=%d, want=%d", got, want)
+			}
+		})
+	}
+
+	// tagging mode.
+	tagged, err := contrib.SoftDrop{Beta: -1, ZCut: 0.9}.Groom(&jet)
+	if err != nil {
+		t.Fatalf("could not groom jet: %+v", err)
+	}
+	if got := tagged.E(); got != 0 {
+		t.Fatalf("invalid tagged jet: got E=%v, want=0", got)
+	}
+}
+
+func TestMassDropTagger(t *testing.T) {
+	jet := clusterOne(t, twoProngs())
+
+	tagged, err := contrib.MassDropTagger{Mu: 0.67, YCut: 0.09}.Groom(&jet)
+	if err != nil {
+		t.Fatalf("could not tag jet: %+v", err)
+	}
+	if got, want := len(tagged.Constituents()), 5; got != want {
+		t.Fatalf("invalid number of constituents: got=%d, want=%d", got, want)
+	}
+
+	// an asymmetric splitting is not tagged.
+	prong := clusterOne(t, []fastjet.Jet{
+		particle(200, 0, 0),
+		particle(5, 0.05, 0.02),
+	})
+	tagged, err = contrib.MassDropTagger{Mu: 0.67, YCut: 0.09}.Groom(&prong)
+	if err != nil {
+		t.Fatalf("could not tag jet: %+v", err)
+	}
+	if got := tagged.E(); got != 0 {
+		t.Fatalf("invalid tagged jet: got E=%v, want=0", got)
+	}
+}
@@ -39,6 +39,29 @@ func NewJetDefinitionExtra(alg JetAlgorithm, r float64, scheme RecombinationSche
 	}
 }
 
+// NewJetDefinitionRecombiner returns a new JetDefinition using the provided
+// recombiner to merge jets.
+func NewJetDefinitionRecombiner(alg JetAlgorithm, r float64, rec Recombiner, strategy Strategy) JetDefinition {
+	return JetDefinition{
+		alg:        alg,
+		r:          r,
+		recombiner: rec,
+		strategy:   strategy,
+	}
+}
+
+// NewJetDefinitionPlugin returns a new JetDefinition clustering jets with
+// the provided plugin.
+func NewJetDefinitionPlugin(plugin Plugin) JetDefinition {
+	return JetDefinition{
+		alg:        PluginAlgorithm,
+		r:          plugin.R(),
+		recombiner: NewRecombiner(EScheme),
+		strategy:   PluginStrategy,
+		plugin:     plugin,
+	}
+}
+
 // Description returns a string description of the current JetDefinition
 // matching the one from C++ FastJet.
 func (def JetDefinition) Description() string {
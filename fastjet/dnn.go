@@ -0,0 +1,199 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+
+	"go-hep.org/x/hep/fastjet/internal/delaunay"
+)
+
+// dnnCylinder holds the dynamic nearest neighbours of jets on the
+// rapidity-phi cylinder.
+//
+// The cylinder is unrolled on the plane, phi being in [0, 2pi).
+// Jets with phi < mirror are also inserted at phi+2pi, so that all the
+// nearest neighbours closer than mirror are found across the phi=0 edge:
+//   - mirror=2pi is the 4pi-cylinder of C++ FastJet (every jet is mirrored),
+//   - mirror=pi is the 3pi-cylinder,
+//   - mirror=R only mirrors the jets that may have a neighbour, closer
+//     than R, across the phi=0 edge.
+//
+// Coincident jets share the same point of the triangulation, and are each
+// other's nearest neighbours, at a null distance.
+// Positions are rounded to multiples of dnnGrid, so that jets which only
+// coincide up to rounding errors (e.g. after a recombination) share a point
+// too: the triangulation does not support nearly coincident points.
+type dnnCylinder struct {
+	dt     *delaunay.Delaunay
+	mirror float64
+
+	pts    [][2]*delaunay.Point           // points of each jet in the plane (original and mirror)
+	owners map[*delaunay.Point][]int      // indices of the jets sharing a point
+	at     map[[2]float64]*delaunay.Point // point at a given position in the plane
+}
+
+// dnnGrid is the resolution of the positions of the jets in the plane.
+const dnnGrid = 0x1p-32
+
+func newDnnCylinder(jets []Jet, mirror float64, capacity int) *dnnCylinder {
+	dnn := &dnnCylinder{
+		mirror: mirror,
+		pts:    make([][2]*delaunay.Point, len(jets), capacity),
+		owners: make(map[*delaunay.Point][]int, 2*len(jets)),
+		at:     make(map[[2]float64]*delaunay.Point, 2*len(jets)),
+	}
+
+	pts := make([]*delaunay.Point, 0, 2*len(jets))
+	for i := range jets {
+		for _, p := range dnn.points(i, &jets[i]) {
+			if p == nil || len(dnn.owners[p]) > 1 {
+				continue
+			}
+			pts = append(pts, p)
+		}
+	}
+	dnn.dt = delaunay.WalkDelaunay(pts, rand.New(rand.NewSource(1)))
+
+	return dnn
+}
+
+// points associates the i-th jet with its points in the plane.
+// Points already at the same position are shared with the jets they belong to.
+func (dnn *dnnCylinder) points(i int, jet *Jet) [2]*delaunay.Point {
+	phi := jet.Phi()
+	if phi < 0 {
+		phi += 2 * math.Pi
+	}
+	if phi >= 2*math.Pi {
+		phi -= 2 * math.Pi
+	}
+	rap := jet.Rapidity()
+
+	rap = math.Round(rap/dnnGrid) * dnnGrid
+	phi = math.Round(phi/dnnGrid) * dnnGrid
+
+	pos := [][2]float64{{rap, phi}}
+	if phi < dnn.mirror {
+		pos = append(pos, [2]float64{rap, phi + 2*math.Pi})
+	}
+
+	var pts [2]*delaunay.Point
+	for k, xy := range pos {
+		p, ok := dnn.at[xy]
+		if !ok {
+			p = delaunay.NewPoint(xy[0], xy[1])
+			dnn.at[xy] = p
+		}
+		dnn.owners[p] = append(dnn.owners[p], i)
+		pts[k] = p
+	}
+
+	if i >= len(dnn.pts) {
+		dnn.pts = dnn.pts[:i+1]
+	}
+	dnn.pts[i] = pts
+	return pts
+}
+
+// insert inserts the i-th jet and returns the indices of the jets whose
+// nearest neighbour changed. The returned slice may contain duplicates.
+func (dnn *dnnCylinder) insert(i int, jet *Jet) []int {
+	var updated []*delaunay.Point
+	for _, p := range dnn.points(i, jet) {
+		switch {
+		case p == nil:
+			continue
+		case len(dnn.owners[p]) > 1:
+			// coincident jets: only their nearest neighbours changed.
+			updated = append(updated, p)
+		default:
+			updated = append(updated, dnn.dt.Insert(p)...)
+		}
+	}
+	return dnn.jets(updated)
+}
+
+// remove removes the i-th jet and returns the indices of the jets whose
+// nearest neighbour changed. The returned slice may contain duplicates.
+func (dnn *dnnCylinder) remove(i int) []int {
+	var updated []*delaunay.Point
+	for _, p := range dnn.pts[i] {
+		if p == nil {
+			continue
+		}
+		updated = append(updated, dnn.dt.Remove(p)...)
+
+		owners := slices.DeleteFunc(dnn.owners[p], func(j int) bool { return j == i })
+		delete(dnn.owners, p)
+		if len(owners) == 0 {
+			x, y := p.Coordinates()
+			delete(dnn.at, [2]float64{x, y})
+			continue
+		}
+
+		// the point is still used by coincident jets: re-insert it, so
+		// the jets whose nearest neighbour was the removed jet are updated.
+		x, y := p.Coordinates()
+		q := delaunay.NewPoint(x, y)
+		dnn.at[[2]float64{x, y}] = q
+		dnn.owners[q] = owners
+		for _, j := range owners {
+			for k := range dnn.pts[j] {
+				if dnn.pts[j][k] == p {
+					dnn.pts[j][k] = q
+				}
+			}
+		}
+		updated = append(updated, dnn.dt.Insert(q)...)
+		updated = append(updated, q)
+	}
+	dnn.pts[i] = [2]*delaunay.Point{}
+	return dnn.jets(updated)
+}
+
+// nearest returns the index of the nearest neighbour of the i-th jet and
+// the squared distance to that neighbour.
+// nearest returns beamJetIndex if the jet has no neighbour.
+func (dnn *dnnCylinder) nearest(i int) (int, float64) {
+	var (
+		jj = beamJetIndex
+		d2 = math.Inf(+1)
+	)
+	for _, p := range dnn.pts[i] {
+		if p == nil {
+			continue
+		}
+		for _, j := range dnn.owners[p] {
+			if j != i {
+				return j, 0
+			}
+		}
+		n, dist := p.NearestNeighbor()
+		if n == nil {
+			continue
+		}
+		owners := dnn.owners[n]
+		if len(owners) == 0 || owners[0] == i {
+			continue
+		}
+		if dist*dist < d2 {
+			jj = owners[0]
+			d2 = dist * dist
+		}
+	}
+	return jj, d2
+}
+
+// jets returns the indices of the jets owning the given points.
+func (dnn *dnnCylinder) jets(pts []*delaunay.Point) []int {
+	o := make([]int, 0, len(pts))
+	for _, p := range pts {
+		o = append(o, dnn.owners[p]...)
+	}
+	return o
+}
@@ -5,9 +5,11 @@
 package delaunay
 
 import (
+	"cmp"
 	"fmt"
 	"math"
 	"math/rand"
+	"slices"
 
 	"go-hep.org/x/hep/fastjet/internal/predicates"
 )
@@ -18,9 +20,13 @@ type Delaunay struct {
 	// list of triangles in the delaunay triangulation.
 	triangles triangles
 	// root is a triangle that contains all points. It is used as the starting point in the hierarchy
-	// to locate a point. The variable root's nil-ness also indicates which method to use to locate a point.
+	// to locate a point.
 	root *Triangle
 	n    int // n is the number of points inserted. It is used to assign the id to points.
+	// walk indicates whether points are located by walking through the triangulation
+	// rather than by going down the hierarchy.
+	walk bool
+	rnd  *rand.Rand // rnd is used to randomize the walk when locating points.
 }
 
 // HierarchicalDelaunay creates a Delaunay Triangulation using the delaunay hierarchy.
@@ -50,8 +56,104 @@ func HierarchicalDelaunay() *Delaunay {
 	}
 }
 
+// WalkDelaunay creates a Delaunay Triangulation of the given points, inserted along a Hilbert curve
+// so that consecutive points are close to each other and the walks locating them are short.
+//
+// The root triangle is computed from the bounding box of the points, so it can be used with points
+// of any magnitude. Points inserted after the creation of the triangulation need to be inside that
+// root triangle, i.e. roughly within 2^20 times the size of the bounding box of the initial points.
+//
+// To locate a point this algorithm walks through the current triangulation, starting from the most
+// recently created triangle, towards the triangle containing the point. The expected time complexity
+// for locating a point is O(sqrt(n)) but no hierarchy of triangles needs to be traversed, which makes
+// it efficient when points are often removed.
+//
+// Duplicate points are handled as in HierarchicalDelaunay.
 func WalkDelaunay(points []*Point, r *rand.Rand) *Delaunay {
-	panic(fmt.Errorf("delaunay: WalkDelaunay not implemented"))
+	if r == nil {
+		r = rand.New(rand.NewSource(1))
+	}
+
+	xmin, xmax := math.Inf(+1), math.Inf(-1)
+	ymin, ymax := math.Inf(+1), math.Inf(-1)
+	for _, p := range points {
+		xmin = math.Min(xmin, p.x)
+		xmax = math.Max(xmax, p.x)
+		ymin = math.Min(ymin, p.y)
+		ymax = math.Max(ymax, p.y)
+	}
+	if len(points) == 0 {
+		xmin, xmax, ymin, ymax = 0, 0, 0, 0
+	}
+
+	const scale = 1 << 20
+	var (
+		size = math.Max(math.Max(xmax-xmin, ymax-ymin), 1) * scale
+		cx   = 0.5 * (xmin + xmax)
+		cy   = 0.5 * (ymin + ymax)
+		a    = NewPoint(cx-size, cy-size)
+		b    = NewPoint(cx+size, cy-size)
+		c    = NewPoint(cx, cy+size)
+		root = NewTriangle(a, b, c)
+	)
+	root.isInTriangulation = true
+
+	d := &Delaunay{
+		triangles: triangles{root},
+		root:      root,
+		walk:      true,
+		rnd:       r,
+	}
+
+	for _, i := range hilbertOrder(points, xmin, ymin, xmax, ymax) {
+		d.Insert(points[i])
+	}
+	return d
+}
+
+// hilbertOrder returns the indices of the points sorted by their position along
+// a Hilbert curve covering the bounding box of the points.
+func hilbertOrder(points []*Point, xmin, ymin, xmax, ymax float64) []int {
+	const n = 1 << 16 // number of cells of the grid along each axis.
+	var (
+		sx   = (n - 1) / math.Max(xmax-xmin, math.SmallestNonzeroFloat64)
+		sy   = (n - 1) / math.Max(ymax-ymin, math.SmallestNonzeroFloat64)
+		keys = make([]uint64, len(points))
+		idx  = make([]int, len(points))
+	)
+	for i, p := range points {
+		keys[i] = hilbertIndex(n, uint32((p.x-xmin)*sx), uint32((p.y-ymin)*sy))
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(i, j int) int {
+		return cmp.Compare(keys[i], keys[j])
+	})
+	return idx
+}
+
+// hilbertIndex returns the distance along the Hilbert curve filling
+// a n x n grid of the cell (x,y). n must be a power of 2.
+func hilbertIndex(n, x, y uint32) uint64 {
+	var d uint64
+	for s := n / 2; s > 0; s /= 2 {
+		var rx, ry uint32
+		if x&s != 0 {
+			rx = 1
+		}
+		if y&s != 0 {
+			ry = 1
+		}
+		d += uint64(s) * uint64(s) * uint64((3*rx)^ry)
+		// rotate the quadrant.
+		if ry == 0 {
+			if rx == 1 {
+				x = n - 1 - x
+				y = n - 1 - y
+			}
+			x, y = y, x
+		}
+	}
+	return d
 }
 
 // Triangles returns the triangles that form the delaunay triangulation.
@@ -80,17 +182,27 @@ func (d *Delaunay) Insert(p *Point) (updatedNearestNeighbor []*Point) {
 		p.adjacentTriangles = make(triangles, 0)
 	}
 	p.adjacentTriangles = p.adjacentTriangles[:0]
-	t, l := d.locatePointHierarchy(p, d.root)
-	var updated points
+	var (
+		t *Triangle
+		l location
+	)
+	switch {
+	case d.walk:
+		t, l = d.locatePointWalk(p)
+	default:
+		t, l = d.locatePointHierarchy(p, d.root)
+	}
+	// an insertion typically updates a handful of points.
+	updated := make(points, 0, 16)
 	switch l {
 	case inside:
-		updated = d.insertInside(p, t)
+		updated = d.insertInside(p, t, updated)
 	case onEdge:
-		updated = d.insertOnEdge(p, t)
+		updated = d.insertOnEdge(p, t, updated)
 	default:
 		panic(fmt.Errorf("delaunay: no triangle containing point %v", p))
 	}
-	return updated.remove(d.root.A, d.root.B, d.root.C)
+	return updated.removeRoot(d.root)
 }
 
 // Remove removes the point from the triangulation. It returns the points
@@ -99,21 +211,81 @@ func (d *Delaunay) Insert(p *Point) (updatedNearestNeighbor []*Point) {
 func (d *Delaunay) Remove(p *Point) (updatedNearestNeighbor []*Point) {
 	if len(p.adjacentTriangles) < 3 {
 		if p.dist2 == 0 {
-			// must be a duplicate point, therefore don't panic
+			// must be a duplicate point, therefore don't panic.
+			// detach it from the point it duplicates.
+			q := p.nearest
+			p.nearest = nil
+			p.dist2 = math.Inf(1)
+			if q != nil && q.nearest == p {
+				q.findNearest()
+				updatedNearestNeighbor = append(updatedNearestNeighbor, q)
+			}
 			return updatedNearestNeighbor
 		}
 		panic(fmt.Errorf("delaunay: can't remove point %v, not enough adjacent triangles", p))
 	}
-	var updated points
+	// dup is a duplicate of p which is not part of the triangulation.
+	// It needs to replace p once p is removed.
+	var dup *Point
+	if q := p.nearest; p.dist2 == 0 && q != nil && q.nearest == p && len(q.adjacentTriangles) == 0 {
+		dup = q
+	}
+	updated := make(points, 0, 16)
 	pts := p.surroundingPoints()
 	ts := make([]*Triangle, len(p.adjacentTriangles))
 	copy(ts, p.adjacentTriangles)
 	for _, t := range ts {
-		updtemp := t.remove()
-		updated = append(updated, updtemp...)
+		updated = t.remove(updated)
+	}
+	if len(pts) <= maxEarPoints {
+		updated = d.retriangulateEars(pts, ts, updated)
+	} else {
+		updated = d.retriangulateAndSew(pts, ts, updated)
+	}
+	if dup != nil {
+		dup.nearest = nil
+		dup.dist2 = math.Inf(1)
+		updated = append(updated, d.Insert(dup)...)
+		updated = append(updated, dup)
+	}
+	return updated.removeRoot(d.root)
+}
+
+// addTriangles records newly created triangles.
+//
+// When points are located by walking through the triangulation, the
+// triangles which are no longer part of the triangulation are dropped
+// before the slice of triangles grows, so its size stays proportional
+// to the number of triangles in the triangulation.
+func (d *Delaunay) addTriangles(ts ...*Triangle) {
+	if d.walk && len(d.triangles)+len(ts) > cap(d.triangles) {
+		n := 0
+		for _, t := range d.triangles {
+			if t.isInTriangulation {
+				d.triangles[n] = t
+				n++
+			}
+		}
+		clear(d.triangles[n:])
+		d.triangles = d.triangles[:n]
+		if 2*(n+len(ts)) > cap(d.triangles) {
+			d.triangles = slices.Grow(d.triangles, n+len(ts))
+		}
+	}
+	d.triangles = append(d.triangles, ts...)
+}
+
+// addChildren records the triangles which replaced the parent triangles
+// in the hierarchy of triangles.
+// The hierarchy is only needed when points are not located by walking
+// through the triangulation.
+func (d *Delaunay) addChildren(parents []*Triangle, children ...*Triangle) {
+	if d.walk {
+		return
+	}
+	for _, t := range parents {
+		t.children = append(t.children, children...)
 	}
-	updtemp := d.retriangulateAndSew(pts, ts)
-	return append(updated, updtemp...).remove(d.root.A, d.root.B, d.root.C)
 }
 
 // locatePointHierarchy locates the point using the delaunay hierarchy.
@@ -140,35 +312,73 @@ func (d *Delaunay) locatePointHierarchy(p *Point, t *Triangle) (*Triangle, locat
 	panic(fmt.Errorf("delaunay: error locating Point %v in Triangle %v", p, t))
 }
 
-// insertInside inserts a point inside a triangle. It returns the points whose nearest
+// locatePointWalk locates the point by walking through the triangulation.
+//
+// The walk starts from the most recently created triangle that is still part of the
+// triangulation and crosses, at each step, an edge which separates the current triangle
+// from the point. The edges are tested in a random order to guarantee termination.
+// It returns the triangle that contains the point and the location
+// indicates whether it is on an edge or not.
+func (d *Delaunay) locatePointWalk(p *Point) (*Triangle, location) {
+	var t *Triangle
+	for i := len(d.triangles) - 1; i >= 0; i-- {
+		if d.triangles[i].isInTriangulation {
+			t = d.triangles[i]
+			break
+		}
+	}
+	if t == nil {
+		panic(fmt.Errorf("delaunay: no triangle in triangulation to locate point %v", p))
+	}
+	if p.inTriangle(d.root) == outside {
+		return nil, outside
+	}
+
+	var prev *Triangle
+walk:
+	for {
+		edges := [3][2]*Point{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}}
+		k := d.rnd.Intn(3)
+		for i := range edges {
+			e := edges[(i+k)%3]
+			o := predicates.Orientation(e[0].x, e[0].y, e[1].x, e[1].y, p.x, p.y)
+			if o != predicates.CW {
+				continue
+			}
+			nt := t.neighbor(e[0], e[1])
+			if nt == nil || nt == prev {
+				continue
+			}
+			prev, t = t, nt
+			continue walk
+		}
+		return t, p.inTriangle(t)
+	}
+}
+
+// insertInside inserts a point inside a triangle. It appends to updated the points whose nearest
 // neighbor changed during the process.
-func (d *Delaunay) insertInside(p *Point, t *Triangle) []*Point {
+func (d *Delaunay) insertInside(p *Point, t *Triangle, updated []*Point) []*Point {
 	// form three new triangles
 	t1 := NewTriangle(t.A, t.B, p)
 	t2 := NewTriangle(t.B, t.C, p)
 	t3 := NewTriangle(t.C, t.A, p)
-	updated := t1.add()
-	updtemp := t2.add()
-	updated = append(updated, updtemp...)
-	updtemp = t3.add()
-	updated = append(updated, updtemp...)
-	updtemp = t.remove()
-	updated = append(updated, updtemp...)
-	t.children = append(t.children, t1, t2, t3)
-	d.triangles = append(d.triangles, t1, t2, t3)
+	updated = t1.add(updated)
+	updated = t2.add(updated)
+	updated = t3.add(updated)
+	updated = t.remove(updated)
+	d.addChildren([]*Triangle{t}, t1, t2, t3)
+	d.addTriangles(t1, t2, t3)
 	// change the edges so it is a valid delaunay triangulation
-	updtemp = d.swapDelaunay(t1, p)
-	updated = append(updated, updtemp...)
-	updtemp = d.swapDelaunay(t2, p)
-	updated = append(updated, updtemp...)
-	updtemp = d.swapDelaunay(t3, p)
-	updated = append(updated, updtemp...)
+	updated = d.swapDelaunay(t1, p, updated)
+	updated = d.swapDelaunay(t2, p, updated)
+	updated = d.swapDelaunay(t3, p, updated)
 	return updated
 }
 
-// insertOnEdge inserts a point on an Edge between two triangles. It returns the points
-// whose nearest neighbor changed.
-func (d *Delaunay) insertOnEdge(p *Point, t *Triangle) []*Point {
+// insertOnEdge inserts a point on an Edge between two triangles. It appends to updated
+// the points whose nearest neighbor changed.
+func (d *Delaunay) insertOnEdge(p *Point, t *Triangle, updated []*Point) []*Point {
 	// Check if p is a duplicate
 	switch {
 	case p.Equals(t.A):
@@ -179,7 +389,7 @@ func (d *Delaunay) insertOnEdge(p *Point, t *Triangle) []*Point {
 		p.dist2 = 0
 		t.A.nearest = p
 		t.A.dist2 = 0
-		return []*Point{p, t.A}
+		return append(updated, p, t.A)
 	case p.Equals(t.B):
 		if p.id == t.B.id {
 			panic(fmt.Errorf("delaunay: Point %v was previously inserted", p))
@@ -188,7 +398,7 @@ func (d *Delaunay) insertOnEdge(p *Point, t *Triangle) []*Point {
 		p.dist2 = 0
 		t.B.nearest = p
 		t.B.dist2 = 0
-		return []*Point{p, t.B}
+		return append(updated, p, t.B)
 	case p.Equals(t.C):
 		if p.id == t.C.id {
 			panic(fmt.Errorf("delaunay: Point %v was previously inserted", p))
@@ -197,7 +407,7 @@ func (d *Delaunay) insertOnEdge(p *Point, t *Triangle) []*Point {
 		p.dist2 = 0
 		t.C.nearest = p
 		t.C.dist2 = 0
-		return []*Point{p, t.C}
+		return append(updated, p, t.C)
 	}
 	// To increase performance find the points in t, where p1 has the least adjacent triangles and
 	// p2 the second least.
@@ -292,29 +502,20 @@ func (d *Delaunay) insertOnEdge(p *Point, t *Triangle) []*Point {
 	nt2 := NewTriangle(pA1, p, pO2)
 	nt3 := NewTriangle(pA2, p, pO1)
 	nt4 := NewTriangle(pA2, p, pO2)
-	updated := nt1.add()
-	updtemp := nt2.add()
-	updated = append(updated, updtemp...)
-	updtemp = nt3.add()
-	updated = append(updated, updtemp...)
-	updtemp = nt4.add()
-	updated = append(updated, updtemp...)
-	updtemp = t.remove()
-	updated = append(updated, updtemp...)
-	updtemp = t2.remove()
-	updated = append(updated, updtemp...)
-	t.children = append(t.children, nt1, nt3)
-	t2.children = append(t2.children, nt2, nt4)
-	d.triangles = append(d.triangles, nt1, nt2, nt3, nt4)
+	updated = nt1.add(updated)
+	updated = nt2.add(updated)
+	updated = nt3.add(updated)
+	updated = nt4.add(updated)
+	updated = t.remove(updated)
+	updated = t2.remove(updated)
+	d.addChildren([]*Triangle{t}, nt1, nt3)
+	d.addChildren([]*Triangle{t2}, nt2, nt4)
+	d.addTriangles(nt1, nt2, nt3, nt4)
 	// change the edges so it is a valid delaunay triangulation
-	updtemp = d.swapDelaunay(nt1, p)
-	updated = append(updated, updtemp...)
-	updtemp = d.swapDelaunay(nt2, p)
-	updated = append(updated, updtemp...)
-	updtemp = d.swapDelaunay(nt3, p)
-	updated = append(updated, updtemp...)
-	updtemp = d.swapDelaunay(nt4, p)
-	updated = append(updated, updtemp...)
+	updated = d.swapDelaunay(nt1, p, updated)
+	updated = d.swapDelaunay(nt2, p, updated)
+	updated = d.swapDelaunay(nt3, p, updated)
+	updated = d.swapDelaunay(nt4, p, updated)
 	return updated
 }
 
@@ -323,9 +524,9 @@ func (d *Delaunay) insertOnEdge(p *Point, t *Triangle) []*Point {
 // that means that the triangle is not a valid delaunay triangle.
 // Therefore the edge in between the two triangles is swapped, creating
 // two new triangles that need to be checked.
-// It returns the points whose nearest neighbors changed during the
+// It appends to updated the points whose nearest neighbors changed during the
 // process.
-func (d *Delaunay) swapDelaunay(t *Triangle, p *Point) []*Point {
+func (d *Delaunay) swapDelaunay(t *Triangle, p *Point, updated []*Point) []*Point {
 	// find points in the triangle that are not p
 	var p2, p3 *Point
 	switch {
@@ -342,18 +543,7 @@ func (d *Delaunay) swapDelaunay(t *Triangle, p *Point) []*Point {
 		panic(fmt.Errorf("delaunay: can't find point %v in Triangle %v", p, t))
 	}
 	// find triangle opposite to p
-	var ta *Triangle
-loop:
-	for _, t1 := range p2.adjacentTriangles {
-		for _, t2 := range p3.adjacentTriangles {
-			if !t1.Equals(t) && t1.Equals(t2) {
-				ta = t1
-				break loop
-			}
-		}
-
-	}
-	var updated []*Point
+	ta := t.neighbor(p2, p3)
 	if ta == nil {
 		return updated
 	}
@@ -361,11 +551,9 @@ loop:
 	// swap edges if p is inside the circumcircle of ta
 	if pos == predicates.Inside {
 		var nt1, nt2 *Triangle
-		nt1, nt2, updated = d.swapEdge(t, ta)
-		updtemp := d.swapDelaunay(nt1, p)
-		updated = append(updated, updtemp...)
-		updtemp = d.swapDelaunay(nt2, p)
-		updated = append(updated, updtemp...)
+		nt1, nt2, updated = d.swapEdge(t, ta, updated)
+		updated = d.swapDelaunay(nt1, p, updated)
+		updated = d.swapDelaunay(nt2, p, updated)
 	}
 	return updated
 }
@@ -373,7 +561,8 @@ loop:
 // swapEdge swaps edge between two triangles.
 // The edge in the middle of the two triangles is removed and
 // an edge between the two opposite points is added.
-func (d *Delaunay) swapEdge(t1, t2 *Triangle) (nt1, nt2 *Triangle, updated []*Point) {
+// The points whose nearest neighbor changed are appended to updated.
+func (d *Delaunay) swapEdge(t1, t2 *Triangle, updated []*Point) (nt1, nt2 *Triangle, _ []*Point) {
 	// find points adjacent and opposite to edge
 	var adj1, adj2, opp1, opp2 *Point
 	switch {
@@ -405,23 +594,72 @@ func (d *Delaunay) swapEdge(t1, t2 *Triangle) (nt1, nt2 *Triangle, updated []*Po
 	// create two new triangles
 	nt1 = NewTriangle(adj1, adj2, opp1)
 	nt2 = NewTriangle(adj1, adj2, opp2)
-	updated = nt1.add()
-	updtemp := nt2.add()
-	updated = append(updated, updtemp...)
-	updtemp = t1.remove()
-	updated = append(updated, updtemp...)
-	updtemp = t2.remove()
-	updated = append(updated, updtemp...)
-	t1.children = append(t1.children, nt1, nt2)
-	t2.children = append(t2.children, nt1, nt2)
-	d.triangles = append(d.triangles, nt1, nt2)
+	updated = nt1.add(updated)
+	updated = nt2.add(updated)
+	updated = t1.remove(updated)
+	updated = t2.remove(updated)
+	d.addChildren([]*Triangle{t1, t2}, nt1, nt2)
+	d.addTriangles(nt1, nt2)
 	return nt1, nt2, updated
 }
 
+// maxEarPoints is the maximal number of points of the polygon left by the removal of a point
+// for which the polygon is re-triangulated by cutting off its ears.
+const maxEarPoints = 64
+
+// retriangulateEars finds the delaunay triangles inside the polygon formed by the CCW-ordered
+// points, left by the removal of a point, and appends to updated the points whose nearest
+// neighbor changed.
+//
+// It repeatedly cuts off an ear of the polygon whose circumcircle holds no other vertex of
+// the polygon: such a triangle is a delaunay triangle of the polygon.
+// If k = len(points) then it has a worst-time complexity of O(k^3), but does not allocate
+// an auxiliary triangulation as retriangulateAndSew does.
+func (d *Delaunay) retriangulateEars(points []*Point, parents []*Triangle, updated []*Point) []*Point {
+	var (
+		poly      = append(make([]*Point, 0, maxEarPoints), points...)
+		triangles = make([]*Triangle, 0, len(points)-2)
+	)
+	for len(poly) > 3 {
+		n := len(poly)
+		ear := -1
+	loop:
+		for i := range n {
+			a, b, c := poly[i], poly[(i+1)%n], poly[(i+2)%n]
+			if predicates.Orientation(a.x, a.y, b.x, b.y, c.x, c.y) != predicates.CCW {
+				continue
+			}
+			for j := 3; j < n; j++ {
+				q := poly[(i+j)%n]
+				if predicates.Incircle(a.x, a.y, b.x, b.y, c.x, c.y, q.x, q.y) == predicates.Inside {
+					continue loop
+				}
+			}
+			ear = i
+			break
+		}
+		if ear < 0 {
+			panic(fmt.Errorf("delaunay: no ear in polygon %v", poly))
+		}
+		tr := NewTriangle(poly[ear], poly[(ear+1)%n], poly[(ear+2)%n])
+		updated = tr.add(updated)
+		triangles = append(triangles, tr)
+		poly = slices.Delete(poly, (ear+1)%n, (ear+1)%n+1)
+	}
+	tr := NewTriangle(poly[0], poly[1], poly[2])
+	updated = tr.add(updated)
+	triangles = append(triangles, tr)
+
+	d.addTriangles(triangles...)
+	d.addChildren(parents, triangles...)
+	return updated
+}
+
 // retriangulateAndSew uses the re-triangulate and sew method to find the delaunay triangles
 // inside the polygon formed by the CCW-ordered points. If k = len(points) then it has a
 // worst-time complexity of O(k*log(k)).
-func (d *Delaunay) retriangulateAndSew(points []*Point, parents []*Triangle) (updated []*Point) {
+// The points whose nearest neighbor changed are appended to updated.
+func (d *Delaunay) retriangulateAndSew(points []*Point, parents []*Triangle, updated []*Point) []*Point {
 	nd := HierarchicalDelaunay()
 	// change limits to create a root triangle that's far outside of the original root triangle
 	nd.root.A.x = -1 << 35
@@ -450,15 +688,12 @@ func (d *Delaunay) retriangulateAndSew(points []*Point, parents []*Triangle) (up
 		// is counterclockwise
 		if areCounterclockwise(a, b, c) {
 			tr := NewTriangle(points[a], points[b], points[c])
-			updtemp := tr.add()
-			updated = append(updated, updtemp...)
+			updated = tr.add(updated)
 			triangles = append(triangles, tr)
 		}
 	}
-	d.triangles = append(d.triangles, triangles...)
-	for i := range parents {
-		parents[i].children = append(parents[i].children, triangles...)
-	}
+	d.addTriangles(triangles...)
+	d.addChildren(parents, triangles...)
 	return updated
 }
 
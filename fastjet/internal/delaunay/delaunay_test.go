@@ -5,6 +5,7 @@
 package delaunay
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
 	"slices"
@@ -541,6 +542,228 @@ func TestHierarchicalDelaunayRemovalMedium(t *testing.T) {
 	}
 }
 
+func TestWalkDelaunayMedium(t *testing.T) {
+	// NewPoint(x, y)
+	p1 := NewPoint(-1.5, 3.2)
+	p2 := NewPoint(1.8, 3.3)
+	p3 := NewPoint(-3.7, 1.5)
+	p4 := NewPoint(-1.5, 1.3)
+	p5 := NewPoint(0.8, 1.2)
+	p6 := NewPoint(3.3, 1.5)
+	p7 := NewPoint(-4, -1)
+	p8 := NewPoint(-2.3, -0.7)
+	p9 := NewPoint(0, -0.5)
+	p10 := NewPoint(2, -1.5)
+	p11 := NewPoint(3.7, -0.8)
+	p12 := NewPoint(-3.5, -2.9)
+	p13 := NewPoint(-0.9, -3.9)
+	p14 := NewPoint(2, -3.5)
+	p15 := NewPoint(3.5, -2.25)
+	// points to be removed later
+	pE1 := NewPoint(0, 0)
+	pE2 := NewPoint(-2.3, -0.6)
+	pE3 := NewPoint(2, 1.2)
+	ps := []*Point{p1, p2, p3, p4, p5, p6, pE3,
+		p9, p10, p11, p12, p13, p14}
+	d := WalkDelaunay(ps, rand.New(rand.NewSource(1234)))
+	d.Insert(pE1)
+	d.Remove(pE3)
+	d.Insert(p15)
+	d.Insert(pE2)
+	d.Remove(pE1)
+	d.Insert(p7)
+	d.Insert(p8)
+	d.Remove(pE2)
+	ts := d.Triangles()
+	exp := []*Triangle{
+		NewTriangle(p1, p3, p4),
+		NewTriangle(p1, p4, p5),
+		NewTriangle(p1, p5, p2),
+		NewTriangle(p2, p5, p6),
+		NewTriangle(p3, p4, p8),
+		NewTriangle(p3, p8, p7),
+		NewTriangle(p4, p8, p9),
+		NewTriangle(p4, p9, p5),
+		NewTriangle(p5, p9, p10),
+		NewTriangle(p5, p10, p6),
+		NewTriangle(p6, p10, p11),
+		NewTriangle(p7, p8, p12),
+		NewTriangle(p8, p12, p13),
+		NewTriangle(p8, p13, p9),
+		NewTriangle(p9, p13, p10),
+		NewTriangle(p10, p13, p14),
+		NewTriangle(p10, p14, p15),
+		NewTriangle(p10, p15, p11),
+	}
+	got, want := len(ts), len(exp)
+	if got != want {
+		t.Errorf("got=%d delaunay triangles, want=%d", got, want)
+	}
+	for i := range ts {
+		ok := false
+		for j := range exp {
+			if ts[i].Equals(exp[j]) {
+				ok = true
+				exp = slices.Delete(exp, j, j+1)
+				break
+			}
+		}
+		if !ok {
+			t.Errorf("Triangle T%s not as expected", ts[i])
+		}
+	}
+	pts := []*Point{p1, p2, p3, p4, p5, p6, p7, p8, p9, p10, p11, p12, p13, p14, p15}
+	expN := []*Point{p4, p5, p4, p1, p9, p11, p8, p7, p5, p15, p15, p7, p12, p15, p11}
+	for i, p := range pts {
+		n, _ := p.NearestNeighbor()
+		if !n.Equals(expN[i]) {
+			t.Errorf("got=N%s nearest neighbor, want=N%s", n, expN[i])
+		}
+	}
+}
+
+func TestWalkDelaunayRandom(t *testing.T) {
+	const n = 500
+	rnd := rand.New(rand.NewSource(42))
+	ps := make([]*Point, n)
+	for i := range ps {
+		ps[i] = NewPoint(rnd.Float64()*10, rnd.Float64()*2*math.Pi)
+	}
+
+	check := func(ps []*Point) {
+		t.Helper()
+		for i, p := range ps {
+			want := math.Inf(1)
+			for j, q := range ps {
+				if i == j {
+					continue
+				}
+				want = math.Min(want, math.Sqrt(p.distance(q)))
+			}
+			_, got := p.NearestNeighbor()
+			if math.Abs(got-want) > 1e-12 {
+				t.Fatalf("invalid nearest neighbor distance for P%s: got=%v, want=%v", p, got, want)
+			}
+		}
+	}
+
+	d := WalkDelaunay(ps, rnd)
+	check(ps)
+
+	// remove half of the points
+	for _, p := range ps[:n/2] {
+		d.Remove(p)
+	}
+	check(ps[n/2:])
+}
+
+func TestWalkDelaunayRemoveLargeCell(t *testing.T) {
+	// the center is surrounded by more points than can be retriangulated by ear clipping.
+	for _, n := range []int{5, maxEarPoints, 2 * maxEarPoints} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			center := NewPoint(0, 0)
+			ps := []*Point{center}
+			for i := range n {
+				phi := 2 * math.Pi * float64(i) / float64(n)
+				ps = append(ps, NewPoint(math.Cos(phi), math.Sin(phi)))
+			}
+			d := WalkDelaunay(ps, nil)
+			d.Remove(center)
+			if got, want := len(d.Triangles()), n-2; got != want {
+				t.Fatalf("invalid number of triangles: got=%d, want=%d", got, want)
+			}
+			want := 2 * math.Sin(math.Pi/float64(n))
+			for _, p := range ps[1:] {
+				_, got := p.NearestNeighbor()
+				if math.Abs(got-want) > 1e-12 {
+					t.Fatalf("invalid nearest neighbor distance for P%s: got=%v, want=%v", p, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHilbertIndex(t *testing.T) {
+	const n = 8
+	seen := make(map[uint64][2]uint32)
+	for x := range uint32(n) {
+		for y := range uint32(n) {
+			seen[hilbertIndex(n, x, y)] = [2]uint32{x, y}
+		}
+	}
+	if len(seen) != n*n {
+		t.Fatalf("invalid number of indices: got=%d, want=%d", len(seen), n*n)
+	}
+	// consecutive cells along the curve are neighbors on the grid.
+	for d := uint64(1); d < n*n; d++ {
+		p, q := seen[d-1], seen[d]
+		dx := int(p[0]) - int(q[0])
+		dy := int(p[1]) - int(q[1])
+		if dx*dx+dy*dy != 1 {
+			t.Fatalf("cells %d%v and %d%v are not adjacent", d-1, p, d, q)
+		}
+	}
+}
+
+func TestDelaunayRemoveDuplicates(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		new  func(ps []*Point) *Delaunay
+	}{
+		{
+			name: "hierarchical",
+			new: func(ps []*Point) *Delaunay {
+				d := HierarchicalDelaunay()
+				for _, p := range ps {
+					d.Insert(p)
+				}
+				return d
+			},
+		},
+		{
+			name: "walk",
+			new: func(ps []*Point) *Delaunay {
+				d := WalkDelaunay(ps[:4], rand.New(rand.NewSource(1)))
+				d.Insert(ps[4])
+				return d
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p1 := NewPoint(0, 0)
+			p2 := NewPoint(0, 2)
+			p3 := NewPoint(1, 0)
+			p4 := NewPoint(4, 4)
+			p5 := NewPoint(1, 0) // p5 is a duplicate of p3
+
+			d := tc.new([]*Point{p1, p2, p3, p4, p5})
+
+			// remove the triangulated point: its duplicate takes its place.
+			d.Remove(p3)
+			if n, dist := p5.NearestNeighbor(); !n.Equals(p1) || math.Abs(dist-1) > tol {
+				t.Fatalf("invalid nearest neighbor for P5: got=N%s (d=%v)", n, dist)
+			}
+			if n, _ := p1.NearestNeighbor(); !n.Equals(p5) {
+				t.Fatalf("invalid nearest neighbor for P1: got=N%s", n)
+			}
+			if got, want := len(d.Triangles()), 2; got != want {
+				t.Fatalf("invalid number of triangles: got=%d, want=%d", got, want)
+			}
+
+			// insert and remove a duplicate: its twin recovers its nearest neighbor.
+			p6 := NewPoint(0, 2)
+			d.Insert(p6)
+			if n, dist := p2.NearestNeighbor(); !n.Equals(p6) || dist != 0 {
+				t.Fatalf("invalid nearest neighbor for P2: got=N%s (d=%v)", n, dist)
+			}
+			d.Remove(p6)
+			if n, dist := p2.NearestNeighbor(); n != p1 || math.Abs(dist-2) > tol {
+				t.Fatalf("invalid nearest neighbor for P2: got=N%s (d=%v)", n, dist)
+			}
+		})
+	}
+}
+
 func benchmarkHierarchicalDelaunayRemoval(i int, b *testing.B) {
 	ps := make([]*Point, i)
 	for j := range i {
@@ -323,6 +323,13 @@ func (ps points) remove(pts ...*Point) points {
 	return out
 }
 
+// removeRoot removes the points of the root triangle from the receiver, in place.
+func (ps points) removeRoot(root *Triangle) points {
+	return slices.DeleteFunc(ps, func(p *Point) bool {
+		return p.Equals(root.A) || p.Equals(root.B) || p.Equals(root.C)
+	})
+}
+
 // polyArea finds the area of an irregular polygon.
 // The points need to be in clockwise order.
 func (points points) polyArea() float64 {
@@ -42,8 +42,8 @@ func NewTriangle(a, b, c *Point) *Triangle {
 
 // add is used when the triangle t is added to the delaunay triangulation.
 // It updates the information of the points in t.
-// It returns all points whose nearest neighbor was updated.
-func (t *Triangle) add() []*Point {
+// It appends to updated all points whose nearest neighbor was updated.
+func (t *Triangle) add(updated []*Point) []*Point {
 	t.isInTriangulation = true
 	// update the adjacent lists
 	t.A.adjacentTriangles = append(t.A.adjacentTriangles, t)
@@ -52,7 +52,6 @@ func (t *Triangle) add() []*Point {
 	// update nearest neighbor if one of the points is closer than current nearest.
 	// First find the nearest neighbor in t.
 	// Then check whether local min distance is smaller than current min distance.
-	updated := make([]*Point, 0, 3)
 	distAB := t.A.distance(t.B)
 	distBC := t.B.distance(t.C)
 	distCA := t.C.distance(t.A)
@@ -99,14 +98,13 @@ func (t *Triangle) add() []*Point {
 
 // remove is used when the triangle t is removed from the delaunay triangulation.
 // It updates the information of the points in t.
-// It returns all points whose nearest neighbor was updated.
-func (t *Triangle) remove() []*Point {
+// It appends to updated all points whose nearest neighbor was updated.
+func (t *Triangle) remove(updated []*Point) []*Point {
 	t.isInTriangulation = false
-	t.A.adjacentTriangles = t.A.adjacentTriangles.remove(t)
-	t.B.adjacentTriangles = t.B.adjacentTriangles.remove(t)
-	t.C.adjacentTriangles = t.C.adjacentTriangles.remove(t)
+	t.A.adjacentTriangles = t.A.adjacentTriangles.detach(t)
+	t.B.adjacentTriangles = t.B.adjacentTriangles.detach(t)
+	t.C.adjacentTriangles = t.C.adjacentTriangles.detach(t)
 	// update the nearest neighbor if the nearest neighbor is in t.
-	updated := make([]*Point, 0, 3)
 	if t.A.nearest.Equals(t.B) || t.A.nearest.Equals(t.C) {
 		t.A.findNearest()
 		updated = append(updated, t.A)
@@ -122,6 +120,20 @@ func (t *Triangle) remove() []*Point {
 	return updated
 }
 
+// neighbor returns the triangle of the triangulation that shares the edge formed
+// by the points a and b with t, or nil if there is none.
+func (t *Triangle) neighbor(a, b *Point) *Triangle {
+	for _, ta := range a.adjacentTriangles {
+		if ta == t {
+			continue
+		}
+		if ta.A == b || ta.B == b || ta.C == b {
+			return ta
+		}
+	}
+	return nil
+}
+
 // circumcenter returns the x,y coordinates of the circumcenter of the triangle
 func (t *Triangle) circumcenter() (x, y float64) {
 	// TODO: there are few divisions by the same value, store the inverse of these delta values and use that instead
@@ -198,6 +210,20 @@ func (ts triangles) remove(triangles ...*Triangle) triangles {
 	return ts
 }
 
+// detach removes the triangle t, compared by identity, from the receiver.
+// The order of the remaining triangles is not preserved.
+func (ts triangles) detach(t *Triangle) triangles {
+	for i, ti := range ts {
+		if ti != t {
+			continue
+		}
+		n := len(ts) - 1
+		ts[i], ts[n] = ts[n], nil
+		return ts[:n]
+	}
+	return ts
+}
+
 // keepCurrentTriangles returns all triangles that are in the current triangulation.
 func (ts triangles) keepCurrentTriangles() triangles {
 	triangles := make(triangles, 0, len(ts))
@@ -61,11 +61,11 @@ func TestTriangleAdd(t *testing.T) {
 		NewPoint(0.5, -6),
 	}
 	t1 := NewTriangle(points[0], points[1], points[2])
-	t1.add()
+	t1.add(nil)
 	t2 := NewTriangle(points[1], points[2], points[3])
-	t2.add()
+	t2.add(nil)
 	t3 := NewTriangle(points[0], points[2], points[4])
-	t3.add()
+	t3.add(nil)
 	want := []*Point{
 		points[2], points[2], points[0], points[1], points[0],
 	}
@@ -88,10 +88,10 @@ func TestTriangleRemove(t *testing.T) {
 		NewPoint(1, 10),
 	}
 	t1 := NewTriangle(points[0], points[1], points[2])
-	t1.add()
+	t1.add(nil)
 	t2 := NewTriangle(points[0], points[1], points[3])
-	t2.add()
-	t1.remove()
+	t2.add(nil)
+	t1.remove(nil)
 	want := []*Point{
 		points[1], points[0], nil, points[1],
 	}
@@ -17,6 +17,10 @@ const (
 	// Go's float64 type has a 52-bit fractional mantissa,
 	// therefore the value 2^-52
 	macheps = 1.0 / (1 << 52)
+
+	// epsilon is the largest power of two such that 1+epsilon rounds to 1,
+	// i.e. half a unit in the last place of 1.
+	epsilon = 1.0 / (1 << 53)
 )
 
 // float64Pred dynamically updates the potential error.
@@ -6,6 +6,7 @@ package predicates
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 
 	"gonum.org/v1/gonum/mat"
@@ -43,13 +44,56 @@ func (p RelativePosition) String() string {
 // by the three points (x1,y1),(x2,y2) and (x3,y3). The three points have to be ordered counterclockwise or
 // Outside and Inside will be reversed.
 func Incircle(x1, y1, x2, y2, x3, y3, x, y float64) RelativePosition {
-	pos := simpleIncircle(x1, y1, x2, y2, x3, y3, x, y)
+	pos := fastIncircle(x1, y1, x2, y2, x3, y3, x, y)
+	if pos != IndeterminatePosition {
+		return pos
+	}
+	pos = simpleIncircle(x1, y1, x2, y2, x3, y3, x, y)
 	if pos == IndeterminatePosition {
 		pos = matIncircle(x1, y1, x2, y2, x3, y3, x, y)
 	}
 	return pos
 }
 
+// iccErrBound is the relative error bound of the determinant computed by fastIncircle.
+// See J. R. Shewchuk, "Adaptive Precision Floating-Point Arithmetic and Fast Robust
+// Geometric Predicates", Discrete & Computational Geometry 18:305-363, 1997.
+const iccErrBound = (10 + 96*epsilon) * epsilon
+
+// fastIncircle determines the relative position with a few floating point operations,
+// translating the points so that (x,y) is at the origin.
+// The sign of the determinant is only trusted when its magnitude is larger than
+// a static bound of the rounding errors, otherwise IndeterminatePosition is returned.
+func fastIncircle(x1, y1, x2, y2, x3, y3, x, y float64) RelativePosition {
+	var (
+		adx, ady = x1 - x, y1 - y
+		bdx, bdy = x2 - x, y2 - y
+		cdx, cdy = x3 - x, y3 - y
+
+		bdxcdy, cdxbdy = bdx * cdy, cdx * bdy
+		cdxady, adxcdy = cdx * ady, adx * cdy
+		adxbdy, bdxady = adx * bdy, bdx * ady
+
+		alift = adx*adx + ady*ady
+		blift = bdx*bdx + bdy*bdy
+		clift = cdx*cdx + cdy*cdy
+
+		det = alift*(bdxcdy-cdxbdy) + blift*(cdxady-adxcdy) + clift*(adxbdy-bdxady)
+
+		permanent = (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift +
+			(math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+			(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+		bound = iccErrBound * permanent
+	)
+	switch {
+	case det > bound:
+		return Inside
+	case -det > bound:
+		return Outside
+	}
+	return IndeterminatePosition
+}
+
 // simpleIncircle determines the relative position using the simple float64 type.
 // Its accuracy can't be guaranteed, therefore close decisions
 // return IndeterminatePosition which signals Incircle that further
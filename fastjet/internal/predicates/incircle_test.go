@@ -5,6 +5,7 @@
 package predicates
 
 import (
+	"math/rand"
 	"testing"
 )
 
@@ -33,6 +34,28 @@ func TestIncircle(t *testing.T) {
 	}
 }
 
+func TestFastVsRobustIncircle(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1234))
+	for i := range 10000 {
+		// points on a coarse grid, so that cocircular points are frequent.
+		v := make([]float64, 8)
+		for j := range v {
+			v[j] = float64(rnd.Intn(8)) * 0.25
+			if i%2 == 1 {
+				v[j] += rnd.Float64() * 1e-12
+			}
+		}
+		got := fastIncircle(v[0], v[1], v[2], v[3], v[4], v[5], v[6], v[7])
+		if got == IndeterminatePosition {
+			continue
+		}
+		want := robustIncircle(setBig(v[0]), setBig(v[1]), setBig(v[2]), setBig(v[3]), setBig(v[4]), setBig(v[5]), setBig(v[6]), setBig(v[7]))
+		if got != want {
+			t.Fatalf("fastIncircle%v = %v, want = %v", v, got, want)
+		}
+	}
+}
+
 func BenchmarkSimpleIncircle(b *testing.B) {
 	tests := []struct {
 		x1, y1, x2, y2, x3, y3, x, y float64
@@ -6,6 +6,7 @@ package predicates
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 
 	"gonum.org/v1/gonum/mat"
@@ -41,7 +42,11 @@ func (o OrientationKind) String() string {
 // Orientation returns how the point (x,y) is oriented with respect to
 // the line defined by the points (x1,y1) and (x2,y2).
 func Orientation(x1, y1, x2, y2, x, y float64) OrientationKind {
-	o := simpleOrientation(x1, y1, x2, y2, x, y)
+	o := fastOrientation(x1, y1, x2, y2, x, y)
+	if o != IndeterminateOrientation {
+		return o
+	}
+	o = simpleOrientation(x1, y1, x2, y2, x, y)
 	if o == IndeterminateOrientation {
 		// too close to 0 to give a definite answer.
 		// Therefore check with more expansive tests.
@@ -50,6 +55,30 @@ func Orientation(x1, y1, x2, y2, x, y float64) OrientationKind {
 	return o
 }
 
+// ccwErrBound is the relative error bound of the determinant computed by fastOrientation.
+// See J. R. Shewchuk, "Adaptive Precision Floating-Point Arithmetic and Fast Robust
+// Geometric Predicates", Discrete & Computational Geometry 18:305-363, 1997.
+const ccwErrBound = (3 + 16*epsilon) * epsilon
+
+// fastOrientation finds the orientation with a few floating point operations.
+// The sign of the determinant is only trusted when its magnitude is larger than
+// a static bound of the rounding errors, otherwise IndeterminateOrientation is returned.
+func fastOrientation(x1, y1, x2, y2, x, y float64) OrientationKind {
+	var (
+		left  = (x1 - x) * (y2 - y)
+		right = (y1 - y) * (x2 - x)
+		det   = left - right
+		bound = ccwErrBound * (math.Abs(left) + math.Abs(right))
+	)
+	switch {
+	case det > bound:
+		return CCW
+	case -det > bound:
+		return CW
+	}
+	return IndeterminateOrientation
+}
+
 // simpleOrientation finds the orientation using the simple float64 type.
 // Its accuracy can't be guaranteed, therefore close decisions
 // return IndeterminateOrientation which signals Orientation that further
@@ -5,6 +5,7 @@
 package predicates
 
 import (
+	"math/rand"
 	"testing"
 )
 
@@ -61,6 +62,28 @@ func TestSimpleVsRobustOrientation(t *testing.T) {
 	}
 }
 
+func TestFastVsRobustOrientation(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1234))
+	for i := range 10000 {
+		// points on a coarse grid, so that colinear points are frequent.
+		v := make([]float64, 6)
+		for j := range v {
+			v[j] = float64(rnd.Intn(8)) * 0.25
+			if i%2 == 1 {
+				v[j] += rnd.Float64() * 1e-12
+			}
+		}
+		got := fastOrientation(v[0], v[1], v[2], v[3], v[4], v[5])
+		if got == IndeterminateOrientation {
+			continue
+		}
+		want := robustOrientation(setBig(v[0]), setBig(v[1]), setBig(v[2]), setBig(v[3]), setBig(v[4]), setBig(v[5]))
+		if got != want {
+			t.Fatalf("fastOrientation%v = %v, want = %v", v, got, want)
+		}
+	}
+}
+
 func BenchmarkSimpleOrientation(b *testing.B) {
 	tests := []struct {
 		x1, y1, x2, y2, x, y float64
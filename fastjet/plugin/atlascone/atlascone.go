@@ -0,0 +1,107 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package atlascone implements the ATLAS iterative cone jet algorithm, with
+// split-merge, as a fastjet plugin.
+//
+// Importing this package registers the plugin, with a cone radius of 0.7,
+// an overlap threshold of 0.5 and a seed threshold of 2 GeV, under the name
+// "atlascone":
+//
+//	import _ "go-hep.org/x/hep/fastjet/plugin/atlascone"
+//
+// The ATLAS cone algorithm is not infrared safe: it is provided to
+// reproduce published results. New analyses should use SISCone or anti-kt.
+package atlascone // import "go-hep.org/x/hep/fastjet/plugin/atlascone"
+
+import (
+	"fmt"
+	"sort"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fastjet/plugin/internal/cones"
+)
+
+// Plugin is the ATLAS iterative cone jet algorithm.
+//
+// Stable cones are searched for by iterating cones starting from seeds,
+// the particles harder than SeedThreshold.
+// Stable cones are then turned into jets with a split-merge procedure,
+// protojets being ordered by transverse momentum.
+type Plugin struct {
+	r float64
+
+	Overlap       float64 // overlap threshold of the split-merge procedure
+	SeedThreshold float64 // minimal transverse momentum of the seeds
+	MaxIterations int     // maximal number of iterations of a cone
+}
+
+// New returns an ATLAS cone plugin with cone radius r and overlap
+// threshold f.
+func New(r, f float64) Plugin {
+	return Plugin{
+		r:             r,
+		Overlap:       f,
+		SeedThreshold: 2,
+		MaxIterations: 100,
+	}
+}
+
+func init() {
+	fastjet.Register("atlascone", New(0.7, 0.5))
+}
+
+// R returns the radius of the cones.
+func (p Plugin) R() float64 { return p.r }
+
+// Description returns a string description of the plugin.
+func (p Plugin) Description() string {
+	return fmt.Sprintf(
+		"ATLASCone plugin with R = %v, seed threshold = %v, overlap threshold f = %v",
+		p.r, p.SeedThreshold, p.Overlap,
+	)
+}
+
+// RunClustering finds the jets of the event and records them into the
+// clustering sequence.
+func (p Plugin) RunClustering(b fastjet.Builder) error {
+	switch {
+	case p.r <= 0:
+		return fmt.Errorf("atlascone: invalid cone radius (%v)", p.r)
+	case p.Overlap <= 0 || p.Overlap >= 1:
+		return fmt.Errorf("atlascone: invalid overlap threshold (%v)", p.Overlap)
+	case p.MaxIterations <= 0:
+		return fmt.Errorf("atlascone: invalid maximal number of iterations (%d)", p.MaxIterations)
+	}
+
+	rec, ok := b.(fastjet.Recorder)
+	if !ok {
+		return fmt.Errorf("atlascone: builder %T can not record recombinations", b)
+	}
+
+	var (
+		evt    = cones.NewEvent(rec)
+		r2     = p.r * p.r
+		seeds  = make([]int, 0, evt.Len())
+		stable []cones.Protojet
+	)
+	for i := range evt.Len() {
+		if evt.Pt(i) > p.SeedThreshold {
+			seeds = append(seeds, i)
+		}
+	}
+	sort.SliceStable(seeds, func(i, j int) bool {
+		return evt.Pt(seeds[i]) > evt.Pt(seeds[j])
+	})
+
+	for _, i := range seeds {
+		pj, ok := evt.Iterate(evt.Rap(i), evt.Phi(i), r2, p.MaxIterations)
+		if ok {
+			stable = append(stable, pj)
+		}
+	}
+
+	jets := evt.SplitMerge(stable, p.Overlap, cones.ScalePt, 0)
+	return cones.Record(rec, jets)
+}
@@ -0,0 +1,137 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cdfcones implements the CDF MidPoint cone jet algorithm as a
+// fastjet plugin.
+//
+// Importing this package registers the plugin, with a cone radius of 0.7,
+// an overlap threshold of 0.5 and a seed threshold of 1 GeV, under the name
+// "cdfmidpoint":
+//
+//	import _ "go-hep.org/x/hep/fastjet/plugin/cdfcones"
+//
+// The MidPoint algorithm is not infrared safe: it is provided to reproduce
+// published results. New analyses should use SISCone or anti-kt.
+package cdfcones // import "go-hep.org/x/hep/fastjet/plugin/cdfcones"
+
+import (
+	"fmt"
+	"sort"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fastjet/plugin/internal/cones"
+)
+
+// MidPoint is the CDF MidPoint cone jet algorithm.
+//
+// Stable cones are searched for by iterating cones starting from seeds
+// (the particles harder than SeedThreshold), and then from the midpoints of
+// all the pairs of stable cones closer than 2R.
+// Stable cones are then turned into jets with a split-merge procedure,
+// protojets being ordered by transverse momentum.
+type MidPoint struct {
+	r float64
+
+	Overlap       float64 // overlap threshold of the split-merge procedure
+	SeedThreshold float64 // minimal transverse momentum of the seeds
+	MaxIterations int     // maximal number of iterations of a cone
+}
+
+// NewMidPoint returns a MidPoint plugin with cone radius r and overlap
+// threshold f.
+func NewMidPoint(r, f float64) MidPoint {
+	return MidPoint{
+		r:             r,
+		Overlap:       f,
+		SeedThreshold: 1,
+		MaxIterations: 100,
+	}
+}
+
+func init() {
+	fastjet.Register("cdfmidpoint", NewMidPoint(0.7, 0.5))
+}
+
+// R returns the radius of the cones.
+func (p MidPoint) R() float64 { return p.r }
+
+// Description returns a string description of the plugin.
+func (p MidPoint) Description() string {
+	return fmt.Sprintf(
+		"CDF MidPoint jet algorithm, with cone_radius = %v, overlap_threshold = %v, seed_threshold = %v, max_iterations = %d",
+		p.r, p.Overlap, p.SeedThreshold, p.MaxIterations,
+	)
+}
+
+// RunClustering finds the jets of the event and records them into the
+// clustering sequence.
+func (p MidPoint) RunClustering(b fastjet.Builder) error {
+	switch {
+	case p.r <= 0:
+		return fmt.Errorf("cdfcones: invalid cone radius (%v)", p.r)
+	case p.Overlap <= 0 || p.Overlap >= 1:
+		return fmt.Errorf("cdfcones: invalid overlap threshold (%v)", p.Overlap)
+	case p.MaxIterations <= 0:
+		return fmt.Errorf("cdfcones: invalid maximal number of iterations (%d)", p.MaxIterations)
+	}
+
+	rec, ok := b.(fastjet.Recorder)
+	if !ok {
+		return fmt.Errorf("cdfcones: builder %T can not record recombinations", b)
+	}
+
+	var (
+		evt    = cones.NewEvent(rec)
+		r2     = p.r * p.r
+		seeds  = make([]int, 0, evt.Len())
+		stable []cones.Protojet
+		seen   = make(cones.Set)
+	)
+	add := func(pj cones.Protojet) {
+		if !seen.Add(pj.Parts) {
+			return
+		}
+		stable = append(stable, pj)
+	}
+
+	for i := range evt.Len() {
+		if evt.Pt(i) > p.SeedThreshold {
+			seeds = append(seeds, i)
+		}
+	}
+	sort.SliceStable(seeds, func(i, j int) bool {
+		return evt.Pt(seeds[i]) > evt.Pt(seeds[j])
+	})
+
+	for _, i := range seeds {
+		pj, ok := evt.Iterate(evt.Rap(i), evt.Phi(i), r2, p.MaxIterations)
+		if ok {
+			add(pj)
+		}
+	}
+
+	n := len(stable)
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			c1 := &stable[i]
+			c2 := &stable[j]
+			if cones.Dist2(c1.Rap, c1.Phi, c2.Rap, c2.Phi) >= 4*r2 {
+				continue
+			}
+			mid := fastjet.NewJet(
+				c1.P.Px()+c2.P.Px(),
+				c1.P.Py()+c2.P.Py(),
+				c1.P.Pz()+c2.P.Pz(),
+				c1.P.E()+c2.P.E(),
+			)
+			pj, ok := evt.Iterate(mid.Rapidity(), mid.Phi(), r2, p.MaxIterations)
+			if ok {
+				add(pj)
+			}
+		}
+	}
+
+	jets := evt.SplitMerge(stable, p.Overlap, cones.ScalePt, 0)
+	return cones.Record(rec, jets)
+}
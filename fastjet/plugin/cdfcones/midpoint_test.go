@@ -0,0 +1,89 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cdfcones_test
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fastjet/plugin/cdfcones"
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func TestRegistry(t *testing.T) {
+	plugin, err := fastjet.GetPlugin("cdfmidpoint")
+	if err != nil {
+		t.Fatalf("could not retrieve plugin: %+v", err)
+	}
+	if got, want := plugin.R(), 0.7; got != want {
+		t.Fatalf("invalid radius: got=%v, want=%v", got, want)
+	}
+}
+
+func TestMidPoint(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		particles []fastjet.Jet
+		want      []float64
+	}{
+		{
+			name: "separated",
+			particles: []fastjet.Jet{
+				particle(50, -2, 0), particle(40, -2.1, 0.1),
+				particle(30, +1, 2), particle(20, +1.1, 2.1),
+			},
+			want: []float64{90, 50},
+		},
+		{
+			// both hard particles are too far apart to be found by a cone
+			// centered on either of them, but the cone centered on their
+			// midpoint contains both of them.
+			name: "midpoint",
+			particles: []fastjet.Jet{
+				particle(50, 0, 0),
+				particle(50, 1.2, 0),
+			},
+			want: []float64{100},
+		},
+		{
+			// soft particles below the seed threshold are still clustered.
+			name: "soft",
+			particles: []fastjet.Jet{
+				particle(50, 0, 0),
+				particle(0.5, 0.3, 0.3),
+			},
+			want: []float64{50.5},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cs, err := fastjet.NewClusterSequence(test.particles, fastjet.NewJetDefinitionPlugin(
+				cdfcones.NewMidPoint(0.7, 0.5),
+			))
+			if err != nil {
+				t.Fatalf("could not cluster: %+v", err)
+			}
+			jets, err := cs.InclusiveJets(0)
+			if err != nil {
+				t.Fatalf("could not retrieve jets: %+v", err)
+			}
+			sort.Sort(fastjet.ByPt(jets))
+
+			if got, want := len(jets), len(test.want); got != want {
+				t.Fatalf("invalid number of jets: got=%d, want=%d", got, want)
+			}
+			for i := range jets {
+				if got, want := jets[i].Pt(), test.want[i]; !scalar.EqualWithinRel(got, want, 5e-2) {
+					t.Fatalf("jet #%d: invalid pt: got=%v, want=%v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func particle(pt, y, phi float64) fastjet.Jet {
+	return fastjet.NewJet(pt*math.Cos(phi), pt*math.Sin(phi), pt*math.Sinh(y), pt*math.Cosh(y))
+}
@@ -0,0 +1,367 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cones holds the building blocks shared by the cone jet algorithm
+// plugins: cone contents, stable cones iterations, split-merge procedure
+// and recording of the jets into a clustering sequence.
+package cones // import "go-hep.org/x/hep/fastjet/plugin/internal/cones"
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"slices"
+	"sort"
+
+	"go-hep.org/x/hep/fastjet"
+)
+
+// Scale is the variable used to order protojets during the split-merge
+// procedure.
+type Scale int
+
+const (
+	ScalePt      Scale = iota // transverse momentum of the protojet 4-momentum
+	ScalePtTilde              // scalar sum of the transverse momenta of the protojet constituents
+	ScaleEt                   // transverse energy of the protojet 4-momentum
+)
+
+func (s Scale) String() string {
+	switch s {
+	case ScalePt:
+		return "pt"
+	case ScalePtTilde:
+		return "pttilde"
+	case ScaleEt:
+		return "Et"
+	default:
+		return fmt.Sprintf("Scale(%d)", int(s))
+	}
+}
+
+// Event holds the input particles of a cone clustering.
+type Event struct {
+	Parts []fastjet.Jet
+	raps  []float64
+	phis  []float64
+	pts   []float64
+}
+
+// NewEvent returns a new event from the input particles of the recorder.
+func NewEvent(b fastjet.Recorder) *Event {
+	jets := b.Jets()
+	evt := &Event{
+		Parts: jets[:len(jets):len(jets)],
+		raps:  make([]float64, len(jets)),
+		phis:  make([]float64, len(jets)),
+		pts:   make([]float64, len(jets)),
+	}
+	for i := range jets {
+		p := &jets[i]
+		evt.raps[i] = p.Rapidity()
+		evt.phis[i] = p.Phi()
+		evt.pts[i] = p.Pt()
+	}
+	return evt
+}
+
+// Len returns the number of particles of the event.
+func (evt *Event) Len() int { return len(evt.Parts) }
+
+// Rap returns the rapidity of the i-th particle.
+func (evt *Event) Rap(i int) float64 { return evt.raps[i] }
+
+// Phi returns the azimuth of the i-th particle.
+func (evt *Event) Phi(i int) float64 { return evt.phis[i] }
+
+// Pt returns the transverse momentum of the i-th particle.
+func (evt *Event) Pt(i int) float64 { return evt.pts[i] }
+
+// Dist2 returns the squared distance on the rapidity-phi cylinder between
+// the i-th particle and the (rap, phi) point.
+func (evt *Event) Dist2(i int, rap, phi float64) float64 {
+	return Dist2(evt.raps[i], evt.phis[i], rap, phi)
+}
+
+// Dist2 returns the squared distance on the rapidity-phi cylinder between
+// two points.
+func Dist2(rap1, phi1, rap2, phi2 float64) float64 {
+	drap := rap1 - rap2
+	dphi := math.Remainder(phi1-phi2, 2*math.Pi)
+	return drap*drap + dphi*dphi
+}
+
+// Within returns the indices of the particles, among the candidates, that
+// are strictly within the circle of squared radius r2 centered on
+// (rap, phi).
+// If cands is nil, all the particles of the event are considered.
+func (evt *Event) Within(dst, cands []int, rap, phi, r2 float64) []int {
+	dst = dst[:0]
+	if cands == nil {
+		for i := range evt.Parts {
+			if evt.Dist2(i, rap, phi) < r2 {
+				dst = append(dst, i)
+			}
+		}
+		return dst
+	}
+	for _, i := range cands {
+		if evt.Dist2(i, rap, phi) < r2 {
+			dst = append(dst, i)
+		}
+	}
+	return dst
+}
+
+// Protojet is a candidate jet, made of a set of particles.
+type Protojet struct {
+	Parts []int // sorted indices of the particles of the protojet
+
+	P       fastjet.Jet // 4-momentum of the protojet
+	Rap     float64     // rapidity of the protojet axis
+	Phi     float64     // azimuth of the protojet axis
+	PtTilde float64     // scalar sum of the transverse momenta of the particles
+}
+
+// Protojet returns the protojet made of the provided particles.
+func (evt *Event) Protojet(parts []int) Protojet {
+	parts = slices.Clone(parts)
+	slices.Sort(parts)
+
+	var (
+		px, py, pz, e float64
+		pttilde       float64
+	)
+	for _, i := range parts {
+		p := &evt.Parts[i]
+		px += p.Px()
+		py += p.Py()
+		pz += p.Pz()
+		e += p.E()
+		pttilde += evt.pts[i]
+	}
+	p := fastjet.NewJet(px, py, pz, e)
+	return Protojet{
+		Parts:   parts,
+		P:       p,
+		Rap:     p.Rapidity(),
+		Phi:     p.Phi(),
+		PtTilde: pttilde,
+	}
+}
+
+// Scale returns the value of the split-merge variable for the protojet.
+func (pj *Protojet) Scale(s Scale) float64 {
+	switch s {
+	case ScalePt:
+		return pj.P.Pt()
+	case ScalePtTilde:
+		return pj.PtTilde
+	case ScaleEt:
+		return pj.P.Et()
+	default:
+		panic(fmt.Errorf("cones: invalid split-merge scale %v", s))
+	}
+}
+
+// Key returns a hash of the content of a cone, from the sorted indices of
+// its particles.
+func Key(parts []int) uint64 {
+	var (
+		h   = fnv.New64a()
+		buf [8]byte
+	)
+	for _, i := range parts {
+		v := uint64(i)
+		for j := range buf {
+			buf[j] = byte(v >> (8 * j))
+		}
+		_, _ = h.Write(buf[:])
+	}
+	return h.Sum64()
+}
+
+// Set is a set of cone contents.
+//
+// Contents are bucketed by their Key, and compared index by index when
+// their keys collide.
+type Set map[uint64][][]int
+
+// Add adds the sorted indices of the particles of a cone to the set, and
+// reports whether they were not already in the set.
+func (set Set) Add(parts []int) bool {
+	key := Key(parts)
+	for _, v := range set[key] {
+		if slices.Equal(v, parts) {
+			return false
+		}
+	}
+	set[key] = append(set[key], slices.Clone(parts))
+	return true
+}
+
+// Iterate iterates a cone of squared radius r2, starting at (rap, phi),
+// moving the cone axis to the axis of the 4-momentum of its content,
+// until its content is stable.
+// Iterate returns false if the cone is empty or does not converge after
+// maxIter iterations.
+func (evt *Event) Iterate(rap, phi, r2 float64, maxIter int) (Protojet, bool) {
+	var (
+		parts []int
+		prev  []int
+	)
+	for it := range maxIter {
+		parts = evt.Within(parts, nil, rap, phi, r2)
+		if len(parts) == 0 {
+			return Protojet{}, false
+		}
+		pj := evt.Protojet(parts)
+		if it > 0 && slices.Equal(pj.Parts, prev) {
+			return pj, true
+		}
+		prev = pj.Parts
+		rap, phi = pj.Rap, pj.Phi
+	}
+	return Protojet{}, false
+}
+
+// SplitMerge runs the split-merge procedure on the provided protojets and
+// returns the final jets.
+//
+// At each step, the hardest protojet (according to the scale variable) is
+// compared with the next hardest protojet it shares particles with.
+// If the scale of the shared particles exceeds the fraction f of the scale
+// of the softer protojet, both protojets are merged.
+// Otherwise, the shared particles are split between both protojets, each
+// particle going to the protojet with the nearest axis.
+// A protojet without overlap with any other protojet becomes a jet.
+// Protojets softer than stop are discarded.
+func (evt *Event) SplitMerge(protos []Protojet, f float64, scale Scale, stop float64) []Protojet {
+	var (
+		cands = make([]Protojet, 0, len(protos))
+		seen  = make(Set, len(protos))
+		jets  []Protojet
+	)
+	for _, pj := range protos {
+		if len(pj.Parts) == 0 || !seen.Add(pj.Parts) {
+			continue
+		}
+		cands = append(cands, pj)
+	}
+
+	for len(cands) > 0 {
+		sort.SliceStable(cands, func(i, j int) bool {
+			return cands[i].Scale(scale) > cands[j].Scale(scale)
+		})
+		j1 := &cands[0]
+		if j1.Scale(scale) < stop {
+			break
+		}
+
+		ij2 := -1
+		var shared []int
+		for i := 1; i < len(cands); i++ {
+			shared = intersect(shared[:0], j1.Parts, cands[i].Parts)
+			if len(shared) > 0 {
+				ij2 = i
+				break
+			}
+		}
+
+		if ij2 < 0 {
+			jets = append(jets, *j1)
+			cands = cands[1:]
+			continue
+		}
+
+		j2 := &cands[ij2]
+		overlap := evt.Protojet(shared)
+		if overlap.Scale(scale) > f*j2.Scale(scale) {
+			*j1 = evt.Protojet(union(j1.Parts, j2.Parts))
+			cands = slices.Delete(cands, ij2, ij2+1)
+			continue
+		}
+
+		var p1, p2 []int
+		for _, i := range j1.Parts {
+			if !slices.Contains(shared, i) || evt.Dist2(i, j1.Rap, j1.Phi) <= evt.Dist2(i, j2.Rap, j2.Phi) {
+				p1 = append(p1, i)
+			}
+		}
+		for _, i := range j2.Parts {
+			if !slices.Contains(shared, i) || evt.Dist2(i, j2.Rap, j2.Phi) < evt.Dist2(i, j1.Rap, j1.Phi) {
+				p2 = append(p2, i)
+			}
+		}
+		*j1 = evt.Protojet(p1)
+		*j2 = evt.Protojet(p2)
+		cands = slices.DeleteFunc(cands, func(pj Protojet) bool { return len(pj.Parts) == 0 })
+	}
+
+	return jets
+}
+
+// intersect appends to dst the elements shared by the sorted slices a and b.
+func intersect(dst, a, b []int) []int {
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			dst = append(dst, a[i])
+			i++
+			j++
+		}
+	}
+	return dst
+}
+
+// union returns the union of the sorted slices a and b.
+func union(a, b []int) []int {
+	o := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			o = append(o, a[i])
+			i++
+		case a[i] > b[j]:
+			o = append(o, b[j])
+			j++
+		default:
+			o = append(o, a[i])
+			i++
+			j++
+		}
+	}
+	o = append(o, a[i:]...)
+	o = append(o, b[j:]...)
+	return o
+}
+
+// Record records the jets into the clustering sequence of the recorder.
+//
+// The particles of each jet are recombined one at a time, with a null
+// distance, and the resulting jet is recombined with the beam, with a
+// distance equal to its squared transverse momentum.
+// Particles that do not belong to any jet are not recombined.
+func Record(b fastjet.Recorder, jets []Protojet) error {
+	for _, jet := range jets {
+		k := jet.Parts[0]
+		for _, i := range jet.Parts[1:] {
+			var err error
+			k, err = b.RecordIJRecombination(k, i, 0)
+			if err != nil {
+				return fmt.Errorf("cones: could not record jet: %w", err)
+			}
+		}
+		err := b.RecordIBRecombination(k, b.Jets()[k].Pt2())
+		if err != nil {
+			return fmt.Errorf("cones: could not record jet: %w", err)
+		}
+	}
+	return nil
+}
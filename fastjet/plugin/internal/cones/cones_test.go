@@ -0,0 +1,40 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cones
+
+import (
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	set := make(Set)
+	for _, tc := range []struct {
+		parts []int
+		want  bool
+	}{
+		{[]int{1, 2, 3}, true},
+		{[]int{1, 2, 3}, false},
+		{[]int{1, 2}, true},
+		{[]int{1, 2, 3, 4}, true},
+		{[]int{1, 2}, false},
+	} {
+		if got, want := set.Add(tc.parts), tc.want; got != want {
+			t.Fatalf("invalid add(%v): got=%v, want=%v", tc.parts, got, want)
+		}
+	}
+
+	// cones whose keys collide are still distinct.
+	var (
+		a = []int{5, 6}
+		b = []int{7, 8}
+	)
+	set[Key(a)] = append(set[Key(a)], b)
+	if !set.Add(a) {
+		t.Fatalf("cone %v shadowed by cone %v with the same key", a, b)
+	}
+	if set.Add(a) {
+		t.Fatalf("cone %v added twice", a)
+	}
+}
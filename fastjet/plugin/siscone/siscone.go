@@ -0,0 +1,207 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package siscone implements the SISCone (Seedless Infrared-Safe Cone) jet
+// algorithm as a fastjet plugin.
+//
+// Importing this package registers the plugin, with a cone radius of 0.7
+// and an overlap threshold of 0.75, under the name "siscone":
+//
+//	import _ "go-hep.org/x/hep/fastjet/plugin/siscone"
+//
+// See G.P. Salam and G. Soyez, JHEP 0705:086 (2007), arXiv:0704.0292.
+package siscone // import "go-hep.org/x/hep/fastjet/plugin/siscone"
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fastjet/plugin/internal/cones"
+)
+
+// SplitMergeScale is the variable used to order protojets during the
+// split-merge procedure.
+type SplitMergeScale = cones.Scale
+
+const (
+	ScalePt      = cones.ScalePt      // transverse momentum of the protojet 4-momentum
+	ScalePtTilde = cones.ScalePtTilde // scalar sum of the transverse momenta of the protojet constituents
+	ScaleEt      = cones.ScaleEt      // transverse energy of the protojet 4-momentum
+)
+
+// Plugin is the SISCone jet algorithm.
+//
+// All the stable cones of the event are found without seeds, by
+// considering, for every pair of particles, the circles of radius R passing
+// through both particles.
+// When NPassMax differs from one, the search is repeated on the particles
+// that do not belong to any stable cone found during the previous passes.
+// Stable cones are then turned into jets with a split-merge procedure.
+type Plugin struct {
+	r float64
+
+	Overlap       float64         // overlap threshold of the split-merge procedure
+	NPassMax      int             // maximal number of passes of the stable cones search (0: no limit)
+	ProtojetPtMin float64         // minimal transverse momentum of the stable cones entering the split-merge
+	Scale         SplitMergeScale // variable used to order protojets in the split-merge
+	StoppingScale float64         // protojets with a smaller scale are discarded by the split-merge
+}
+
+// New returns a SISCone plugin with cone radius r and overlap threshold f.
+func New(r, f float64) Plugin {
+	return Plugin{
+		r:       r,
+		Overlap: f,
+		Scale:   ScalePtTilde,
+	}
+}
+
+func init() {
+	fastjet.Register("siscone", New(0.7, 0.75))
+}
+
+// R returns the radius of the cones.
+func (p Plugin) R() float64 { return p.r }
+
+// Description returns a string description of the plugin.
+func (p Plugin) Description() string {
+	return fmt.Sprintf(
+		"SISCone jet algorithm with cone_radius = %v, overlap_threshold = %v, n_pass_max = %d, protojet_ptmin = %v, %v as split_merge_scale",
+		p.r, p.Overlap, p.NPassMax, p.ProtojetPtMin, p.Scale,
+	)
+}
+
+// RunClustering finds the jets of the event and records them into the
+// clustering sequence.
+func (p Plugin) RunClustering(b fastjet.Builder) error {
+	switch {
+	case p.r <= 0:
+		return fmt.Errorf("siscone: invalid cone radius (%v)", p.r)
+	case p.Overlap <= 0 || p.Overlap >= 1:
+		return fmt.Errorf("siscone: invalid overlap threshold (%v)", p.Overlap)
+	}
+
+	rec, ok := b.(fastjet.Recorder)
+	if !ok {
+		return fmt.Errorf("siscone: builder %T can not record recombinations", b)
+	}
+
+	var (
+		evt    = cones.NewEvent(rec)
+		active = make([]int, 0, evt.Len())
+		protos []cones.Protojet
+	)
+	for i := range evt.Len() {
+		if evt.Pt(i) > 0 {
+			active = append(active, i)
+		}
+	}
+
+	for pass := 0; len(active) > 0 && (p.NPassMax <= 0 || pass < p.NPassMax); pass++ {
+		stables := p.stableCones(evt, active)
+		if len(stables) == 0 {
+			break
+		}
+		used := make(map[int]struct{})
+		for _, pj := range stables {
+			for _, i := range pj.Parts {
+				used[i] = struct{}{}
+			}
+			if pj.P.Pt() >= p.ProtojetPtMin {
+				protos = append(protos, pj)
+			}
+		}
+		active = slices.DeleteFunc(active, func(i int) bool {
+			_, ok := used[i]
+			return ok
+		})
+	}
+
+	jets := evt.SplitMerge(protos, p.Overlap, p.Scale, p.StoppingScale)
+	return cones.Record(rec, jets)
+}
+
+// stableCones returns all the stable cones made of the active particles.
+//
+// Any enclosure of a set of particles by a circle of radius R can be moved
+// until two of the particles lie on its edge.
+// All the distinct cone contents are thus obtained by considering, for each
+// pair of particles closer than 2R, the two circles going through both
+// particles, with each edge particle either included or not.
+// A cone content is stable when the circle centered on the axis of its
+// 4-momentum encloses exactly the same particles.
+func (p Plugin) stableCones(evt *cones.Event, active []int) []cones.Protojet {
+	var (
+		r2     = p.r * p.r
+		seen   = make(cones.Set)
+		stable []cones.Protojet
+		buf    []int
+	)
+
+	check := func(parts []int) {
+		if len(parts) == 0 {
+			return
+		}
+		if !seen.Add(parts) {
+			return
+		}
+		pj := evt.Protojet(parts)
+		buf = evt.Within(buf, active, pj.Rap, pj.Phi, r2)
+		if slices.Equal(buf, pj.Parts) {
+			stable = append(stable, pj)
+		}
+	}
+
+	var (
+		nbrs []int
+		base []int
+		comb = make([]int, 0, len(active))
+	)
+	for _, i := range active {
+		check([]int{i})
+
+		nbrs = evt.Within(nbrs, active, evt.Rap(i), evt.Phi(i), 4*r2)
+		for _, j := range nbrs {
+			if j <= i {
+				continue
+			}
+			var (
+				drap = evt.Rap(j) - evt.Rap(i)
+				dphi = math.Remainder(evt.Phi(j)-evt.Phi(i), 2*math.Pi)
+				d2   = drap*drap + dphi*dphi
+			)
+			if d2 == 0 {
+				continue
+			}
+			var (
+				h     = math.Sqrt((r2 - 0.25*d2) / d2)
+				mrap  = evt.Rap(i) + 0.5*drap
+				mphi  = evt.Phi(i) + 0.5*dphi
+				sides = [2]float64{-1, +1}
+			)
+			for _, s := range sides {
+				crap := mrap - s*h*dphi
+				cphi := mphi + s*h*drap
+				base = base[:0]
+				for _, k := range nbrs {
+					if k == i || k == j {
+						continue
+					}
+					if evt.Dist2(k, crap, cphi) < r2 {
+						base = append(base, k)
+					}
+				}
+				for _, edge := range [][]int{nil, {i}, {j}, {i, j}} {
+					comb = append(comb[:0], base...)
+					comb = append(comb, edge...)
+					slices.Sort(comb)
+					check(comb)
+				}
+			}
+		}
+	}
+	return stable
+}
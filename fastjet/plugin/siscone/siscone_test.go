@@ -0,0 +1,215 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package siscone_test
+
+import (
+	"bufio"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fastjet/plugin/siscone"
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func TestRegistry(t *testing.T) {
+	plugin, err := fastjet.GetPlugin("siscone")
+	if err != nil {
+		t.Fatalf("could not retrieve plugin: %+v", err)
+	}
+	if got, want := plugin.R(), 0.7; got != want {
+		t.Fatalf("invalid radius: got=%v, want=%v", got, want)
+	}
+
+	def := fastjet.NewJetDefinitionPlugin(plugin)
+	if got, want := def.Description(), plugin.Description(); got != want {
+		t.Fatalf("invalid description:\ngot= %q\nwant=%q", got, want)
+	}
+}
+
+type builder struct{}
+
+func (builder) InclusiveJets(ptmin float64) ([]fastjet.Jet, error)   { return nil, nil }
+func (builder) Constituents(jet *fastjet.Jet) ([]fastjet.Jet, error) { return nil, nil }
+
+func TestBuilder(t *testing.T) {
+	// a builder that can not record recombinations is not supported.
+	err := siscone.New(0.7, 0.75).RunClustering(builder{})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestSeparatedClusters(t *testing.T) {
+	var (
+		particles []fastjet.Jet
+		want      = []float64{90, 60, 30}
+	)
+	for i, pt := range want {
+		y := -2 + 2*float64(i)
+		phi := 2 * float64(i)
+		for _, dy := range []float64{-0.1, 0, +0.1} {
+			particles = append(particles, particle(pt/3, y+dy, phi-dy))
+		}
+	}
+
+	jets := cluster(t, siscone.New(0.5, 0.75), particles, 0)
+	if got, want := len(jets), len(want); got != want {
+		t.Fatalf("invalid number of jets: got=%d, want=%d", got, want)
+	}
+	for i := range jets {
+		jet := &jets[i]
+		if got, want := len(jet.Constituents()), 3; got != want {
+			t.Fatalf("jet #%d: invalid number of constituents: got=%d, want=%d", i, got, want)
+		}
+		if got, want := jet.Pt(), want[i]; !scalar.EqualWithinRel(got, want, 1e-2) {
+			t.Fatalf("jet #%d: invalid pt: got=%v, want=%v", i, got, want)
+		}
+	}
+}
+
+func TestSplitMerge(t *testing.T) {
+	const r = 0.7
+	for _, test := range []struct {
+		name string
+		sep  float64
+		want int
+	}{
+		// both particles always share the same cone.
+		{name: "merged", sep: 0.9, want: 1},
+		// no cone may contain both particles.
+		{name: "separated", sep: 1.5, want: 2},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			particles := []fastjet.Jet{
+				particle(100, 0, 0),
+				particle(80, test.sep, 0),
+			}
+			jets := cluster(t, siscone.New(r, 0.75), particles, 0)
+			if got, want := len(jets), test.want; got != want {
+				t.Fatalf("invalid number of jets: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestInfraredSafety(t *testing.T) {
+	particles, err := loadParticles("../../testdata/single-pp-event.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const ptmin = 5
+	plugin := siscone.New(0.7, 0.75)
+	want := cluster(t, plugin, particles, ptmin)
+
+	// add infinitely soft particles all over the event.
+	soft := append([]fastjet.Jet(nil), particles...)
+	for i := range 20 {
+		y := -4 + 0.4*float64(i)
+		phi := 0.9 * float64(i)
+		soft = append(soft, particle(1e-8, y, phi))
+	}
+	got := cluster(t, plugin, soft, ptmin)
+
+	if len(got) != len(want) {
+		t.Fatalf("invalid number of jets: got=%d, want=%d", len(got), len(want))
+	}
+	for i := range got {
+		if !scalar.EqualWithinAbs(got[i].Pt(), want[i].Pt(), 1e-6) ||
+			!scalar.EqualWithinAbs(got[i].Rapidity(), want[i].Rapidity(), 1e-6) {
+			t.Fatalf("jet #%d: soft particles changed the jet:\ngot= %v\nwant=%v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPPEvent(t *testing.T) {
+	particles, err := loadParticles("../../testdata/single-pp-event.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		r     = 0.7
+		ptmin = 20
+	)
+	jets := cluster(t, siscone.New(r, 0.75), particles, ptmin)
+
+	cs, err := fastjet.NewClusterSequence(particles, fastjet.NewJetDefinition(
+		fastjet.AntiKtAlgorithm, r, fastjet.EScheme, fastjet.BestStrategy,
+	))
+	if err != nil {
+		t.Fatalf("could not cluster: %+v", err)
+	}
+	ref, err := cs.InclusiveJets(ptmin)
+	if err != nil {
+		t.Fatalf("could not retrieve jets: %+v", err)
+	}
+	sort.Sort(fastjet.ByPt(ref))
+
+	if got, want := len(jets), len(ref); got != want {
+		t.Fatalf("invalid number of jets: got=%d, want=%d", got, want)
+	}
+
+	// the hard jets of SISCone and anti-kt are expected to be close.
+	for i := range jets {
+		if d := math.Sqrt(fastjet.Distance(&jets[i], &ref[i])); d > 0.1 {
+			t.Errorf("jet #%d: invalid position: dR=%v", i, d)
+		}
+		if got, want := jets[i].Pt(), ref[i].Pt(); !scalar.EqualWithinRel(got, want, 0.1) {
+			t.Errorf("jet #%d: invalid pt: got=%v, want=%v", i, got, want)
+		}
+	}
+}
+
+func cluster(t *testing.T, plugin fastjet.Plugin, particles []fastjet.Jet, ptmin float64) []fastjet.Jet {
+	t.Helper()
+
+	cs, err := fastjet.NewClusterSequence(particles, fastjet.NewJetDefinitionPlugin(plugin))
+	if err != nil {
+		t.Fatalf("could not cluster: %+v", err)
+	}
+	jets, err := cs.InclusiveJets(ptmin)
+	if err != nil {
+		t.Fatalf("could not retrieve jets: %+v", err)
+	}
+	sort.Sort(fastjet.ByPt(jets))
+	return jets
+}
+
+func particle(pt, y, phi float64) fastjet.Jet {
+	return fastjet.NewJet(pt*math.Cos(phi), pt*math.Sin(phi), pt*math.Sinh(y), pt*math.Cosh(y))
+}
+
+func loadParticles(name string) ([]fastjet.Jet, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var particles []fastjet.Jet
+	scan := bufio.NewScanner(f)
+	for scan.Scan() {
+		toks := strings.Fields(scan.Text())
+		var p [4]float64
+		for i := range p {
+			p[i], err = strconv.ParseFloat(toks[i], 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		particles = append(particles, fastjet.NewJet(p[0], p[1], p[2], p[3]))
+	}
+	err = scan.Err()
+	if err != nil {
+		return nil, err
+	}
+	return particles, nil
+}
@@ -0,0 +1,245 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fmom"
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func genEvent(n int, seed uint64) []fastjet.Jet {
+	rnd := rand.New(rand.NewPCG(seed, seed))
+	jets := make([]fastjet.Jet, n)
+	for i := range jets {
+		var (
+			pt  = 0.5 + rnd.ExpFloat64()*5
+			eta = -5 + 10*rnd.Float64()
+			phi = -math.Pi + 2*math.Pi*rnd.Float64()
+			px  = pt * math.Cos(phi)
+			py  = pt * math.Sin(phi)
+			pz  = pt * math.Sinh(eta)
+			e   = math.Sqrt(px*px + py*py + pz*pz)
+		)
+		jets[i] = fastjet.NewJet(px, py, pz, e)
+	}
+	return jets
+}
+
+func TestNlnNStrategies(t *testing.T) {
+	for _, n := range []int{1, 2, 10, 250} {
+		evt := genEvent(n, uint64(n))
+		for _, alg := range []struct {
+			alg   fastjet.JetAlgorithm
+			extra float64
+		}{
+			{fastjet.KtAlgorithm, 0},
+			{fastjet.AntiKtAlgorithm, 0},
+			{fastjet.CambridgeAlgorithm, 0},
+			{fastjet.GenKtAlgorithm, 0.5},
+		} {
+			for _, r := range []float64{0.4, 1.0, 2.5} {
+				ref := clusterWith(t, evt, fastjet.NewJetDefinitionExtra(
+					alg.alg, r, fastjet.EScheme, fastjet.N3DumbStrategy, alg.extra,
+				))
+				for _, strategy := range []fastjet.Strategy{
+					fastjet.NlnNStrategy,
+					fastjet.NlnN3piStrategy,
+					fastjet.NlnN4piStrategy,
+					fastjet.BestStrategy,
+				} {
+					name := fmt.Sprintf("n=%d-%v-r=%v-%v", n, alg.alg, r, strategy)
+					t.Run(name, func(t *testing.T) {
+						got := clusterWith(t, evt, fastjet.NewJetDefinitionExtra(
+							alg.alg, r, fastjet.EScheme, strategy, alg.extra,
+						))
+						if len(got) != len(ref) {
+							t.Fatalf("invalid number of jets: got=%d, want=%d", len(got), len(ref))
+						}
+						for i := range got {
+							if !fmom.Equal(&got[i], &ref[i]) {
+								t.Fatalf("jet[%d] differ:\ngot= %v\nwant=%v", i, got[i].PxPyPzE, ref[i].PxPyPzE)
+							}
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestNlnNCamStrategies(t *testing.T) {
+	evt := genEvent(250, 250)
+	ref := clusterWith(t, evt, fastjet.NewJetDefinition(fastjet.CambridgeAlgorithm, 0.4, fastjet.EScheme, fastjet.N3DumbStrategy))
+	for _, strategy := range []fastjet.Strategy{
+		fastjet.NlnNCamStrategy,
+		fastjet.NlnNCam2pi2RStrategy,
+		fastjet.NlnNCam4piStrategy,
+	} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			got := clusterWith(t, evt, fastjet.NewJetDefinition(fastjet.CambridgeAlgorithm, 0.4, fastjet.EScheme, strategy))
+			if len(got) != len(ref) {
+				t.Fatalf("invalid number of jets: got=%d, want=%d", len(got), len(ref))
+			}
+			for i := range got {
+				if !fmom.Equal(&got[i], &ref[i]) {
+					t.Fatalf("jet[%d] differ:\ngot= %v\nwant=%v", i, got[i].PxPyPzE, ref[i].PxPyPzE)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidStrategies(t *testing.T) {
+	evt := genEvent(10, 10)
+	for _, tc := range []struct {
+		alg      fastjet.JetAlgorithm
+		r        float64
+		strategy fastjet.Strategy
+		err      string
+	}{
+		{
+			alg:      fastjet.KtAlgorithm,
+			r:        0.4,
+			strategy: fastjet.NlnNCamStrategy,
+			err:      "fastjet: NlnNCam strategy can only be used with the Cambridge/Aachen algorithm",
+		},
+		{
+			alg:      fastjet.AntiKtAlgorithm,
+			r:        0.4,
+			strategy: fastjet.NlnNCam4piStrategy,
+			err:      "fastjet: NlnNCam4pi strategy can only be used with the Cambridge/Aachen algorithm",
+		},
+		{
+			alg:      fastjet.EeKtAlgorithm,
+			r:        0.4,
+			strategy: fastjet.NlnNStrategy,
+			err:      "fastjet: NlnN strategy can not be used with e+e- algorithms",
+		},
+		{
+			alg:      fastjet.EeGenKtAlgorithm,
+			r:        0.4,
+			strategy: fastjet.NlnN4piStrategy,
+			err:      "fastjet: NlnN4pi strategy can not be used with e+e- algorithms",
+		},
+		{
+			alg:      fastjet.KtAlgorithm,
+			r:        2 * math.Pi,
+			strategy: fastjet.NlnN3piStrategy,
+			err:      "fastjet: NlnN3pi strategy requires R < 2pi (R=6.283185307179586)",
+		},
+		{
+			alg:      fastjet.CambridgeAlgorithm,
+			r:        7,
+			strategy: fastjet.NlnNCamStrategy,
+			err:      "fastjet: NlnNCam strategy requires R < 2pi (R=7)",
+		},
+	} {
+		t.Run(fmt.Sprintf("%v-%v-r=%v", tc.alg, tc.strategy, tc.r), func(t *testing.T) {
+			def := fastjet.NewJetDefinitionExtra(tc.alg, tc.r, fastjet.EScheme, tc.strategy, 1)
+			_, err := fastjet.NewClusterSequence(evt, def)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+			}
+			if got, want := def.Strategy(), tc.strategy; got != want {
+				t.Fatalf("invalid strategy: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestNlnNCoincidentJets(t *testing.T) {
+	// particles sharing the same rapidity and azimuth, as when several
+	// objects are built out of the same calorimeter cell.
+	evt := genEvent(300, 42)
+	for i := 0; i < 300; i += 3 {
+		evt = append(evt, fastjet.NewJet(0.5*evt[i].Px(), 0.5*evt[i].Py(), 0.5*evt[i].Pz(), 0.5*evt[i].E()))
+		if i%2 == 0 {
+			evt = append(evt, fastjet.NewJet(2*evt[i].Px(), 2*evt[i].Py(), 2*evt[i].Pz(), 2*evt[i].E()))
+		}
+	}
+
+	for _, alg := range []fastjet.JetAlgorithm{
+		fastjet.KtAlgorithm,
+		fastjet.AntiKtAlgorithm,
+		fastjet.CambridgeAlgorithm,
+	} {
+		ref := clusterWith(t, evt, fastjet.NewJetDefinition(alg, 0.4, fastjet.EScheme, fastjet.N3DumbStrategy))
+		for _, strategy := range []fastjet.Strategy{
+			fastjet.NlnNStrategy,
+			fastjet.NlnN4piStrategy,
+		} {
+			t.Run(fmt.Sprintf("%v-%v", alg, strategy), func(t *testing.T) {
+				got := clusterWith(t, evt, fastjet.NewJetDefinition(alg, 0.4, fastjet.EScheme, strategy))
+				if len(got) != len(ref) {
+					t.Fatalf("invalid number of jets: got=%d, want=%d", len(got), len(ref))
+				}
+				// coincident jets are recombined in an unspecified order.
+				for i := range got {
+					if !equalWithinRel(&got[i], &ref[i], 1e-12) {
+						t.Fatalf("jet[%d] differ:\ngot= %v\nwant=%v", i, got[i].PxPyPzE, ref[i].PxPyPzE)
+					}
+				}
+			})
+		}
+	}
+}
+
+func equalWithinRel(p1, p2 *fastjet.Jet, tol float64) bool {
+	return scalar.EqualWithinRel(p1.Px(), p2.Px(), tol) &&
+		scalar.EqualWithinRel(p1.Py(), p2.Py(), tol) &&
+		scalar.EqualWithinRel(p1.Pz(), p2.Pz(), tol) &&
+		scalar.EqualWithinRel(p1.E(), p2.E(), tol)
+}
+
+func clusterWith(t testing.TB, evt []fastjet.Jet, def fastjet.JetDefinition) []fastjet.Jet {
+	t.Helper()
+
+	cs, err := fastjet.NewClusterSequence(evt, def)
+	if err != nil {
+		t.Fatalf("could not run clustering: %+v", err)
+	}
+
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		t.Fatalf("could not retrieve inclusive jets: %+v", err)
+	}
+	sort.Sort(fastjet.ByPt(jets))
+	return jets
+}
+
+func benchmarkStrategy(b *testing.B, n int, strategy fastjet.Strategy) {
+	evt := genEvent(n, 1234)
+	def := fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 0.4, fastjet.EScheme, strategy)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		_, err := fastjet.NewClusterSequence(evt, def)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkN3Dumb100(b *testing.B)    { benchmarkStrategy(b, 100, fastjet.N3DumbStrategy) }
+func BenchmarkN3Dumb200(b *testing.B)    { benchmarkStrategy(b, 200, fastjet.N3DumbStrategy) }
+func BenchmarkN3Dumb1000(b *testing.B)   { benchmarkStrategy(b, 1000, fastjet.N3DumbStrategy) }
+func BenchmarkNlnN100(b *testing.B)      { benchmarkStrategy(b, 100, fastjet.NlnNStrategy) }
+func BenchmarkNlnN200(b *testing.B)      { benchmarkStrategy(b, 200, fastjet.NlnNStrategy) }
+func BenchmarkNlnN1000(b *testing.B)     { benchmarkStrategy(b, 1000, fastjet.NlnNStrategy) }
+func BenchmarkNlnN10000(b *testing.B)    { benchmarkStrategy(b, 10000, fastjet.NlnNStrategy) }
+func BenchmarkNlnN50000(b *testing.B)    { benchmarkStrategy(b, 50000, fastjet.NlnNStrategy) }
+func BenchmarkBest10000(b *testing.B)    { benchmarkStrategy(b, 10000, fastjet.BestStrategy) }
+func BenchmarkNlnN3pi10000(b *testing.B) { benchmarkStrategy(b, 10000, fastjet.NlnN3piStrategy) }
+func BenchmarkNlnN4pi10000(b *testing.B) { benchmarkStrategy(b, 10000, fastjet.NlnN4piStrategy) }
@@ -5,12 +5,13 @@
 package fit
 
 import (
+	"gonum.org/v1/gonum/floats"
 	"gonum.org/v1/gonum/optimize"
 )
 
 // Curve1D returns the result of a non-linear least squares to fit
 // a function f to the underlying data with method m.
-func Curve1D(f Func1D, settings *optimize.Settings, m optimize.Method) (*optimize.Result, error) {
+func Curve1D(f Func1D, settings *optimize.Settings, m optimize.Method) (*Result, error) {
 	f.init()
 
 	p := optimize.Problem{
@@ -25,5 +26,16 @@ func Curve1D(f Func1D, settings *optimize.Settings, m optimize.Method) (*optimiz
 
 	p0 := make([]float64, len(f.Ps))
 	copy(p0, f.Ps)
-	return optimize.Minimize(p, p0, settings, m)
+	res, err := optimize.Minimize(p, p0, settings, m)
+	if res == nil {
+		return nil, err
+	}
+
+	o := newResult(res, f.Names, f.hess, len(f.X), f.Err != nil)
+	o.f1 = f.F
+	if len(f.X) > 0 {
+		o.xmin = floats.Min(f.X)
+		o.xmax = floats.Max(f.X)
+	}
+	return o, err
 }
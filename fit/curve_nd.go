@@ -11,7 +11,7 @@ import (
 // CurveND returns the result of a non-linear least squares to fit
 // a function f to the underlying data with method m, where there
 // is more than one independent variable.
-func CurveND(f FuncND, settings *optimize.Settings, m optimize.Method) (*optimize.Result, error) {
+func CurveND(f FuncND, settings *optimize.Settings, m optimize.Method) (*Result, error) {
 	f.init()
 
 	p := optimize.Problem{
@@ -26,5 +26,10 @@ func CurveND(f FuncND, settings *optimize.Settings, m optimize.Method) (*optimiz
 
 	p0 := make([]float64, len(f.Ps))
 	copy(p0, f.Ps)
-	return optimize.Minimize(p, p0, settings, m)
+	res, err := optimize.Minimize(p, p0, settings, m)
+	if res == nil {
+		return nil, err
+	}
+
+	return newResult(res, f.Names, f.hess, len(f.X), f.Err != nil), err
 }
@@ -27,6 +27,10 @@ type Func1D struct {
 	// length N filled with zeros.
 	Ps []float64
 
+	// Names is the optional list of names of the parameters.
+	// If Names is nil, parameters are named p0, p1, ... pn.
+	Names []string
+
 	X   []float64
 	Y   []float64
 	Err []float64
@@ -60,6 +64,10 @@ func (f *Func1D) init() {
 		panic("fit: invalid number of initial parameters")
 	}
 
+	if f.Names != nil && len(f.Names) != len(f.Ps) {
+		panic("fit: invalid number of parameter names")
+	}
+
 	if len(f.X) != len(f.Y) {
 		panic("fit: mismatch length")
 	}
@@ -111,6 +119,10 @@ type FuncND struct {
 	// length N filled with zeros.
 	Ps []float64
 
+	// Names is the optional list of names of the parameters.
+	// If Names is nil, parameters are named p0, p1, ... pn.
+	Names []string
+
 	// X is the multidimensional slice of the independent variables,
 	// it must be structured so that the X[i] is a list of values for the
 	// independent variables that corresponds to a single Y value.
@@ -149,6 +161,10 @@ func (f *FuncND) init() {
 		panic("fit: invalid number of initial parameters")
 	}
 
+	if f.Names != nil && len(f.Names) != len(f.Ps) {
+		panic("fit: invalid number of parameter names")
+	}
+
 	if len(f.X) != len(f.Y) {
 		panic("fit: mismatch length")
 	}
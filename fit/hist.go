@@ -14,7 +14,7 @@ import (
 // Only bins with at least an entry are considered for the fit.
 // In case settings is nil, the optimize.DefaultSettingsLocal is used.
 // In case m is nil, the same default optimization method than for Curve1D is used.
-func H1D(h *hbook.H1D, f Func1D, settings *optimize.Settings, m optimize.Method) (*optimize.Result, error) {
+func H1D(h *hbook.H1D, f Func1D, settings *optimize.Settings, m optimize.Method) (*Result, error) {
 	var (
 		n     = h.Len()
 		xdata = make([]float64, 0, n)
@@ -36,5 +36,10 @@ func H1D(h *hbook.H1D, f Func1D, settings *optimize.Settings, m optimize.Method)
 	f.Y = ydata
 	f.Err = yerrs
 
-	return Curve1D(f, settings, m)
+	res, err := Curve1D(f, settings, m)
+	if res != nil {
+		res.xmin = h.XMin()
+		res.xmax = h.XMax()
+	}
+	return res, err
 }
@@ -0,0 +1,181 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Result holds the outcome of a fit.
+//
+// The best-fit values of the parameters are stored in the X field of
+// the embedded optimize.Result.
+type Result struct {
+	optimize.Result
+
+	Names []string      // names of the parameters
+	Errs  []float64     // errors on the best-fit values of the parameters
+	Cov   *mat.SymDense // covariance matrix of the parameters
+	Corr  *mat.SymDense // correlation matrix of the parameters
+
+	Chi2 float64 // chi-square of the fit
+	NDF  int     // number of degrees of freedom of the fit
+	NPts int     // number of data points used in the fit
+
+	f1   func(x float64, ps []float64) float64 // 1-dim fitted function, if any
+	xmin float64                               // lower bound of the 1-dim fitted function
+	xmax float64                               // upper bound of the 1-dim fitted function
+}
+
+// newResult creates a new fit result from the optimization result res.
+//
+// hess computes the hessian matrix of the cost function (half the chi-square)
+// at the minimum, from which the covariance matrix is derived.
+// If the data points had no associated errors, the covariance matrix is
+// scaled by the reduced chi-square.
+func newResult(res *optimize.Result, names []string, hess func(*mat.SymDense, []float64), npts int, weighted bool) *Result {
+	var (
+		npar = len(res.X)
+		o    = &Result{
+			Result: *res,
+			Names:  parNames(names, npar),
+			Errs:   make([]float64, npar),
+			Chi2:   2 * res.F,
+			NDF:    npts - npar,
+			NPts:   npts,
+		}
+	)
+
+	h := mat.NewSymDense(npar, nil)
+	hess(h, res.X)
+
+	var (
+		chol mat.Cholesky
+		cov  = mat.NewSymDense(npar, nil)
+	)
+	if ok := chol.Factorize(h); !ok || chol.InverseTo(cov) != nil {
+		// hessian is not positive definite: no covariance matrix.
+		for i := range o.Errs {
+			o.Errs[i] = math.NaN()
+		}
+		return o
+	}
+
+	if !weighted && o.NDF > 0 {
+		cov.ScaleSym(o.Chi2/float64(o.NDF), cov)
+	}
+
+	corr := mat.NewSymDense(npar, nil)
+	for i := range npar {
+		o.Errs[i] = math.Sqrt(cov.At(i, i))
+	}
+	for i := range npar {
+		for j := i; j < npar; j++ {
+			corr.SetSym(i, j, cov.At(i, j)/(o.Errs[i]*o.Errs[j]))
+		}
+	}
+
+	o.Cov = cov
+	o.Corr = corr
+	return o
+}
+
+func parNames(names []string, n int) []string {
+	if names != nil {
+		return append([]string(nil), names...)
+	}
+	names = make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("p%d", i)
+	}
+	return names
+}
+
+// Chi2NDF returns the reduced chi-square of the fit.
+func (res *Result) Chi2NDF() float64 {
+	return res.Chi2 / float64(res.NDF)
+}
+
+// PValue returns the probability to obtain a chi-square value at least as
+// large as the one of the fit, for the number of degrees of freedom of
+// the fit.
+func (res *Result) PValue() float64 {
+	if res.NDF <= 0 {
+		return math.NaN()
+	}
+	return distuv.ChiSquared{K: float64(res.NDF)}.Survival(res.Chi2)
+}
+
+// F1 returns the fitted function as a ROOT TF1, carrying the best-fit
+// values and errors of the parameters as well as the chi-square of the fit.
+//
+// F1 returns an error if the result does not describe the fit of a
+// 1-dim function.
+func (res *Result) F1(name string) (*rhist.F1, error) {
+	if res.f1 == nil {
+		return nil, fmt.Errorf("fit: result does not describe a 1-dim function fit")
+	}
+
+	ps := append([]float64(nil), res.X...)
+	f := rhist.NewF1(
+		name, name, res.xmin, res.xmax,
+		func(x float64) float64 { return res.f1(x, ps) },
+		ps, res.Names,
+	)
+	f.SetParErrors(res.Errs)
+	f.SetFitResult(res.Chi2, res.NDF, res.NPts)
+
+	return f, nil
+}
+
+// String returns a tabular representation of the fit result.
+func (res *Result) String() string {
+	o := new(strings.Builder)
+	_, _ = res.WriteTo(o)
+	return o.String()
+}
+
+// WriteTo writes a tabular representation of the fit result to w.
+// WriteTo implements io.WriterTo.
+func (res *Result) WriteTo(w io.Writer) (int64, error) {
+	var (
+		o  = new(strings.Builder)
+		tw = tabwriter.NewWriter(o, 0, 8, 2, ' ', tabwriter.AlignRight)
+	)
+
+	fmt.Fprintf(o, "status:   %v\n", res.Status)
+	fmt.Fprintf(o, "chi2/ndf: %g/%d = %g\n", res.Chi2, res.NDF, res.Chi2NDF())
+	fmt.Fprintf(o, "p-value:  %g\n", res.PValue())
+	fmt.Fprintf(tw, "#\tname\tvalue\terror\t\n")
+	for i, v := range res.X {
+		fmt.Fprintf(tw, "%d\t%s\t%e\t%e\t\n", i, res.Names[i], v, res.Errs[i])
+	}
+	_ = tw.Flush()
+
+	if res.Corr != nil {
+		fmt.Fprintf(o, "correlation matrix:\n")
+		n := res.Corr.SymmetricDim()
+		for i := range n {
+			fmt.Fprintf(tw, "%s\t", res.Names[i])
+			for j := range n {
+				fmt.Fprintf(tw, "%+.3f\t", res.Corr.At(i, j))
+			}
+			fmt.Fprintf(tw, "\n")
+		}
+		_ = tw.Flush()
+	}
+
+	n, err := io.WriteString(w, o.String())
+	return int64(n), err
+}
@@ -0,0 +1,184 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit_test
+
+import (
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rhist"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestResultLinear(t *testing.T) {
+	var (
+		xs   = []float64{0, 1, 2, 3, 4, 5, 6, 7}
+		ys   = []float64{1.1, 2.9, 5.2, 7.1, 8.8, 11.2, 12.9, 15.1}
+		errs = []float64{0.1, 0.2, 0.1, 0.3, 0.2, 0.1, 0.2, 0.3}
+	)
+
+	res, err := fit.Curve1D(
+		fit.Func1D{
+			F: func(x float64, ps []float64) float64 {
+				return ps[0] + ps[1]*x
+			},
+			X:     xs,
+			Y:     ys,
+			Err:   errs,
+			Ps:    []float64{1, 1},
+			Names: []string{"a", "b"},
+		},
+		nil, &optimize.NelderMead{},
+	)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+
+	// analytical weighted linear least-squares.
+	var s, sx, sxx, sy, sxy float64
+	for i, x := range xs {
+		w := 1 / (errs[i] * errs[i])
+		s += w
+		sx += w * x
+		sxx += w * x * x
+		sy += w * ys[i]
+		sxy += w * x * ys[i]
+	}
+	var (
+		delta = s*sxx - sx*sx
+		a     = (sxx*sy - sx*sxy) / delta
+		b     = (s*sxy - sx*sy) / delta
+		cov   = mat.NewSymDense(2, []float64{
+			sxx / delta, -sx / delta,
+			-sx / delta, s / delta,
+		})
+		chi2 float64
+	)
+	for i, x := range xs {
+		v := (ys[i] - a - b*x) / errs[i]
+		chi2 += v * v
+	}
+
+	if got, want := res.X, []float64{a, b}; !floats.EqualApprox(got, want, 1e-6) {
+		t.Fatalf("invalid best-fit values:\ngot= %v\nwant=%v", got, want)
+	}
+	if got, want := res.Errs, []float64{math.Sqrt(cov.At(0, 0)), math.Sqrt(cov.At(1, 1))}; !floats.EqualApprox(got, want, 1e-4) {
+		t.Fatalf("invalid errors:\ngot= %v\nwant=%v", got, want)
+	}
+	if !mat.EqualApprox(res.Cov, cov, 1e-4) {
+		t.Fatalf("invalid covariance matrix:\ngot= %v\nwant=%v", mat.Formatted(res.Cov), mat.Formatted(cov))
+	}
+	if got, want := res.Corr.At(0, 1), cov.At(0, 1)/math.Sqrt(cov.At(0, 0)*cov.At(1, 1)); math.Abs(got-want) > 1e-4 {
+		t.Fatalf("invalid correlation: got=%v, want=%v", got, want)
+	}
+	if got, want := res.Corr.At(0, 0), 1.0; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid correlation: got=%v, want=%v", got, want)
+	}
+	if got, want := res.Chi2, chi2; math.Abs(got-want) > 1e-6 {
+		t.Fatalf("invalid chi2: got=%v, want=%v", got, want)
+	}
+	if got, want := res.NDF, len(xs)-2; got != want {
+		t.Fatalf("invalid ndf: got=%d, want=%d", got, want)
+	}
+	if p := res.PValue(); !(0 <= p && p <= 1) {
+		t.Fatalf("invalid p-value: %v", p)
+	}
+
+	str := res.String()
+	for _, want := range []string{"chi2/ndf:", "p-value:", "correlation matrix:", " a ", " b "} {
+		if !strings.Contains(str, want) {
+			t.Fatalf("missing %q in fit result table:\n%s", want, str)
+		}
+	}
+
+	f1, err := res.F1("linear")
+	if err != nil {
+		t.Fatalf("could not create TF1: %+v", err)
+	}
+
+	fname := filepath.Join(t.TempDir(), "fit.root")
+	w, err := groot.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create ROOT file: %+v", err)
+	}
+	defer w.Close()
+
+	err = w.Put("linear", f1)
+	if err != nil {
+		t.Fatalf("could not store TF1: %+v", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close ROOT file: %+v", err)
+	}
+
+	r, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer r.Close()
+
+	obj, err := r.Get("linear")
+	if err != nil {
+		t.Fatalf("could not retrieve TF1: %+v", err)
+	}
+
+	rf := obj.(*rhist.F1)
+	if got, want := rf.Params(), res.X; !floats.Equal(got, want) {
+		t.Fatalf("invalid TF1 parameters:\ngot= %v\nwant=%v", got, want)
+	}
+	if got, want := rf.ParErrors(), res.Errs; !floats.Equal(got, want) {
+		t.Fatalf("invalid TF1 parameter errors:\ngot= %v\nwant=%v", got, want)
+	}
+	if got, want := rf.ParNames(), res.Names; !slices.Equal(got, want) {
+		t.Fatalf("invalid TF1 parameter names:\ngot= %q\nwant=%q", got, want)
+	}
+	if got, want := rf.Chi2(), res.Chi2; got != want {
+		t.Fatalf("invalid TF1 chi2: got=%v, want=%v", got, want)
+	}
+	if got, want := rf.NDF(), res.NDF; got != want {
+		t.Fatalf("invalid TF1 ndf: got=%v, want=%v", got, want)
+	}
+	if got, want := rf.XMax(), 7.0; got != want {
+		t.Fatalf("invalid TF1 xmax: got=%v, want=%v", got, want)
+	}
+}
+
+func TestResultUnweighted(t *testing.T) {
+	res, err := fit.CurveND(
+		fit.FuncND{
+			F: func(x []float64, ps []float64) float64 {
+				return ps[0]*x[0] + ps[1]*x[1]
+			},
+			X: [][]float64{{1, 0}, {0, 1}, {1, 1}, {2, 1}, {1, 2}},
+			Y: []float64{1.1, 1.9, 3.2, 3.9, 5.1},
+			N: 2,
+		},
+		nil, &optimize.NelderMead{},
+	)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+
+	if got, want := res.Names, []string{"p0", "p1"}; !slices.Equal(got, want) {
+		t.Fatalf("invalid default names: got=%q, want=%q", got, want)
+	}
+	if res.Cov == nil {
+		t.Fatalf("missing covariance matrix")
+	}
+
+	_, err = res.F1("fnd")
+	if err == nil {
+		t.Fatalf("expected an error creating a TF1 from a N-dim fit")
+	}
+}
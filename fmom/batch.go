@@ -0,0 +1,344 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fmom
+
+import (
+	"fmt"
+	"iter"
+	"math"
+
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+// Batch is a collection of four-momenta, stored as parallel slices of
+// their Px, Py, Pz and E components (structure of arrays).
+//
+// Operations on a Batch work directly on the components, without going
+// through the P4 interface nor allocating a four-vector per element.
+// Functions and methods taking a dst slice reuse its storage, when large
+// enough, and return the resized slice.
+type Batch struct {
+	Px []float64
+	Py []float64
+	Pz []float64
+	E  []float64
+}
+
+// NewBatch returns a batch of n null four-momenta.
+func NewBatch(n int) Batch {
+	return Batch{
+		Px: make([]float64, n),
+		Py: make([]float64, n),
+		Pz: make([]float64, n),
+		E:  make([]float64, n),
+	}
+}
+
+// NewBatchFrom returns a batch holding the provided four-momenta.
+func NewBatchFrom(ps []P4) Batch {
+	b := NewBatch(len(ps))
+	for i, p := range ps {
+		b.set(i, p)
+	}
+	return b
+}
+
+// NewBatchPxPyPzE returns a batch from slices of px, py, pz and e
+// components, such as the ones read from ROOT slice branches.
+// NewBatchPxPyPzE panics if the slices do not have the same length.
+func NewBatchPxPyPzE[T float32 | float64](px, py, pz, e []T) Batch {
+	n := checkLens(len(px), len(py), len(pz), len(e))
+	b := NewBatch(n)
+	for i := range n {
+		b.Px[i] = float64(px[i])
+		b.Py[i] = float64(py[i])
+		b.Pz[i] = float64(pz[i])
+		b.E[i] = float64(e[i])
+	}
+	return b
+}
+
+// NewBatchPtEtaPhiM returns a batch from slices of pt, eta, phi and m
+// components, such as the ones read from ROOT slice branches.
+// NewBatchPtEtaPhiM panics if the slices do not have the same length.
+func NewBatchPtEtaPhiM[T float32 | float64](pt, eta, phi, m []T) Batch {
+	n := checkLens(len(pt), len(eta), len(phi), len(m))
+	b := NewBatch(n)
+	var p PxPyPzE
+	for i := range n {
+		p.SetPtEtaPhiM(float64(pt[i]), float64(eta[i]), float64(phi[i]), float64(m[i]))
+		b.Px[i] = p.P4.X
+		b.Py[i] = p.P4.Y
+		b.Pz[i] = p.P4.Z
+		b.E[i] = p.P4.T
+	}
+	return b
+}
+
+func checkLens(n int, ns ...int) int {
+	for _, v := range ns {
+		if v != n {
+			panic(fmt.Errorf("fmom: slices length mismatch (%d != %d)", v, n))
+		}
+	}
+	return n
+}
+
+// Len returns the number of four-momenta in the batch.
+func (b Batch) Len() int { return len(b.E) }
+
+// At returns the i-th four-momentum of the batch.
+func (b Batch) At(i int) PxPyPzE {
+	return NewPxPyPzE(b.Px[i], b.Py[i], b.Pz[i], b.E[i])
+}
+
+// Set sets the i-th four-momentum of the batch.
+func (b Batch) Set(i int, p P4) {
+	b.set(i, p)
+}
+
+func (b Batch) set(i int, p P4) {
+	if p, ok := p.(*PxPyPzE); ok {
+		b.Px[i] = p.P4.X
+		b.Py[i] = p.P4.Y
+		b.Pz[i] = p.P4.Z
+		b.E[i] = p.P4.T
+		return
+	}
+	b.Px[i] = p.Px()
+	b.Py[i] = p.Py()
+	b.Pz[i] = p.Pz()
+	b.E[i] = p.E()
+}
+
+// Append appends the provided four-momenta to the batch.
+func (b *Batch) Append(ps ...P4) {
+	n := b.Len()
+	b.Px = append(b.Px, make([]float64, len(ps))...)
+	b.Py = append(b.Py, make([]float64, len(ps))...)
+	b.Pz = append(b.Pz, make([]float64, len(ps))...)
+	b.E = append(b.E, make([]float64, len(ps))...)
+	for i, p := range ps {
+		b.set(n+i, p)
+	}
+}
+
+// P4s returns the four-momenta of the batch.
+func (b Batch) P4s() []PxPyPzE {
+	o := make([]PxPyPzE, b.Len())
+	for i := range o {
+		o[i] = b.At(i)
+	}
+	return o
+}
+
+// Pt returns the transverse momenta of the batch.
+func (b Batch) Pt(dst []float64) []float64 {
+	dst = resize(dst, b.Len())
+	for i := range dst {
+		p := b.At(i)
+		dst[i] = p.Pt()
+	}
+	return dst
+}
+
+// Eta returns the pseudo-rapidities of the batch.
+func (b Batch) Eta(dst []float64) []float64 {
+	dst = resize(dst, b.Len())
+	for i := range dst {
+		p := b.At(i)
+		dst[i] = p.Eta()
+	}
+	return dst
+}
+
+// Rapidity returns the rapidities of the batch.
+func (b Batch) Rapidity(dst []float64) []float64 {
+	dst = resize(dst, b.Len())
+	for i := range dst {
+		p := b.At(i)
+		dst[i] = p.Rapidity()
+	}
+	return dst
+}
+
+// Phi returns the azimuthal angles of the batch.
+func (b Batch) Phi(dst []float64) []float64 {
+	dst = resize(dst, b.Len())
+	for i := range dst {
+		p := b.At(i)
+		dst[i] = p.Phi()
+	}
+	return dst
+}
+
+// M returns the masses of the batch.
+func (b Batch) M(dst []float64) []float64 {
+	dst = resize(dst, b.Len())
+	for i := range dst {
+		dst[i] = mass(b.Px[i], b.Py[i], b.Pz[i], b.E[i])
+	}
+	return dst
+}
+
+// Boost boosts, in place, all the four-momenta of the batch by the
+// provided three-vector.
+func (b Batch) Boost(vec r3.Vec) {
+	if vec == (r3.Vec{}) {
+		return
+	}
+	var (
+		v2    = r3.Dot(vec, vec)
+		gamma = 1 / math.Sqrt(1-v2)
+		beta  = (gamma - 1) / v2
+	)
+	for i := range b.E {
+		var (
+			bp    = vec.X*b.Px[i] + vec.Y*b.Py[i] + vec.Z*b.Pz[i]
+			alpha = beta*bp + gamma*b.E[i]
+		)
+		b.Px[i] += alpha * vec.X
+		b.Py[i] += alpha * vec.Y
+		b.Pz[i] += alpha * vec.Z
+		b.E[i] = gamma * (b.E[i] + bp)
+	}
+}
+
+// BoostToRest boosts, in place, all the four-momenta of the batch to the
+// rest frame of the provided four-vector.
+// It panics if frame isn't a timelike four-vector.
+func (b Batch) BoostToRest(frame P4) {
+	b.Boost(r3.Scale(-1, BoostOf(frame)))
+}
+
+// InvMass returns the invariant mass of the sum of the i-th and j-th
+// four-momenta of the batch.
+func (b Batch) InvMass(i, j int) float64 {
+	return mass(b.Px[i]+b.Px[j], b.Py[i]+b.Py[j], b.Pz[i]+b.Pz[j], b.E[i]+b.E[j])
+}
+
+// PairMasses returns the invariant masses of all the pairs (i,j), with i<j,
+// of four-momenta of the batch, ordered as (0,1), (0,2), ..., (1,2), ...
+func (b Batch) PairMasses(dst []float64) []float64 {
+	n := b.Len()
+	dst = resize(dst, n*(n-1)/2)[:0]
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			dst = append(dst, b.InvMass(i, j))
+		}
+	}
+	return dst
+}
+
+// InvMasses returns the invariant masses of the element-wise sums of the
+// four-momenta of b1 and b2.
+// InvMasses panics if both batches do not have the same length.
+func InvMasses(dst []float64, b1, b2 Batch) []float64 {
+	n := checkLens(b1.Len(), b2.Len())
+	dst = resize(dst, n)
+	for i := range dst {
+		dst[i] = mass(
+			b1.Px[i]+b2.Px[i],
+			b1.Py[i]+b2.Py[i],
+			b1.Pz[i]+b2.Pz[i],
+			b1.E[i]+b2.E[i],
+		)
+	}
+	return dst
+}
+
+// DeltaRMatrix returns the delta R between all the four-momenta of b1 and
+// all the four-momenta of b2, as a row-major matrix of b1.Len() rows and
+// b2.Len() columns.
+func DeltaRMatrix(dst []float64, b1, b2 Batch) []float64 {
+	var (
+		n1   = b1.Len()
+		n2   = b2.Len()
+		eta1 = b1.Eta(nil)
+		phi1 = b1.Phi(nil)
+		eta2 = b2.Eta(nil)
+		phi2 = b2.Phi(nil)
+	)
+	dst = resize(dst, n1*n2)
+	for i := range n1 {
+		row := dst[i*n2 : (i+1)*n2]
+		for j := range row {
+			deta := eta1[i] - eta2[j]
+			dphi := math.Remainder(phi1[i]-phi2[j], twopi)
+			row[j] = math.Sqrt(deta*deta + dphi*dphi)
+		}
+	}
+	return dst
+}
+
+// Sum returns the sum of the four-momenta of the batch with the provided
+// indices.
+func (b Batch) Sum(idx []int) PxPyPzE {
+	var p PxPyPzE
+	for _, i := range idx {
+		p.P4.X += b.Px[i]
+		p.P4.Y += b.Py[i]
+		p.P4.Z += b.Pz[i]
+		p.P4.T += b.E[i]
+	}
+	return p
+}
+
+// CombMasses returns the invariant masses of all the combinations of k
+// four-momenta of the batch, in the order of Combinations.
+func (b Batch) CombMasses(dst []float64, k int) []float64 {
+	dst = dst[:0]
+	for idx := range Combinations(b.Len(), k) {
+		p := b.Sum(idx)
+		dst = append(dst, p.M())
+	}
+	return dst
+}
+
+// Combinations returns an iterator over all the combinations of k indices
+// among n, in lexicographic order.
+// The yielded slice is reused from one iteration to the next.
+func Combinations(n, k int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if k < 0 || k > n {
+			return
+		}
+		idx := make([]int, k)
+		for i := range idx {
+			idx[i] = i
+		}
+		for {
+			if !yield(idx) {
+				return
+			}
+			i := k - 1
+			for i >= 0 && idx[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			idx[i]++
+			for j := i + 1; j < k; j++ {
+				idx[j] = idx[j-1] + 1
+			}
+		}
+	}
+}
+
+func mass(px, py, pz, e float64) float64 {
+	m2 := e*e - (px*px + py*py + pz*pz)
+	if m2 < 0 {
+		return -math.Sqrt(-m2)
+	}
+	return math.Sqrt(m2)
+}
+
+func resize(sli []float64, n int) []float64 {
+	if cap(sli) < n {
+		return make([]float64, n)
+	}
+	return sli[:n]
+}
@@ -0,0 +1,283 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fmom_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"go-hep.org/x/hep/fmom"
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rtree"
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+func genP4s(n int) []fmom.P4 {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	ps := make([]fmom.P4, n)
+	for i := range ps {
+		p := fmom.NewPtEtaPhiM(
+			10+90*rnd.Float64(),
+			4*rnd.Float64()-2,
+			2*math.Pi*rnd.Float64()-math.Pi,
+			10*rnd.Float64(),
+		)
+		ps[i] = &p
+	}
+	return ps
+}
+
+func TestBatch(t *testing.T) {
+	const tol = 1e-9
+	ps := genP4s(10)
+	b := fmom.NewBatchFrom(ps)
+
+	if got, want := b.Len(), len(ps); got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+
+	var (
+		pts  = b.Pt(nil)
+		etas = b.Eta(nil)
+		phis = b.Phi(nil)
+		ms   = b.M(nil)
+		ys   = b.Rapidity(nil)
+	)
+	for i, p := range ps {
+		for _, v := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"pt", pts[i], p.Pt()},
+			{"eta", etas[i], p.Eta()},
+			{"phi", phis[i], p.Phi()},
+			{"m", ms[i], p.M()},
+			{"rapidity", ys[i], p.Rapidity()},
+		} {
+			if !scalar.EqualWithinAbs(v.got, v.want, tol) {
+				t.Fatalf("#%d: invalid %s: got=%v, want=%v", i, v.name, v.got, v.want)
+			}
+		}
+	}
+
+	masses := b.PairMasses(nil)
+	if got, want := len(masses), len(ps)*(len(ps)-1)/2; got != want {
+		t.Fatalf("invalid number of pairs: got=%d, want=%d", got, want)
+	}
+	k := 0
+	for i := range ps {
+		for j := i + 1; j < len(ps); j++ {
+			if got, want := masses[k], fmom.InvMass(ps[i], ps[j]); !scalar.EqualWithinAbs(got, want, tol) {
+				t.Fatalf("(%d,%d): invalid mass: got=%v, want=%v", i, j, got, want)
+			}
+			k++
+		}
+	}
+
+	b1 := fmom.NewBatchFrom(ps[:5])
+	b2 := fmom.NewBatchFrom(ps[5:])
+	for i, m := range fmom.InvMasses(nil, b1, b2) {
+		if got, want := m, fmom.InvMass(ps[i], ps[5+i]); !scalar.EqualWithinAbs(got, want, tol) {
+			t.Fatalf("#%d: invalid mass: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	drs := fmom.DeltaRMatrix(nil, b1, b2)
+	for i := range b1.Len() {
+		for j := range b2.Len() {
+			if got, want := drs[i*b2.Len()+j], fmom.DeltaR(ps[i], ps[5+j]); !scalar.EqualWithinAbs(got, want, tol) {
+				t.Fatalf("(%d,%d): invalid delta R: got=%v, want=%v", i, j, got, want)
+			}
+		}
+	}
+
+	vec := r3.Vec{X: 0.1, Y: -0.2, Z: 0.3}
+	boosted := fmom.NewBatchFrom(ps)
+	boosted.Boost(vec)
+	for i, p := range ps {
+		got := boosted.At(i)
+		want := fmom.Boost(p, vec)
+		if !scalar.EqualWithinAbs(got.E(), want.E(), tol) || !scalar.EqualWithinAbs(got.Pz(), want.Pz(), tol) {
+			t.Fatalf("#%d: invalid boost: got=%v, want=%v", i, &got, want)
+		}
+	}
+
+	frame := b.At(0)
+	b.BoostToRest(&frame)
+	if p := b.At(0); !scalar.EqualWithinAbs(p.P(), 0, 1e-9) {
+		t.Fatalf("invalid rest frame: %v", p)
+	}
+}
+
+func TestBatchAppend(t *testing.T) {
+	ps := genP4s(3)
+	var b fmom.Batch
+	b.Append(ps...)
+	b.Append(ps[0])
+	if got, want := b.Len(), 4; got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+	p := b.At(3)
+	if !fmom.Equal(&p, ps[0]) {
+		t.Fatalf("invalid appended four-momentum: %v", p)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	var got [][]int
+	for idx := range fmom.Combinations(4, 2) {
+		got = append(got, slices.Clone(idx))
+	}
+	want := [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("invalid combinations:\ngot= %v\nwant=%v", got, want)
+	}
+
+	for _, tc := range []struct{ n, k, want int }{
+		{5, 0, 1},
+		{5, 3, 10},
+		{5, 5, 1},
+		{3, 4, 0},
+	} {
+		n := 0
+		for range fmom.Combinations(tc.n, tc.k) {
+			n++
+		}
+		if n != tc.want {
+			t.Fatalf("C(%d,%d): got=%d, want=%d", tc.n, tc.k, n, tc.want)
+		}
+	}
+
+	ps := genP4s(5)
+	b := fmom.NewBatchFrom(ps)
+	masses := b.CombMasses(nil, 3)
+	if got, want := len(masses), 10; got != want {
+		t.Fatalf("invalid number of masses: got=%d, want=%d", got, want)
+	}
+	sum := fmom.Add(fmom.Add(ps[0], ps[1]), ps[2])
+	if got, want := masses[0], sum.M(); !scalar.EqualWithinAbs(got, want, 1e-9) {
+		t.Fatalf("invalid mass: got=%v, want=%v", got, want)
+	}
+}
+
+func TestBatchFromTree(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "batch.root")
+
+	type Event struct {
+		N   int32
+		Pt  []float32
+		Eta []float32
+		Phi []float32
+		M   []float32
+	}
+	var (
+		evts = []Event{
+			{N: 2, Pt: []float32{50, 40}, Eta: []float32{0.5, -0.5}, Phi: []float32{0, math.Pi / 2}, M: []float32{5, 4}},
+			{N: 0},
+			{N: 3, Pt: []float32{30, 20, 10}, Eta: []float32{1, 0, -1}, Phi: []float32{1, 2, 3}, M: []float32{1, 2, 3}},
+		}
+	)
+
+	func() {
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var evt Event
+		w, err := rtree.NewWriter(f, "tree", []rtree.WriteVar{
+			{Name: "N", Value: &evt.N},
+			{Name: "Pt", Value: &evt.Pt, Count: "N"},
+			{Name: "Eta", Value: &evt.Eta, Count: "N"},
+			{Name: "Phi", Value: &evt.Phi, Count: "N"},
+			{Name: "M", Value: &evt.M, Count: "N"},
+		})
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		defer w.Close()
+
+		for _, v := range evts {
+			evt = v
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write event: %+v", err)
+			}
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree writer: %+v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	var evt Event
+	r, err := rtree.NewReader(obj.(rtree.Tree), rtree.ReadVarsFromStruct(&evt))
+	if err != nil {
+		t.Fatalf("could not create tree reader: %+v", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx rtree.RCtx) error {
+		b := fmom.NewBatchPtEtaPhiM(evt.Pt, evt.Eta, evt.Phi, evt.M)
+		want := evts[ctx.Entry]
+		if got, want := b.Len(), int(want.N); got != want {
+			t.Fatalf("entry %d: invalid batch length: got=%d, want=%d", ctx.Entry, got, want)
+		}
+		for i, pt := range b.Pt(nil) {
+			if !scalar.EqualWithinAbs(pt, float64(want.Pt[i]), 1e-4) {
+				t.Fatalf("entry %d: invalid pt[%d]: got=%v, want=%v", ctx.Entry, i, pt, want.Pt[i])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+}
+
+func BenchmarkInvMasses(b *testing.B) {
+	const n = 1000
+	var (
+		j1 = genP4s(n)
+		j2 = genP4s(n)
+	)
+	b.Run("P4", func(b *testing.B) {
+		for b.Loop() {
+			for i := range j1 {
+				_ = fmom.InvMass(j1[i], j2[i])
+			}
+		}
+	})
+	b.Run("Batch", func(b *testing.B) {
+		var (
+			b1  = fmom.NewBatchFrom(j1)
+			b2  = fmom.NewBatchFrom(j2)
+			dst = make([]float64, n)
+		)
+		for b.Loop() {
+			dst = fmom.InvMasses(dst, b1, b2)
+		}
+	})
+}
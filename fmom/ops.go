@@ -36,13 +36,15 @@ func cmpeq(x, y, epsilon float64) bool {
 
 // Add returns the sum p1+p2.
 func Add(p1, p2 P4) P4 {
-	// FIXME(sbinet):
-	// dispatch most efficient/less-lossy addition
-	// based on type(dst) (and, optionally, type(src))
 	var sum P4
 	switch p1 := p1.(type) {
 
 	case *PxPyPzE:
+		if p2, ok := p2.(*PxPyPzE); ok {
+			p := NewPxPyPzE(p1.P4.X+p2.P4.X, p1.P4.Y+p2.P4.Y, p1.P4.Z+p2.P4.Z, p1.P4.T+p2.P4.T)
+			sum = &p
+			break
+		}
 		p := NewPxPyPzE(p1.Px()+p2.Px(), p1.Py()+p2.Py(), p1.Pz()+p2.Pz(), p1.E()+p2.E())
 		sum = &p
 
@@ -78,9 +80,16 @@ func Add(p1, p2 P4) P4 {
 
 // IAdd adds src into dst, and returns dst
 func IAdd(dst, src P4) P4 {
-	// FIXME(sbinet):
-	// dispatch most efficient/less-lossy addition
-	// based on type(dst) (and, optionally, type(src))
+	if dst, ok := dst.(*PxPyPzE); ok {
+		if src, ok := src.(*PxPyPzE); ok {
+			dst.P4.X += src.P4.X
+			dst.P4.Y += src.P4.Y
+			dst.P4.Z += src.P4.Z
+			dst.P4.T += src.P4.T
+			return dst
+		}
+	}
+
 	var sum P4
 	var p4 *PxPyPzE = nil
 	switch p1 := dst.(type) {
@@ -133,7 +142,7 @@ func Scale(a float64, p P4) P4 {
 
 // InvMass computes the invariant mass of two incoming 4-vectors p1 and p2.
 func InvMass(p1, p2 P4) float64 {
-	p := Add(p1, p2)
+	p := NewPxPyPzE(p1.Px()+p2.Px(), p1.Py()+p2.Py(), p1.Pz()+p2.Pz(), p1.E()+p2.E())
 	return p.M()
 }
 
@@ -192,6 +201,83 @@ func Boost(p P4, vec r3.Vec) P4 {
 	return o
 }
 
+// BoostToRest returns a copy of the provided four-vector p,
+// boosted to the rest frame of the provided four-vector frame.
+// It panics if frame isn't a timelike four-vector.
+func BoostToRest(p, frame P4) P4 {
+	return Boost(p, r3.Scale(-1, BoostOf(frame)))
+}
+
+// RotateX returns a copy of the provided four-vector,
+// rotated by the angle (in radians) around the x axis.
+func RotateX(p P4, angle float64) P4 {
+	sin, cos := math.Sincos(angle)
+	py := p.Py()
+	pz := p.Pz()
+	return setP3(p, p.Px(), cos*py-sin*pz, sin*py+cos*pz)
+}
+
+// RotateY returns a copy of the provided four-vector,
+// rotated by the angle (in radians) around the y axis.
+func RotateY(p P4, angle float64) P4 {
+	sin, cos := math.Sincos(angle)
+	px := p.Px()
+	pz := p.Pz()
+	return setP3(p, cos*px+sin*pz, p.Py(), -sin*px+cos*pz)
+}
+
+// RotateZ returns a copy of the provided four-vector,
+// rotated by the angle (in radians) around the z axis.
+func RotateZ(p P4, angle float64) P4 {
+	switch p := p.(type) {
+	case *PtEtaPhiM:
+		o := *p
+		o.P4.Z = phiRange(p.P4.Z + angle)
+		return &o
+	case *EtEtaPhiM:
+		o := *p
+		o.P4.Z = phiRange(p.P4.Z + angle)
+		return &o
+	case *EEtaPhiM:
+		o := *p
+		o.P4.Z = phiRange(p.P4.Z + angle)
+		return &o
+	case *IPtCotThPhiM:
+		o := *p
+		o.P4.Z = phiRange(p.P4.Z + angle)
+		return &o
+	}
+
+	sin, cos := math.Sincos(angle)
+	px := p.Px()
+	py := p.Py()
+	return setP3(p, cos*px-sin*py, sin*px+cos*py, p.Pz())
+}
+
+// Rotate returns a copy of the provided four-vector,
+// rotated by the angle (in radians) around the provided axis.
+// It panics if the axis is the null vector.
+func Rotate(p P4, angle float64, axis r3.Vec) P4 {
+	if axis == (r3.Vec{}) {
+		panic("fmom: null rotation axis")
+	}
+	p3 := r3.Rotate(VecOf(p), angle, axis)
+	return setP3(p, p3.X, p3.Y, p3.Z)
+}
+
+// setP3 returns a copy of the provided four-vector with the provided
+// 3-momentum, keeping the energy unchanged.
+func setP3(p P4, px, py, pz float64) P4 {
+	if p, ok := p.(*PxPyPzE); ok {
+		o := NewPxPyPzE(px, py, pz, p.P4.T)
+		return &o
+	}
+	o := p.Clone()
+	pp := NewPxPyPzE(px, py, pz, p.E())
+	o.Set(&pp)
+	return o
+}
+
 // VecOf returns the 3-vector of fhe four-momentum
 func VecOf(p P4) r3.Vec {
 	return r3.Vec{
@@ -5,6 +5,7 @@
 package fmom
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -428,3 +429,99 @@ func TestVecOf(t *testing.T) {
 		}
 	}
 }
+
+func TestBoostToRest(t *testing.T) {
+	var (
+		parent = NewPxPyPzE(10, 20, 30, 50)
+		rest   = BoostToRest(&parent, &parent)
+		want   = NewPxPyPzE(0, 0, 0, parent.M())
+	)
+	if !p4equal(rest, &want, 1e-12) {
+		t.Fatalf("invalid rest frame four-vector:\ngot= %v\nwant=%v", rest, &want)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	const (
+		px, py, pz, e = 10.0, 20.0, 30.0, 50.0
+		angle         = 0.5 * math.Pi
+	)
+	p := NewPxPyPzE(px, py, pz, e)
+
+	for _, tc := range []struct {
+		name string
+		new  func(PxPyPzE) P4
+	}{
+		{"pxpypze", newPxPyPzE},
+		{"eetaphim", newEEtaPhiM},
+		{"etetaphim", newEtEtaPhiM},
+		{"ptetaphim", newPtEtaPhiM},
+		{"iptcotthphim", newIPtCotThPhiM},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, rot := range []struct {
+				name string
+				fct  func(P4) P4
+				want PxPyPzE
+			}{
+				{"x", func(p P4) P4 { return RotateX(p, angle) }, NewPxPyPzE(px, -pz, py, e)},
+				{"y", func(p P4) P4 { return RotateY(p, angle) }, NewPxPyPzE(pz, py, -px, e)},
+				{"z", func(p P4) P4 { return RotateZ(p, angle) }, NewPxPyPzE(-py, px, pz, e)},
+				{"axis-z", func(p P4) P4 { return Rotate(p, angle, r3.Vec{Z: 2}) }, NewPxPyPzE(-py, px, pz, e)},
+				{"axis", func(p P4) P4 { return Rotate(p, 2*math.Pi/3, r3.Vec{X: 1, Y: 1, Z: 1}) }, NewPxPyPzE(pz, px, py, e)},
+			} {
+				p1 := tc.new(p)
+				got := rot.fct(p1)
+				if !p4equal(got, &rot.want, 1e-9) {
+					t.Fatalf("%s: invalid rotation:\ngot= %v\nwant=%v", rot.name, got, &rot.want)
+				}
+				if got, want := reflect.TypeOf(got), reflect.TypeOf(p1); got != want {
+					t.Fatalf("%s: invalid type: got=%v, want=%v", rot.name, got, want)
+				}
+				if !deepEqual(p1, tc.new(p)) {
+					t.Fatalf("%s: rotation modified its input in-place", rot.name)
+				}
+				if got, want := got.M(), p1.M(); !scalar.EqualWithinAbs(got, want, 1e-9) {
+					t.Fatalf("%s: invalid mass: got=%v, want=%v", rot.name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRotateZPhiRange(t *testing.T) {
+	p := NewPxPyPzE(10, 0, 30, 50)
+	for _, tc := range []struct {
+		name string
+		p    P4
+	}{
+		{"eetaphim", newEEtaPhiM(p)},
+		{"etetaphim", newEtEtaPhiM(p)},
+		{"ptetaphim", newPtEtaPhiM(p)},
+		{"iptcotthphim", newIPtCotThPhiM(p)},
+	} {
+		for _, angle := range []float64{math.Pi, -math.Pi, 3 * math.Pi} {
+			got := RotateZ(tc.p, angle).Phi()
+			if got < -math.Pi || got >= math.Pi {
+				t.Fatalf("%s: rotation by %v: phi=%v out of [-pi, pi)", tc.name, angle, got)
+			}
+			if want := -math.Pi; !scalar.EqualWithinAbs(got, want, 1e-12) {
+				t.Fatalf("%s: rotation by %v: got phi=%v, want=%v", tc.name, angle, got, want)
+			}
+		}
+	}
+}
+
+func TestRotatePanics(t *testing.T) {
+	defer func() {
+		e := recover()
+		if e == nil {
+			t.Fatalf("expected a panic")
+		}
+		if got, want := e.(string), "fmom: null rotation axis"; got != want {
+			t.Fatalf("invalid panic message:\ngot= %v\nwant=%v", got, want)
+		}
+	}()
+	p := NewPxPyPzE(1, 2, 3, 4)
+	_ = Rotate(&p, 1, r3.Vec{})
+}
@@ -14,16 +14,57 @@ const (
 	twopi = 2 * math.Pi
 )
 
+// phiRange returns the angle phi, mapped into [-pi, pi).
+func phiRange(phi float64) float64 {
+	phi = math.Remainder(phi, twopi)
+	if phi >= math.Pi {
+		phi -= twopi
+	}
+	return phi
+}
+
 // DeltaPhi returns the delta Phi in range [-pi,pi[ from two P4
 func DeltaPhi(p1, p2 P4) float64 {
-	// FIXME: do something more efficient when p1&p2 are PxPyPzE
+	if p1, ok := p1.(*PxPyPzE); ok {
+		if p2, ok := p2.(*PxPyPzE); ok {
+			return deltaPhiPxPyPzE(p1, p2)
+		}
+	}
 	dphi := p1.Phi() - p2.Phi()
 	return -math.Remainder(dphi, twopi)
 }
 
+// deltaPhiPxPyPzE computes the delta Phi between two PxPyPzE directly from
+// their transverse components, without computing both azimuthal angles.
+func deltaPhiPxPyPzE(p1, p2 *PxPyPzE) float64 {
+	x1, y1 := p1.P4.X, p1.P4.Y
+	x2, y2 := p2.P4.X, p2.P4.Y
+	if (x1 == 0 && y1 == 0) || (x2 == 0 && y2 == 0) {
+		dphi := p1.Phi() - p2.Phi()
+		return -math.Remainder(dphi, twopi)
+	}
+	// flip if negative e, as in PxPyPzE.Phi
+	if (p1.P4.T < 0) != (p2.P4.T < 0) {
+		x2, y2 = -x2, -y2
+	}
+	dphi := math.Atan2(x1*y2-y1*x2, x1*x2+y1*y2)
+	if dphi == math.Pi {
+		dphi = -math.Pi
+	}
+	return dphi
+}
+
 // DeltaEta returns the delta Eta between two P4
 func DeltaEta(p1, p2 P4) float64 {
-	// FIXME: do something more efficient when p1&p2 are PxPyPzE
+	if p1, ok := p1.(*PxPyPzE); ok {
+		if p2, ok := p2.(*PxPyPzE); ok && p1.P4.T > 0 && p2.P4.T > 0 {
+			m1 := math.Sqrt(p1.P2())
+			m2 := math.Sqrt(p2.P2())
+			if deta, ok := deltaLog(m1+p1.P4.Z, m1-p1.P4.Z, m2+p2.P4.Z, m2-p2.P4.Z); ok {
+				return deta
+			}
+		}
+	}
 	return p1.Eta() - p2.Eta()
 }
 
@@ -34,6 +75,52 @@ func DeltaR(p1, p2 P4) float64 {
 	return math.Sqrt(deta*deta + dphi*dphi)
 }
 
+// DeltaRapidity returns the delta rapidity between two P4
+func DeltaRapidity(p1, p2 P4) float64 {
+	if p1, ok := p1.(*PxPyPzE); ok {
+		if p2, ok := p2.(*PxPyPzE); ok {
+			e1, z1 := p1.P4.T, p1.P4.Z
+			e2, z2 := p2.P4.T, p2.P4.Z
+			if dy, ok := deltaLog(e1+z1, e1-z1, e2+z2, e2-z2); ok {
+				return dy
+			}
+		}
+	}
+	return p1.Rapidity() - p2.Rapidity()
+}
+
+// deltaLog returns 0.5*log(a1/b1) - 0.5*log(a2/b2), computed with a single
+// logarithm.
+// deltaLog returns false if any of the factors isn't strictly positive.
+func deltaLog(a1, b1, a2, b2 float64) (float64, bool) {
+	if !(a1 > 0 && b1 > 0 && a2 > 0 && b2 > 0) {
+		return 0, false
+	}
+	return 0.5 * math.Log((a1*b2)/(b1*a2)), true
+}
+
+// DeltaRy returns the delta R between two P4, using the rapidity
+// instead of the pseudo-rapidity.
+func DeltaRy(p1, p2 P4) float64 {
+	dy := DeltaRapidity(p1, p2)
+	dphi := DeltaPhi(p1, p2)
+	return math.Sqrt(dy*dy + dphi*dphi)
+}
+
+// Mt returns the transverse mass sqrt(E^2-Pz^2) of a P4,
+// negative for space-like four-vectors.
+func Mt(p P4) float64 {
+	var (
+		e   = p.E()
+		pz  = p.Pz()
+		mt2 = e*e - pz*pz
+	)
+	if mt2 < 0 {
+		return -math.Sqrt(-mt2)
+	}
+	return +math.Sqrt(+mt2)
+}
+
 // Dot returns the dot product p1·p2.
 func Dot(p1, p2 P4) float64 {
 	return p1.E()*p2.E() - p1.Px()*p2.Px() - p1.Py()*p2.Py() - p1.Pz()*p2.Pz()
@@ -47,3 +134,52 @@ func CosTheta(p1, p2 P4) float64 {
 	cosTh := dot / math.Sqrt(mag1*mag2)
 	return cosTh
 }
+
+// CosThetaHelicity returns the cosine of the helicity angle of the
+// four-vector p, decay product of the four-vector parent.
+// The helicity angle is the angle between the momentum of p, in the rest
+// frame of parent, and the direction of flight of parent.
+// It panics if parent isn't a timelike four-vector.
+func CosThetaHelicity(p, parent P4) float64 {
+	rest := BoostToRest(p, parent)
+	return CosTheta(rest, parent)
+}
+
+// CollinsSoper returns the cosine of the polar angle and the azimuthal
+// angle, in the Collins-Soper frame, of the four-vector p1 decaying,
+// together with the four-vector p2, from a parent produced in a
+// hadron-hadron collision along the z axis.
+//
+// By convention, p1 is the negatively charged lepton of a Drell-Yan pair.
+// The z axis of the Collins-Soper frame is the bisector of the angle
+// between the momentum of one beam and the opposite of the momentum of the
+// other beam, in the rest frame of the parent.
+// The z axis is oriented along the direction of flight of the parent.
+// It panics if p1+p2 isn't a timelike four-vector.
+func CollinsSoper(p1, p2 P4) (cosTheta, phi float64) {
+	var (
+		q    = NewPxPyPzE(p1.Px()+p2.Px(), p1.Py()+p2.Py(), p1.Pz()+p2.Pz(), p1.E()+p2.E())
+		beam = [2]PxPyPzE{NewPxPyPzE(0, 0, +1, 1), NewPxPyPzE(0, 0, -1, 1)}
+	)
+	if q.Pz() < 0 {
+		beam[0], beam[1] = beam[1], beam[0]
+	}
+
+	var (
+		l  = VecOf(BoostToRest(p1, &q))
+		b1 = r3.Unit(VecOf(BoostToRest(&beam[0], &q)))
+		b2 = r3.Unit(VecOf(BoostToRest(&beam[1], &q)))
+		z  = r3.Unit(r3.Sub(b1, b2))
+		y  = r3.Cross(b1, b2)
+	)
+	if r3.Norm2(y) == 0 {
+		// no transverse momentum: the x axis is the lab x axis.
+		y = r3.Cross(z, r3.Vec{X: 1})
+	}
+	y = r3.Unit(y)
+	x := r3.Cross(y, z)
+
+	cosTheta = r3.Dot(l, z) / r3.Norm(l)
+	phi = math.Atan2(r3.Dot(l, y), r3.Dot(l, x))
+	return cosTheta, phi
+}
@@ -6,7 +6,11 @@ package fmom
 
 import (
 	"math"
+	"math/rand/v2"
 	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/spatial/r3"
 )
 
 const (
@@ -423,3 +427,178 @@ func TestCosTheta(t *testing.T) {
 		}
 	}
 }
+
+func TestMt(t *testing.T) {
+	for _, tc := range []struct {
+		p    PxPyPzE
+		want float64
+	}{
+		{NewPxPyPzE(30, 40, 120, 150), math.Sqrt(150*150 - 120*120)},
+		{NewPxPyPzE(30, 40, -120, 150), math.Sqrt(150*150 - 120*120)},
+		{NewPxPyPzE(3, 4, 0, 5), 5},
+		{NewPxPyPzE(3, 4, 12, 13), 5},
+	} {
+		for _, p := range []struct {
+			name string
+			p    P4
+		}{
+			{"pxpypze", newPxPyPzE(tc.p)},
+			{"eetaphim", newEEtaPhiM(tc.p)},
+			{"etetaphim", newEtEtaPhiM(tc.p)},
+			{"ptetaphim", newPtEtaPhiM(tc.p)},
+			{"iptcotthphim", newIPtCotThPhiM(tc.p)},
+		} {
+			if got, want := Mt(p.p), tc.want; !scalar.EqualWithinAbs(got, want, 1e-9) {
+				t.Fatalf("%s(%v): invalid transverse mass: got=%v, want=%v", p.name, &tc.p, got, want)
+			}
+		}
+	}
+
+	// space-like four-vectors have a negative transverse mass.
+	p := NewPxPyPzE(3, 4, 12, 10)
+	if got, want := Mt(&p), -math.Sqrt(12*12-10*10); !scalar.EqualWithinAbs(got, want, 1e-9) {
+		t.Fatalf("invalid transverse mass: got=%v, want=%v", got, want)
+	}
+}
+
+func TestDeltaRy(t *testing.T) {
+	var (
+		p1 = NewPxPyPzE(10, 0, 10, 20)
+		p2 = NewPxPyPzE(0, 10, -10, 20)
+		y1 = 0.5 * math.Log(30.0/10)
+		y2 = 0.5 * math.Log(10.0/30)
+	)
+	for _, tc := range []struct {
+		name   string
+		p1, p2 P4
+	}{
+		{"pxpypze", newPxPyPzE(p1), newPxPyPzE(p2)},
+		{"eetaphim", newEEtaPhiM(p1), newEEtaPhiM(p2)},
+		{"etetaphim", newEtEtaPhiM(p1), newPxPyPzE(p2)},
+		{"ptetaphim", newPtEtaPhiM(p1), newPtEtaPhiM(p2)},
+		{"iptcotthphim", newIPtCotThPhiM(p1), newIPtCotThPhiM(p2)},
+	} {
+		if got, want := DeltaRapidity(tc.p1, tc.p2), y1-y2; !scalar.EqualWithinAbs(got, want, 1e-9) {
+			t.Fatalf("%s: invalid delta rapidity: got=%v, want=%v", tc.name, got, want)
+		}
+		if got, want := DeltaRy(tc.p1, tc.p2), math.Hypot(y1-y2, 0.5*math.Pi); !scalar.EqualWithinAbs(got, want, 1e-9) {
+			t.Fatalf("%s: invalid delta R: got=%v, want=%v", tc.name, got, want)
+		}
+	}
+}
+
+func TestDeltaPxPyPzE(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	gen := func() PxPyPzE {
+		px := 100 * (2*rnd.Float64() - 1)
+		py := 100 * (2*rnd.Float64() - 1)
+		pz := 100 * (2*rnd.Float64() - 1)
+		m := 10 * rnd.Float64()
+		e := math.Sqrt(px*px + py*py + pz*pz + m*m)
+		if rnd.IntN(10) == 0 {
+			e = -e
+		}
+		return NewPxPyPzE(px, py, pz, e)
+	}
+
+	for i := range 1000 {
+		var (
+			p1 = gen()
+			p2 = gen()
+			q1 = newEEtaPhiM(p1)
+			q2 = newEEtaPhiM(p2)
+		)
+		for _, tc := range []struct {
+			name string
+			fct  func(p1, p2 P4) float64
+		}{
+			{"DeltaPhi", DeltaPhi},
+			{"DeltaEta", DeltaEta},
+			{"DeltaRapidity", DeltaRapidity},
+			{"DeltaR", DeltaR},
+		} {
+			got := tc.fct(&p1, &p2)
+			want := tc.fct(q1, q2)
+			if !scalar.EqualWithinAbs(got, want, 1e-9) {
+				t.Fatalf("#%d: %s: p1=%v, p2=%v: got=%v, want=%v", i, tc.name, p1, p2, got, want)
+			}
+		}
+	}
+}
+
+func TestCosThetaHelicity(t *testing.T) {
+	const (
+		m     = 90.0
+		costh = 0.6
+	)
+	var (
+		sinth = math.Sqrt(1 - costh*costh)
+		l1    = NewPxPyPzE(0.5*m*sinth, 0, 0.5*m*costh, 0.5*m)
+		l2    = NewPxPyPzE(-0.5*m*sinth, 0, -0.5*m*costh, 0.5*m)
+		boost = r3.Vec{X: 0.3, Y: 0.4, Z: 0.5}
+		dir   = r3.Unit(boost)
+	)
+
+	// rotate the decay so that the helicity axis is the direction of
+	// flight of the parent.
+	axis := r3.Cross(r3.Vec{Z: 1}, dir)
+	angle := math.Acos(dir.Z)
+	p1 := Boost(Rotate(&l1, angle, axis), boost)
+	p2 := Boost(Rotate(&l2, angle, axis), boost)
+	parent := Add(p1, p2)
+
+	if got, want := CosThetaHelicity(p1, parent), costh; !scalar.EqualWithinAbs(got, want, 1e-9) {
+		t.Fatalf("invalid helicity angle: got=%v, want=%v", got, want)
+	}
+	if got, want := CosThetaHelicity(p2, parent), -costh; !scalar.EqualWithinAbs(got, want, 1e-9) {
+		t.Fatalf("invalid helicity angle: got=%v, want=%v", got, want)
+	}
+}
+
+func TestCollinsSoper(t *testing.T) {
+	const (
+		m     = 90.0
+		costh = 0.6
+		phi   = 0.7
+	)
+	var (
+		sinth    = math.Sqrt(1 - costh*costh)
+		sin, cos = math.Sincos(phi)
+		l1       = NewPxPyPzE(0.5*m*sinth*cos, 0.5*m*sinth*sin, 0.5*m*costh, 0.5*m)
+		l2       = NewPxPyPzE(-l1.Px(), -l1.Py(), -l1.Pz(), l1.E())
+	)
+
+	// without transverse momentum, the Collins-Soper frame is the rest
+	// frame obtained from a longitudinal boost.
+	for _, tc := range []struct {
+		boost    float64
+		cos, phi float64
+	}{
+		{boost: 0, cos: costh, phi: phi},
+		{boost: +0.8, cos: costh, phi: phi},
+		{boost: -0.8, cos: -costh, phi: -phi},
+	} {
+		p1 := Boost(&l1, r3.Vec{Z: tc.boost})
+		p2 := Boost(&l2, r3.Vec{Z: tc.boost})
+		cs, phics := CollinsSoper(p1, p2)
+		if !scalar.EqualWithinAbs(cs, tc.cos, 1e-9) || !scalar.EqualWithinAbs(phics, tc.phi, 1e-9) {
+			t.Fatalf("boost=%v: invalid angles: got=(%v, %v), want=(%v, %v)", tc.boost, cs, phics, tc.cos, tc.phi)
+		}
+	}
+
+	// with transverse momentum, compare with the usual formula in terms of
+	// light-cone components.
+	p1 := Boost(&l1, r3.Vec{X: 0.4, Y: -0.2, Z: 0.6})
+	p2 := Boost(&l2, r3.Vec{X: 0.4, Y: -0.2, Z: 0.6})
+	q := Add(p1, p2)
+	var (
+		lp = func(p P4) float64 { return (p.E() + p.Pz()) / math.Sqrt2 }
+		lm = func(p P4) float64 { return (p.E() - p.Pz()) / math.Sqrt2 }
+		qt = q.Pt()
+		mq = q.M()
+	)
+	want := 2 * (lp(p1)*lm(p2) - lm(p1)*lp(p2)) / (mq * math.Sqrt(mq*mq+qt*qt))
+	if got, _ := CollinsSoper(p1, p2); !scalar.EqualWithinAbs(got, want, 1e-9) {
+		t.Fatalf("invalid Collins-Soper angle: got=%v, want=%v", got, want)
+	}
+}
@@ -13,13 +13,15 @@ import (
 	"runtime"
 	"slices"
 	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go-hep.org/x/hep/fwk/fsm"
 )
 
 type appmgr struct {
-	state fsm.State
+	state fsmstate
 	name  string
 
 	props map[string]map[string]any
@@ -27,14 +29,46 @@ type appmgr struct {
 	store *datastore
 	msg   msgstream
 
-	evtmax int64
-	nprocs int
+	evtmax  int64
+	evtskip int64
+	nprocs  int
+
+	ckptfile string
+	ckptfreq int64
+	ckpt     *checkpoint
+	commit   sync.RWMutex // held for writing while committing outputs and saving a checkpoint
 
 	comps   map[string]Component
 	tsks    []Task
 	svcs    []Svc
 	istream Task
 	ctxs    [2][]ctxType
+	stats   evtstats
+	prof    *ProfSvc
+	mons    []Monitor
+	tap     *msgtap
+}
+
+// fsmstate holds the FSM state of an application, so it can be
+// inspected concurrently (eg: by monitoring services).
+type fsmstate struct {
+	v atomic.Int64
+}
+
+func (st *fsmstate) get() fsm.State  { return fsm.State(st.v.Load()) }
+func (st *fsmstate) set(s fsm.State) { st.v.Store(int64(s)) }
+
+// evtstats counts the processed and accepted events.
+type evtstats struct {
+	nevts atomic.Int64
+	nacc  atomic.Int64
+}
+
+func (stats *evtstats) add(accepted bool) {
+	stats.nevts.Add(1)
+	if accepted {
+		stats.nacc.Add(1)
+	}
 }
 
 // NewApp creates a (default) fwk application with (default and) sensible options.
@@ -46,7 +80,6 @@ func NewApp() App {
 	const appname = "app"
 
 	app = &appmgr{
-		state: fsm.Undefined,
 		name:  appname,
 		props: make(map[string]map[string]any),
 		dflow: nil,
@@ -58,12 +91,16 @@ func NewApp() App {
 			//LvlError,
 			nil,
 		),
-		evtmax: -1,
-		nprocs: -1,
-		comps:  make(map[string]Component),
-		tsks:   make([]Task, 0),
-		svcs:   make([]Svc, 0),
+		tap:      &msgtap{},
+		evtmax:   -1,
+		evtskip:  0,
+		nprocs:   -1,
+		ckptfreq: 100,
+		comps:    make(map[string]Component),
+		tsks:     make([]Task, 0),
+		svcs:     make([]Svc, 0),
 	}
+	app.msg.tap = app.tap
 
 	svc, err := app.New("go-hep.org/x/hep/fwk.datastore", "evtstore")
 	if err != nil {
@@ -97,6 +134,24 @@ func NewApp() App {
 		return nil
 	}
 
+	err = app.DeclProp(app, "EvtSkip", &app.evtskip)
+	if err != nil {
+		app.msg.Errorf("fwk.NewApp: could not declare property 'EvtSkip': %w\n", err)
+		return nil
+	}
+
+	err = app.DeclProp(app, "Checkpoint", &app.ckptfile)
+	if err != nil {
+		app.msg.Errorf("fwk.NewApp: could not declare property 'Checkpoint': %w\n", err)
+		return nil
+	}
+
+	err = app.DeclProp(app, "CheckpointFreq", &app.ckptfreq)
+	if err != nil {
+		app.msg.Errorf("fwk.NewApp: could not declare property 'CheckpointFreq': %w\n", err)
+		return nil
+	}
+
 	err = app.DeclProp(app, "NProcs", &app.nprocs)
 	if err != nil {
 		app.msg.Errorf("fwk.NewApp: could not declare property 'NProcs': %w\n", err)
@@ -303,6 +358,19 @@ func (app *appmgr) GetProp(c Component, name string) (any, error) {
 	return v, nil
 }
 
+// PropType returns the type of the property name of the component c.
+func (app *appmgr) PropType(c Component, name string) (reflect.Type, error) {
+	ptr, ok := app.props[c.Name()][name]
+	if !ok {
+		return nil, fmt.Errorf(
+			"fwk.PropType: component [%s] didn't declare any property with name [%s]",
+			c.Name(),
+			name,
+		)
+	}
+	return reflect.TypeOf(ptr).Elem(), nil
+}
+
 func (app *appmgr) HasProp(c Component, name string) bool {
 	cname := c.Name()
 	_, ok := app.props[cname]
@@ -314,10 +382,10 @@ func (app *appmgr) HasProp(c Component, name string) bool {
 }
 
 func (app *appmgr) DeclInPort(c Component, name string, t reflect.Type) error {
-	if app.state < fsm.Configuring {
+	if app.state.get() < fsm.Configuring {
 		return fmt.Errorf(
 			"fwk.DeclInPort: invalid App state (%s). put the DeclInPort in Configure() of %s:%s",
-			app.state,
+			app.state.get(),
 			c.Type(),
 			c.Name(),
 		)
@@ -326,10 +394,10 @@ func (app *appmgr) DeclInPort(c Component, name string, t reflect.Type) error {
 }
 
 func (app *appmgr) DeclOutPort(c Component, name string, t reflect.Type) error {
-	if app.state < fsm.Configuring {
+	if app.state.get() < fsm.Configuring {
 		return fmt.Errorf(
 			"fwk.DeclOutPort: invalid App state (%s). put the DeclInPort in Configure() of %s:%s",
-			app.state,
+			app.state.get(),
 			c.Type(),
 			c.Name(),
 		)
@@ -338,7 +406,7 @@ func (app *appmgr) DeclOutPort(c Component, name string, t reflect.Type) error {
 }
 
 func (app *appmgr) FSMState() fsm.State {
-	return app.state
+	return app.state.get()
 }
 
 func (app *appmgr) Run() error {
@@ -347,7 +415,7 @@ func (app *appmgr) Run() error {
 		id:    0,
 		slot:  0,
 		store: nil,
-		msg:   newMsgStream("<root>", app.msg.lvl, nil),
+		msg:   app.newMsgStream("<root>"),
 		mgr:   app,
 	}
 
@@ -355,35 +423,35 @@ func (app *appmgr) Run() error {
 	var mstart runtime.MemStats
 	runtime.ReadMemStats(&mstart)
 
-	if app.state == fsm.Undefined {
+	if app.state.get() == fsm.Undefined {
 		err = app.configure(ctx)
 		if err != nil {
 			return err
 		}
 	}
 
-	if app.state == fsm.Configured {
+	if app.state.get() == fsm.Configured {
 		err = app.start(ctx)
 		if err != nil {
 			return err
 		}
 	}
 
-	if app.state == fsm.Started {
+	if app.state.get() == fsm.Started {
 		err = app.run(ctx)
 		if err != nil && err != io.EOF {
 			return err
 		}
 	}
 
-	if app.state == fsm.Running {
+	if app.state.get() == fsm.Running {
 		err = app.stop(ctx)
 		if err != nil {
 			return err
 		}
 	}
 
-	if app.state == fsm.Stopped {
+	if app.state.get() == fsm.Stopped {
 		err = app.shutdown(ctx)
 		if err != nil {
 			return err
@@ -418,7 +486,22 @@ func (app *appmgr) configure(ctx Context) error {
 	var err error
 	defer app.msg.flush()
 	app.msg.Debugf("configure...\n")
-	app.state = fsm.Configuring
+	app.state.set(fsm.Configuring)
+
+	if app.evtskip < 0 {
+		return fmt.Errorf("fwk: invalid number of events to skip (%d)", app.evtskip)
+	}
+
+	app.ckpt = nil
+	if app.ckptfile != "" {
+		app.ckpt, err = openCheckpoint(app.ckptfile, app.ckptfreq, app.evtskip, app.evtmax)
+		if err != nil {
+			return err
+		}
+		if n := app.ckpt.len(); n > 0 {
+			app.msg.Infof("resuming from checkpoint %q (%d events already processed)\n", app.ckptfile, n)
+		}
+	}
 
 	if app.evtmax == -1 {
 		app.evtmax = math.MaxInt64
@@ -434,7 +517,7 @@ func (app *appmgr) configure(ctx Context) error {
 			id:    -1,
 			slot:  0,
 			store: app.store,
-			msg:   newMsgStream(tsk.Name(), app.msg.lvl, nil),
+			msg:   app.newMsgStream(tsk.Name()),
 			mgr:   app,
 		}
 	}
@@ -445,7 +528,7 @@ func (app *appmgr) configure(ctx Context) error {
 			id:    -1,
 			slot:  0,
 			store: app.store,
-			msg:   newMsgStream(svc.Name(), app.msg.lvl, nil),
+			msg:   app.newMsgStream(svc.Name()),
 			mgr:   app,
 		}
 	}
@@ -474,6 +557,26 @@ func (app *appmgr) configure(ctx Context) error {
 		}
 	}
 
+	tsks, err = app.setupFilterChains(tsks)
+	if err != nil {
+		return err
+	}
+
+	if app.ckpt != nil {
+		for _, tsk := range app.tsks {
+			out, ok := tsk.(*OutputStream)
+			if !ok {
+				continue
+			}
+			if _, ok := out.streamer.(OutputCommitter); !ok {
+				return fmt.Errorf(
+					"fwk: output stream [%s] can not be checkpointed: its streamer (%T) does not implement fwk.OutputCommitter",
+					out.Name(), out.streamer,
+				)
+			}
+		}
+	}
+
 	err = app.printDataFlow()
 	if err != nil {
 		return err
@@ -481,7 +584,7 @@ func (app *appmgr) configure(ctx Context) error {
 
 	app.ctxs[0] = tsks
 	app.ctxs[1] = svcs
-	app.state = fsm.Configured
+	app.state.set(fsm.Configured)
 	app.msg.Debugf("configure... [done]\n")
 	return err
 }
@@ -489,7 +592,7 @@ func (app *appmgr) configure(ctx Context) error {
 func (app *appmgr) start(ctx Context) error {
 	var err error
 	defer app.msg.flush()
-	app.state = fsm.Starting
+	app.state.set(fsm.Starting)
 	for i, svc := range app.svcs {
 		app.msg.Debugf("starting [%s]...\n", svc.Name())
 		err = svc.StartSvc(app.ctxs[1][i])
@@ -498,6 +601,14 @@ func (app *appmgr) start(ctx Context) error {
 		}
 	}
 
+	app.mons = nil
+	for _, svc := range app.svcs {
+		if mon, ok := svc.(Monitor); ok {
+			app.mons = append(app.mons, mon)
+		}
+	}
+	app.tap.set(app.mons)
+
 	for i, tsk := range app.tsks {
 		app.msg.Debugf("starting [%s]...\n", tsk.Name())
 		err = tsk.StartTask(app.ctxs[0][i])
@@ -506,17 +617,28 @@ func (app *appmgr) start(ctx Context) error {
 		}
 	}
 
-	app.state = fsm.Started
+	app.state.set(fsm.Started)
 	return err
 }
 
 func (app *appmgr) run(ctx Context) error {
 	var err error
 	defer app.msg.flush()
-	app.state = fsm.Running
+	app.state.set(fsm.Running)
 
 	maxprocs := runtime.GOMAXPROCS(app.nprocs)
 
+	app.prof = nil
+	for _, svc := range app.svcs {
+		if prof, ok := svc.(*ProfSvc); ok {
+			app.prof = prof
+			break
+		}
+	}
+	if app.prof != nil {
+		app.prof.begin(app.nprocs, len(app.dflow.keys()))
+	}
+
 	switch app.nprocs {
 	case 0:
 		err = app.runSequential(ctx)
@@ -526,9 +648,102 @@ func (app *appmgr) run(ctx Context) error {
 
 	runtime.GOMAXPROCS(maxprocs)
 
+	if app.ckpt != nil {
+		// save the events processed so far, even if the event loop failed.
+		e := app.checkpoint()
+		if e != nil && (err == nil || err == io.EOF) {
+			err = e
+		}
+	}
+
+	if app.prof != nil {
+		app.prof.end()
+	}
+
+	if nevts, nacc := app.stats.nevts.Load(), app.stats.nacc.Load(); nacc != nevts {
+		app.msg.Infof("events: processed=%d, accepted=%d\n", nevts, nacc)
+	}
+
 	return err
 }
 
+// newMsgStream creates a message stream for the component name,
+// forwarding its messages to the monitors of the application.
+func (app *appmgr) newMsgStream(name string) msgstream {
+	msg := newMsgStream(name, app.msg.lvl, nil)
+	msg.tap = app.tap
+	return msg
+}
+
+// evtend returns the ID one past the last event to process.
+func (app *appmgr) evtend() int64 {
+	if app.evtmax > math.MaxInt64-app.evtskip {
+		return math.MaxInt64
+	}
+	return app.evtskip + app.evtmax
+}
+
+// skipped returns whether the event ievt should be read from the input
+// stream but not processed, either because it is before the requested
+// range of events or because it was processed by a previous run.
+func (app *appmgr) skipped(ievt int64) bool {
+	if ievt < app.evtskip {
+		return true
+	}
+	return app.ckpt != nil && app.ckpt.has(ievt)
+}
+
+// process processes the event ievt with run, which reports whether the
+// event was accepted, and records its processing.
+//
+// Events are processed and recorded while holding app.commit for reading,
+// so a checkpoint only ever sees events that were fully written out and
+// recorded.
+func (app *appmgr) process(ievt int64, run func() (bool, error)) error {
+	app.commit.RLock()
+	accepted, err := run()
+	save := false
+	if err == nil {
+		save = app.record(ievt, accepted)
+	}
+	app.commit.RUnlock()
+
+	if err != nil || !save {
+		return err
+	}
+	return app.checkpoint()
+}
+
+// record records the processing of the event ievt, and reports whether
+// a checkpoint should be saved.
+func (app *appmgr) record(ievt int64, accepted bool) bool {
+	app.stats.add(accepted)
+	for _, mon := range app.mons {
+		mon.EventDone(ievt, accepted)
+	}
+	return app.ckpt != nil && app.ckpt.add(ievt)
+}
+
+// checkpoint commits the output streams and then saves the checkpoint file,
+// while no event is being processed.
+func (app *appmgr) checkpoint() error {
+	app.commit.Lock()
+	defer app.commit.Unlock()
+
+	for _, tsk := range app.tsks {
+		out, ok := tsk.(*OutputStream)
+		if !ok {
+			continue
+		}
+		err := out.commit()
+		if err != nil {
+			return fmt.Errorf("fwk: could not commit output stream [%s]: %w", out.Name(), err)
+		}
+	}
+
+	return app.ckpt.flush()
+}
+
 func (app *appmgr) runSequential(ctx Context) error {
 	var err error
 
@@ -543,7 +758,7 @@ func (app *appmgr) runSequential(ctx Context) error {
 			id:    -1,
 			slot:  0,
 			store: &store,
-			msg:   newMsgStream(tsk.Name(), app.msg.lvl, nil),
+			msg:   app.newMsgStream(tsk.Name()),
 			mgr:   app,
 		}
 	}
@@ -561,10 +776,16 @@ func (app *appmgr) runSequential(ctx Context) error {
 
 	defer close(octrl.Quit)
 
-	for ievt := int64(0); ievt < app.evtmax; ievt++ {
+	outs := app.outPorts(app.tsks)
+	obs := observers{prof: app.prof, mons: app.mons}
+
+	for ievt, end := int64(0), app.evtend(); ievt < end; ievt++ {
 		evtctx, evtCancel := context.WithCancel(runctx)
 
-		app.msg.Infof(">>> running evt=%d...\n", ievt)
+		skip := app.skipped(ievt)
+		if !skip {
+			app.msg.Infof(">>> running evt=%d...\n", ievt)
+		}
 		err = store.reset(keys)
 		if err != nil {
 			evtCancel()
@@ -577,27 +798,19 @@ func (app *appmgr) runSequential(ctx Context) error {
 			app.msg.flush()
 			return err
 		}
-		run := taskrunner{
-			ievt:   ievt,
-			errc:   make(chan error, len(app.tsks)),
-			evtctx: evtctx,
-		}
-		for i, tsk := range app.tsks {
-			go run.run(i, ctxs[i], tsk)
+		if skip {
+			evtCancel()
+			store.close()
+			continue
 		}
-		ndone := 0
-	errloop:
-		for err = range run.errc {
-			ndone++
-			if err != nil {
-				evtCancel()
-				store.close()
-				app.msg.flush()
-				return err
-			}
-			if ndone == len(app.tsks) {
-				break errloop
-			}
+		err = app.process(ievt, func() (bool, error) {
+			return runEvent(evtctx, ievt, ctxs, app.tsks, outs, obs)
+		})
+		if err != nil {
+			evtCancel()
+			store.close()
+			app.msg.flush()
+			return err
 		}
 		evtCancel()
 		store.close()
@@ -639,8 +852,8 @@ func (app *appmgr) runConcurrent(ctx Context) error {
 
 	go func() {
 		keys := app.dflow.keys()
-		msg := newMsgStream(app.istream.Name(), app.msg.lvl, nil)
-		for ievt := int64(0); ievt < app.evtmax; ievt++ {
+		msg := app.newMsgStream(app.istream.Name())
+		for ievt, end := int64(0), app.evtend(); ievt < end; ievt++ {
 			evtctx, evtCancel := context.WithCancel(runctx)
 			store := *app.store
 			store.store = make(map[string]achan, len(keys))
@@ -669,6 +882,11 @@ func (app *appmgr) runConcurrent(ctx Context) error {
 				evtCancel()
 				return
 			}
+			if app.skipped(ievt) {
+				store.close()
+				evtCancel()
+				continue
+			}
 			ctrl.evts <- ctx
 			evtCancel()
 		}
@@ -756,12 +974,13 @@ func (app *appmgr) startOutputStreams() (StreamControl, error) {
 	}
 
 	// start output streams
+	resume := app.ckpt != nil && app.ckpt.len() > 0
 	for _, tsk := range app.tsks {
 		in, ok := tsk.(*OutputStream)
 		if !ok {
 			continue
 		}
-		err = in.connect(ctrl)
+		err = in.connect(ctrl, resume)
 		if err != nil {
 			return ctrl, err
 		}
@@ -773,7 +992,7 @@ func (app *appmgr) startOutputStreams() (StreamControl, error) {
 func (app *appmgr) stop(ctx Context) error {
 	var err error
 	defer app.msg.flush()
-	app.state = fsm.Stopping
+	app.state.set(fsm.Stopping)
 
 	if app.istream != nil {
 		err = app.istream.StopTask(ctx)
@@ -796,17 +1015,19 @@ func (app *appmgr) stop(ctx Context) error {
 		}
 	}
 
-	app.state = fsm.Stopped
+	app.state.set(fsm.Stopped)
 	return err
 }
 
 func (app *appmgr) shutdown(ctx Context) error {
 	var err error
 	defer app.msg.flush()
+	app.tap.set(nil)
+	app.mons = nil
 	app.comps = nil
 	app.tsks = nil
 	app.svcs = nil
-	app.state = fsm.Offline
+	app.state.set(fsm.Offline)
 
 	app.props = nil
 	app.dflow = nil
@@ -815,6 +1036,132 @@ func (app *appmgr) shutdown(ctx Context) error {
 	return err
 }
 
+// setupFilterChains hands the tasks of filter chains over to their chain,
+// and removes them from the list of tasks scheduled by the application.
+// setupFilterChains returns the contexts of the remaining tasks.
+func (app *appmgr) setupFilterChains(ctxs []ctxType) ([]ctxType, error) {
+	var (
+		chains = make(map[string]*FilterChain)
+		owner  = make(map[string]string) // task name -> chain name
+		index  = make(map[string]int, len(app.tsks))
+	)
+	for i, tsk := range app.tsks {
+		index[tsk.Name()] = i
+		if chain, ok := tsk.(*FilterChain); ok {
+			chains[chain.Name()] = chain
+		}
+	}
+	if len(chains) == 0 {
+		return ctxs, nil
+	}
+
+	for _, tsk := range app.tsks {
+		chain, ok := tsk.(*FilterChain)
+		if !ok {
+			continue
+		}
+		chain.tsks = make([]Task, 0, len(chain.names))
+		chain.ctxs = make([]ctxType, 0, len(chain.names))
+		for _, name := range chain.names {
+			i, ok := index[name]
+			if !ok {
+				return nil, fmt.Errorf("fwk: filter chain [%s] has no such task [%s]", chain.Name(), name)
+			}
+			if prev, dup := owner[name]; dup {
+				return nil, fmt.Errorf("fwk: task [%s] belongs to filter chains [%s] and [%s]", name, prev, chain.Name())
+			}
+			sub := app.tsks[i]
+			switch sub.(type) {
+			case *InputStream, *OutputStream:
+				return nil, fmt.Errorf("fwk: filter chain [%s] can not hold stream [%s]", chain.Name(), name)
+			}
+			owner[name] = chain.Name()
+			chain.tsks = append(chain.tsks, sub)
+			chain.ctxs = append(chain.ctxs, ctxs[i])
+		}
+	}
+
+	// detect chains owning themselves, directly or not.
+	for name := range chains {
+		cur, ok := owner[name]
+		for n := 0; ok && n < len(chains); n++ {
+			if cur == name {
+				return nil, fmt.Errorf("fwk: filter chain [%s] contains itself", name)
+			}
+			cur, ok = owner[cur]
+		}
+	}
+
+	// make sure the tasks of a chain do not depend on the outputs of the
+	// tasks run after them.
+	for _, chain := range chains {
+		chain.outs = app.outPorts(chain.tsks)
+		for i, sub := range chain.tsks {
+			in, _ := app.ports(sub)
+			for _, next := range chain.tsks[i+1:] {
+				_, out := app.ports(next)
+				for _, port := range in {
+					if slices.Contains(out, port) {
+						return nil, fmt.Errorf(
+							"fwk: filter chain [%s] runs task [%s] before task [%s] producing its input [%s]",
+							chain.Name(), sub.Name(), next.Name(), port,
+						)
+					}
+				}
+			}
+		}
+	}
+
+	var (
+		tsks = make([]Task, 0, len(app.tsks))
+		octx = make([]ctxType, 0, len(ctxs))
+	)
+	for i, tsk := range app.tsks {
+		if _, owned := owner[tsk.Name()]; owned {
+			continue
+		}
+		tsks = append(tsks, tsk)
+		octx = append(octx, ctxs[i])
+	}
+	app.tsks = tsks
+	return octx, nil
+}
+
+// ports returns the input and output ports of a task.
+// The ports of a filter chain are the ones of its tasks.
+func (app *appmgr) ports(tsk Task) (in, out []string) {
+	if chain, ok := tsk.(*FilterChain); ok {
+		for _, sub := range chain.tsks {
+			i, o := app.ports(sub)
+			in = append(in, i...)
+			out = append(out, o...)
+		}
+		return in, out
+	}
+	node, ok := app.dflow.nodes[tsk.Name()]
+	if !ok {
+		return nil, nil
+	}
+	for k := range node.in {
+		in = append(in, k)
+	}
+	for k := range node.out {
+		out = append(out, k)
+	}
+	sort.Strings(in)
+	sort.Strings(out)
+	return in, out
+}
+
+// outPorts returns the output ports of each of the provided tasks.
+func (app *appmgr) outPorts(tsks []Task) [][]string {
+	outs := make([][]string, len(tsks))
+	for i, tsk := range tsks {
+		_, outs[i] = app.ports(tsk)
+	}
+	return outs
+}
+
 func (app *appmgr) Msg() MsgStream {
 	return app.msg
 }
@@ -0,0 +1,179 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fwk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// checkpoint records the IDs of the events that were fully processed
+// by an application, and periodically saves them to a file.
+//
+// An application resuming from a checkpoint file will not process again
+// the events recorded in that file.
+type checkpoint struct {
+	mu    sync.Mutex
+	fname string
+	freq  int64 // number of completed events between two saves
+	n     int64 // number of completed events since last save
+
+	skip   int64
+	evtmax int64
+	done   map[int64]struct{}
+}
+
+// ckptFile is the on-disk layout of a checkpoint file.
+type ckptFile struct {
+	EvtSkip int64      `json:"evtskip"`
+	EvtMax  int64      `json:"evtmax"`
+	Done    [][2]int64 `json:"done"` // closed ranges of completed event IDs
+}
+
+// openCheckpoint creates a checkpoint saved to fname, loading the
+// completed events of a previous run if fname already exists.
+func openCheckpoint(fname string, freq, skip, evtmax int64) (*checkpoint, error) {
+	if freq <= 0 {
+		return nil, fmt.Errorf("fwk: invalid checkpoint frequency (%d)", freq)
+	}
+
+	ckpt := &checkpoint{
+		fname:  fname,
+		freq:   freq,
+		skip:   skip,
+		evtmax: evtmax,
+		done:   make(map[int64]struct{}),
+	}
+
+	raw, err := os.ReadFile(fname)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return ckpt, nil
+	case err != nil:
+		return nil, fmt.Errorf("fwk: could not read checkpoint file %q: %w", fname, err)
+	}
+
+	var f ckptFile
+	err = json.Unmarshal(raw, &f)
+	if err != nil {
+		return nil, fmt.Errorf("fwk: could not decode checkpoint file %q: %w", fname, err)
+	}
+
+	if f.EvtSkip != skip || f.EvtMax != evtmax {
+		return nil, fmt.Errorf(
+			"fwk: checkpoint file %q was created for another event range (skip=%d, max=%d)",
+			fname, f.EvtSkip, f.EvtMax,
+		)
+	}
+
+	for _, rng := range f.Done {
+		if rng[0] > rng[1] {
+			return nil, fmt.Errorf("fwk: invalid event range [%d, %d] in checkpoint file %q", rng[0], rng[1], fname)
+		}
+		for id := rng[0]; id <= rng[1]; id++ {
+			ckpt.done[id] = struct{}{}
+		}
+	}
+
+	return ckpt, nil
+}
+
+// has returns whether the event id was already processed.
+func (ckpt *checkpoint) has(id int64) bool {
+	ckpt.mu.Lock()
+	defer ckpt.mu.Unlock()
+	_, ok := ckpt.done[id]
+	return ok
+}
+
+// len returns the number of processed events.
+func (ckpt *checkpoint) len() int {
+	ckpt.mu.Lock()
+	defer ckpt.mu.Unlock()
+	return len(ckpt.done)
+}
+
+// add records the event id as processed, and reports whether the
+// checkpoint file should be saved.
+func (ckpt *checkpoint) add(id int64) bool {
+	ckpt.mu.Lock()
+	defer ckpt.mu.Unlock()
+
+	ckpt.done[id] = struct{}{}
+	ckpt.n++
+	return ckpt.n >= ckpt.freq
+}
+
+// flush saves the checkpoint file.
+func (ckpt *checkpoint) flush() error {
+	ckpt.mu.Lock()
+	defer ckpt.mu.Unlock()
+	return ckpt.save()
+}
+
+// save atomically writes the checkpoint file.
+// save must be called with ckpt.mu held.
+func (ckpt *checkpoint) save() error {
+	ids := make([]int64, 0, len(ckpt.done))
+	for id := range ckpt.done {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	f := ckptFile{
+		EvtSkip: ckpt.skip,
+		EvtMax:  ckpt.evtmax,
+		Done:    make([][2]int64, 0),
+	}
+	for i, id := range ids {
+		if i > 0 && ids[i-1]+1 == id {
+			f.Done[len(f.Done)-1][1] = id
+			continue
+		}
+		f.Done = append(f.Done, [2]int64{id, id})
+	}
+
+	raw, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("fwk: could not encode checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(ckpt.fname), filepath.Base(ckpt.fname)+".*")
+	if err != nil {
+		return fmt.Errorf("fwk: could not create checkpoint file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(raw)
+	if err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("fwk: could not write checkpoint file: %w", err)
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("fwk: could not sync checkpoint file: %w", err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("fwk: could not close checkpoint file: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), ckpt.fname)
+	if err != nil {
+		return fmt.Errorf("fwk: could not save checkpoint file %q: %w", ckpt.fname, err)
+	}
+
+	ckpt.n = 0
+	return nil
+}
@@ -18,16 +18,19 @@ import (
 func fwk_make_cmd_run() *commander.Command {
 	cmd := &commander.Command{
 		Run:       fwk_run_cmd_run,
-		UsageLine: "run [options] <config.go> [<config2.go> [...]]",
+		UsageLine: "run [options] <config.go|config.yaml> [<config2.go> [...]]",
 		Short:     "run a fwk job",
 		Long: `
-run runs a fwk-based job.
+run runs a fwk-based job, described by Go setup functions or
+YAML job descriptions (see go-hep.org/x/hep/fwk/job.YAML).
 
 ex:
  $ fwk-app run config.go
  $ fwk-app run config1.go config2.go
  $ fwk-app run ./some-dir
+ $ fwk-app run job.yaml
  $ fwk-app run -l=INFO -nprocs=4 -evtmax=-1 config.go
+ $ fwk-app run -evtskip=1000 -evtmax=500 -checkpoint=job.ckpt config.go
 `,
 		Flag: *flag.NewFlagSet("fwk-app-run", flag.ExitOnError),
 	}
@@ -38,8 +41,10 @@ ex:
 	// flags passed to sub-process
 	cmd.Flag.String("l", "INFO", "log level (DEBUG|INFO|WARN|ERROR)")
 	cmd.Flag.Int("evtmax", -1, "number of events to process")
+	cmd.Flag.Int("evtskip", 0, "number of events to skip")
 	cmd.Flag.Int("nprocs", 0, "number of concurrent events to process")
 	cmd.Flag.Bool("cpu-prof", false, "enable CPU profiling")
+	cmd.Flag.String("checkpoint", "", "path to a checkpoint file to resume from and update")
 	return cmd
 }
 
@@ -48,17 +53,18 @@ func fwk_run_cmd_run(cmd *commander.Command, args []string) error {
 
 	n := "fwk-app-" + cmd.Name()
 
+	// only pass explicitly set flags, so they do not override
+	// the values from the job configuration.
 	subargs := make([]string, 0, len(args))
-	for _, nn := range []string{"l", "evtmax", "nprocs", "cpu-prof"} {
-		val := cmd.Flag.Lookup(nn)
-		if val == nil {
-			continue
+	cmd.Flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "l", "evtmax", "evtskip", "nprocs", "cpu-prof", "checkpoint":
+			subargs = append(
+				subargs,
+				fmt.Sprintf("-"+f.Name+"=%v", f.Value.(flag.Getter).Get()),
+			)
 		}
-		subargs = append(
-			subargs,
-			fmt.Sprintf("-"+nn+"=%v", val.Value.(flag.Getter).Get()),
-		)
-	}
+	})
 
 	fnames := make([]string, 0, len(args))
 	for _, arg := range args {
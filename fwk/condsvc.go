@@ -0,0 +1,16 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fwk
+
+// CondSvc is the interface providing access to conditions data, such as
+// calibration or alignment payloads, valid over intervals of runs and events.
+type CondSvc interface {
+	Svc
+
+	// Get returns the payload identified by tag, valid for the event of
+	// the provided context.
+	// Get may be called concurrently from multiple goroutines.
+	Get(ctx Context, tag string) (any, error)
+}
@@ -0,0 +1,270 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package condsvc provides a fwk.CondSvc serving conditions payloads,
+// such as calibration or alignment constants, keyed by intervals of validity.
+//
+// Payloads are loaded from a pluggable Backend: a database/sql table
+// (SQLite, CSV, ...) or a directory of a ROOT file.
+// Payloads are cached by the service across events.
+package condsvc // import "go-hep.org/x/hep/fwk/condsvc"
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"go-hep.org/x/hep/fwk"
+)
+
+// ErrNotFound is returned when no payload is valid for a given key.
+var ErrNotFound = errors.New("condsvc: no valid payload")
+
+// Key identifies a point in the (run, event) space.
+// Event may hold an event number or a luminosity block number,
+// following the conventions of the conditions data.
+type Key struct {
+	Run   int64
+	Event int64
+}
+
+// Compare returns -1, 0 or +1 depending on whether k is before, equal to or
+// after o.
+func (k Key) Compare(o Key) int {
+	if c := cmp.Compare(k.Run, o.Run); c != 0 {
+		return c
+	}
+	return cmp.Compare(k.Event, o.Event)
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("%d:%d", k.Run, k.Event)
+}
+
+// IOV is an interval of validity, the half-open interval [Since, Until).
+// An IOV with a nil Until is valid until the next IOV of the same tag.
+type IOV struct {
+	Since Key
+	Until *Key
+}
+
+// Contains returns whether the key k is within the interval of validity.
+func (iov IOV) Contains(k Key) bool {
+	if k.Compare(iov.Since) < 0 {
+		return false
+	}
+	return iov.Until == nil || k.Compare(*iov.Until) < 0
+}
+
+func (iov IOV) String() string {
+	if iov.Until == nil {
+		return fmt.Sprintf("[%v, +inf)", iov.Since)
+	}
+	return fmt.Sprintf("[%v, %v)", iov.Since, *iov.Until)
+}
+
+// Payload is a conditions payload, together with its interval of validity.
+type Payload struct {
+	IOV   IOV
+	Value any
+}
+
+// Backend is a source of conditions payloads.
+type Backend interface {
+	// Payload returns the payload identified by tag, valid for the key k.
+	// The returned IOV must be bounded when a later IOV exists for that tag.
+	// Payload returns an error wrapping ErrNotFound when no payload is valid.
+	Payload(tag string, k Key) (Payload, error)
+
+	// Close releases the resources held by the backend.
+	Close() error
+}
+
+// Svc is the interface of the conditions service provided by this package.
+type Svc interface {
+	fwk.CondSvc
+
+	// At returns the payload identified by tag, valid for the key k.
+	At(tag string, k Key) (Payload, error)
+}
+
+// csvc implements Svc.
+//
+// csvc declares the following properties:
+//   - 'Backend', a condsvc.Backend, the source of conditions payloads;
+//   - 'EventKey', a string, the event store key holding the event number
+//     of the processed events;
+//   - 'RunKey', a string, the event store key holding the run number of
+//     the processed events;
+//   - 'Run', an int64, the run number of the processed events, used when
+//     'RunKey' is not set;
+//   - 'CacheSize', an int, the number of payloads cached per tag.
+//
+// The event store keys must hold integer values.
+// Tasks calling Get should declare these keys as input ports, so they are
+// scheduled after the producers of the run and event numbers.
+type csvc struct {
+	fwk.SvcBase
+
+	backend Backend
+	evtkey  string
+	runkey  string
+	run     int64
+	size    int
+
+	mu    sync.Mutex
+	cache map[string][]Payload // payloads per tag, most recently used first
+}
+
+func (svc *csvc) Configure(ctx fwk.Context) error {
+	if svc.backend == nil {
+		return fmt.Errorf("condsvc: service [%s] has no backend", svc.Name())
+	}
+	if svc.size <= 0 {
+		return fmt.Errorf("condsvc: service [%s] has an invalid cache size (%d)", svc.Name(), svc.size)
+	}
+	return nil
+}
+
+func (svc *csvc) StartSvc(ctx fwk.Context) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.cache = make(map[string][]Payload)
+	return nil
+}
+
+func (svc *csvc) StopSvc(ctx fwk.Context) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.cache = nil
+	return svc.backend.Close()
+}
+
+// Get returns the payload identified by tag, valid for the event of
+// the provided context.
+// The run and event numbers of the event are read from the event store.
+func (svc *csvc) Get(ctx fwk.Context, tag string) (any, error) {
+	k, err := svc.key(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := svc.At(tag, k)
+	if err != nil {
+		return nil, err
+	}
+	return p.Value, nil
+}
+
+// key returns the (run, event) key of the event of the provided context.
+func (svc *csvc) key(ctx fwk.Context) (Key, error) {
+	if svc.evtkey == "" {
+		return Key{}, fmt.Errorf("condsvc: service [%s] has no event store key for event numbers (use At)", svc.Name())
+	}
+
+	var (
+		k   = Key{Run: svc.run}
+		err error
+	)
+	k.Event, err = storeInt(ctx.Store(), svc.evtkey)
+	if err != nil {
+		return Key{}, err
+	}
+	if svc.runkey != "" {
+		k.Run, err = storeInt(ctx.Store(), svc.runkey)
+		if err != nil {
+			return Key{}, err
+		}
+	}
+	return k, nil
+}
+
+func storeInt(store fwk.Store, key string) (int64, error) {
+	v, err := store.Get(key)
+	if err != nil {
+		return 0, fmt.Errorf("condsvc: could not read key %q: %w", key, err)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), nil
+	default:
+		return 0, fmt.Errorf("condsvc: key %q holds a %T, not an integer", key, v)
+	}
+}
+
+// At returns the payload identified by tag, valid for the key k.
+func (svc *csvc) At(tag string, k Key) (Payload, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	ps := svc.cache[tag]
+	for i, p := range ps {
+		if !p.IOV.Contains(k) {
+			continue
+		}
+		// move to front.
+		copy(ps[1:i+1], ps[:i])
+		ps[0] = p
+		return p, nil
+	}
+
+	p, err := svc.backend.Payload(tag, k)
+	if err != nil {
+		return Payload{}, fmt.Errorf("condsvc: could not retrieve payload %q at %v: %w", tag, k, err)
+	}
+
+	if len(ps) < svc.size {
+		ps = append(ps, Payload{})
+	}
+	copy(ps[1:], ps)
+	ps[0] = p
+	svc.cache[tag] = ps
+
+	return p, nil
+}
+
+func newcsvc(typ, name string, mgr fwk.App) (fwk.Component, error) {
+	var err error
+	svc := &csvc{
+		SvcBase: fwk.NewSvc(typ, name, mgr),
+		size:    16,
+	}
+
+	err = svc.DeclProp("Backend", &svc.backend)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("EventKey", &svc.evtkey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("RunKey", &svc.runkey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("Run", &svc.run)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("CacheSize", &svc.size)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc, err
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(csvc{}), newcsvc)
+}
+
+var _ Svc = (*csvc)(nil)
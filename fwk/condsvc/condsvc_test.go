@@ -0,0 +1,433 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package condsvc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-hep.org/x/hep/csvutil/csvdriver"
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestIOVs(t *testing.T) {
+	until := func(run, evt int64) *Key { return &Key{Run: run, Event: evt} }
+	vs, err := newIOVs("tag", []IOV{
+		{Since: Key{2, 100}},
+		{Since: Key{1, 0}, Until: until(1, 50)},
+		{Since: Key{2, 0}},
+	})
+	if err != nil {
+		t.Fatalf("could not create IOVs: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		k    Key
+		want int
+	}{
+		{Key{0, 0}, -1},
+		{Key{1, 0}, 0},
+		{Key{1, 49}, 0},
+		{Key{1, 50}, -1},
+		{Key{2, 0}, 1},
+		{Key{2, 99}, 1},
+		{Key{2, 100}, 2},
+		{Key{5, 0}, 2},
+	} {
+		if got := vs.find(tc.k); got != tc.want {
+			t.Errorf("find(%v): got=%d, want=%d", tc.k, got, tc.want)
+		}
+	}
+
+	_, err = newIOVs("tag", []IOV{
+		{Since: Key{1, 0}, Until: until(2, 10)},
+		{Since: Key{2, 0}},
+	})
+	if err == nil {
+		t.Fatalf("expected an error for overlapping IOVs")
+	}
+}
+
+func TestSQL(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "conds.csv")
+	err := os.WriteFile(fname, []byte(`tag;since_run;since_event;until_run;until_event;payload
+ecal;1;0;-1;-1;1.5
+ecal;2;0;-1;-1;2.5
+ecal;2;100;-1;-1;3.5
+hcal;1;0;1;10;10.5
+`), 0644)
+	if err != nil {
+		t.Fatalf("could not create CSV file: %+v", err)
+	}
+
+	db, err := csvdriver.Conn{File: fname, Comma: ';', Header: true}.Open()
+	if err != nil {
+		t.Fatalf("could not open CSV file: %+v", err)
+	}
+	defer db.Close()
+
+	be := NewSQL(db, "csv")
+	defer be.Close()
+
+	for _, tc := range []struct {
+		tag  string
+		k    Key
+		want any
+		err  error
+	}{
+		{"ecal", Key{1, 0}, 1.5, nil},
+		{"ecal", Key{1, 1000}, 1.5, nil},
+		{"ecal", Key{2, 99}, 2.5, nil},
+		{"ecal", Key{2, 100}, 3.5, nil},
+		{"ecal", Key{0, 100}, nil, ErrNotFound},
+		{"hcal", Key{1, 9}, 10.5, nil},
+		{"hcal", Key{1, 10}, nil, ErrNotFound},
+		{"tracker", Key{1, 0}, nil, ErrNotFound},
+	} {
+		t.Run(fmt.Sprintf("%s@%v", tc.tag, tc.k), func(t *testing.T) {
+			p, err := be.Payload(tc.tag, tc.k)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("could not retrieve payload: %+v", err)
+			}
+			if !p.IOV.Contains(tc.k) {
+				t.Fatalf("invalid IOV %v for key %v", p.IOV, tc.k)
+			}
+			if p.Value != tc.want {
+				t.Fatalf("invalid payload: got=%v (%T), want=%v", p.Value, p.Value, tc.want)
+			}
+		})
+	}
+}
+
+func createROOT(t *testing.T) string {
+	t.Helper()
+
+	fname := filepath.Join(t.TempDir(), "conds.root")
+	f, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	dir, err := riofs.Dir(f).Mkdir("calib/ecal")
+	if err != nil {
+		t.Fatalf("could not create directory: %+v", err)
+	}
+	for _, name := range []string{"1_0", "2_0", "2_100"} {
+		err = dir.Put(name, rbase.NewObjString("ecal-"+name))
+		if err != nil {
+			t.Fatalf("could not put payload %q: %+v", name, err)
+		}
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close ROOT file: %+v", err)
+	}
+	return fname
+}
+
+func TestROOT(t *testing.T) {
+	be, err := OpenROOT(createROOT(t))
+	if err != nil {
+		t.Fatalf("could not open backend: %+v", err)
+	}
+	defer be.Close()
+
+	for _, tc := range []struct {
+		k    Key
+		want string
+	}{
+		{Key{1, 0}, "ecal-1_0"},
+		{Key{1, 1000}, "ecal-1_0"},
+		{Key{2, 99}, "ecal-2_0"},
+		{Key{2, 100}, "ecal-2_100"},
+		{Key{3, 0}, "ecal-2_100"},
+	} {
+		p, err := be.Payload("calib/ecal", tc.k)
+		if err != nil {
+			t.Fatalf("could not retrieve payload at %v: %+v", tc.k, err)
+		}
+		if got := p.Value.(*rbase.ObjString).String(); got != tc.want {
+			t.Fatalf("invalid payload at %v: got=%q, want=%q", tc.k, got, tc.want)
+		}
+	}
+
+	_, err = be.Payload("calib/ecal", Key{0, 0})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	_, err = be.Payload("calib/hcal", Key{1, 0})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
+
+// countingBackend counts the number of payloads requested to a backend.
+type countingBackend struct {
+	Backend
+	mu sync.Mutex
+	n  int
+}
+
+func (be *countingBackend) Payload(tag string, k Key) (Payload, error) {
+	be.mu.Lock()
+	be.n++
+	be.mu.Unlock()
+	return be.Backend.Payload(tag, k)
+}
+
+// testevt puts the run and event numbers of each event in the event store.
+type testevt struct {
+	fwk.TaskBase
+
+	run func(id int64) int64
+	evt func(id int64) int64
+}
+
+func (tsk *testevt) Configure(ctx fwk.Context) error {
+	err := tsk.DeclOutPort("run", reflect.TypeOf(int64(0)))
+	if err != nil {
+		return err
+	}
+	return tsk.DeclOutPort("evt", reflect.TypeOf(int32(0)))
+}
+
+func (tsk *testevt) StartTask(ctx fwk.Context) error { return nil }
+func (tsk *testevt) StopTask(ctx fwk.Context) error  { return nil }
+
+func (tsk *testevt) Process(ctx fwk.Context) error {
+	err := ctx.Store().Put("run", tsk.run(ctx.ID()))
+	if err != nil {
+		return err
+	}
+	return ctx.Store().Put("evt", int32(tsk.evt(ctx.ID())))
+}
+
+// testcond records the payloads it retrieves for each event.
+type testcond struct {
+	fwk.TaskBase
+
+	svc fwk.CondSvc
+
+	mu   sync.Mutex
+	vals map[int64]string
+}
+
+func (tsk *testcond) Configure(ctx fwk.Context) error {
+	err := tsk.DeclInPort("run", reflect.TypeOf(int64(0)))
+	if err != nil {
+		return err
+	}
+	return tsk.DeclInPort("evt", reflect.TypeOf(int32(0)))
+}
+
+func (tsk *testcond) StartTask(ctx fwk.Context) error {
+	svc, err := ctx.Svc("condsvc")
+	if err != nil {
+		return err
+	}
+	tsk.svc = svc.(fwk.CondSvc)
+	return nil
+}
+
+func (tsk *testcond) StopTask(ctx fwk.Context) error {
+	return nil
+}
+
+func (tsk *testcond) Process(ctx fwk.Context) error {
+	v, err := tsk.svc.Get(ctx, "calib/ecal")
+	if err != nil {
+		return err
+	}
+	tsk.mu.Lock()
+	tsk.vals[ctx.ID()] = v.(*rbase.ObjString).String()
+	tsk.mu.Unlock()
+	return nil
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(testevt{}),
+		func(typ, name string, mgr fwk.App) (fwk.Component, error) {
+			tsk := &testevt{
+				TaskBase: fwk.NewTask(typ, name, mgr),
+			}
+			err := tsk.DeclProp("Run", &tsk.run)
+			if err != nil {
+				return nil, err
+			}
+			err = tsk.DeclProp("Event", &tsk.evt)
+			if err != nil {
+				return nil, err
+			}
+			return tsk, nil
+		},
+	)
+	fwk.Register(reflect.TypeOf(testcond{}),
+		func(typ, name string, mgr fwk.App) (fwk.Component, error) {
+			tsk := &testcond{
+				TaskBase: fwk.NewTask(typ, name, mgr),
+				vals:     make(map[int64]string),
+			}
+			return tsk, nil
+		},
+	)
+}
+
+func TestCondSvc(t *testing.T) {
+	const nevts = 200
+	fname := createROOT(t)
+
+	for _, tc := range []struct {
+		name  string
+		props job.P
+		run   func(id int64) int64
+		evt   func(id int64) int64
+		want  func(id int64) string
+		nreqs int
+	}{
+		{
+			name:  "event-key",
+			props: job.P{"EventKey": "evt", "Run": int64(2)},
+			run:   func(id int64) int64 { return 0 },
+			evt:   func(id int64) int64 { return id },
+			want: func(id int64) string {
+				if id >= 100 {
+					return "ecal-2_100"
+				}
+				return "ecal-2_0"
+			},
+			nreqs: 2,
+		},
+		{
+			name:  "run-event-keys",
+			props: job.P{"EventKey": "evt", "RunKey": "run"},
+			run:   func(id int64) int64 { return 1 + id/100 },
+			evt:   func(id int64) int64 { return 2 * (id % 100) },
+			want: func(id int64) string {
+				switch {
+				case id < 100:
+					return "ecal-1_0"
+				case id < 150:
+					return "ecal-2_0"
+				default:
+					return "ecal-2_100"
+				}
+			},
+			nreqs: 3,
+		},
+	} {
+		for _, nprocs := range []int{0, 4} {
+			t.Run(fmt.Sprintf("%s-nprocs=%d", tc.name, nprocs), func(t *testing.T) {
+				be, err := OpenROOT(fname)
+				if err != nil {
+					t.Fatalf("could not open backend: %+v", err)
+				}
+				cbe := &countingBackend{Backend: be}
+
+				app := job.NewJob(nil, job.P{
+					"EvtMax":   int64(nevts),
+					"NProcs":   nprocs,
+					"MsgLevel": job.MsgLevel("ERROR"),
+				})
+				props := job.P{"Backend": Backend(cbe)}
+				for k, v := range tc.props {
+					props[k] = v
+				}
+				app.Create(job.C{
+					Type:  "go-hep.org/x/hep/fwk/condsvc.csvc",
+					Name:  "condsvc",
+					Props: props,
+				})
+				app.Create(job.C{
+					Type: "go-hep.org/x/hep/fwk/condsvc.testevt",
+					Name: "evt",
+					Props: job.P{
+						"Run":   tc.run,
+						"Event": tc.evt,
+					},
+				})
+				tsk := app.Create(job.C{
+					Type: "go-hep.org/x/hep/fwk/condsvc.testcond",
+					Name: "cond",
+				}).(*testcond)
+
+				err = app.App().Run()
+				if err != nil {
+					t.Fatalf("could not run app: %+v", err)
+				}
+
+				if got, want := len(tsk.vals), nevts; got != want {
+					t.Fatalf("invalid number of events: got=%d, want=%d", got, want)
+				}
+				for id, got := range tsk.vals {
+					if want := tc.want(id); got != want {
+						t.Fatalf("invalid payload for event %d: got=%q, want=%q", id, got, want)
+					}
+				}
+
+				if got, want := cbe.n, tc.nreqs; got != want {
+					t.Fatalf("invalid number of backend requests: got=%d, want=%d", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestCondSvcNoEventKey(t *testing.T) {
+	be, err := OpenROOT(createROOT(t))
+	if err != nil {
+		t.Fatalf("could not open backend: %+v", err)
+	}
+
+	app := job.NewJob(nil, job.P{
+		"EvtMax":   int64(1),
+		"MsgLevel": job.MsgLevel("ERROR"),
+	})
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk/condsvc.csvc",
+		Name: "condsvc",
+		Props: job.P{
+			"Backend": Backend(be),
+			"Run":     int64(2),
+		},
+	})
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk/condsvc.testevt",
+		Name: "evt",
+		Props: job.P{
+			"Run":   func(id int64) int64 { return 2 },
+			"Event": func(id int64) int64 { return id },
+		},
+	})
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk/condsvc.testcond",
+		Name: "cond",
+	})
+
+	err = app.App().Run()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), "no event store key"; !strings.Contains(got, want) {
+		t.Fatalf("invalid error: got=%q, want=%q", got, want)
+	}
+}
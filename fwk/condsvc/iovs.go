@@ -0,0 +1,52 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package condsvc
+
+import (
+	"fmt"
+	"slices"
+)
+
+// iovs is a list of IOVs of a tag, sorted by the beginning of their validity.
+type iovs []IOV
+
+// newIOVs sorts the provided IOVs and bounds the open-ended ones
+// with the beginning of the next IOV.
+func newIOVs(tag string, vs []IOV) (iovs, error) {
+	slices.SortStableFunc(vs, func(a, b IOV) int {
+		return a.Since.Compare(b.Since)
+	})
+	for i := range vs {
+		if i+1 == len(vs) {
+			break
+		}
+		next := vs[i+1].Since
+		if vs[i].Since.Compare(next) == 0 {
+			return nil, fmt.Errorf("condsvc: tag %q has two IOVs starting at %v", tag, next)
+		}
+		switch {
+		case vs[i].Until == nil:
+			vs[i].Until = &next
+		case vs[i].Until.Compare(next) > 0:
+			return nil, fmt.Errorf("condsvc: tag %q has overlapping IOVs %v and %v", tag, vs[i], vs[i+1])
+		}
+	}
+	return vs, nil
+}
+
+// find returns the index of the IOV containing k, or -1.
+func (vs iovs) find(k Key) int {
+	i, _ := slices.BinarySearchFunc(vs, k, func(iov IOV, k Key) int {
+		return iov.Since.Compare(k)
+	})
+	// i is the index of the first IOV starting after or at k.
+	if i < len(vs) && vs[i].Since.Compare(k) == 0 {
+		return i
+	}
+	if i == 0 || !vs[i-1].Contains(k) {
+		return -1
+	}
+	return i - 1
+}
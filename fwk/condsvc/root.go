@@ -0,0 +1,153 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package condsvc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// ROOT is a Backend reading payloads from a directory of a ROOT file.
+//
+// Each tag is the path to a sub-directory holding one object per IOV.
+// Objects are named after the beginning of their validity, "<run>_<event>"
+// (e.g. "1_0", "2_100"), and are valid until the next one.
+// Payloads are the ROOT objects read from the file.
+type ROOT struct {
+	dir riofs.Directory
+	f   *riofs.File // owned file, if any
+
+	mu   sync.Mutex
+	tags map[string]rootTag
+}
+
+type rootTag struct {
+	iovs  iovs
+	names []string
+}
+
+// NewROOT returns a Backend reading payloads from the provided directory.
+// Closing the backend does not close the underlying file.
+func NewROOT(dir riofs.Directory) *ROOT {
+	return &ROOT{dir: riofs.Dir(dir), tags: make(map[string]rootTag)}
+}
+
+// OpenROOT opens the named ROOT file and returns a Backend reading payloads
+// from its top-level directory.
+// Closing the backend closes the file.
+func OpenROOT(fname string) (*ROOT, error) {
+	f, err := riofs.Open(fname)
+	if err != nil {
+		return nil, fmt.Errorf("condsvc: could not open ROOT file: %w", err)
+	}
+	be := NewROOT(f)
+	be.f = f
+	return be, nil
+}
+
+// Payload returns the payload identified by tag, valid for the key k.
+func (be *ROOT) Payload(tag string, k Key) (Payload, error) {
+	be.mu.Lock()
+	defer be.mu.Unlock()
+
+	t, ok := be.tags[tag]
+	if !ok {
+		var err error
+		t, err = be.index(tag)
+		if err != nil {
+			return Payload{}, err
+		}
+		be.tags[tag] = t
+	}
+
+	i := t.iovs.find(k)
+	if i < 0 {
+		return Payload{}, ErrNotFound
+	}
+
+	obj, err := be.dir.Get(tag + "/" + t.names[i])
+	if err != nil {
+		return Payload{}, fmt.Errorf("condsvc: could not read payload %q of tag %q: %w", t.names[i], tag, err)
+	}
+
+	return Payload{IOV: t.iovs[i], Value: obj}, nil
+}
+
+// index lists the IOVs of the provided tag.
+func (be *ROOT) index(tag string) (rootTag, error) {
+	obj, err := be.dir.Get(tag)
+	if err != nil {
+		return rootTag{}, fmt.Errorf("condsvc: no tag %q: %w", tag, ErrNotFound)
+	}
+	dir, ok := obj.(riofs.Directory)
+	if !ok {
+		return rootTag{}, fmt.Errorf("condsvc: tag %q is not a directory (type=%T)", tag, obj)
+	}
+
+	var (
+		keys = dir.Keys()
+		vs   = make([]IOV, 0, len(keys))
+		idx  = make(map[Key]string, len(keys))
+	)
+	for _, key := range keys {
+		name := key.Name()
+		since, err := parseKey(name)
+		if err != nil {
+			return rootTag{}, fmt.Errorf("condsvc: invalid payload name %q in tag %q: %w", name, tag, err)
+		}
+		if _, dup := idx[since]; dup {
+			// only consider the highest cycle of a key.
+			continue
+		}
+		idx[since] = name
+		vs = append(vs, IOV{Since: since})
+	}
+
+	vs, err = newIOVs(tag, vs)
+	if err != nil {
+		return rootTag{}, err
+	}
+
+	names := make([]string, len(vs))
+	for i, iov := range vs {
+		names[i] = idx[iov.Since]
+	}
+	return rootTag{iovs: vs, names: names}, nil
+}
+
+// parseKey parses a key of the form "<run>_<event>".
+func parseKey(name string) (Key, error) {
+	run, evt, ok := strings.Cut(name, "_")
+	if !ok {
+		return Key{}, fmt.Errorf("missing '_' separator")
+	}
+	var (
+		k   Key
+		err error
+	)
+	k.Run, err = strconv.ParseInt(run, 10, 64)
+	if err != nil {
+		return Key{}, fmt.Errorf("invalid run: %w", err)
+	}
+	k.Event, err = strconv.ParseInt(evt, 10, 64)
+	if err != nil {
+		return Key{}, fmt.Errorf("invalid event: %w", err)
+	}
+	return k, nil
+}
+
+// Close closes the underlying file, if it is owned by the backend.
+func (be *ROOT) Close() error {
+	if be.f == nil {
+		return nil
+	}
+	return be.f.Close()
+}
+
+var _ Backend = (*ROOT)(nil)
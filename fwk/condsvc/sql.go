@@ -0,0 +1,144 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package condsvc
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+)
+
+// SQL is a Backend reading payloads from a database/sql table.
+//
+// The table must provide the following columns:
+//   - tag (string): the name of the payload,
+//   - since_run, since_event (int64): the beginning of the IOV,
+//   - until_run, until_event (int64): the end of the IOV,
+//   - payload: the payload value.
+//
+// A negative until_run denotes an IOV valid until the next one of the same tag.
+//
+// The whole table is read when the first payload is requested:
+// SQL is meant for the small tables typical of conditions data.
+type SQL struct {
+	db    *sql.DB
+	table string
+	owned bool
+
+	once sync.Once
+	err  error
+	tags map[string]sqlTag
+}
+
+type sqlTag struct {
+	iovs iovs
+	vals []any
+}
+
+// NewSQL returns a Backend reading payloads from the named table of the
+// provided database.
+// Closing the backend does not close the database.
+func NewSQL(db *sql.DB, table string) *SQL {
+	return &SQL{db: db, table: table}
+}
+
+// OpenSQL opens the database with the provided driver and data source name,
+// and returns a Backend reading payloads from the named table.
+// Closing the backend closes the database.
+func OpenSQL(driver, dsn, table string) (*SQL, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("condsvc: could not open database: %w", err)
+	}
+	return &SQL{db: db, table: table, owned: true}, nil
+}
+
+// Payload returns the payload identified by tag, valid for the key k.
+func (be *SQL) Payload(tag string, k Key) (Payload, error) {
+	be.once.Do(func() { be.err = be.load() })
+	if be.err != nil {
+		return Payload{}, be.err
+	}
+
+	t, ok := be.tags[tag]
+	if !ok {
+		return Payload{}, fmt.Errorf("condsvc: no tag %q: %w", tag, ErrNotFound)
+	}
+
+	i := t.iovs.find(k)
+	if i < 0 {
+		return Payload{}, ErrNotFound
+	}
+	return Payload{IOV: t.iovs[i], Value: t.vals[i]}, nil
+}
+
+func (be *SQL) load() error {
+	rows, err := be.db.Query(
+		"SELECT tag, since_run, since_event, until_run, until_event, payload FROM " + be.table,
+	)
+	if err != nil {
+		return fmt.Errorf("condsvc: could not query table %q: %w", be.table, err)
+	}
+	defer rows.Close()
+
+	type row struct {
+		iov IOV
+		val any
+	}
+	byTag := make(map[string][]row)
+	for rows.Next() {
+		var (
+			tag   string
+			since Key
+			until Key
+			val   any
+		)
+		err = rows.Scan(&tag, &since.Run, &since.Event, &until.Run, &until.Event, &val)
+		if err != nil {
+			return fmt.Errorf("condsvc: could not scan table %q: %w", be.table, err)
+		}
+		iov := IOV{Since: since}
+		if until.Run >= 0 {
+			iov.Until = &until
+		}
+		byTag[tag] = append(byTag[tag], row{iov: iov, val: val})
+	}
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("condsvc: could not read table %q: %w", be.table, err)
+	}
+
+	be.tags = make(map[string]sqlTag, len(byTag))
+	for tag, rs := range byTag {
+		// sort the rows through their IOVs, keeping track of their values.
+		vs := make([]IOV, len(rs))
+		idx := make(map[Key]any, len(rs))
+		for i, r := range rs {
+			vs[i] = r.iov
+			idx[r.iov.Since] = r.val
+		}
+		vs, err = newIOVs(tag, vs)
+		if err != nil {
+			return err
+		}
+		vals := make([]any, len(vs))
+		for i, iov := range vs {
+			vals[i] = idx[iov.Since]
+		}
+		be.tags[tag] = sqlTag{iovs: vs, vals: vals}
+	}
+
+	return nil
+}
+
+// Close closes the database, if it is owned by the backend.
+func (be *SQL) Close() error {
+	if !be.owned {
+		return nil
+	}
+	return be.db.Close()
+}
+
+var _ Backend = (*SQL)(nil)
@@ -0,0 +1,17 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package fwk
+
+import (
+	"time"
+)
+
+// threadCPUTime returns the CPU time consumed by the current thread.
+// It is not measured on this platform.
+func threadCPUTime() time.Duration {
+	return 0
+}
@@ -0,0 +1,188 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fwk
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrRejected is returned by a Task's Process method to reject the current
+// event. ErrRejected may be wrapped.
+//
+// The output ports of a rejecting task that were not filled are marked as
+// rejected in the event store: any downstream task retrieving them gets an
+// error wrapping ErrRejected and is rejected in turn, unless it handles it.
+//
+// An event is accepted when none of the top-level tasks rejected it.
+// Output streams only write accepted events.
+var ErrRejected = errors.New("fwk: event rejected")
+
+// Filter modes of a FilterChain.
+const (
+	FilterSeq = "seq" // run tasks in order, stop at the first rejection
+	FilterAnd = "and" // run all tasks in order, accept if all tasks accept
+	FilterOr  = "or"  // run tasks in order, stop at the first acceptance
+)
+
+// FilterChain is a task running a list of tasks sequentially, combining
+// their filter decisions.
+//
+// FilterChain declares a property 'Tasks', a []string, holding the names of
+// the tasks of the chain, in order.
+// These tasks are owned by the chain: they are only run as part of the
+// chain and are not scheduled by the application anymore.
+// A task may only belong to one chain; chains may be nested.
+//
+// FilterChain declares a property 'Mode', a string, holding the way filter
+// decisions are combined:
+//   - "seq" (default): the event is accepted if all tasks accept it, and
+//     the tasks following the first rejecting task are skipped;
+//   - "and": the event is accepted if all tasks accept it, all tasks are run;
+//   - "or": the event is accepted if any task accepts it, and the tasks
+//     following the first accepting task are skipped.
+//
+// The output ports of skipped tasks are marked as rejected.
+// A FilterChain returns ErrRejected when it rejects the event.
+type FilterChain struct {
+	TaskBase
+
+	names []string
+	mode  string
+
+	tsks []Task
+	ctxs []ctxType
+	outs [][]string
+}
+
+// Configure validates the filter mode.
+func (tsk *FilterChain) Configure(ctx Context) error {
+	switch tsk.mode {
+	case FilterSeq, FilterAnd, FilterOr:
+	default:
+		return fmt.Errorf("fwk: filter chain [%s] has an invalid mode %q", tsk.Name(), tsk.mode)
+	}
+	return nil
+}
+
+// StartTask starts all the tasks of the chain.
+func (tsk *FilterChain) StartTask(ctx Context) error {
+	for i, sub := range tsk.tsks {
+		err := sub.StartTask(tsk.ctxs[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StopTask stops all the tasks of the chain.
+func (tsk *FilterChain) StopTask(ctx Context) error {
+	for i, sub := range tsk.tsks {
+		err := sub.StopTask(tsk.ctxs[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Process runs the tasks of the chain on the current event and combines
+// their filter decisions.
+func (tsk *FilterChain) Process(ctx Context) error {
+	var (
+		accepted = tsk.mode != FilterOr
+		n        = 0
+	)
+loop:
+	for i, sub := range tsk.tsks {
+		n = i + 1
+		var (
+			err  = sub.Process(tsk.subctx(ctx, i))
+			pass = true
+		)
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrRejected):
+			pass = false
+			rejectPorts(ctx, tsk.outs[i])
+		default:
+			return err
+		}
+
+		switch tsk.mode {
+		case FilterSeq:
+			if !pass {
+				accepted = false
+				break loop
+			}
+		case FilterAnd:
+			accepted = accepted && pass
+		case FilterOr:
+			if pass {
+				accepted = true
+				break loop
+			}
+		}
+	}
+
+	for _, outs := range tsk.outs[n:] {
+		rejectPorts(ctx, outs)
+	}
+
+	if !accepted {
+		return ErrRejected
+	}
+	return nil
+}
+
+// subctx returns the context for the i-th task of the chain.
+func (tsk *FilterChain) subctx(ctx Context, i int) Context {
+	evt, ok := ctx.(ctxType)
+	if !ok {
+		return ctx
+	}
+	evt.msg = tsk.ctxs[i].msg
+	return evt
+}
+
+// rejectPorts marks the provided ports, when not already filled,
+// as rejected in the store of the context.
+func rejectPorts(ctx Context, ports []string) {
+	if len(ports) == 0 {
+		return
+	}
+	store, ok := ctx.Store().(*datastore)
+	if !ok {
+		return
+	}
+	store.reject(ports)
+}
+
+func newFilterChain(typ, name string, mgr App) (Component, error) {
+	var err error
+
+	tsk := &FilterChain{
+		TaskBase: NewTask(typ, name, mgr),
+		mode:     FilterSeq,
+	}
+
+	err = tsk.DeclProp("Tasks", &tsk.names)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsk.DeclProp("Mode", &tsk.mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return tsk, err
+}
+
+func init() {
+	Register(reflect.TypeOf(FilterChain{}), newFilterChain)
+}
@@ -86,4 +86,27 @@
 //
 //	   return err
 //	}
+//
+// A task may reject an event by returning fwk.ErrRejected from its Process
+// method.
+// Downstream tasks requiring the outputs of a rejecting task are rejected
+// in turn, and output streams only write accepted events.
+// Tasks may be grouped into a fwk.FilterChain, running them in order and
+// combining their decisions with a "seq", "and" or "or" logic.
+//
+// The application property 'EvtSkip' selects the first event to process,
+// and 'EvtMax' the number of events to process from there on.
+// Skipped events are still read from the input stream, so event IDs do
+// not depend on the slice of input a job is processing.
+// When the 'Checkpoint' property holds a file name, the IDs of the fully
+// processed events are saved to that file every 'CheckpointFreq' events
+// and at the end of the event loop, even if it failed.
+// A job started again with the same checkpoint file does not process
+// these events again, so its output streams only receive the remaining
+// events.
+// Checkpointing requires all output streamers to implement
+// fwk.OutputCommitter: they are committed right before each checkpoint is
+// saved, while no event is being processed, so that after a crash their
+// committed output holds the events of the last checkpoint.
+// A resumed job appends its events to that output.
 package fwk // import "go-hep.org/x/hep/fwk"
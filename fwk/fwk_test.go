@@ -6,15 +6,22 @@ package fwk_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"go-hep.org/x/hep/fwk"
 	"go-hep.org/x/hep/fwk/internal/fwktest"
 	"go-hep.org/x/hep/fwk/job"
+	fwkrio "go-hep.org/x/hep/fwk/rio"
+	"go-hep.org/x/hep/rio"
 )
 
 func newapp(evtmax int64, nprocs int) *job.Job {
@@ -532,3 +539,629 @@ func Benchmark__ConcApp(b *testing.B) {
 		}
 	}
 }
+
+func sumOf(n int64, accept func(i int64) bool, fct func(i int64) int64) int64 {
+	sum := int64(0)
+	for i := range n {
+		if accept(i) {
+			sum += fct(i)
+		}
+	}
+	return sum
+}
+
+func sumOutput(t *testing.T, r io.Reader) int64 {
+	t.Helper()
+	sum := int64(0)
+	for {
+		var val int64
+		_, err := fmt.Fscanf(r, "%d\n", &val)
+		if err != nil {
+			if err != io.EOF {
+				t.Fatalf("could not scan output: %+v", err)
+			}
+			break
+		}
+		sum += val
+	}
+	return sum
+}
+
+func TestFilter(t *testing.T) {
+	const max = 100
+	for _, nprocs := range []int{0, 1, 2, 4, 8, -1} {
+		t.Run(fmt.Sprintf("nprocs=%d", nprocs), func(t *testing.T) {
+			app := newapp(-1, nprocs)
+			out := new(bytes.Buffer)
+
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk.InputStream",
+				Name: "input",
+				Props: job.P{
+					"Ports": []fwk.Port{
+						{Name: "ints", Type: reflect.TypeOf(int64(1))},
+					},
+					"Streamer": &fwktest.InputStream{R: newTestReader(max)},
+				},
+			})
+
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk/internal/fwktest.filter",
+				Name: "even",
+				Props: job.P{
+					"Input":  "ints",
+					"Output": "evens",
+					"Modulo": int64(2),
+				},
+			})
+
+			// t2 is rejected in turn when 'even' rejects the event.
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk/internal/fwktest.task2",
+				Name: "t2",
+				Props: job.P{
+					"Input":  "evens",
+					"Output": "evens-sq",
+				},
+			})
+
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk.OutputStream",
+				Name: "output",
+				Props: job.P{
+					"Ports": []fwk.Port{
+						{Name: "evens-sq", Type: reflect.TypeOf(int64(1))},
+					},
+					"Streamer": &fwktest.OutputStream{W: out},
+				},
+			})
+
+			err := app.App().Run()
+			if err != nil {
+				t.Fatalf("could not run app: %+v", err)
+			}
+
+			var (
+				got  = sumOutput(t, out)
+				want = sumOf(max,
+					func(i int64) bool { return i%2 == 0 },
+					func(i int64) int64 { return i * i },
+				)
+			)
+			if got != want {
+				t.Fatalf("invalid sum: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestFilterChain(t *testing.T) {
+	const max = 100
+	for _, tc := range []struct {
+		mode   string
+		accept func(i int64) bool
+	}{
+		{fwk.FilterSeq, func(i int64) bool { return i%6 == 0 }},
+		{fwk.FilterAnd, func(i int64) bool { return i%6 == 0 }},
+		{fwk.FilterOr, func(i int64) bool { return i%2 == 0 || i%3 == 0 }},
+	} {
+		for _, nprocs := range []int{0, 1, 4, -1} {
+			t.Run(fmt.Sprintf("%s-nprocs=%d", tc.mode, nprocs), func(t *testing.T) {
+				app := newapp(-1, nprocs)
+				out := new(bytes.Buffer)
+
+				app.Create(job.C{
+					Type: "go-hep.org/x/hep/fwk.OutputStream",
+					Name: "output",
+					Props: job.P{
+						"Ports": []fwk.Port{
+							{Name: "ints", Type: reflect.TypeOf(int64(1))},
+						},
+						"Streamer": &fwktest.OutputStream{W: out},
+					},
+				})
+
+				app.Create(job.C{
+					Type: "go-hep.org/x/hep/fwk.FilterChain",
+					Name: "chain",
+					Props: job.P{
+						"Tasks": []string{"mod2", "mod3"},
+						"Mode":  tc.mode,
+					},
+				})
+
+				for _, v := range []int64{2, 3} {
+					app.Create(job.C{
+						Type: "go-hep.org/x/hep/fwk/internal/fwktest.filter",
+						Name: fmt.Sprintf("mod%d", v),
+						Props: job.P{
+							"Input":  "ints",
+							"Output": fmt.Sprintf("ints-mod%d", v),
+							"Modulo": v,
+						},
+					})
+				}
+
+				app.Create(job.C{
+					Type: "go-hep.org/x/hep/fwk.InputStream",
+					Name: "input",
+					Props: job.P{
+						"Ports": []fwk.Port{
+							{Name: "ints", Type: reflect.TypeOf(int64(1))},
+						},
+						"Streamer": &fwktest.InputStream{R: newTestReader(max)},
+					},
+				})
+
+				err := app.App().Run()
+				if err != nil {
+					t.Fatalf("could not run app: %+v", err)
+				}
+
+				var (
+					got  = sumOutput(t, out)
+					want = sumOf(max, tc.accept, func(i int64) int64 { return i })
+				)
+				if got != want {
+					t.Fatalf("invalid sum: got=%d, want=%d", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestFilterChainConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		chains map[string]job.P
+		err    string
+	}{
+		{
+			name:   "invalid-mode",
+			chains: map[string]job.P{"chain": {"Tasks": []string{"mod2"}, "Mode": "xor"}},
+			err:    `fwk: filter chain [chain] has an invalid mode "xor"`,
+		},
+		{
+			name:   "unknown-task",
+			chains: map[string]job.P{"chain": {"Tasks": []string{"mod2", "mod5"}}},
+			err:    "fwk: filter chain [chain] has no such task [mod5]",
+		},
+		{
+			name:   "stream",
+			chains: map[string]job.P{"chain": {"Tasks": []string{"mod2", "input"}}},
+			err:    "fwk: filter chain [chain] can not hold stream [input]",
+		},
+		{
+			name:   "self",
+			chains: map[string]job.P{"chain": {"Tasks": []string{"mod2", "chain"}}},
+			err:    "fwk: filter chain [chain] contains itself",
+		},
+		{
+			name:   "dependency",
+			chains: map[string]job.P{"chain": {"Tasks": []string{"mod6", "mod2"}}},
+			err:    "fwk: filter chain [chain] runs task [mod6] before task [mod2] producing its input [ints-mod2]",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			app := newapp(-1, 0)
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk.InputStream",
+				Name: "input",
+				Props: job.P{
+					"Ports": []fwk.Port{
+						{Name: "ints", Type: reflect.TypeOf(int64(1))},
+					},
+					"Streamer": &fwktest.InputStream{R: newTestReader(10)},
+				},
+			})
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk/internal/fwktest.filter",
+				Name: "mod2",
+				Props: job.P{
+					"Input":  "ints",
+					"Output": "ints-mod2",
+					"Modulo": int64(2),
+				},
+			})
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk/internal/fwktest.filter",
+				Name: "mod6",
+				Props: job.P{
+					"Input":  "ints-mod2",
+					"Output": "ints-mod6",
+					"Modulo": int64(3),
+				},
+			})
+			for name, props := range tc.chains {
+				app.Create(job.C{
+					Type:  "go-hep.org/x/hep/fwk.FilterChain",
+					Name:  name,
+					Props: props,
+				})
+			}
+
+			err := app.App().Run()
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
+
+func TestEvtSkip(t *testing.T) {
+	const (
+		max  = 100
+		skip = 10
+		nevt = 25
+	)
+	for _, nprocs := range []int{0, 1, 4, -1} {
+		t.Run(fmt.Sprintf("nprocs=%d", nprocs), func(t *testing.T) {
+			app := newapp(nevt, nprocs)
+			app.SetProp(app.App(), "EvtSkip", int64(skip))
+			out := new(bytes.Buffer)
+
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk.InputStream",
+				Name: "input",
+				Props: job.P{
+					"Ports": []fwk.Port{
+						{Name: "ints", Type: reflect.TypeOf(int64(1))},
+					},
+					"Streamer": &fwktest.InputStream{R: newTestReader(max)},
+				},
+			})
+
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk.OutputStream",
+				Name: "output",
+				Props: job.P{
+					"Ports": []fwk.Port{
+						{Name: "ints", Type: reflect.TypeOf(int64(1))},
+					},
+					"Streamer": &fwktest.OutputStream{W: out},
+				},
+			})
+
+			err := app.App().Run()
+			if err != nil {
+				t.Fatalf("could not run app: %+v", err)
+			}
+
+			var (
+				got  = sumOutput(t, out)
+				want = sumOf(max,
+					func(i int64) bool { return skip <= i && i < skip+nevt },
+					func(i int64) int64 { return i },
+				)
+			)
+			if got != want {
+				t.Fatalf("invalid sum: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	const (
+		max   = 100
+		crash = 42
+	)
+
+	run := func(t *testing.T, nprocs int, dir string, r io.Reader) error {
+		t.Helper()
+		app := newapp(-1, nprocs)
+		app.SetProp(app.App(), "Checkpoint", filepath.Join(dir, "ckpt.json"))
+		app.SetProp(app.App(), "CheckpointFreq", int64(7))
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk.InputStream",
+			Name: "input",
+			Props: job.P{
+				"Ports": []fwk.Port{
+					{Name: "ints", Type: reflect.TypeOf(int64(1))},
+				},
+				"Streamer": &fwktest.InputStream{R: r},
+			},
+		})
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk/internal/fwktest.task2",
+			Name: "t2",
+			Props: job.P{
+				"Input":  "ints",
+				"Output": "ints-sq",
+			},
+		})
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk.OutputStream",
+			Name: "output",
+			Props: job.P{
+				"Ports": []fwk.Port{
+					{Name: "ints-sq", Type: reflect.TypeOf(int64(1))},
+				},
+				"Streamer": &fwktest.CommitOutputStream{Name: filepath.Join(dir, "out.txt")},
+			},
+		})
+
+		return app.App().Run()
+	}
+
+	sum := func(t *testing.T, dir string) int64 {
+		t.Helper()
+		f, err := os.Open(filepath.Join(dir, "out.txt"))
+		if err != nil {
+			t.Fatalf("could not open output: %+v", err)
+		}
+		defer f.Close()
+		return sumOutput(t, f)
+	}
+
+	for _, nprocs := range []int{0, 1, 4, -1} {
+		t.Run(fmt.Sprintf("nprocs=%d", nprocs), func(t *testing.T) {
+			dir := t.TempDir()
+
+			// first run fails while reading the input stream.
+			r := new(bytes.Buffer)
+			for i := range crash {
+				fmt.Fprintf(r, "%d\n", i)
+			}
+			fmt.Fprintf(r, "boom\n")
+
+			err := run(t, nprocs, dir, r)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			sum1 := sum(t, dir)
+
+			// second run resumes from the checkpoint.
+			err = run(t, nprocs, dir, newTestReader(max))
+			if err != nil {
+				t.Fatalf("could not resume app: %+v", err)
+			}
+			sum2 := sum(t, dir) - sum1
+
+			want := sumOf(max,
+				func(i int64) bool { return true },
+				func(i int64) int64 { return i * i },
+			)
+			if got := sum1 + sum2; got != want {
+				t.Fatalf("invalid sum: got=%d+%d=%d, want=%d", sum1, sum2, got, want)
+			}
+
+			// third run has nothing left to process.
+			err = run(t, nprocs, dir, newTestReader(max))
+			if err != nil {
+				t.Fatalf("could not resume app: %+v", err)
+			}
+			if sum3 := sum(t, dir) - sum1 - sum2; sum3 != 0 {
+				t.Fatalf("events were processed again: sum=%d", sum3)
+			}
+		})
+	}
+}
+
+func TestCheckpointKill(t *testing.T) {
+	const (
+		max  = 100
+		kill = 55
+	)
+
+	streamer := func(kind, dir string) fwk.OutputStreamer {
+		switch kind {
+		case "txt":
+			return &fwktest.CommitOutputStream{Name: filepath.Join(dir, "out.txt")}
+		case "rio":
+			return &fwkrio.OutputStreamer{Name: filepath.Join(dir, "out.rio")}
+		}
+		panic("invalid output kind " + kind)
+	}
+
+	run := func(nprocs int, kind, dir string, fct func(int64) int64) error {
+		app := newapp(-1, nprocs)
+		app.SetProp(app.App(), "Checkpoint", filepath.Join(dir, "ckpt.json"))
+		app.SetProp(app.App(), "CheckpointFreq", int64(7))
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk.InputStream",
+			Name: "input",
+			Props: job.P{
+				"Ports": []fwk.Port{
+					{Name: "ints", Type: reflect.TypeOf(int64(1))},
+				},
+				"Streamer": &fwktest.InputStream{R: newTestReader(max)},
+			},
+		})
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk/internal/fwktest.task2",
+			Name: "t2",
+			Props: job.P{
+				"Input":  "ints",
+				"Output": "ints-out",
+				"Fct":    fct,
+			},
+		})
+
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk.OutputStream",
+			Name: "output",
+			Props: job.P{
+				"Ports": []fwk.Port{
+					{Name: "ints-out", Type: reflect.TypeOf(int64(1))},
+				},
+				"Streamer": streamer(kind, dir),
+			},
+		})
+
+		return app.App().Run()
+	}
+
+	read := func(t *testing.T, kind, dir string) []int64 {
+		t.Helper()
+		f, err := os.Open(filepath.Join(dir, "out."+kind))
+		if err != nil {
+			t.Fatalf("could not open output: %+v", err)
+		}
+		defer f.Close()
+
+		var vs []int64
+		switch kind {
+		case "txt":
+			raw, err := io.ReadAll(f)
+			if err != nil {
+				t.Fatalf("could not read output: %+v", err)
+			}
+			for _, line := range strings.Fields(string(raw)) {
+				v, err := strconv.ParseInt(line, 10, 64)
+				if err != nil {
+					t.Fatalf("could not parse output: %+v", err)
+				}
+				vs = append(vs, v)
+			}
+		case "rio":
+			r, err := rio.NewReader(f)
+			if err != nil {
+				t.Fatalf("could not open rio stream: %+v", err)
+			}
+			defer r.Close()
+			sc := rio.NewScanner(r)
+			sc.Select([]rio.Selector{{Name: "ints-out", Unpack: true}})
+			for sc.Scan() {
+				var v int64
+				err = sc.Record().Block("ints-out").Read(&v)
+				if err != nil {
+					t.Fatalf("could not read record: %+v", err)
+				}
+				vs = append(vs, v)
+			}
+			if err := sc.Err(); err != nil && err != io.EOF {
+				t.Fatalf("could not scan rio stream: %+v", err)
+			}
+		}
+		return vs
+	}
+
+	if dir := os.Getenv("FWK_TEST_KILL_DIR"); dir != "" {
+		// child process, killed while processing an event.
+		nprocs, err := strconv.Atoi(os.Getenv("FWK_TEST_KILL_NPROCS"))
+		if err != nil {
+			t.Fatalf("could not parse nprocs: %+v", err)
+		}
+		err = run(nprocs, os.Getenv("FWK_TEST_KILL_OUTPUT"), dir, func(i int64) int64 {
+			if i == kill {
+				os.Exit(3)
+			}
+			return i
+		})
+		t.Fatalf("process was not killed (err=%v)", err)
+	}
+
+	for _, kind := range []string{"txt", "rio"} {
+		for _, nprocs := range []int{0, 1, 4} {
+			t.Run(fmt.Sprintf("%s-nprocs=%d", kind, nprocs), func(t *testing.T) {
+				dir := t.TempDir()
+				cmd := exec.Command(os.Args[0], "-test.run=^TestCheckpointKill$")
+				cmd.Env = append(os.Environ(),
+					"FWK_TEST_KILL_DIR="+dir,
+					"FWK_TEST_KILL_OUTPUT="+kind,
+					fmt.Sprintf("FWK_TEST_KILL_NPROCS=%d", nprocs),
+				)
+				out, err := cmd.CombinedOutput()
+				var eerr *exec.ExitError
+				if !errors.As(err, &eerr) || eerr.ExitCode() != 3 {
+					t.Fatalf("process was not killed: %+v\n%s", err, out)
+				}
+
+				fi, err := os.Stat(filepath.Join(dir, "out."+kind))
+				if err != nil {
+					t.Fatalf("could not stat output of killed process: %+v", err)
+				}
+				if fi.Size() == 0 {
+					t.Fatalf("killed process did not commit any output")
+				}
+
+				err = run(nprocs, kind, dir, func(i int64) int64 { return i })
+				if err != nil {
+					t.Fatalf("could not resume app: %+v", err)
+				}
+
+				seen := make(map[int64]int)
+				for _, v := range read(t, kind, dir) {
+					seen[v]++
+				}
+				for i := range int64(max) {
+					if n := seen[i]; n != 1 {
+						t.Errorf("event %d written %d times", i, n)
+					}
+				}
+				if got, want := len(seen), max; got != want {
+					t.Fatalf("invalid number of events: got=%d, want=%d", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestCheckpointNoCommit(t *testing.T) {
+	app := newapp(10, 0)
+	app.SetProp(app.App(), "Checkpoint", filepath.Join(t.TempDir(), "ckpt.json"))
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk/internal/fwktest.task1",
+		Name: "t1",
+		Props: job.P{
+			"Ints1": "t1-ints1",
+			"Ints2": "t1-ints2",
+		},
+	})
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk.OutputStream",
+		Name: "output",
+		Props: job.P{
+			"Ports": []fwk.Port{
+				{Name: "t1-ints1", Type: reflect.TypeOf(int64(1))},
+			},
+			"Streamer": &fwktest.OutputStream{W: io.Discard},
+		},
+	})
+
+	err := app.App().Run()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	want := "fwk: output stream [output] can not be checkpointed: its streamer (*fwktest.OutputStream) does not implement fwk.OutputCommitter"
+	if got := err.Error(); got != want {
+		t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+	}
+}
+
+func TestCheckpointMismatch(t *testing.T) {
+	ckpt := filepath.Join(t.TempDir(), "ckpt.json")
+	for i, skip := range []int64{0, 10} {
+		app := newapp(20, 0)
+		app.SetProp(app.App(), "EvtSkip", skip)
+		app.SetProp(app.App(), "Checkpoint", ckpt)
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk/internal/fwktest.task1",
+			Name: "t1",
+		})
+		err := app.App().Run()
+		switch i {
+		case 0:
+			if err != nil {
+				t.Fatalf("could not run app: %+v", err)
+			}
+		default:
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			want := fmt.Sprintf("fwk: checkpoint file %q was created for another event range (skip=0, max=20)", ckpt)
+			if got := err.Error(); got != want {
+				t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+			}
+		}
+	}
+}
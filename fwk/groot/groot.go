@@ -0,0 +1,59 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package groot provides fwk input and output streamers reading and writing
+// ROOT trees, mapping fwk ports to tree branches.
+//
+// The streamers are registered with fwk/job, so they can be used from YAML
+// job descriptions.
+//
+// Importing this package also enables the ".root" output format of the
+// fwk.ProfSvc profiling service.
+package groot // import "go-hep.org/x/hep/fwk/groot"
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+)
+
+func init() {
+	job.RegisterType(reflect.TypeOf(InputStreamer{}))
+	job.RegisterType(reflect.TypeOf(OutputStreamer{}))
+	fwk.RegisterProfOutput(".root", writeProf)
+}
+
+// branchesOf returns the name of the branch associated with each port,
+// following the provided port-name to branch-name mapping.
+// Ports absent from the mapping are associated with a branch of the same name.
+func branchesOf(ports []fwk.Port, mapping map[string]string) ([]string, error) {
+	names := make(map[string]struct{}, len(ports))
+	for _, port := range ports {
+		names[port.Name] = struct{}{}
+	}
+	for k := range mapping {
+		if _, ok := names[k]; !ok {
+			return nil, fmt.Errorf("fwk/groot: no port [%s] for branch [%s]", k, mapping[k])
+		}
+	}
+
+	var (
+		brs  = make([]string, len(ports))
+		seen = make(map[string]string, len(ports))
+	)
+	for i, port := range ports {
+		br, ok := mapping[port.Name]
+		if !ok {
+			br = port.Name
+		}
+		if prev, dup := seen[br]; dup {
+			return nil, fmt.Errorf("fwk/groot: ports [%s] and [%s] both map to branch [%s]", prev, port.Name, br)
+		}
+		seen[br] = port.Name
+		brs[i] = br
+	}
+	return brs, nil
+}
@@ -0,0 +1,282 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package groot_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/groot"
+	"go-hep.org/x/hep/fwk/internal/fwktest"
+	"go-hep.org/x/hep/fwk/job"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// vecs creates a slice out of its input.
+type vecs struct {
+	fwk.TaskBase
+}
+
+func (tsk *vecs) Configure(ctx fwk.Context) error {
+	err := tsk.DeclInPort("ints", reflect.TypeOf(int64(0)))
+	if err != nil {
+		return err
+	}
+	return tsk.DeclOutPort("vecs", reflect.TypeOf([]float64(nil)))
+}
+
+func (tsk *vecs) StartTask(ctx fwk.Context) error { return nil }
+func (tsk *vecs) StopTask(ctx fwk.Context) error  { return nil }
+
+func (tsk *vecs) Process(ctx fwk.Context) error {
+	v, err := ctx.Store().Get("ints")
+	if err != nil {
+		return err
+	}
+	i := float64(v.(int64))
+	return ctx.Store().Put("vecs", []float64{i, 2 * i, 3 * i}[:v.(int64)%4])
+}
+
+// vecsum checks the sum of the slices it reads.
+type vecsum struct {
+	fwk.TaskBase
+	mu  sync.Mutex
+	sum float64
+	exp float64
+}
+
+func (tsk *vecsum) Configure(ctx fwk.Context) error {
+	return tsk.DeclInPort("vecs", reflect.TypeOf([]float64(nil)))
+}
+
+func (tsk *vecsum) StartTask(ctx fwk.Context) error { return nil }
+
+func (tsk *vecsum) StopTask(ctx fwk.Context) error {
+	if tsk.sum != tsk.exp {
+		return fmt.Errorf("invalid sum: got=%v, want=%v", tsk.sum, tsk.exp)
+	}
+	return nil
+}
+
+func (tsk *vecsum) Process(ctx fwk.Context) error {
+	v, err := ctx.Store().Get("vecs")
+	if err != nil {
+		return err
+	}
+	tsk.mu.Lock()
+	defer tsk.mu.Unlock()
+	for _, x := range v.([]float64) {
+		tsk.sum += x
+	}
+	return nil
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(vecs{}), func(typ, name string, mgr fwk.App) (fwk.Component, error) {
+		return &vecs{TaskBase: fwk.NewTask(typ, name, mgr)}, nil
+	})
+	fwk.Register(reflect.TypeOf(vecsum{}), func(typ, name string, mgr fwk.App) (fwk.Component, error) {
+		tsk := &vecsum{TaskBase: fwk.NewTask(typ, name, mgr)}
+		err := tsk.DeclProp("Sum", &tsk.exp)
+		if err != nil {
+			return nil, err
+		}
+		return tsk, nil
+	})
+}
+
+func newTestReader(max int) *bytes.Buffer {
+	buf := new(bytes.Buffer)
+	for i := range max {
+		fmt.Fprintf(buf, "%d\n", int64(i))
+	}
+	return buf
+}
+
+func TestStreamers(t *testing.T) {
+	const nevts = 100
+
+	var (
+		sum  int64
+		vsum float64
+	)
+	for i := range int64(nevts) {
+		sum += i
+		for j := range i % 4 {
+			vsum += float64((j + 1) * i)
+		}
+	}
+
+	for _, nprocs := range []int{0, 2} {
+		t.Run(fmt.Sprintf("nprocs=%d", nprocs), func(t *testing.T) {
+			fname := filepath.Join(t.TempDir(), "out.root")
+
+			app := job.NewJob(nil, job.P{
+				"EvtMax":   int64(-1),
+				"NProcs":   nprocs,
+				"MsgLevel": job.MsgLevel("ERROR"),
+			})
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk.InputStream",
+				Name: "input",
+				Props: job.P{
+					"Ports": []fwk.Port{
+						{Name: "ints", Type: reflect.TypeOf(int64(0))},
+					},
+					"Streamer": &fwktest.InputStream{R: newTestReader(nevts)},
+				},
+			})
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk/groot_test.vecs",
+				Name: "vecs",
+			})
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk.OutputStream",
+				Name: "output",
+				Props: job.P{
+					"Ports": []fwk.Port{
+						{Name: "ints", Type: reflect.TypeOf(int64(0))},
+						{Name: "vecs", Type: reflect.TypeOf([]float64(nil))},
+					},
+					"Streamer": &groot.OutputStreamer{
+						Name:     fname,
+						Tree:     "evts",
+						Branches: map[string]string{"ints": "evt"},
+					},
+				},
+			})
+
+			err := app.App().Run()
+			if err != nil {
+				t.Fatalf("could not run writer app: %+v", err)
+			}
+
+			f, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer f.Close()
+
+			o, err := riofs.Dir(f).Get("evts")
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+			tree := o.(rtree.Tree)
+			if got, want := tree.Entries(), int64(nevts); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+			for _, name := range []string{"evt", "vecs"} {
+				if tree.Branch(name) == nil {
+					t.Fatalf("no branch %q in output tree", name)
+				}
+			}
+
+			app = job.NewJob(nil, job.P{
+				"EvtMax":   int64(-1),
+				"NProcs":   nprocs,
+				"MsgLevel": job.MsgLevel("ERROR"),
+			})
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk.InputStream",
+				Name: "input",
+				Props: job.P{
+					"Ports": []fwk.Port{
+						{Name: "ints", Type: reflect.TypeOf(int64(0))},
+						{Name: "vecs", Type: reflect.TypeOf([]float64(nil))},
+					},
+					"Streamer": &groot.InputStreamer{
+						Names:    []string{fname, fname},
+						Tree:     "evts",
+						Branches: map[string]string{"ints": "evt"},
+					},
+				},
+			})
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk/internal/fwktest.reducer",
+				Name: "reducer",
+				Props: job.P{
+					"Input": "ints",
+					"Sum":   2 * sum,
+				},
+			})
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk/groot_test.vecsum",
+				Name: "vecsum",
+				Props: job.P{
+					"Sum": 2 * vsum,
+				},
+			})
+
+			err = app.App().Run()
+			if err != nil {
+				t.Fatalf("could not run reader app: %+v", err)
+			}
+
+			// same reader, from a YAML job description.
+			cfg := filepath.Join(t.TempDir(), "job.yaml")
+			err = os.WriteFile(cfg, []byte(fmt.Sprintf(`
+app: {EvtMax: -1, NProcs: %d, MsgLevel: ERROR}
+components:
+  - name: input
+    type: go-hep.org/x/hep/fwk.InputStream
+    ports:
+      - {name: ints, type: int64}
+      - {name: vecs, type: "[]float64"}
+    props:
+      Streamer:
+        type: go-hep.org/x/hep/fwk/groot.InputStreamer
+        names: [%q]
+        tree: evts
+        branches: {ints: evt}
+  - name: reducer
+    type: go-hep.org/x/hep/fwk/internal/fwktest.reducer
+    props: {Input: ints, Sum: %d}
+  - name: vecsum
+    type: go-hep.org/x/hep/fwk/groot_test.vecsum
+    props: {Sum: %v}
+`, nprocs, fname, sum, vsum)), 0644)
+			if err != nil {
+				t.Fatalf("could not write YAML job: %+v", err)
+			}
+
+			desc, err := job.ReadYAML(cfg)
+			if err != nil {
+				t.Fatalf("could not read YAML job: %+v", err)
+			}
+			app = job.New(nil)
+			err = desc.Apply(app)
+			if err != nil {
+				t.Fatalf("could not configure YAML job: %+v", err)
+			}
+
+			err = app.App().Run()
+			if err != nil {
+				t.Fatalf("could not run YAML reader app: %+v", err)
+			}
+		})
+	}
+}
+
+func TestInvalidMapping(t *testing.T) {
+	o := &groot.OutputStreamer{
+		Name:     filepath.Join(t.TempDir(), "out.root"),
+		Tree:     "evts",
+		Branches: map[string]string{"ints": "evt", "foo": "bar"},
+	}
+	err := o.Connect([]fwk.Port{{Name: "ints", Type: reflect.TypeOf(int64(0))}})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), "fwk/groot: no port [foo] for branch [bar]"; got != want {
+		t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package groot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// InputStreamer reads data from a ROOT tree, possibly chained over a set of
+// ROOT files.
+//
+// Each port is filled with the content of the branch named after the port,
+// unless a different branch name is provided in the Branches mapping.
+type InputStreamer struct {
+	Names    []string          // input filenames
+	Tree     string            // name of the input tree
+	Branches map[string]string // port name to branch name mapping
+
+	close func() error
+	r     *rtree.Reader
+	ports []fwk.Port
+	vals  []reflect.Value
+
+	evts chan error    // notifies an entry is ready, or the end of the reading
+	next chan struct{} // notifies the current entry has been consumed
+	quit chan struct{}
+	done chan struct{}
+	err  error
+}
+
+var errQuit = errors.New("fwk/groot: input streamer disconnected")
+
+func (input *InputStreamer) Connect(ports []fwk.Port) error {
+	var err error
+
+	if len(input.Names) == 0 {
+		return fmt.Errorf("fwk/groot: no input file")
+	}
+
+	brs, err := branchesOf(ports, input.Branches)
+	if err != nil {
+		return err
+	}
+
+	tree, closef, err := rtree.ChainOf(input.Tree, input.Names...)
+	if err != nil {
+		return fmt.Errorf("fwk/groot: could not open tree [%s]: %w", input.Tree, err)
+	}
+	input.close = closef
+
+	input.ports = make([]fwk.Port, len(ports))
+	copy(input.ports, ports)
+
+	input.vals = make([]reflect.Value, len(ports))
+	rvars := make([]rtree.ReadVar, len(ports))
+	for i, port := range ports {
+		ptr := reflect.New(port.Type)
+		input.vals[i] = ptr.Elem()
+		rvars[i] = rtree.ReadVar{Name: brs[i], Value: ptr.Interface()}
+	}
+
+	input.r, err = rtree.NewReader(tree, rvars)
+	if err != nil {
+		_ = input.close()
+		return fmt.Errorf("fwk/groot: could not create reader for tree [%s]: %w", input.Tree, err)
+	}
+
+	input.evts = make(chan error)
+	input.next = make(chan struct{})
+	input.quit = make(chan struct{})
+	input.done = make(chan struct{})
+	input.err = nil
+
+	go input.run()
+
+	return err
+}
+
+// run drives the tree reader, pausing at each entry until it has been
+// consumed by Read.
+func (input *InputStreamer) run() {
+	defer close(input.done)
+
+	err := input.r.Read(func(rtree.RCtx) error {
+		select {
+		case input.evts <- nil:
+		case <-input.quit:
+			return errQuit
+		}
+		select {
+		case <-input.next:
+			return nil
+		case <-input.quit:
+			return errQuit
+		}
+	})
+	if err == nil {
+		err = io.EOF
+	}
+
+	select {
+	case input.evts <- err:
+	case <-input.quit:
+	}
+}
+
+func (input *InputStreamer) Read(ctx fwk.Context) error {
+	if input.err != nil {
+		return input.err
+	}
+
+	err := <-input.evts
+	if err != nil {
+		input.err = err
+		return err
+	}
+	defer func() {
+		input.next <- struct{}{}
+	}()
+
+	store := ctx.Store()
+	for i, port := range input.ports {
+		// the tree reader re-uses the memory of its read variables:
+		// hand over a copy to the event store.
+		v := clone(input.vals[i])
+		err = store.Put(port.Name, v.Interface())
+		if err != nil {
+			return fmt.Errorf("fwk/groot: could not put [%s] into store: %w", port.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (input *InputStreamer) Disconnect() error {
+	if input.quit == nil {
+		return nil
+	}
+	close(input.quit)
+	<-input.done
+	input.quit = nil
+
+	err := input.r.Close()
+	if err != nil {
+		_ = input.close()
+		return fmt.Errorf("fwk/groot: could not close tree reader: %w", err)
+	}
+
+	err = input.close()
+	if err != nil {
+		return fmt.Errorf("fwk/groot: could not close input files: %w", err)
+	}
+
+	return nil
+}
+
+// clone returns a deep copy of the provided value.
+func clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		o := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		if isFlat(v.Type().Elem()) {
+			reflect.Copy(o, v)
+			return o
+		}
+		for i := range v.Len() {
+			o.Index(i).Set(clone(v.Index(i)))
+		}
+		return o
+	case reflect.Array:
+		o := reflect.New(v.Type()).Elem()
+		o.Set(v)
+		if isFlat(v.Type().Elem()) {
+			return o
+		}
+		for i := range v.Len() {
+			o.Index(i).Set(clone(v.Index(i)))
+		}
+		return o
+	case reflect.Struct:
+		o := reflect.New(v.Type()).Elem()
+		o.Set(v)
+		for i := range v.NumField() {
+			if !o.Field(i).CanSet() {
+				continue
+			}
+			o.Field(i).Set(clone(v.Field(i)))
+		}
+		return o
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		o := reflect.New(v.Type().Elem())
+		o.Elem().Set(clone(v.Elem()))
+		return o
+	default:
+		return v
+	}
+}
+
+// isFlat returns whether values of the provided type hold no reference
+// to other memory locations.
+func isFlat(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.String:
+		return true
+	case reflect.Array:
+		return isFlat(rt.Elem())
+	case reflect.Struct:
+		for i := range rt.NumField() {
+			if !isFlat(rt.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package groot
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// OutputStreamer writes data to a ROOT tree.
+//
+// Each port is written to a branch named after the port, unless a different
+// branch name is provided in the Branches mapping.
+// Slices are written as std::vector branches.
+type OutputStreamer struct {
+	Name     string              // output filename
+	Tree     string              // name of the output tree
+	Branches map[string]string   // port name to branch name mapping
+	Options  []rtree.WriteOption // options of the output tree (compression, basket size, ...)
+
+	f     *riofs.File
+	w     rtree.Writer
+	ports []fwk.Port
+	vals  []reflect.Value
+}
+
+func (o *OutputStreamer) Connect(ports []fwk.Port) error {
+	var err error
+
+	if o.Tree == "" {
+		return fmt.Errorf("fwk/groot: no output tree name")
+	}
+
+	brs, err := branchesOf(ports, o.Branches)
+	if err != nil {
+		return err
+	}
+
+	o.ports = make([]fwk.Port, len(ports))
+	copy(o.ports, ports)
+
+	o.vals = make([]reflect.Value, len(ports))
+	wvars := make([]rtree.WriteVar, len(ports))
+	for i, port := range ports {
+		ptr := reflect.New(port.Type)
+		o.vals[i] = ptr.Elem()
+		wvars[i] = rtree.WriteVar{Name: brs[i], Value: ptr.Interface()}
+	}
+
+	o.f, err = riofs.Create(o.Name)
+	if err != nil {
+		return fmt.Errorf("fwk/groot: could not create output file: %w", err)
+	}
+
+	o.w, err = rtree.NewWriter(o.f, o.Tree, wvars, o.Options...)
+	if err != nil {
+		_ = o.f.Close()
+		return fmt.Errorf("fwk/groot: could not create tree writer: %w", err)
+	}
+
+	return err
+}
+
+func (o *OutputStreamer) Disconnect() error {
+	// make sure we don't leak filedescriptors
+	defer o.f.Close()
+
+	err := o.w.Close()
+	if err != nil {
+		return fmt.Errorf("fwk/groot: could not close tree writer: %w", err)
+	}
+
+	err = o.f.Close()
+	if err != nil {
+		return fmt.Errorf("fwk/groot: could not close output file: %w", err)
+	}
+
+	return nil
+}
+
+func (o *OutputStreamer) Write(ctx fwk.Context) error {
+	store := ctx.Store()
+
+	for i, port := range o.ports {
+		obj, err := store.Get(port.Name)
+		if err != nil {
+			return err
+		}
+
+		rv := reflect.ValueOf(obj)
+		if rv.Type() != port.Type {
+			return fmt.Errorf("fwk/groot: port[%s]: got type=%q, want type=%q",
+				port.Name,
+				rv.Type(),
+				port.Type,
+			)
+		}
+		o.vals[i].Set(rv)
+	}
+
+	_, err := o.w.Write()
+	if err != nil {
+		return fmt.Errorf("fwk/groot: could not write event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package groot
+
+import (
+	"fmt"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// writeProf writes the profiling records of a fwk.ProfSvc to a ROOT file,
+// with a "calls" and an "events" trees.
+func writeProf(fname string, data fwk.ProfData) error {
+	f, err := riofs.Create(fname)
+	if err != nil {
+		return fmt.Errorf("fwk/groot: could not create profiling output: %w", err)
+	}
+	defer f.Close()
+
+	err = writeTree(f, "calls", data.Calls)
+	if err != nil {
+		return err
+	}
+
+	err = writeTree(f, "events", data.Events)
+	if err != nil {
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("fwk/groot: could not close profiling output: %w", err)
+	}
+	return nil
+}
+
+func writeTree[T any](dir riofs.Directory, name string, rows []T) error {
+	var row T
+	w, err := rtree.NewWriter(dir, name, rtree.WriteVarsFromStruct(&row))
+	if err != nil {
+		return fmt.Errorf("fwk/groot: could not create profiling tree %q: %w", name, err)
+	}
+	defer w.Close()
+
+	for _, v := range rows {
+		row = v
+		_, err = w.Write()
+		if err != nil {
+			return fmt.Errorf("fwk/groot: could not write profiling tree %q: %w", name, err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("fwk/groot: could not close profiling tree %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package groot_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestProfOutput(t *testing.T) {
+	const nevts = 20
+
+	fname := filepath.Join(t.TempDir(), "prof.root")
+	app := job.NewJob(nil, job.P{
+		"EvtMax":   int64(nevts),
+		"NProcs":   2,
+		"MsgLevel": job.MsgLevel("ERROR"),
+	})
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk/internal/fwktest.task1",
+		Name: "t1",
+		Props: job.P{
+			"Ints1": "t1-ints1",
+			"Ints2": "t1-ints2",
+		},
+	})
+	prof := app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk.ProfSvc",
+		Name: "profsvc",
+		Props: job.P{
+			"Output": fname,
+		},
+	}).(*fwk.ProfSvc)
+
+	err := app.App().Run()
+	if err != nil {
+		t.Fatalf("could not run app: %+v", err)
+	}
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open output: %+v", err)
+	}
+	defer f.Close()
+
+	for name, n := range map[string]int{"calls": len(prof.Calls()), "events": len(prof.Events())} {
+		o, err := riofs.Dir(f).Get(name)
+		if err != nil {
+			t.Fatalf("could not get tree %q: %+v", name, err)
+		}
+		if got, want := o.(rtree.Tree).Entries(), int64(n); got != want {
+			t.Fatalf("invalid entries for tree %q: got=%d, want=%d", name, got, want)
+		}
+		if n != nevts {
+			t.Fatalf("invalid number of %s records: got=%d, want=%d", name, n, nevts)
+		}
+	}
+}
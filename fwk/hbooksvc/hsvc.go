@@ -37,6 +37,12 @@ type s2d struct {
 	mu sync.RWMutex
 }
 
+// booked is a histogram, scatter or profile with its fill lock.
+type booked struct {
+	h  fwk.Hist
+	mu *sync.RWMutex
+}
+
 type hsvc struct {
 	fwk.SvcBase
 
@@ -45,6 +51,9 @@ type hsvc struct {
 	p1ds map[fwk.HID]*p1d
 	s2ds map[fwk.HID]*s2d
 
+	mu     sync.Mutex // protects booked
+	booked []booked   // objects in booking order
+
 	streams map[string]Stream
 	w       map[string]ostream
 	r       map[string]istream
@@ -200,6 +209,7 @@ func (svc *hsvc) BookH1D(name string, nbins int, low, high float64) (fwk.H1D, er
 
 	hh := &h1d{H1D: h}
 	svc.h1ds[h.ID] = hh
+	svc.book(hh.H1D, &hh.mu)
 	return hh.H1D, err
 }
 
@@ -255,6 +265,7 @@ func (svc *hsvc) BookH2D(name string, nx int, xmin, xmax float64, ny int, ymin,
 
 	hh := &h2d{H2D: h}
 	svc.h2ds[h.ID] = hh
+	svc.book(hh.H2D, &hh.mu)
 	return hh.H2D, err
 }
 
@@ -310,6 +321,7 @@ func (svc *hsvc) BookP1D(name string, nbins int, low, high float64) (fwk.P1D, er
 
 	hh := &p1d{P1D: h}
 	svc.p1ds[h.ID] = hh
+	svc.book(hh.P1D, &hh.mu)
 	return hh.P1D, err
 }
 
@@ -365,6 +377,7 @@ func (svc *hsvc) BookS2D(name string) (fwk.S2D, error) {
 
 	hh := &s2d{S2D: h}
 	svc.s2ds[h.ID] = hh
+	svc.book(hh.S2D, &hh.mu)
 	return hh.S2D, err
 }
 
@@ -401,6 +414,29 @@ func (svc *hsvc) split(n string) (string, string) {
 	}
 }
 
+func (svc *hsvc) book(h fwk.Hist, mu *sync.RWMutex) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.booked = append(svc.booked, booked{h: h, mu: mu})
+}
+
+// VisitHists calls fn sequentially for each booked object, in booking order.
+func (svc *hsvc) VisitHists(fn func(h fwk.Hist) error) error {
+	svc.mu.Lock()
+	objs := svc.booked[:len(svc.booked):len(svc.booked)]
+	svc.mu.Unlock()
+
+	for _, o := range objs {
+		o.mu.RLock()
+		err := fn(o.h)
+		o.mu.RUnlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (svc *hsvc) FillH1D(id fwk.HID, x, w float64) {
 	h := svc.h1ds[id]
 	h.mu.Lock()
@@ -454,4 +490,7 @@ func init() {
 	fwk.Register(reflect.TypeOf(hsvc{}), newhsvc)
 }
 
-var _ fwk.HistSvc = (*hsvc)(nil)
+var (
+	_ fwk.HistSvc     = (*hsvc)(nil)
+	_ fwk.HistVisitor = (*hsvc)(nil)
+)
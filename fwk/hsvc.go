@@ -107,6 +107,15 @@ type HistSvc interface {
 	FillS2D(id HID, x, y float64)
 }
 
+// HistVisitor is implemented by HistSvc values giving access to
+// their booked histograms, scatters and profiles.
+type HistVisitor interface {
+	// VisitHists calls fn sequentially for each booked histogram,
+	// scatter or profile, in booking order.
+	// The object can not be filled during the call to fn.
+	VisitHists(fn func(h Hist) error) error
+}
+
 var _ Hist = (*H1D)(nil)
 var _ Hist = (*H2D)(nil)
 var _ Hist = (*P1D)(nil)
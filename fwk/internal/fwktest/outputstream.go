@@ -5,7 +5,9 @@
 package fwktest
 
 import (
+	"bytes"
 	"io"
+	"os"
 	"strconv"
 
 	"go-hep.org/x/hep/fwk"
@@ -45,3 +47,60 @@ func (out *OutputStream) Disconnect() error {
 
 	return err
 }
+
+// CommitOutputStream writes the values of its input port to a file, one
+// per line.
+// Values are buffered in memory until they are committed, so that only
+// committed values are visible in the file after a crash.
+type CommitOutputStream struct {
+	input string
+	f     *os.File
+	buf   bytes.Buffer
+
+	Name string // name of the output file
+}
+
+func (out *CommitOutputStream) Connect(ports []fwk.Port) error {
+	var err error
+	out.input = ports[0].Name
+	out.f, err = os.Create(out.Name)
+	return err
+}
+
+// Resume opens the output file in append mode: only committed values were
+// written to it.
+func (out *CommitOutputStream) Resume(ports []fwk.Port) error {
+	var err error
+	out.input = ports[0].Name
+	out.f, err = os.OpenFile(out.Name, os.O_APPEND|os.O_WRONLY, 0)
+	return err
+}
+
+func (out *CommitOutputStream) Write(ctx fwk.Context) error {
+	v, err := ctx.Store().Get(out.input)
+	if err != nil {
+		return err
+	}
+	out.buf.WriteString(strconv.FormatInt(v.(int64), 10) + "\n")
+	return nil
+}
+
+func (out *CommitOutputStream) Commit() error {
+	_, err := out.f.Write(out.buf.Bytes())
+	if err != nil {
+		return err
+	}
+	out.buf.Reset()
+	return out.f.Sync()
+}
+
+func (out *CommitOutputStream) Disconnect() error {
+	err := out.Commit()
+	if err != nil {
+		_ = out.f.Close()
+		return err
+	}
+	return out.f.Close()
+}
+
+var _ fwk.OutputCommitter = (*CommitOutputStream)(nil)
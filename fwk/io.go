@@ -47,3 +47,25 @@ type OutputStreamer interface {
 	// It does not (and can not) close the underlying io.Writer.
 	Disconnect() error
 }
+
+// OutputCommitter is an OutputStreamer able to make the data written so far
+// durable, and to resume writing after it.
+//
+// When checkpointing is enabled, an application commits its output
+// streamers right before saving a checkpoint, while no event is being
+// processed.
+// An application resuming from a checkpoint connects its output streamers
+// with Resume instead of Connect.
+type OutputCommitter interface {
+	OutputStreamer
+
+	// Commit makes the data written so far durable.
+	// Data written after the last call to Commit must not be visible
+	// after a crash of the application.
+	Commit() error
+
+	// Resume connects the OutputCommitter to the output of a previous
+	// run of the application, keeping the data committed by that run
+	// and discarding the rest.
+	Resume(ports []Port) error
+}
@@ -15,16 +15,24 @@ import (
 // MsgLevel panics if no fwk.Level value corresponds to the lvl string value.
 // Valid values are: "DEBUG", "INFO", "WARNING"|"WARN" and "ERROR"|"ERR".
 func MsgLevel(lvl string) fwk.Level {
+	v, err := parseLevel(lvl)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func parseLevel(lvl string) (fwk.Level, error) {
 	switch strings.ToUpper(lvl) {
 	case "DEBUG":
-		return fwk.LvlDebug
+		return fwk.LvlDebug, nil
 	case "INFO":
-		return fwk.LvlInfo
+		return fwk.LvlInfo, nil
 	case "WARNING", "WARN":
-		return fwk.LvlWarning
+		return fwk.LvlWarning, nil
 	case "ERROR", "ERR":
-		return fwk.LvlError
+		return fwk.LvlError, nil
 	default:
-		panic(fmt.Errorf("fwk.MsgLevel: invalid fwk.Level string %q", lvl))
+		return 0, fmt.Errorf("fwk.MsgLevel: invalid fwk.Level string %q", lvl)
 	}
 }
@@ -0,0 +1,490 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package job
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"go-hep.org/x/hep/fwk"
+	"gopkg.in/yaml.v3"
+)
+
+// YAML is a declarative job description, read from a YAML document.
+//
+// A YAML job description has the following layout:
+//
+//	# comments are allowed.
+//	include:          # job descriptions loaded first,
+//	  - base.yaml     # relative to the including document.
+//	app:              # properties of the fwk.App.
+//	  EvtMax: 100
+//	  NProcs: 4
+//	  MsgLevel: INFO
+//	components:       # components to create, in order.
+//	  - name: input
+//	    type: go-hep.org/x/hep/fwk.InputStream
+//	    ports:        # shorthand for the 'Ports' property.
+//	      - {name: ints, type: int64}
+//	    props:
+//	      Streamer:   # interface values need a registered concrete type.
+//	        type: go-hep.org/x/hep/fwk/groot.InputStreamer
+//	        names: [input.root]
+//	        tree: events
+//	  - name: t1
+//	    type: go-hep.org/x/hep/fwk/internal/fwktest.task1
+//	    props:
+//	      Ints1: t1-ints1
+//	overrides:        # properties of already declared components.
+//	  t1:
+//	    Ints1: t1-ints1-modified
+//
+// A component declared with the name of a component from an included
+// document is merged with it, its properties taking precedence.
+//
+// Property values are decoded into the Go type of the declared property,
+// following the rules of gopkg.in/yaml.v3: struct fields are matched with
+// their lower-cased name, or their 'yaml' tag.
+// fwk.Level values may be given by name (DEBUG, INFO, WARN, ERROR) and
+// fwk.Port types by name (see RegisterType).
+type YAML struct {
+	app   map[string]*yaml.Node
+	comps []*yamlComponent
+}
+
+type yamlDoc struct {
+	Include    []string                        `yaml:"include"`
+	App        map[string]yaml.Node            `yaml:"app"`
+	Components []yamlComp                      `yaml:"components"`
+	Overrides  map[string]map[string]yaml.Node `yaml:"overrides"`
+}
+
+type yamlComp struct {
+	Name  string               `yaml:"name"`
+	Type  string               `yaml:"type"`
+	Ports yaml.Node            `yaml:"ports"`
+	Props map[string]yaml.Node `yaml:"props"`
+}
+
+// yamlComponent is a component description, merged from all documents.
+type yamlComponent struct {
+	Name  string
+	Type  string
+	Props map[string]*yaml.Node
+}
+
+// ReadYAML reads the YAML job description from the named file,
+// resolving its includes and overrides.
+func ReadYAML(fname string) (*YAML, error) {
+	cfg := &YAML{
+		app: make(map[string]*yaml.Node),
+	}
+	err := cfg.read(fname, nil)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (cfg *YAML) read(fname string, stack []string) error {
+	fname, err := filepath.Abs(fname)
+	if err != nil {
+		return fmt.Errorf("fwk/job: could not find %q: %w", fname, err)
+	}
+	if slices.Contains(stack, fname) {
+		return fmt.Errorf("fwk/job: include cycle detected with %q", fname)
+	}
+
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		return fmt.Errorf("fwk/job: could not read job description: %w", err)
+	}
+
+	var doc yamlDoc
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	err = dec.Decode(&doc)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("fwk/job: could not decode %q: %w", fname, err)
+	}
+
+	stack = append(stack, fname)
+	for _, inc := range doc.Include {
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(fname), inc)
+		}
+		err = cfg.read(inc, stack)
+		if err != nil {
+			return err
+		}
+	}
+
+	for k, v := range doc.App {
+		cfg.app[k] = &v
+	}
+
+	for i := range doc.Components {
+		err = cfg.merge(&doc.Components[i])
+		if err != nil {
+			return fmt.Errorf("fwk/job: %s: %w", fname, err)
+		}
+	}
+
+	for name, props := range doc.Overrides {
+		comp := cfg.comp(name)
+		if comp == nil {
+			return fmt.Errorf("fwk/job: %s: no component [%s] to override", fname, name)
+		}
+		for k, v := range props {
+			comp.Props[k] = &v
+		}
+	}
+
+	return nil
+}
+
+func (cfg *YAML) comp(name string) *yamlComponent {
+	for _, c := range cfg.comps {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (cfg *YAML) merge(c *yamlComp) error {
+	if c.Name == "" {
+		return fmt.Errorf("component with type %q has no name", c.Type)
+	}
+
+	old := cfg.comp(c.Name)
+	if old == nil {
+		if c.Type == "" {
+			return fmt.Errorf("component [%s] has no type", c.Name)
+		}
+		old = &yamlComponent{
+			Name:  c.Name,
+			Type:  c.Type,
+			Props: make(map[string]*yaml.Node),
+		}
+		cfg.comps = append(cfg.comps, old)
+	}
+
+	if c.Type != "" && c.Type != old.Type {
+		return fmt.Errorf(
+			"component [%s] redeclared with type %q (was %q)",
+			c.Name, c.Type, old.Type,
+		)
+	}
+
+	for k, v := range c.Props {
+		old.Props[k] = &v
+	}
+	if !c.Ports.IsZero() {
+		old.Props["Ports"] = &c.Ports
+	}
+	return nil
+}
+
+// Packages returns the sorted list of Go packages providing the types of
+// the components and of the property values of the job description.
+func (cfg *YAML) Packages() []string {
+	set := make(map[string]struct{})
+	add := func(typ string) {
+		typ = strings.TrimLeft(typ, "[]*")
+		i := strings.LastIndex(typ, ".")
+		if i <= 0 {
+			return
+		}
+		set[typ[:i]] = struct{}{}
+	}
+
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node == nil {
+			return
+		}
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				k, v := node.Content[i], node.Content[i+1]
+				if k.Value == "type" && v.Kind == yaml.ScalarNode {
+					add(v.Value)
+				}
+			}
+		}
+		for _, sub := range node.Content {
+			walk(sub)
+		}
+	}
+
+	for _, v := range cfg.app {
+		walk(v)
+	}
+	for _, c := range cfg.comps {
+		add(c.Type)
+		for _, v := range c.Props {
+			walk(v)
+		}
+	}
+
+	pkgs := make([]string, 0, len(set))
+	for pkg := range set {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	return pkgs
+}
+
+// Apply configures the application of the job with the properties of
+// the job description, and creates its components.
+// The corresponding statements are recorded by the job.
+func (cfg *YAML) Apply(job *Job) error {
+	props, err := job.decodeProps(job.app, cfg.app)
+	if err != nil {
+		return err
+	}
+
+	app := make(P, len(job.stmts[0].Data.Props)+len(props))
+	for k, v := range job.stmts[0].Data.Props {
+		app[k] = v
+	}
+	for k, v := range props {
+		err = job.app.SetProp(job.app, k, v)
+		if err != nil {
+			return fmt.Errorf("fwk/job: could not set property %q: %w", k, err)
+		}
+		app[k] = v
+	}
+	job.stmts[0].Data.Props = app
+
+	for _, c := range cfg.comps {
+		comp, err := job.app.New(c.Type, c.Name)
+		if err != nil {
+			return fmt.Errorf("fwk/job: could not create [%s:%s]: %w", c.Type, c.Name, err)
+		}
+
+		props, err := job.decodeProps(comp, c.Props)
+		if err != nil {
+			return err
+		}
+
+		for k, v := range props {
+			err = job.app.SetProp(comp, k, v)
+			if err != nil {
+				return fmt.Errorf("fwk/job: could not set property %q of [%s]: %w", k, c.Name, err)
+			}
+		}
+
+		job.stmts = append(job.stmts, Stmt{
+			Type: StmtCreate,
+			Data: C{
+				Name:  c.Name,
+				Type:  c.Type,
+				Props: props,
+			},
+		})
+	}
+
+	return nil
+}
+
+// propTyper is implemented by fwk.App values providing the declared
+// type of properties, including interface types.
+type propTyper interface {
+	PropType(c fwk.Component, name string) (reflect.Type, error)
+}
+
+func (job *Job) decodeProps(c fwk.Component, nodes map[string]*yaml.Node) (P, error) {
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	props := make(P, len(nodes))
+	for name, node := range nodes {
+		if !job.app.HasProp(c, name) {
+			return nil, fmt.Errorf(
+				"fwk/job: component [%s:%s] has no property named %q",
+				c.Type(), c.Name(), name,
+			)
+		}
+
+		var typ reflect.Type
+		switch app := job.app.(type) {
+		case propTyper:
+			t, err := app.PropType(c, name)
+			if err != nil {
+				return nil, err
+			}
+			typ = t
+		default:
+			v, err := job.app.GetProp(c, name)
+			if err != nil {
+				return nil, err
+			}
+			typ = reflect.TypeOf(v)
+		}
+		if typ == nil {
+			return nil, fmt.Errorf(
+				"fwk/job: could not find type of property %q of component [%s]",
+				name, c.Name(),
+			)
+		}
+
+		v, err := decodeYAML(node, typ)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"fwk/job: could not decode property %q of component [%s]: %w",
+				name, c.Name(), err,
+			)
+		}
+		props[name] = v.Interface()
+	}
+	return props, nil
+}
+
+var (
+	levelType = reflect.TypeOf(fwk.Level(0))
+	portsType = reflect.TypeOf([]fwk.Port(nil))
+)
+
+// decodeYAML decodes the node into a value of type typ.
+func decodeYAML(node *yaml.Node, typ reflect.Type) (reflect.Value, error) {
+	switch {
+	case typ == levelType && node.Kind == yaml.ScalarNode && node.Tag == "!!str":
+		lvl, err := parseLevel(node.Value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(lvl), nil
+
+	case typ == portsType:
+		var raw []struct {
+			Name string `yaml:"name"`
+			Type string `yaml:"type"`
+		}
+		err := node.Decode(&raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ports := make([]fwk.Port, len(raw))
+		for i, p := range raw {
+			t, err := typeByName(p.Type)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("invalid type for port %q: %w", p.Name, err)
+			}
+			ports[i] = fwk.Port{Name: p.Name, Type: t}
+		}
+		return reflect.ValueOf(ports), nil
+
+	case typ.Kind() == reflect.Interface:
+		if node.Kind != yaml.MappingNode {
+			return reflect.Value{}, fmt.Errorf("expected a mapping with a 'type' key for a %v value", typ)
+		}
+		var (
+			name string
+			body = *node
+		)
+		body.Content = nil
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			if k.Value == "type" {
+				name = v.Value
+				continue
+			}
+			body.Content = append(body.Content, k, v)
+		}
+		if name == "" {
+			return reflect.Value{}, fmt.Errorf("missing 'type' key for a %v value", typ)
+		}
+		t, err := typeByName(name)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(t)
+		err = body.Decode(ptr.Interface())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		switch {
+		case ptr.Type().Implements(typ):
+			return ptr.Convert(typ), nil
+		case t.Implements(typ):
+			return ptr.Elem().Convert(typ), nil
+		default:
+			return reflect.Value{}, fmt.Errorf("type %q does not implement %v", name, typ)
+		}
+	}
+
+	ptr := reflect.New(typ)
+	err := node.Decode(ptr.Interface())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return ptr.Elem(), nil
+}
+
+var typedb = struct {
+	sync.RWMutex
+	db map[string]reflect.Type
+}{
+	db: func() map[string]reflect.Type {
+		db := make(map[string]reflect.Type)
+		for _, v := range []any{
+			false, "",
+			int(0), int8(0), int16(0), int32(0), int64(0),
+			uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+			float32(0), float64(0), complex64(0), complex128(0),
+		} {
+			t := reflect.TypeOf(v)
+			db[t.Name()] = t
+		}
+		return db
+	}(),
+}
+
+// RegisterType registers the named type t, so it can be referred to by
+// its fully qualified name (eg: "go-hep.org/x/hep/fwk/groot.InputStreamer")
+// from YAML job descriptions.
+// Builtin types, and slices of registered types, need not be registered.
+func RegisterType(t reflect.Type) {
+	if t.Name() == "" || t.PkgPath() == "" {
+		panic(fmt.Errorf("fwk/job: can not register unnamed type %v", t))
+	}
+	name := t.PkgPath() + "." + t.Name()
+
+	typedb.Lock()
+	defer typedb.Unlock()
+	if old, dup := typedb.db[name]; dup && old != t {
+		panic(fmt.Errorf("fwk/job: type %q already registered", name))
+	}
+	typedb.db[name] = t
+}
+
+func typeByName(name string) (reflect.Type, error) {
+	if elem, ok := strings.CutPrefix(name, "[]"); ok {
+		t, err := typeByName(elem)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(t), nil
+	}
+
+	typedb.RLock()
+	defer typedb.RUnlock()
+	t, ok := typedb.db[name]
+	if !ok {
+		return nil, fmt.Errorf("fwk/job: no type registered with name %q", name)
+	}
+	return t, nil
+}
@@ -0,0 +1,259 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package job
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/internal/fwktest"
+)
+
+func writeYAML(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, src := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644)
+		if err != nil {
+			t.Fatalf("could not write %q: %+v", name, err)
+		}
+	}
+}
+
+func TestYAML(t *testing.T) {
+	dir := t.TempDir()
+	writeYAML(t, dir, map[string]string{
+		"base.yaml": `
+app:
+  EvtMax: 10
+  NProcs: 2
+components:
+  - name: t0
+    type: go-hep.org/x/hep/fwk/internal/fwktest.task1
+    props:
+      Ints1: t0-ints1
+      Int1: 3
+  - name: svc1
+    type: go-hep.org/x/hep/fwk/internal/fwktest.svc1
+    props:
+      Int: 12
+      Struct: {i: 12}
+`,
+		"job.yaml": `
+# a job built on top of base.yaml.
+include: [base.yaml]
+app:
+  NProcs: 0
+  MsgLevel: ERROR
+components:
+  - name: t0
+    props:
+      Ints2: t0-ints2
+  - name: t1
+    type: go-hep.org/x/hep/fwk/internal/fwktest.task1
+    props:
+      Ints1: t1-ints1
+  - name: input
+    type: go-hep.org/x/hep/fwk.InputStream
+    ports:
+      - {name: ints, type: int64}
+      - {name: floats, type: "[]float64"}
+overrides:
+  t0:
+    Int1: 4
+`,
+	})
+
+	cfg, err := ReadYAML(filepath.Join(dir, "job.yaml"))
+	if err != nil {
+		t.Fatalf("could not read YAML job: %+v", err)
+	}
+
+	if got, want := cfg.Packages(), []string{
+		"go-hep.org/x/hep/fwk",
+		"go-hep.org/x/hep/fwk/internal/fwktest",
+	}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid packages:\ngot= %q\nwant=%q", got, want)
+	}
+
+	job := NewJob(fwk.NewApp(), nil)
+	err = cfg.Apply(job)
+	if err != nil {
+		t.Fatalf("could not apply YAML job: %+v", err)
+	}
+
+	want := []Stmt{
+		{
+			Type: StmtNewApp,
+			Data: C{
+				Name: "app",
+				Type: "go-hep.org/x/hep/fwk.appmgr",
+				Props: P{
+					"EvtMax":   int64(10),
+					"NProcs":   0,
+					"MsgLevel": fwk.LvlError,
+				},
+			},
+		},
+		{
+			Type: StmtCreate,
+			Data: C{
+				Name: "t0",
+				Type: "go-hep.org/x/hep/fwk/internal/fwktest.task1",
+				Props: P{
+					"Ints1": "t0-ints1",
+					"Ints2": "t0-ints2",
+					"Int1":  int64(4),
+				},
+			},
+		},
+		{
+			Type: StmtCreate,
+			Data: C{
+				Name: "svc1",
+				Type: "go-hep.org/x/hep/fwk/internal/fwktest.svc1",
+				Props: P{
+					"Int":    fwktest.MyInt(12),
+					"Struct": fwktest.MyStruct{I: 12},
+				},
+			},
+		},
+		{
+			Type: StmtCreate,
+			Data: C{
+				Name: "t1",
+				Type: "go-hep.org/x/hep/fwk/internal/fwktest.task1",
+				Props: P{
+					"Ints1": "t1-ints1",
+				},
+			},
+		},
+		{
+			Type: StmtCreate,
+			Data: C{
+				Name: "input",
+				Type: "go-hep.org/x/hep/fwk.InputStream",
+				Props: P{
+					"Ports": []fwk.Port{
+						{Name: "ints", Type: reflect.TypeOf(int64(0))},
+						{Name: "floats", Type: reflect.TypeOf([]float64(nil))},
+					},
+				},
+			},
+		},
+	}
+
+	if got := job.Stmts(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid statements:\ngot= %#v\nwant=%#v", got, want)
+	}
+
+	v, err := job.App().GetProp(job.App().Component("t0"), "Int1")
+	if err != nil {
+		t.Fatalf("could not get property: %+v", err)
+	}
+	if v != int64(4) {
+		t.Fatalf("invalid property value: got=%v, want=4", v)
+	}
+}
+
+func TestYAMLErrors(t *testing.T) {
+	const task1 = "go-hep.org/x/hep/fwk/internal/fwktest.task1"
+	for _, tc := range []struct {
+		name  string
+		files map[string]string
+		err   string
+	}{
+		{
+			name: "include-cycle",
+			files: map[string]string{
+				"job.yaml": "include: [inc.yaml]\n",
+				"inc.yaml": "include: [job.yaml]\n",
+			},
+			err: "include cycle detected",
+		},
+		{
+			name: "unknown-field",
+			files: map[string]string{
+				"job.yaml": "components:\n  - {name: t1, type: " + task1 + ", prop: {}}\n",
+			},
+			err: "field prop not found",
+		},
+		{
+			name: "redeclared-type",
+			files: map[string]string{
+				"job.yaml": "components:\n  - {name: t1, type: " + task1 + "}\n  - {name: t1, type: go-hep.org/x/hep/fwk.InputStream}\n",
+			},
+			err: `component [t1] redeclared with type "go-hep.org/x/hep/fwk.InputStream"`,
+		},
+		{
+			name: "no-type",
+			files: map[string]string{
+				"job.yaml": "components:\n  - {name: t1}\n",
+			},
+			err: "component [t1] has no type",
+		},
+		{
+			name: "override",
+			files: map[string]string{
+				"job.yaml": "overrides:\n  t1: {Int1: 2}\n",
+			},
+			err: "no component [t1] to override",
+		},
+		{
+			name: "unknown-prop",
+			files: map[string]string{
+				"job.yaml": "components:\n  - {name: t1, type: " + task1 + ", props: {Int3: 2}}\n",
+			},
+			err: `component [` + task1 + `:t1] has no property named "Int3"`,
+		},
+		{
+			name: "invalid-value",
+			files: map[string]string{
+				"job.yaml": "components:\n  - {name: t1, type: " + task1 + ", props: {Int1: one}}\n",
+			},
+			err: `could not decode property "Int1" of component [t1]`,
+		},
+		{
+			name: "invalid-level",
+			files: map[string]string{
+				"job.yaml": "app: {MsgLevel: VERBOSE}\n",
+			},
+			err: `invalid fwk.Level string "VERBOSE"`,
+		},
+		{
+			name: "invalid-port",
+			files: map[string]string{
+				"job.yaml": "components:\n  - name: in\n    type: go-hep.org/x/hep/fwk.InputStream\n    ports: [{name: ints, type: int128}]\n",
+			},
+			err: `no type registered with name "int128"`,
+		},
+		{
+			name: "interface",
+			files: map[string]string{
+				"job.yaml": "components:\n  - name: in\n    type: go-hep.org/x/hep/fwk.InputStream\n    props: {Streamer: {names: [f.root]}}\n",
+			},
+			err: "missing 'type' key",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeYAML(t, dir, tc.files)
+
+			cfg, err := ReadYAML(filepath.Join(dir, "job.yaml"))
+			if err == nil {
+				err = cfg.Apply(NewJob(fwk.NewApp(), nil))
+			}
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, tc.err)
+			}
+		})
+	}
+}
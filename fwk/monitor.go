@@ -0,0 +1,61 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fwk
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Monitor is implemented by services observing a running application.
+//
+// The application notifies the monitors among its services from the
+// moment they are started until the application is shut down.
+// The methods of a Monitor are called concurrently.
+type Monitor interface {
+	Svc
+
+	// ProcessDone is called each time the Process method of the task
+	// tsk returned for the event ievt, after having run for dt.
+	ProcessDone(ievt int64, tsk string, dt time.Duration, err error)
+
+	// EventDone is called once the event ievt was processed by all tasks.
+	EventDone(ievt int64, accepted bool)
+
+	// Message is called with each message emitted through the MsgStreams
+	// of the application, with the level lvl by the component src.
+	// Message must not emit messages through the application MsgStreams.
+	Message(lvl Level, src, msg string)
+}
+
+// msgtap forwards the messages of an application to its monitors.
+type msgtap struct {
+	mons atomic.Pointer[[]Monitor]
+}
+
+func (tap *msgtap) set(mons []Monitor) {
+	if len(mons) == 0 {
+		tap.mons.Store(nil)
+		return
+	}
+	tap.mons.Store(&mons)
+}
+
+func (tap *msgtap) monitors() []Monitor {
+	if tap == nil {
+		return nil
+	}
+	mons := tap.mons.Load()
+	if mons == nil {
+		return nil
+	}
+	return *mons
+}
+
+// observers gathers the services observing the event loop.
+type observers struct {
+	prof *ProfSvc
+	mons []Monitor
+}
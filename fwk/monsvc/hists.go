@@ -0,0 +1,118 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package monsvc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+)
+
+var errStop = errors.New("fwk/monsvc: stop visiting")
+
+// histList returns the list of objects booked with the HistSvc.
+func (svc *msvc) histList() ([]Hist, error) {
+	hists := make([]Hist, 0)
+	if svc.hists == nil {
+		return hists, nil
+	}
+
+	err := svc.hists.VisitHists(func(h fwk.Hist) error {
+		o := Hist{Name: h.Name()}
+		switch h := h.(type) {
+		case fwk.H1D:
+			o.Kind, o.Entries = "H1D", h.Hist.Entries()
+		case fwk.H2D:
+			o.Kind, o.Entries = "H2D", h.Hist.Entries()
+		case fwk.P1D:
+			o.Kind, o.Entries = "P1D", h.Profile.Entries()
+		case fwk.S2D:
+			o.Kind, o.Entries = "S2D", h.Scatter.Entries()
+		default:
+			o.Kind = fmt.Sprintf("%T", h)
+		}
+		hists = append(hists, o)
+		return nil
+	})
+	return hists, err
+}
+
+// handleHist renders the named object booked with the HistSvc.
+func (svc *msvc) handleHist(w http.ResponseWriter, r *http.Request) {
+	var (
+		name   = r.PathValue("name")
+		format = strings.TrimPrefix(path.Ext(name), ".")
+	)
+	switch format {
+	case "png", "svg":
+		name = strings.TrimSuffix(name, "."+format)
+	default:
+		http.Error(w, fmt.Sprintf("invalid image format %q", format), http.StatusBadRequest)
+		return
+	}
+
+	if svc.hists == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var raw []byte
+	err := svc.hists.VisitHists(func(h fwk.Hist) error {
+		if h.Name() != name {
+			return nil
+		}
+		var err error
+		raw, err = render(h, format)
+		if err != nil {
+			return err
+		}
+		return errStop
+	})
+	switch {
+	case errors.Is(err, errStop):
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	switch format {
+	case "svg":
+		w.Header().Set("Content-Type", "image/svg+xml")
+	default:
+		w.Header().Set("Content-Type", "image/png")
+	}
+	_, _ = w.Write(raw)
+}
+
+// render renders the histogram, scatter or profile h with hplot.
+func render(h fwk.Hist, format string) ([]byte, error) {
+	p := hplot.New()
+	p.Title.Text = h.Name()
+
+	switch h := h.(type) {
+	case fwk.H1D:
+		p.Add(hplot.NewH1D(h.Hist))
+	case fwk.H2D:
+		p.Add(hplot.NewH2D(h.Hist, nil))
+	case fwk.P1D:
+		p.Add(hplot.NewS2D(hbook.NewS2DFromP1D(h.Profile), hplot.WithYErrBars(true)))
+	case fwk.S2D:
+		p.Add(hplot.NewS2D(h.Scatter))
+	default:
+		return nil, fmt.Errorf("fwk/monsvc: can not render %T", h)
+	}
+	p.Add(hplot.NewGrid())
+
+	return hplot.Show(p, -1, -1, format)
+}
@@ -0,0 +1,104 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package monsvc
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+)
+
+// nmsgsPage is the number of messages displayed on the status page.
+const nmsgsPage = 50
+
+func (svc *msvc) handleIndex(w http.ResponseWriter, r *http.Request) {
+	hists, err := svc.histList()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	msgs := svc.messages(0)
+	if len(msgs) > nmsgsPage {
+		msgs = msgs[len(msgs)-nmsgsPage:]
+	}
+
+	data := struct {
+		Name     string
+		Refresh  int
+		Status   Status
+		Messages []Message
+		Hists    []Hist
+	}{
+		Name:     svc.Name(),
+		Refresh:  svc.refresh,
+		Status:   svc.status(),
+		Messages: msgs,
+		Hists:    hists,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = indexTmpl.Execute(w, data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+var indexTmpl = template.Must(template.New("index").Funcs(template.FuncMap{
+	"pathEscape": url.PathEscape,
+	"mul1e3":     func(v float64) float64 { return 1e3 * v },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+{{- if gt .Refresh 0}}
+<meta http-equiv="refresh" content="{{.Refresh}}">
+{{- end}}
+<title>fwk monitoring - {{.Name}}</title>
+<style>
+body  { font-family: sans-serif; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: 2px 8px; text-align: right; }
+td.txt, th.txt { text-align: left; }
+pre   { background: #f4f4f4; padding: 4px; }
+img   { margin: 4px; }
+</style>
+</head>
+<body>
+<h1>fwk monitoring</h1>
+
+<h2>Application</h2>
+<table>
+<tr><th class="txt">state</th><td>{{.Status.State}}</td></tr>
+<tr><th class="txt">uptime</th><td>{{printf "%.1f" .Status.Uptime}} s</td></tr>
+<tr><th class="txt">events</th><td>{{.Status.Events}}</td></tr>
+<tr><th class="txt">accepted</th><td>{{.Status.Accepted}}</td></tr>
+<tr><th class="txt">rate</th><td>{{printf "%.2f" .Status.Rate}} evt/s</td></tr>
+</table>
+
+<h2>Tasks</h2>
+<table>
+<tr><th class="txt">task</th><th>calls</th><th>rejected</th><th>errors</th><th>rate [1/s]</th><th>mean [ms]</th></tr>
+{{- range .Status.Tasks}}
+<tr><td class="txt">{{.Name}}</td><td>{{.Calls}}</td><td>{{.Rejected}}</td><td>{{.Errors}}</td><td>{{printf "%.2f" .Rate}}</td><td>{{printf "%.3f" (mul1e3 .Mean)}}</td></tr>
+{{- end}}
+</table>
+
+<h2>Messages</h2>
+<pre>
+{{- range .Messages}}
+{{.Time.Format "15:04:05.000"}} {{printf "%-20s" .Source}} {{printf "%-5s" .Level}} {{.Text}}
+{{- end}}
+</pre>
+
+{{- if .Hists}}
+<h2>Histograms</h2>
+{{- range .Hists}}
+<img src="/hists/{{pathEscape .Name}}.png" alt="{{.Name}}" title="{{.Name}} ({{.Kind}}, {{.Entries}} entries)">
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
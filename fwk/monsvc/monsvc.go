@@ -0,0 +1,362 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package monsvc provides a fwk service monitoring a running application
+// over HTTP.
+//
+// The service serves a status page on its 'Addr' property, with:
+//   - the FSM state of the application,
+//   - the number of processed and accepted events, and the event rate,
+//   - the number of calls, rate and mean processing time of each task,
+//   - the latest messages emitted through the application MsgStreams,
+//   - the histograms booked with the HistSvc named by the 'HistSvc' property.
+//
+// The same data is available as JSON from the following endpoints:
+//   - /api/status: a Status value,
+//   - /api/messages?since=N: the []Message with a sequence number above N,
+//   - /api/hists: the []Hist booked with the HistSvc.
+//
+// Histograms are rendered with hplot from /hists/<name>.png
+// (or /hists/<name>.svg).
+package monsvc // import "go-hep.org/x/hep/fwk/monsvc"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-hep.org/x/hep/fwk"
+)
+
+// Status describes the state of a running application.
+type Status struct {
+	State    string       `json:"state"`    // FSM state of the application
+	Uptime   float64      `json:"uptime"`   // time since the service started, in seconds
+	Events   int64        `json:"events"`   // number of processed events
+	Accepted int64        `json:"accepted"` // number of accepted events
+	Rate     float64      `json:"rate"`     // number of processed events per second
+	Tasks    []TaskStatus `json:"tasks"`
+}
+
+// TaskStatus describes the processing statistics of a task.
+type TaskStatus struct {
+	Name     string  `json:"name"`
+	Calls    int64   `json:"calls"`    // number of calls to Process
+	Rejected int64   `json:"rejected"` // number of rejected events
+	Errors   int64   `json:"errors"`   // number of failed calls to Process
+	Rate     float64 `json:"rate"`     // number of calls per second
+	Mean     float64 `json:"mean"`     // mean time spent in Process, in seconds
+}
+
+// Message is a message emitted through a MsgStream of the application.
+type Message struct {
+	Seq    int64     `json:"seq"` // sequence number of the message
+	Time   time.Time `json:"time"`
+	Level  string    `json:"level"`
+	Source string    `json:"source"` // name of the emitting component
+	Text   string    `json:"text"`
+}
+
+// Hist describes a histogram, scatter or profile booked with the HistSvc.
+type Hist struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"` // H1D, H2D, P1D or S2D
+	Entries int64  `json:"entries"`
+}
+
+type taskStats struct {
+	calls    int64
+	rejected int64
+	errors   int64
+	wall     time.Duration
+}
+
+type msvc struct {
+	fwk.SvcBase
+
+	addr    string
+	hsvc    string
+	nmsgs   int
+	refresh int
+
+	hists fwk.HistVisitor
+	srv   *http.Server
+	ln    net.Listener
+	errc  chan error
+	start time.Time
+
+	nevts atomic.Int64
+	nacc  atomic.Int64
+
+	mu    sync.Mutex
+	tasks map[string]*taskStats
+	msgs  []Message // ring buffer of the latest messages
+	seq   int64     // sequence number of the last message
+}
+
+func (svc *msvc) Configure(ctx fwk.Context) error {
+	if svc.nmsgs < 0 {
+		return fmt.Errorf("fwk/monsvc: invalid number of messages (%d)", svc.nmsgs)
+	}
+	return nil
+}
+
+func (svc *msvc) StartSvc(ctx fwk.Context) error {
+	svc.hists = nil
+	if svc.hsvc != "" {
+		v, err := ctx.Svc(svc.hsvc)
+		if err != nil {
+			return fmt.Errorf("fwk/monsvc: could not find histogram service: %w", err)
+		}
+		hists, ok := v.(fwk.HistVisitor)
+		if !ok {
+			return fmt.Errorf("fwk/monsvc: service [%s] does not give access to its histograms", svc.hsvc)
+		}
+		svc.hists = hists
+	}
+
+	ln, err := net.Listen("tcp", svc.addr)
+	if err != nil {
+		return fmt.Errorf("fwk/monsvc: could not listen on %q: %w", svc.addr, err)
+	}
+
+	svc.ln = ln
+	svc.start = time.Now()
+	svc.srv = &http.Server{Handler: svc.handler()}
+	svc.errc = make(chan error, 1)
+	go func() {
+		err := svc.srv.Serve(ln)
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		svc.errc <- err
+	}()
+
+	ctx.Msg().Infof("monitoring on http://%s/\n", ln.Addr())
+	return nil
+}
+
+func (svc *msvc) StopSvc(ctx fwk.Context) error {
+	if svc.srv == nil {
+		return nil
+	}
+
+	sctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := svc.srv.Shutdown(sctx)
+	if err != nil {
+		return fmt.Errorf("fwk/monsvc: could not shutdown server: %w", err)
+	}
+
+	err = <-svc.errc
+	if err != nil {
+		return fmt.Errorf("fwk/monsvc: could not serve: %w", err)
+	}
+	svc.srv = nil
+	return nil
+}
+
+// Addr returns the address the service is listening on.
+func (svc *msvc) Addr() string {
+	if svc.ln == nil {
+		return svc.addr
+	}
+	return svc.ln.Addr().String()
+}
+
+func (svc *msvc) ProcessDone(ievt int64, tsk string, dt time.Duration, err error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	st, ok := svc.tasks[tsk]
+	if !ok {
+		st = new(taskStats)
+		svc.tasks[tsk] = st
+	}
+	st.calls++
+	st.wall += dt
+	switch {
+	case err == nil:
+	case errors.Is(err, fwk.ErrRejected):
+		st.rejected++
+	default:
+		st.errors++
+	}
+}
+
+func (svc *msvc) EventDone(ievt int64, accepted bool) {
+	svc.nevts.Add(1)
+	if accepted {
+		svc.nacc.Add(1)
+	}
+}
+
+func (svc *msvc) Message(lvl fwk.Level, src, msg string) {
+	if svc.nmsgs == 0 {
+		return
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	svc.seq++
+	m := Message{
+		Seq:    svc.seq,
+		Time:   time.Now().UTC(),
+		Level:  levelName(lvl),
+		Source: src,
+		Text:   msg,
+	}
+	if len(svc.msgs) < svc.nmsgs {
+		svc.msgs = append(svc.msgs, m)
+		return
+	}
+	svc.msgs[int((svc.seq-1)%int64(svc.nmsgs))] = m
+}
+
+// status returns the current status of the application.
+func (svc *msvc) status() Status {
+	uptime := time.Since(svc.start).Seconds()
+	st := Status{
+		State:    svc.FSMState().String(),
+		Uptime:   uptime,
+		Events:   svc.nevts.Load(),
+		Accepted: svc.nacc.Load(),
+	}
+	if uptime > 0 {
+		st.Rate = float64(st.Events) / uptime
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	st.Tasks = make([]TaskStatus, 0, len(svc.tasks))
+	for name, v := range svc.tasks {
+		ts := TaskStatus{
+			Name:     name,
+			Calls:    v.calls,
+			Rejected: v.rejected,
+			Errors:   v.errors,
+		}
+		if uptime > 0 {
+			ts.Rate = float64(v.calls) / uptime
+		}
+		if v.calls > 0 {
+			ts.Mean = v.wall.Seconds() / float64(v.calls)
+		}
+		st.Tasks = append(st.Tasks, ts)
+	}
+	sort.Slice(st.Tasks, func(i, j int) bool { return st.Tasks[i].Name < st.Tasks[j].Name })
+	return st
+}
+
+// messages returns the buffered messages with a sequence number above since.
+func (svc *msvc) messages(since int64) []Message {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	msgs := make([]Message, 0, len(svc.msgs))
+	for _, m := range svc.msgs {
+		if m.Seq > since {
+			msgs = append(msgs, m)
+		}
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Seq < msgs[j].Seq })
+	return msgs
+}
+
+func (svc *msvc) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", svc.handleIndex)
+	mux.HandleFunc("GET /api/status", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, svc.status())
+	})
+	mux.HandleFunc("GET /api/messages", func(w http.ResponseWriter, r *http.Request) {
+		var since int64
+		if v := r.URL.Query().Get("since"); v != "" {
+			var err error
+			since, err = strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid 'since' parameter: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+		writeJSON(w, svc.messages(since))
+	})
+	mux.HandleFunc("GET /api/hists", func(w http.ResponseWriter, r *http.Request) {
+		hists, err := svc.histList()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, hists)
+	})
+	mux.HandleFunc("GET /hists/{name...}", svc.handleHist)
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func levelName(lvl fwk.Level) string {
+	switch lvl {
+	case fwk.LvlDebug, fwk.LvlInfo, fwk.LvlWarning, fwk.LvlError:
+		return lvl.String()
+	}
+	return strconv.Itoa(int(lvl))
+}
+
+func newmsvc(typ, name string, mgr fwk.App) (fwk.Component, error) {
+	var err error
+	svc := &msvc{
+		SvcBase: fwk.NewSvc(typ, name, mgr),
+		addr:    "localhost:8080",
+		nmsgs:   1000,
+		refresh: 5,
+		tasks:   make(map[string]*taskStats),
+	}
+
+	err = svc.DeclProp("Addr", &svc.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("HistSvc", &svc.hsvc)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("MaxMsgs", &svc.nmsgs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("Refresh", &svc.refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc, err
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(msvc{}), newmsvc)
+}
+
+var _ fwk.Monitor = (*msvc)(nil)
@@ -0,0 +1,212 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package monsvc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	_ "go-hep.org/x/hep/fwk/hbooksvc"
+	"go-hep.org/x/hep/fwk/job"
+)
+
+// testmon fills a histogram, rejects odd events and queries the
+// monitoring service once all events have been processed.
+type testmon struct {
+	fwk.TaskBase
+
+	hsvc fwk.HistSvc
+	h1d  fwk.H1D
+	addr string
+
+	status Status
+	msgs   []Message
+	hists  []Hist
+	png    []byte
+	index  string
+}
+
+func (tsk *testmon) StartTask(ctx fwk.Context) error {
+	svc, err := ctx.Svc("histsvc")
+	if err != nil {
+		return err
+	}
+	tsk.hsvc = svc.(fwk.HistSvc)
+
+	tsk.h1d, err = tsk.hsvc.BookH1D("h1d", 10, 0, 100)
+	if err != nil {
+		return err
+	}
+
+	svc, err = ctx.Svc("monsvc")
+	if err != nil {
+		return err
+	}
+	tsk.addr = svc.(interface{ Addr() string }).Addr()
+
+	ctx.Msg().Warnf("booked %s\n", tsk.h1d.Name())
+	return nil
+}
+
+func (tsk *testmon) Process(ctx fwk.Context) error {
+	tsk.hsvc.FillH1D(tsk.h1d.ID, float64(ctx.ID()), 1)
+	if ctx.ID()%2 == 1 {
+		return fwk.ErrRejected
+	}
+	return nil
+}
+
+func (tsk *testmon) StopTask(ctx fwk.Context) error {
+	get := func(path string) ([]byte, error) {
+		resp, err := http.Get("http://" + tsk.addr + path)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GET %s: %s", path, resp.Status)
+		}
+		return io.ReadAll(resp.Body)
+	}
+	getJSON := func(path string, ptr any) error {
+		raw, err := get(path)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(raw, ptr)
+	}
+
+	err := getJSON("/api/status", &tsk.status)
+	if err != nil {
+		return err
+	}
+	err = getJSON("/api/messages?since=0", &tsk.msgs)
+	if err != nil {
+		return err
+	}
+	err = getJSON("/api/hists", &tsk.hists)
+	if err != nil {
+		return err
+	}
+	tsk.png, err = get("/hists/h1d.png")
+	if err != nil {
+		return err
+	}
+	raw, err := get("/")
+	if err != nil {
+		return err
+	}
+	tsk.index = string(raw)
+	return nil
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(testmon{}),
+		func(typ, name string, mgr fwk.App) (fwk.Component, error) {
+			return &testmon{TaskBase: fwk.NewTask(typ, name, mgr)}, nil
+		},
+	)
+}
+
+func TestMonSvc(t *testing.T) {
+	const nevts = 20
+	for _, nprocs := range []int{0, 4} {
+		t.Run(fmt.Sprintf("nprocs=%d", nprocs), func(t *testing.T) {
+			app := job.NewJob(nil, job.P{
+				"EvtMax":   int64(nevts),
+				"NProcs":   nprocs,
+				"MsgLevel": job.MsgLevel("WARN"),
+			})
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk/hbooksvc.hsvc",
+				Name: "histsvc",
+			})
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk/monsvc.msvc",
+				Name: "monsvc",
+				Props: job.P{
+					"Addr":    "localhost:0",
+					"HistSvc": "histsvc",
+				},
+			})
+			tsk := app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk/monsvc.testmon",
+				Name: "mon",
+			}).(*testmon)
+
+			err := app.App().Run()
+			if err != nil {
+				t.Fatalf("could not run app: %+v", err)
+			}
+
+			st := tsk.status
+			if got, want := st.State, "STOPPING"; got != want {
+				t.Errorf("invalid state: got=%q, want=%q", got, want)
+			}
+			if st.Events != nevts || st.Accepted != nevts/2 {
+				t.Errorf("invalid number of events: got=%d/%d, want=%d/%d", st.Accepted, st.Events, nevts/2, nevts)
+			}
+			if len(st.Tasks) != 1 {
+				t.Fatalf("invalid number of tasks: %d", len(st.Tasks))
+			}
+			if ts := st.Tasks[0]; ts.Name != "mon" || ts.Calls != nevts || ts.Rejected != nevts/2 || ts.Errors != 0 {
+				t.Errorf("invalid task status: %+v", ts)
+			}
+
+			found := false
+			for _, msg := range tsk.msgs {
+				if msg.Source == "mon" && msg.Level == "WARN" && msg.Text == "booked h1d\n" {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("could not find message in %+v", tsk.msgs)
+			}
+
+			if got, want := tsk.hists, []Hist{{Name: "h1d", Kind: "H1D", Entries: nevts}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("invalid histograms:\ngot= %+v\nwant=%+v", got, want)
+			}
+
+			if !bytes.HasPrefix(tsk.png, []byte("\x89PNG")) {
+				t.Errorf("invalid PNG image")
+			}
+
+			for _, want := range []string{"STOPPING", `<img src="/hists/h1d.png"`, "booked h1d"} {
+				if !strings.Contains(tsk.index, want) {
+					t.Errorf("status page does not contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestMessagesRing(t *testing.T) {
+	svc := &msvc{nmsgs: 3}
+	for i := range 5 {
+		svc.Message(fwk.LvlInfo, "src", fmt.Sprintf("msg-%d", i))
+	}
+
+	msgs := svc.messages(0)
+	if len(msgs) != 3 {
+		t.Fatalf("invalid number of messages: %d", len(msgs))
+	}
+	for i, msg := range msgs {
+		if got, want := msg.Text, fmt.Sprintf("msg-%d", i+2); got != want {
+			t.Fatalf("invalid message %d: got=%q, want=%q", i, got, want)
+		}
+	}
+
+	msgs = svc.messages(4)
+	if len(msgs) != 1 || msgs[0].Seq != 5 {
+		t.Fatalf("invalid messages: %+v", msgs)
+	}
+}
@@ -20,6 +20,8 @@ type msgstream struct {
 	lvl Level
 	w   WriteSyncer
 	n   string
+	src string
+	tap *msgtap
 }
 
 // NewMsgStream creates a new MsgStream value with name name and minimum
@@ -38,6 +40,7 @@ func newMsgStream(name string, lvl Level, w WriteSyncer) msgstream {
 		lvl: lvl,
 		w:   w,
 		n:   fmt.Sprintf("%-20s ", name),
+		src: name,
 	}
 }
 
@@ -68,8 +71,19 @@ func (msg msgstream) Msg(lvl Level, format string, a ...any) {
 	if lvl < msg.lvl {
 		return
 	}
-	format = msg.n + msg.lvl.msgstring() + " " + format
-	fmt.Fprintf(msg.w, format, a...)
+	mons := msg.tap.monitors()
+	prefix := msg.n + msg.lvl.msgstring() + " "
+	format = prefix + format
+	if len(mons) == 0 {
+		fmt.Fprintf(msg.w, format, a...)
+		return
+	}
+
+	line := fmt.Sprintf(format, a...)
+	fmt.Fprint(msg.w, line)
+	for _, mon := range mons {
+		mon.Message(lvl, msg.src, line[len(prefix):])
+	}
 }
 
 func (msg msgstream) flush() error {
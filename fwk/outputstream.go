@@ -49,12 +49,21 @@ func (tsk *OutputStream) StopTask(ctx Context) error {
 	return tsk.disconnect()
 }
 
-func (tsk *OutputStream) connect(ctrl StreamControl) error {
+// connect connects the underlying OutputStreamer.
+// When resuming from a checkpoint, the OutputStreamer must be an
+// OutputCommitter and is resumed instead.
+func (tsk *OutputStream) connect(ctrl StreamControl, resume bool) error {
 	ctrl.Ports = make([]Port, len(tsk.ctrl.Ports))
 	copy(ctrl.Ports, tsk.ctrl.Ports)
 
 	tsk.ctrl = ctrl
-	err := tsk.streamer.Connect(ctrl.Ports)
+	var err error
+	switch {
+	case resume:
+		err = tsk.streamer.(OutputCommitter).Resume(ctrl.Ports)
+	default:
+		err = tsk.streamer.Connect(ctrl.Ports)
+	}
 	if err != nil {
 		return err
 	}
@@ -68,6 +77,16 @@ func (tsk *OutputStream) disconnect() error {
 	return tsk.streamer.Disconnect()
 }
 
+// commit commits the data written so far by the underlying OutputStreamer,
+// if it is an OutputCommitter.
+func (tsk *OutputStream) commit() error {
+	out, ok := tsk.streamer.(OutputCommitter)
+	if !ok {
+		return nil
+	}
+	return out.Commit()
+}
+
 func (tsk *OutputStream) write() {
 	for {
 		select {
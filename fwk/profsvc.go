@@ -0,0 +1,537 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fwk
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"runtime/metrics"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+// ProfSvc is a service profiling the event loop of an application.
+//
+// ProfSvc records the wall (and optionally CPU) time of each Task.Process
+// call, the latency of each event, the occupancy of the event store and the
+// memory allocated during the event loop.
+// ProfSvc prints a summary table when stopped.
+//
+// ProfSvc declares a property 'Output', a string, holding the name of a file
+// where all the records are written when the service is stopped.
+// The format is selected from the file extension: ".json" for a JSON
+// document, or any extension registered with RegisterProfOutput (e.g. ".root"
+// for a ROOT file, once go-hep.org/x/hep/fwk/groot has been imported).
+// Records are only kept when 'Output' is set, up to 'MaxRecords' (an int,
+// defaulting to 1000000) records of each kind: further records are dropped.
+// The summary table is computed from running aggregates and accounts for all
+// the records.
+//
+// ProfSvc declares a property 'CPU', a bool, defaulting to false, to also
+// measure the CPU time of each Task.Process call.
+// The CPU time is read from the clock of the OS thread running the task, so
+// the goroutine calling Process is locked to its thread for the duration of
+// the call: a task blocking on the event store then also blocks its thread,
+// and the Go scheduler has to start or wake up another thread to run the
+// other goroutines. Reading the thread clock costs two system calls, about
+// a microsecond, per Process call.
+// CPU times are only measured on Linux.
+//
+// Allocations are attributed to the events being processed when they are
+// made: they are exact only when events are processed sequentially.
+// Tasks run by a FilterChain are accounted for as part of the chain.
+type ProfSvc struct {
+	SvcBase
+
+	output string
+	nmax   int
+	cpu    bool
+
+	tsks   sync.Map     // task name -> *profTask
+	ncalls atomic.Int64 // number of call records, kept or dropped
+	drops  atomic.Int64
+
+	mu     sync.Mutex // protects the fields below
+	evts   []ProfEvent
+	agg    profEvents
+	nkeys  int
+	nprocs int
+	wall   time.Duration
+	beg    time.Time
+	allocs [2]allocStats // at the beginning and end of the event loop
+
+	inflight atomic.Int64
+}
+
+// ProfCall is the profiling record of a Task.Process call.
+type ProfCall struct {
+	Event int64  `json:"event" groot:"event"`
+	Task  string `json:"task" groot:"task"`
+	Wall  int64  `json:"wall" groot:"wall"` // wall time, in nanoseconds
+	CPU   int64  `json:"cpu" groot:"cpu"`   // CPU time, in nanoseconds
+}
+
+// ProfEvent is the profiling record of an event.
+type ProfEvent struct {
+	Event    int64 `json:"event" groot:"event"`
+	Latency  int64 `json:"latency" groot:"latency"`       // processing time of the event, in nanoseconds
+	InFlight int64 `json:"inflight" groot:"inflight"`     // number of events being processed, including this one
+	Store    int64 `json:"store" groot:"store"`           // number of filled keys in the event store
+	Bytes    int64 `json:"alloc_bytes" groot:"bytes"`     // number of bytes allocated while processing the event
+	Objects  int64 `json:"alloc_objects" groot:"objects"` // number of objects allocated while processing the event
+}
+
+// ProfData holds the profiling records of an event loop.
+type ProfData struct {
+	NProcs int         `json:"nprocs"`
+	Wall   int64       `json:"wall"` // wall time of the event loop, in nanoseconds
+	Calls  []ProfCall  `json:"calls"`
+	Events []ProfEvent `json:"events"`
+}
+
+// Calls returns the records of the Task.Process calls, sorted by event.
+// Records are only kept when an output file has been configured.
+func (svc *ProfSvc) Calls() []ProfCall {
+	var calls []ProfCall
+	for _, tsk := range svc.tasks() {
+		tsk.mu.Lock()
+		calls = append(calls, tsk.recs...)
+		tsk.mu.Unlock()
+	}
+	slices.SortStableFunc(calls, func(a, b ProfCall) int {
+		if a.Event != b.Event {
+			return cmp.Compare(a.Event, b.Event)
+		}
+		return strings.Compare(a.Task, b.Task)
+	})
+	return calls
+}
+
+// tasks returns the running aggregates of the profiled tasks.
+func (svc *ProfSvc) tasks() []*profTask {
+	var tsks []*profTask
+	svc.tsks.Range(func(_, v any) bool {
+		tsks = append(tsks, v.(*profTask))
+		return true
+	})
+	return tsks
+}
+
+// task returns the running aggregates of the task with the provided name.
+func (svc *ProfSvc) task(name string) *profTask {
+	v, ok := svc.tsks.Load(name)
+	if !ok {
+		v, _ = svc.tsks.LoadOrStore(name, &profTask{name: name})
+	}
+	return v.(*profTask)
+}
+
+// Events returns the records of the events, sorted by event.
+// Records are only kept when an output file has been configured.
+func (svc *ProfSvc) Events() []ProfEvent {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	return slices.Clone(svc.evts)
+}
+
+func (svc *ProfSvc) Configure(ctx Context) error {
+	if svc.output == "" {
+		return nil
+	}
+	if _, ok := profOutput(svc.output); !ok {
+		return fmt.Errorf("fwk: profiling service [%s] has an invalid output format %q", svc.Name(), filepath.Ext(svc.output))
+	}
+	return nil
+}
+
+func (svc *ProfSvc) StartSvc(ctx Context) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.tsks.Clear()
+	svc.ncalls.Store(0)
+	svc.drops.Store(0)
+	svc.evts = svc.evts[:0]
+	svc.agg = profEvents{}
+	svc.wall = 0
+	return nil
+}
+
+func (svc *ProfSvc) StopSvc(ctx Context) error {
+	calls := svc.Calls()
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	slices.SortFunc(svc.evts, func(a, b ProfEvent) int {
+		return cmp.Compare(a.Event, b.Event)
+	})
+
+	ctx.Msg().Infof("%s", svc.summary())
+
+	if svc.output == "" {
+		return nil
+	}
+
+	if drops := svc.drops.Load(); drops > 0 {
+		ctx.Msg().Warnf("%d profiling records dropped (MaxRecords=%d)\n", drops, svc.nmax)
+	}
+
+	write, _ := profOutput(svc.output)
+	return write(svc.output, ProfData{
+		NProcs: svc.nprocs,
+		Wall:   int64(svc.wall),
+		Calls:  calls,
+		Events: svc.evts,
+	})
+}
+
+// begin is called at the beginning of the event loop.
+func (svc *ProfSvc) begin(nprocs, nkeys int) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.nprocs = nprocs
+	svc.nkeys = nkeys
+	svc.allocs[0] = readAllocs()
+	svc.beg = time.Now()
+}
+
+// end is called at the end of the event loop.
+func (svc *ProfSvc) end() {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.wall = time.Since(svc.beg)
+	svc.allocs[1] = readAllocs()
+}
+
+// profEvent holds the state of an event being profiled.
+type profEvent struct {
+	ievt     int64
+	beg      time.Time
+	inflight int64
+	allocs   allocStats
+}
+
+func (svc *ProfSvc) startEvent(ievt int64) profEvent {
+	return profEvent{
+		ievt:     ievt,
+		inflight: svc.inflight.Add(1),
+		allocs:   readAllocs(),
+		beg:      time.Now(),
+	}
+}
+
+func (svc *ProfSvc) endEvent(evt profEvent, store *datastore) {
+	var (
+		latency = time.Since(evt.beg)
+		allocs  = readAllocs()
+	)
+	svc.inflight.Add(-1)
+
+	rec := ProfEvent{
+		Event:    evt.ievt,
+		Latency:  int64(latency),
+		InFlight: evt.inflight,
+		Bytes:    int64(allocs.bytes - evt.allocs.bytes),
+		Objects:  int64(allocs.objects - evt.allocs.objects),
+	}
+	if store != nil {
+		rec.Store = int64(store.filled())
+	}
+
+	svc.mu.Lock()
+	svc.agg.add(rec)
+	if svc.keep(int64(len(svc.evts))) {
+		svc.evts = append(svc.evts, rec)
+	}
+	svc.mu.Unlock()
+}
+
+// process runs and profiles the Process method of the provided task.
+func (svc *ProfSvc) process(ctx ctxType, tsk Task) error {
+	if svc.cpu {
+		// make sure the CPU time is measured on the thread running the task.
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+	}
+
+	var cpu0, cpu time.Duration
+	if svc.cpu {
+		cpu0 = threadCPUTime()
+	}
+	var (
+		beg  = time.Now()
+		err  = tsk.Process(ctx)
+		wall = time.Since(beg)
+	)
+	if svc.cpu {
+		cpu = threadCPUTime() - cpu0
+	}
+
+	agg := svc.task(tsk.Name())
+	agg.add(wall, cpu)
+	if svc.output != "" && svc.keep(svc.ncalls.Add(1)-1) {
+		agg.record(ProfCall{
+			Event: ctx.ID(),
+			Task:  agg.name,
+			Wall:  int64(wall),
+			CPU:   int64(cpu),
+		})
+	}
+
+	return err
+}
+
+// keep reports whether a new record should be appended to a slice of n
+// records.
+func (svc *ProfSvc) keep(n int64) bool {
+	switch {
+	case svc.output == "":
+		return false
+	case n >= int64(svc.nmax):
+		svc.drops.Add(1)
+		return false
+	default:
+		return true
+	}
+}
+
+// profTask holds the running aggregates and the records of the calls of a task.
+type profTask struct {
+	name  string
+	calls atomic.Int64
+	wall  atomic.Int64
+	wmax  atomic.Int64
+	cpu   atomic.Int64
+
+	mu   sync.Mutex
+	recs []ProfCall
+}
+
+func (tsk *profTask) add(wall, cpu time.Duration) {
+	tsk.calls.Add(1)
+	tsk.wall.Add(int64(wall))
+	tsk.cpu.Add(int64(cpu))
+	for {
+		cur := tsk.wmax.Load()
+		if int64(wall) <= cur || tsk.wmax.CompareAndSwap(cur, int64(wall)) {
+			break
+		}
+	}
+}
+
+func (tsk *profTask) record(call ProfCall) {
+	tsk.mu.Lock()
+	tsk.recs = append(tsk.recs, call)
+	tsk.mu.Unlock()
+}
+
+// profEvents holds the running aggregates of the events.
+type profEvents struct {
+	n    int64
+	lat  time.Duration
+	lmax time.Duration
+	nfly int64
+	fmax int64
+	nstr int64
+}
+
+func (agg *profEvents) add(evt ProfEvent) {
+	agg.n++
+	agg.lat += time.Duration(evt.Latency)
+	agg.lmax = max(agg.lmax, time.Duration(evt.Latency))
+	agg.nfly += evt.InFlight
+	agg.fmax = max(agg.fmax, evt.InFlight)
+	agg.nstr += evt.Store
+}
+
+func (svc *ProfSvc) summary() string {
+	type task struct {
+		name  string
+		calls int64
+		wall  time.Duration
+		wmax  time.Duration
+		cpu   time.Duration
+	}
+	var (
+		tsks  []task
+		wtot  time.Duration
+		o     = new(strings.Builder)
+		nevts = svc.agg.n
+	)
+	for _, tsk := range svc.tasks() {
+		tsks = append(tsks, task{
+			name:  tsk.name,
+			calls: tsk.calls.Load(),
+			wall:  time.Duration(tsk.wall.Load()),
+			wmax:  time.Duration(tsk.wmax.Load()),
+			cpu:   time.Duration(tsk.cpu.Load()),
+		})
+		wtot += tsks[len(tsks)-1].wall
+	}
+	slices.SortStableFunc(tsks, func(a, b task) int {
+		if a.wall != b.wall {
+			return cmp.Compare(b.wall, a.wall)
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	mean := func(d time.Duration, n int64) time.Duration {
+		if n == 0 {
+			return 0
+		}
+		return d / time.Duration(n)
+	}
+	frac := func(v, tot float64) float64 {
+		if tot == 0 {
+			return 0
+		}
+		return 100 * v / tot
+	}
+
+	fmt.Fprintf(o, "profiling summary: events=%d, nprocs=%d, wall=%v\n", nevts, svc.nprocs, svc.wall)
+	w := tabwriter.NewWriter(o, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "task\tcalls\twall\twall/call\twall-max\tcpu\tcpu/call\twall-%%\t\n")
+	for _, tsk := range tsks {
+		fmt.Fprintf(w, "%s\t%d\t%v\t%v\t%v\t%v\t%v\t%.1f\t\n",
+			tsk.name, tsk.calls,
+			tsk.wall.Round(time.Microsecond),
+			mean(tsk.wall, tsk.calls).Round(time.Microsecond),
+			tsk.wmax.Round(time.Microsecond),
+			tsk.cpu.Round(time.Microsecond),
+			mean(tsk.cpu, tsk.calls).Round(time.Microsecond),
+			frac(float64(tsk.wall), float64(wtot)),
+		)
+	}
+	w.Flush()
+
+	var (
+		lat   = svc.agg.lat
+		lmax  = svc.agg.lmax
+		nfly  = svc.agg.nfly
+		fmax  = svc.agg.fmax
+		nstr  = svc.agg.nstr
+		bytes = svc.allocs[1].bytes - svc.allocs[0].bytes
+		objs  = svc.allocs[1].objects - svc.allocs[0].objects
+		n     = max(nevts, 1)
+	)
+	fmt.Fprintf(o, "event latency:    mean=%v, max=%v\n", mean(lat, nevts).Round(time.Microsecond), lmax.Round(time.Microsecond))
+	fmt.Fprintf(o, "events in flight: mean=%.2f, max=%d\n", float64(nfly)/float64(n), fmax)
+	fmt.Fprintf(o, "store occupancy:  mean=%.1f%% (keys=%d)\n", frac(float64(nstr)/float64(n), float64(svc.nkeys)), svc.nkeys)
+	fmt.Fprintf(o, "allocations:      bytes=%d (%d/evt), objects=%d (%d/evt)\n", bytes, int64(bytes)/n, objs, int64(objs)/n)
+
+	return o.String()
+}
+
+var profOutputs = struct {
+	sync.RWMutex
+	db map[string]func(fname string, data ProfData) error
+}{
+	db: map[string]func(fname string, data ProfData) error{
+		".json": writeProfJSON,
+	},
+}
+
+// RegisterProfOutput registers the function writing the profiling records
+// of a ProfSvc to files with the provided extension (e.g. ".root").
+// RegisterProfOutput panics if a function is already registered for that
+// extension.
+func RegisterProfOutput(ext string, write func(fname string, data ProfData) error) {
+	ext = strings.ToLower(ext)
+	profOutputs.Lock()
+	defer profOutputs.Unlock()
+	if _, dup := profOutputs.db[ext]; dup {
+		panic(fmt.Errorf("fwk: profiling output %q already registered", ext))
+	}
+	profOutputs.db[ext] = write
+}
+
+func profOutput(fname string) (func(fname string, data ProfData) error, bool) {
+	profOutputs.RLock()
+	defer profOutputs.RUnlock()
+	write, ok := profOutputs.db[strings.ToLower(filepath.Ext(fname))]
+	return write, ok
+}
+
+func writeProfJSON(fname string, data ProfData) error {
+	f, err := os.Create(fname)
+	if err != nil {
+		return fmt.Errorf("fwk: could not create profiling output: %w", err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(data)
+	if err != nil {
+		return fmt.Errorf("fwk: could not encode profiling data: %w", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("fwk: could not close profiling output: %w", err)
+	}
+	return nil
+}
+
+// allocStats holds the cumulative number of heap allocations.
+type allocStats struct {
+	bytes   uint64
+	objects uint64
+}
+
+func readAllocs() allocStats {
+	samples := []metrics.Sample{
+		{Name: "/gc/heap/allocs:bytes"},
+		{Name: "/gc/heap/allocs:objects"},
+	}
+	metrics.Read(samples)
+
+	var stats allocStats
+	if samples[0].Value.Kind() == metrics.KindUint64 {
+		stats.bytes = samples[0].Value.Uint64()
+	}
+	if samples[1].Value.Kind() == metrics.KindUint64 {
+		stats.objects = samples[1].Value.Uint64()
+	}
+	return stats
+}
+
+func newProfSvc(typ, name string, mgr App) (Component, error) {
+	var err error
+	svc := &ProfSvc{
+		SvcBase: NewSvc(typ, name, mgr),
+		nmax:    1000000,
+	}
+
+	err = svc.DeclProp("Output", &svc.output)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("MaxRecords", &svc.nmax)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("CPU", &svc.cpu)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc, err
+}
+
+func init() {
+	Register(reflect.TypeOf(ProfSvc{}), newProfSvc)
+}
@@ -0,0 +1,148 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fwk_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+)
+
+func TestProfSvc(t *testing.T) {
+	const nevts = 20
+	for _, nprocs := range []int{0, 1, 4} {
+		for _, tc := range []struct {
+			name  string
+			ext   string
+			nmax  int
+			cpu   bool
+			calls int
+			evts  int
+		}{
+			{name: "no-output", calls: 0, evts: 0},
+			{name: "json", ext: ".json", nmax: 1000, calls: 2 * nevts, evts: nevts},
+			{name: "json-cpu", ext: ".json", nmax: 1000, cpu: true, calls: 2 * nevts, evts: nevts},
+			{name: "json-capped", ext: ".json", nmax: 5, calls: 5, evts: 5},
+		} {
+			t.Run(fmt.Sprintf("nprocs=%d-%s", nprocs, tc.name), func(t *testing.T) {
+				var (
+					fname string
+					props = job.P{"CPU": tc.cpu}
+				)
+				if tc.ext != "" {
+					fname = filepath.Join(t.TempDir(), "prof"+tc.ext)
+					props["Output"] = fname
+					props["MaxRecords"] = tc.nmax
+				}
+				app := newapp(nevts, nprocs)
+				app.Create(job.C{
+					Type: "go-hep.org/x/hep/fwk/internal/fwktest.task1",
+					Name: "t1",
+					Props: job.P{
+						"Ints1": "t1-ints1",
+						"Ints2": "t1-ints2",
+					},
+				})
+				app.Create(job.C{
+					Type: "go-hep.org/x/hep/fwk/internal/fwktest.task2",
+					Name: "t2",
+					Props: job.P{
+						"Input":  "t1-ints1",
+						"Output": "t1-ints1-massaged",
+					},
+				})
+				prof := app.Create(job.C{
+					Type:  "go-hep.org/x/hep/fwk.ProfSvc",
+					Name:  "profsvc",
+					Props: props,
+				}).(*fwk.ProfSvc)
+
+				err := app.App().Run()
+				if err != nil {
+					t.Fatalf("could not run app: %+v", err)
+				}
+
+				calls := prof.Calls()
+				if got, want := len(calls), tc.calls; got != want {
+					t.Fatalf("invalid number of calls: got=%d, want=%d", got, want)
+				}
+				ntasks := make(map[string]int)
+				for _, call := range calls {
+					ntasks[call.Task]++
+					if call.Wall <= 0 {
+						t.Fatalf("invalid wall time for %+v", call)
+					}
+					if call.CPU < 0 || (!tc.cpu && call.CPU != 0) {
+						t.Fatalf("invalid CPU time for %+v", call)
+					}
+				}
+				if tc.calls == 2*nevts && (ntasks["t1"] != nevts || ntasks["t2"] != nevts) {
+					t.Fatalf("invalid calls per task: %v", ntasks)
+				}
+
+				evts := prof.Events()
+				if got, want := len(evts), tc.evts; got != want {
+					t.Fatalf("invalid number of events: got=%d, want=%d", got, want)
+				}
+				for i, evt := range evts {
+					if i > 0 && evt.Event <= evts[i-1].Event {
+						t.Fatalf("invalid event order: %d after %d", evt.Event, evts[i-1].Event)
+					}
+					if evt.Store != 3 {
+						t.Fatalf("invalid store occupancy: got=%d, want=3", evt.Store)
+					}
+					if evt.InFlight < 1 {
+						t.Fatalf("invalid number of events in flight: %d", evt.InFlight)
+					}
+				}
+
+				if tc.ext == "" {
+					return
+				}
+
+				raw, err := os.ReadFile(fname)
+				if err != nil {
+					t.Fatalf("could not read output: %+v", err)
+				}
+				var doc fwk.ProfData
+				err = json.Unmarshal(raw, &doc)
+				if err != nil {
+					t.Fatalf("could not decode output: %+v", err)
+				}
+				if len(doc.Calls) != len(calls) || len(doc.Events) != len(evts) {
+					t.Fatalf("invalid JSON output")
+				}
+				if doc.NProcs != nprocs {
+					t.Fatalf("invalid nprocs: got=%d, want=%d", doc.NProcs, nprocs)
+				}
+			})
+		}
+	}
+}
+
+func TestProfSvcInvalidOutput(t *testing.T) {
+	// the ".root" format is only available once fwk/groot is imported.
+	for _, fname := range []string{"prof.txt", "prof.root"} {
+		t.Run(fname, func(t *testing.T) {
+			app := newapp(1, 0)
+			app.Create(job.C{
+				Type: "go-hep.org/x/hep/fwk.ProfSvc",
+				Name: "profsvc",
+				Props: job.P{
+					"Output": filepath.Join(t.TempDir(), fname),
+				},
+			})
+			err := app.App().Run()
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
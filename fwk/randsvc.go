@@ -0,0 +1,23 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fwk
+
+import (
+	"math/rand/v2"
+)
+
+// RandSvc is the interface providing reproducible streams of random numbers.
+//
+// The stream handed to a task for an event only depends on the run, the event
+// and the task name: results do not depend on the number of concurrent
+// workers nor on the order in which events are scheduled.
+type RandSvc interface {
+	Svc
+
+	// Rand returns a new random number generator for the task named tsk
+	// and the event of the provided context.
+	// Rand may be called concurrently from multiple goroutines.
+	Rand(ctx Context, tsk string) (*rand.Rand, error)
+}
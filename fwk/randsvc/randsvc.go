@@ -0,0 +1,168 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package randsvc provides a fwk.RandSvc handing out a deterministic stream
+// of random numbers for each (run, event, task) triplet.
+//
+// The run and event numbers of an event are read from the event store, so
+// the stream of an event does not depend on the position of the event in
+// the job, e.g. when the job is split or resumed.
+package randsvc // import "go-hep.org/x/hep/fwk/randsvc"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"reflect"
+
+	"go-hep.org/x/hep/fwk"
+)
+
+// rsvc implements fwk.RandSvc.
+//
+// rsvc declares the following properties:
+//   - 'Seed', a uint64, the global seed of the job;
+//   - 'EventKey', a string, the event store key holding the event number
+//     of the processed events;
+//   - 'RunKey', a string, the event store key holding the run number of
+//     the processed events;
+//   - 'Run', an int64, the run number of the processed events, used when
+//     'RunKey' is not set.
+//
+// The event store keys must hold integer values.
+// Tasks calling Rand should declare these keys as input ports, so they are
+// scheduled after the producers of the run and event numbers.
+type rsvc struct {
+	fwk.SvcBase
+
+	seed   uint64
+	evtkey string
+	runkey string
+	run    int64
+}
+
+func (svc *rsvc) Configure(ctx fwk.Context) error {
+	return nil
+}
+
+func (svc *rsvc) StartSvc(ctx fwk.Context) error {
+	return nil
+}
+
+func (svc *rsvc) StopSvc(ctx fwk.Context) error {
+	return nil
+}
+
+// Rand returns a new random number generator for the task named tsk
+// and the event of the provided context.
+// The run and event numbers of the event are read from the event store.
+func (svc *rsvc) Rand(ctx fwk.Context, tsk string) (*rand.Rand, error) {
+	run, evt, err := svc.ids(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s1, s2 := Seeds(svc.seed, run, evt, tsk)
+	return rand.New(rand.NewPCG(s1, s2)), nil
+}
+
+// ids returns the run and event numbers of the event of the provided context.
+func (svc *rsvc) ids(ctx fwk.Context) (run, evt int64, err error) {
+	if svc.evtkey == "" {
+		return 0, 0, fmt.Errorf("randsvc: service [%s] has no event store key for event numbers", svc.Name())
+	}
+
+	run = svc.run
+	evt, err = storeInt(ctx.Store(), svc.evtkey)
+	if err != nil {
+		return 0, 0, err
+	}
+	if svc.runkey != "" {
+		run, err = storeInt(ctx.Store(), svc.runkey)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return run, evt, nil
+}
+
+func storeInt(store fwk.Store, key string) (int64, error) {
+	v, err := store.Get(key)
+	if err != nil {
+		return 0, fmt.Errorf("randsvc: could not read key %q: %w", key, err)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), nil
+	default:
+		return 0, fmt.Errorf("randsvc: key %q holds a %T, not an integer", key, v)
+	}
+}
+
+// Seeds returns the pair of PCG seeds associated with the provided
+// global seed, run number, event number and task name.
+func Seeds(seed uint64, run, evt int64, tsk string) (uint64, uint64) {
+	var buf [24]byte
+	binary.LittleEndian.PutUint64(buf[0:], seed)
+	binary.LittleEndian.PutUint64(buf[8:], uint64(run))
+	binary.LittleEndian.PutUint64(buf[16:], uint64(evt))
+
+	h := fnv.New64a()
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write([]byte(tsk))
+
+	v := h.Sum64()
+	s1 := splitmix64(&v)
+	s2 := splitmix64(&v)
+	return s1, s2
+}
+
+// splitmix64 returns the next value of the SplitMix64 sequence with state v.
+func splitmix64(v *uint64) uint64 {
+	*v += 0x9e3779b97f4a7c15
+	z := *v
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func newrsvc(typ, name string, mgr fwk.App) (fwk.Component, error) {
+	var err error
+	svc := &rsvc{
+		SvcBase: fwk.NewSvc(typ, name, mgr),
+		seed:    1234,
+		run:     0,
+	}
+
+	err = svc.DeclProp("Seed", &svc.seed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("EventKey", &svc.evtkey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("RunKey", &svc.runkey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.DeclProp("Run", &svc.run)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc, err
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(rsvc{}), newrsvc)
+}
+
+var _ fwk.RandSvc = (*rsvc)(nil)
@@ -0,0 +1,246 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package randsvc
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/job"
+)
+
+// testevt puts the run and event numbers of each event in the event store.
+type testevt struct {
+	fwk.TaskBase
+
+	offset int64
+}
+
+func (tsk *testevt) Configure(ctx fwk.Context) error {
+	err := tsk.DeclOutPort("run", reflect.TypeOf(int64(0)))
+	if err != nil {
+		return err
+	}
+	return tsk.DeclOutPort("evt", reflect.TypeOf(uint32(0)))
+}
+
+func (tsk *testevt) StartTask(ctx fwk.Context) error { return nil }
+func (tsk *testevt) StopTask(ctx fwk.Context) error  { return nil }
+
+func (tsk *testevt) Process(ctx fwk.Context) error {
+	err := ctx.Store().Put("run", int64(42))
+	if err != nil {
+		return err
+	}
+	return ctx.Store().Put("evt", uint32(ctx.ID()+tsk.offset))
+}
+
+// testrand records the random numbers it draws for each event number.
+type testrand struct {
+	fwk.TaskBase
+
+	svc fwk.RandSvc
+
+	mu   sync.Mutex
+	vals map[int64][]float64
+}
+
+func (tsk *testrand) Configure(ctx fwk.Context) error {
+	err := tsk.DeclInPort("run", reflect.TypeOf(int64(0)))
+	if err != nil {
+		return err
+	}
+	return tsk.DeclInPort("evt", reflect.TypeOf(uint32(0)))
+}
+
+func (tsk *testrand) StartTask(ctx fwk.Context) error {
+	svc, err := ctx.Svc("rndsvc")
+	if err != nil {
+		return err
+	}
+	tsk.svc = svc.(fwk.RandSvc)
+	return nil
+}
+
+func (tsk *testrand) StopTask(ctx fwk.Context) error {
+	return nil
+}
+
+func (tsk *testrand) Process(ctx fwk.Context) error {
+	src, err := tsk.svc.Rand(ctx, tsk.Name())
+	if err != nil {
+		return err
+	}
+	evt, err := ctx.Store().Get("evt")
+	if err != nil {
+		return err
+	}
+	vs := make([]float64, 5)
+	for i := range vs {
+		vs[i] = src.Float64()
+	}
+	tsk.mu.Lock()
+	tsk.vals[int64(evt.(uint32))] = vs
+	tsk.mu.Unlock()
+	return nil
+}
+
+func init() {
+	fwk.Register(reflect.TypeOf(testevt{}),
+		func(typ, name string, mgr fwk.App) (fwk.Component, error) {
+			tsk := &testevt{
+				TaskBase: fwk.NewTask(typ, name, mgr),
+			}
+			err := tsk.DeclProp("Offset", &tsk.offset)
+			if err != nil {
+				return nil, err
+			}
+			return tsk, nil
+		},
+	)
+	fwk.Register(reflect.TypeOf(testrand{}),
+		func(typ, name string, mgr fwk.App) (fwk.Component, error) {
+			tsk := &testrand{
+				TaskBase: fwk.NewTask(typ, name, mgr),
+				vals:     make(map[int64][]float64),
+			}
+			return tsk, nil
+		},
+	)
+}
+
+func run(t *testing.T, nprocs int, seed uint64, offset int64) map[string]map[int64][]float64 {
+	t.Helper()
+
+	app := job.NewJob(nil, job.P{
+		"EvtMax":   int64(50),
+		"NProcs":   nprocs,
+		"MsgLevel": job.MsgLevel("ERROR"),
+	})
+
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk/randsvc.rsvc",
+		Name: "rndsvc",
+		Props: job.P{
+			"Seed":     seed,
+			"EventKey": "evt",
+			"RunKey":   "run",
+		},
+	})
+
+	app.Create(job.C{
+		Type:  "go-hep.org/x/hep/fwk/randsvc.testevt",
+		Name:  "evt",
+		Props: job.P{"Offset": offset},
+	})
+
+	var tsks []*testrand
+	for i := range 3 {
+		tsk := app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk/randsvc.testrand",
+			Name: fmt.Sprintf("t%d", i),
+		})
+		tsks = append(tsks, tsk.(*testrand))
+	}
+
+	err := app.App().Run()
+	if err != nil {
+		t.Fatalf("could not run app (nprocs=%d): %+v", nprocs, err)
+	}
+
+	vals := make(map[string]map[int64][]float64)
+	for _, tsk := range tsks {
+		vals[tsk.Name()] = tsk.vals
+	}
+	return vals
+}
+
+func TestReproducible(t *testing.T) {
+	ref := run(t, 0, 1234, 0)
+	for _, nprocs := range []int{1, 2, 4, 8} {
+		got := run(t, nprocs, 1234, 0)
+		if !reflect.DeepEqual(got, ref) {
+			t.Fatalf("nprocs=%d: random streams differ from sequential run", nprocs)
+		}
+	}
+
+	if reflect.DeepEqual(ref["t0"][0], ref["t1"][0]) {
+		t.Fatalf("tasks share the same random stream")
+	}
+	if reflect.DeepEqual(ref["t0"][0], ref["t0"][1]) {
+		t.Fatalf("events share the same random stream")
+	}
+
+	other := run(t, 0, 4321, 0)
+	if reflect.DeepEqual(ref["t0"][0], other["t0"][0]) {
+		t.Fatalf("seeds share the same random stream")
+	}
+
+	// the stream of an event only depends on its event number,
+	// not on its position in the job.
+	shifted := run(t, 4, 1234, 10)
+	for _, tsk := range []string{"t0", "t1", "t2"} {
+		for evt := int64(10); evt < 50; evt++ {
+			if !reflect.DeepEqual(shifted[tsk][evt], ref[tsk][evt]) {
+				t.Fatalf("%s: event %d: random streams differ from sequential run", tsk, evt)
+			}
+		}
+	}
+}
+
+func TestNoEventKey(t *testing.T) {
+	app := job.NewJob(nil, job.P{
+		"EvtMax":   int64(1),
+		"NProcs":   0,
+		"MsgLevel": job.MsgLevel("ERROR"),
+	})
+
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk/randsvc.rsvc",
+		Name: "rndsvc",
+	})
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk/randsvc.testevt",
+		Name: "evt",
+	})
+	app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk/randsvc.testrand",
+		Name: "t0",
+	})
+
+	err := app.App().Run()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), "randsvc: service [rndsvc] has no event store key for event numbers"; !strings.Contains(got, want) {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+func TestSeeds(t *testing.T) {
+	s1, s2 := Seeds(1, 2, 3, "task")
+	for _, tc := range []struct {
+		seed     uint64
+		run, evt int64
+		tsk      string
+	}{
+		{2, 2, 3, "task"},
+		{1, 3, 3, "task"},
+		{1, 2, 4, "task"},
+		{1, 2, 3, "tasK"},
+	} {
+		v1, v2 := Seeds(tc.seed, tc.run, tc.evt, tc.tsk)
+		if v1 == s1 && v2 == s2 {
+			t.Fatalf("seeds collision for %+v", tc)
+		}
+	}
+	if v1, v2 := Seeds(1, 2, 3, "task"); v1 != s1 || v2 != s2 {
+		t.Fatalf("seeds are not deterministic")
+	}
+}
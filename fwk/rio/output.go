@@ -5,41 +5,97 @@
 package rio
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"go-hep.org/x/hep/fwk"
 	"go-hep.org/x/hep/rio"
 )
 
 // OutputStreamer writes data to a rio-stream.
+//
+// OutputStreamer implements fwk.OutputCommitter: the size of the committed
+// rio-stream is saved in a file named after the output file, with a
+// ".commit" suffix, so that a resumed application appends its records to
+// the committed ones.
 type OutputStreamer struct {
-	Name  string         // output filename
-	w     io.WriteCloser // underlying output file
-	rio   *rio.Writer    // output rio-stream
-	recs  []*rio.Record  // list of connected records to write out
+	Name  string        // output filename
+	w     *os.File      // underlying output file
+	rio   *rio.Writer   // output rio-stream
+	recs  []*rio.Record // list of connected records to write out
 	ports []fwk.Port
 }
 
 func (o *OutputStreamer) Connect(ports []fwk.Port) error {
 	var err error
 
-	o.ports = make([]fwk.Port, len(ports))
-	copy(o.ports, ports)
-
 	// FIXME(sbinet): handle local/remote files, protocols
 	o.w, err = os.Create(o.Name)
 	if err != nil {
 		return err
 	}
 
+	err = os.Remove(o.commitName())
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
 	o.rio, err = rio.NewWriter(o.w)
 	if err != nil {
 		return err
 	}
 
+	return o.connect(ports)
+}
+
+// Resume truncates the output file to the size of its last commit and
+// appends records after it.
+func (o *OutputStreamer) Resume(ports []fwk.Port) error {
+	raw, err := os.ReadFile(o.commitName())
+	if err != nil {
+		return fmt.Errorf("fwk/rio: could not read commit of %q: %w", o.Name, err)
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(string(raw)), 10, 64)
+	if err != nil {
+		return fmt.Errorf("fwk/rio: could not parse commit of %q: %w", o.Name, err)
+	}
+
+	o.w, err = os.OpenFile(o.Name, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+
+	err = o.w.Truncate(size)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.w.Seek(size, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	o.rio, err = rio.NewAppendWriter(io.NewSectionReader(o.w, 0, size), o.w)
+	if err != nil {
+		return fmt.Errorf("fwk/rio: could not resume %q: %w", o.Name, err)
+	}
+
+	return o.connect(ports)
+}
+
+func (o *OutputStreamer) connect(ports []fwk.Port) error {
+	var err error
+
+	o.ports = make([]fwk.Port, len(ports))
+	copy(o.ports, ports)
+
+	o.recs = o.recs[:0]
 	for _, port := range o.ports {
 		rec := o.rio.Record(port.Name)
 		err = rec.Connect(port.Name, reflect.New(port.Type))
@@ -52,6 +108,38 @@ func (o *OutputStreamer) Connect(ports []fwk.Port) error {
 	return err
 }
 
+// Commit flushes the records written so far to the output file, and saves
+// the size of the rio-stream once they are on disk.
+func (o *OutputStreamer) Commit() error {
+	err := o.rio.Flush()
+	if err != nil {
+		return err
+	}
+
+	err = o.w.Sync()
+	if err != nil {
+		return err
+	}
+
+	size, err := o.w.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	// write the new commit aside, so a crash can not corrupt the last one.
+	tmp := o.commitName() + ".tmp"
+	err = os.WriteFile(tmp, []byte(strconv.FormatInt(size, 10)+"\n"), 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, o.commitName())
+}
+
+func (o *OutputStreamer) commitName() string {
+	return o.Name + ".commit"
+}
+
 func (o *OutputStreamer) Disconnect() error {
 	// make sure we don't leak filedescriptors
 	defer o.w.Close()
@@ -104,3 +192,5 @@ func (o *OutputStreamer) Write(ctx fwk.Context) error {
 	}
 	return err
 }
+
+var _ fwk.OutputCommitter = (*OutputStreamer)(nil)
@@ -20,7 +20,7 @@ func (ui irunner) lvl() Level {
 }
 
 func (ui irunner) state() fsm.State {
-	return ui.app.state
+	return ui.app.state.get()
 }
 
 func (ui *irunner) Configure() error {
@@ -28,7 +28,7 @@ func (ui *irunner) Configure() error {
 		id:    0,
 		slot:  0,
 		store: nil,
-		msg:   newMsgStream("<root>", ui.lvl(), nil),
+		msg:   ui.app.newMsgStream("<root>"),
 	}
 
 	err := ui.app.configure(ctx)
@@ -44,7 +44,7 @@ func (ui *irunner) Start() error {
 		id:    0,
 		slot:  0,
 		store: nil,
-		msg:   newMsgStream("<root>", ui.lvl(), nil),
+		msg:   ui.app.newMsgStream("<root>"),
 	}
 
 	if ui.state() < fsm.Configured {
@@ -64,7 +64,7 @@ func (ui *irunner) Run(evtmax int64) error {
 		id:    0,
 		slot:  0,
 		store: nil,
-		msg:   newMsgStream("<root>", ui.lvl(), nil),
+		msg:   ui.app.newMsgStream("<root>"),
 	}
 
 	if ui.state() < fsm.Started {
@@ -84,7 +84,7 @@ func (ui *irunner) Stop() error {
 		id:    0,
 		slot:  0,
 		store: nil,
-		msg:   newMsgStream("<root>", ui.lvl(), nil),
+		msg:   ui.app.newMsgStream("<root>"),
 	}
 
 	if ui.state() < fsm.Running {
@@ -104,7 +104,7 @@ func (ui *irunner) Shutdown() error {
 		id:    0,
 		slot:  0,
 		store: nil,
-		msg:   newMsgStream("<root>", ui.lvl(), nil),
+		msg:   ui.app.newMsgStream("<root>"),
 	}
 
 	if ui.state() < fsm.Stopped {
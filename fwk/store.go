@@ -7,6 +7,7 @@ package fwk
 import (
 	"fmt"
 	"reflect"
+	"sync"
 )
 
 type achan chan any
@@ -16,8 +17,29 @@ type datastore struct {
 	SvcBase
 	store map[string]achan
 	quit  chan struct{}
+	puts  *putset
 }
 
+// putset records the keys that were filled for the current event.
+type putset struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+// add records the key k as filled and reports whether it was not already.
+func (ps *putset) add(k string) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if _, dup := ps.keys[k]; dup {
+		return false
+	}
+	ps.keys[k] = struct{}{}
+	return true
+}
+
+// rejected is the value stored for a key whose producer rejected the event.
+type rejected struct{}
+
 func (ds *datastore) Configure(ctx Context) error {
 	return nil
 }
@@ -33,6 +55,9 @@ func (ds *datastore) Get(k string) (any, error) {
 			return nil, fmt.Errorf("%s: closed channel for key [%s]", ds.Name(), k)
 		}
 		ch <- v
+		if _, ok := v.(rejected); ok {
+			return nil, fmt.Errorf("%s: no data for key [%s]: %w", ds.Name(), k, ErrRejected)
+		}
 		return v, nil
 	case <-ds.quit:
 		return nil, fmt.Errorf("%s: timeout to get [%s]", ds.Name(), k)
@@ -40,6 +65,9 @@ func (ds *datastore) Get(k string) (any, error) {
 }
 
 func (ds *datastore) Put(k string, v any) error {
+	if ds.puts != nil && !ds.puts.add(k) {
+		return fmt.Errorf("%s: key [%s] already put", ds.Name(), k)
+	}
 	select {
 	case ds.store[k] <- v:
 		return nil
@@ -83,9 +111,32 @@ func (ds *datastore) reset(keys []string) error {
 		ds.store[k] = make(achan, 1)
 	}
 	ds.quit = make(chan struct{})
+	ds.puts = &putset{keys: make(map[string]struct{}, len(keys))}
 	return err
 }
 
+// filled returns the number of keys filled for the current event.
+func (ds *datastore) filled() int {
+	if ds.puts == nil {
+		return 0
+	}
+	ds.puts.mu.Lock()
+	defer ds.puts.mu.Unlock()
+	return len(ds.puts.keys)
+}
+
+// reject marks the provided keys, when not already filled, as rejected:
+// retrieving them returns an error wrapping ErrRejected.
+func (ds *datastore) reject(keys []string) {
+	for _, k := range keys {
+		ch, ok := ds.store[k]
+		if !ok || ds.puts == nil || !ds.puts.add(k) {
+			continue
+		}
+		ch <- rejected{}
+	}
+}
+
 // close notifies components hanging on store.Get or .Put that event has been aborted
 func (ds *datastore) close() {
 	close(ds.quit)
@@ -2,7 +2,8 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// package builder builds a fwk-app binary from a list of go files.
+// package builder builds a fwk-app binary from a list of go files
+// and YAML job descriptions.
 //
 // builder's architecture and sources are heavily inspired from golint:
 //
@@ -10,8 +11,10 @@
 package builder // import "go-hep.org/x/hep/fwk/utils/builder"
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
+	"go/importer"
 	"go/parser"
 	"go/token"
 	"go/types"
@@ -19,6 +22,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+
+	"go-hep.org/x/hep/fwk/job"
 )
 
 type file struct {
@@ -67,11 +72,19 @@ options:
 		}
 		fm := fi.Mode()
 		if fm.IsRegular() {
-			src, err := os.ReadFile(fname)
+			name := fname
+			var src []byte
+			switch filepath.Ext(fname) {
+			case ".yaml", ".yml":
+				name += ".go"
+				src, err = genYAML(fname, len(b.files))
+			default:
+				src, err = os.ReadFile(fname)
+			}
 			if err != nil {
 				return nil, fmt.Errorf("builder: could not read %q: %w", fname, err)
 			}
-			f, err := parser.ParseFile(b.fset, fname, src, parser.ParseComments)
+			f, err := parser.ParseFile(b.fset, name, src, parser.ParseComments)
 			if err != nil {
 				return nil, fmt.Errorf("builder: could not parse file %q: %w", fname, err)
 			}
@@ -80,7 +93,7 @@ options:
 				f:    f,
 				fset: b.fset,
 				src:  src,
-				name: fname,
+				name: name,
 			}
 		}
 		if fm.IsDir() {
@@ -134,7 +147,8 @@ func (b *Builder) doTypeCheck() error {
 	config := &types.Config{
 		// By setting a no-op error reporter, the type checker does
 		// as much work as possible.
-		Error: func(error) {},
+		Error:    func(error) {},
+		Importer: importer.ForCompiler(b.fset, "source", nil),
 	}
 	info := &types.Info{
 		Types: make(map[ast.Expr]types.TypeAndValue),
@@ -241,7 +255,9 @@ func (b *Builder) isMain() bool {
 
 func (b *Builder) genSources() error {
 	var err error
-	tmpdir, err := os.MkdirTemp("", "fwk-builder-")
+	// create the build directory under the current one, so the
+	// application is built against the enclosing Go module.
+	tmpdir, err := os.MkdirTemp(".", ".fwk-builder-")
 	if err != nil {
 		return fmt.Errorf("builder: could not create tmpdir: %w", err)
 	}
@@ -354,3 +370,41 @@ func (w walker) Visit(node ast.Node) ast.Visitor {
 	}
 	return nil
 }
+
+// genYAML generates the sources of a setup function configuring a job
+// from the named YAML job description.
+// The generated code imports the packages of the components of the job,
+// and reads the job description at run time.
+func genYAML(fname string, i int) ([]byte, error) {
+	fname, err := filepath.Abs(fname)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := job.ReadYAML(fname)
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Pkgs []string
+		Func string
+		File string
+	}{
+		Func: fmt.Sprintf("setupYAML%d", i),
+		File: fname,
+	}
+	for _, pkg := range cfg.Packages() {
+		if pkg == "go-hep.org/x/hep/fwk/job" {
+			continue
+		}
+		data.Pkgs = append(data.Pkgs, pkg)
+	}
+
+	buf := new(bytes.Buffer)
+	err = render(buf, yamlTmpl, data)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -28,8 +28,10 @@ import (
 var (
 	g_lvl      = flag.String("l", "INFO", "log level (DEBUG|INFO|WARN|ERROR)")
 	g_evtmax   = flag.Int("evtmax", -1, "number of events to process")
+	g_evtskip  = flag.Int("evtskip", 0, "number of events to skip")
 	g_nprocs   = flag.Int("nprocs", 0, "number of concurrent events to process")
 	g_cpu_prof = flag.Bool("cpu-prof", false, "enable CPU profiling")
+	g_ckpt     = flag.String("checkpoint", "", "path to a checkpoint file to resume from and update")
 )
 
 func main() {
@@ -52,19 +54,61 @@ func main() {
 	}
 
 	app := job.New(job.P{
-		"EvtMax":   int64(*g_evtmax),
-		"NProcs":   *g_nprocs,
-		"MsgLevel": job.MsgLevel(*g_lvl),
+		"EvtMax":     int64(*g_evtmax),
+		"EvtSkip":    int64(*g_evtskip),
+		"NProcs":     *g_nprocs,
+		"MsgLevel":   job.MsgLevel(*g_lvl),
+		"Checkpoint": *g_ckpt,
 	})
 
     {{with .SetupFuncs}}{{. | gen_setups}}{{end}}
 
+	// flags given on the command line take precedence over the job configuration.
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "l":
+			app.SetProp(app.App(), "MsgLevel", job.MsgLevel(*g_lvl))
+		case "evtmax":
+			app.SetProp(app.App(), "EvtMax", int64(*g_evtmax))
+		case "evtskip":
+			app.SetProp(app.App(), "EvtSkip", int64(*g_evtskip))
+		case "nprocs":
+			app.SetProp(app.App(), "NProcs", *g_nprocs)
+		case "checkpoint":
+			app.SetProp(app.App(), "Checkpoint", *g_ckpt)
+		}
+	})
+
 	app.Run()
 	fmt.Printf("::: {{.Name}}... [done]\n")
 }
 `
 )
 
+const yamlTmpl = `// automatically generated by go-hep.org/x/hep/fwk/utils/builder.
+// do NOT edit!
+
+package main
+
+import (
+	"go-hep.org/x/hep/fwk/job"
+{{range .Pkgs}}
+	_ {{printf "%q" .}}{{end}}
+)
+
+func {{.Func}}(app *job.Job) {
+	cfg, err := job.ReadYAML({{printf "%q" .File}})
+	if err != nil {
+		panic(err)
+	}
+
+	err = cfg.Apply(app)
+	if err != nil {
+		panic(err)
+	}
+}
+`
+
 func render(w io.Writer, text string, data any) error {
 	t := template.New("fwk-main")
 	t.Funcs(template.FuncMap{
@@ -6,7 +6,9 @@ package fwk
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 )
 
 type workercontrol struct {
@@ -21,8 +23,13 @@ type worker struct {
 	keys []string
 	//store datastore
 	ctxs []ctxType
+	outs [][]string
+	obs  observers
 	msg  msgstream
 
+	// process processes and records an event.
+	process func(ievt int64, run func() (bool, error)) error
+
 	evts   <-chan ctxType
 	done   chan<- struct{}
 	errc   chan<- error
@@ -31,20 +38,23 @@ type worker struct {
 
 func newWorker(i int, app *appmgr, ctrl *workercontrol) *worker {
 	wrk := &worker{
-		slot:   i,
-		keys:   app.dflow.keys(),
-		ctxs:   make([]ctxType, len(app.tsks)),
-		msg:    newMsgStream(fmt.Sprintf("%s-worker-%03d", app.name, i), app.msg.lvl, nil),
-		evts:   ctrl.evts,
-		done:   ctrl.done,
-		errc:   ctrl.errc,
-		runctx: ctrl.runctx,
+		slot:    i,
+		keys:    app.dflow.keys(),
+		ctxs:    make([]ctxType, len(app.tsks)),
+		outs:    app.outPorts(app.tsks),
+		obs:     observers{prof: app.prof, mons: app.mons},
+		process: app.process,
+		msg:     app.newMsgStream(fmt.Sprintf("%s-worker-%03d", app.name, i)),
+		evts:    ctrl.evts,
+		done:    ctrl.done,
+		errc:    ctrl.errc,
+		runctx:  ctrl.runctx,
 	}
 	for j, tsk := range app.tsks {
 		wrk.ctxs[j] = ctxType{
 			id:   -1,
 			slot: i,
-			msg:  newMsgStream(tsk.Name(), app.msg.lvl, nil),
+			msg:  app.newMsgStream(tsk.Name()),
 			mgr:  nil, // nobody's supposed to access mgr's state during event-loop
 		}
 	}
@@ -81,43 +91,27 @@ func (wrk *worker) runTask(ctx context.Context, ievt ctxType, tsks []Task) {
 	evtctx, evtCancel := context.WithCancel(wrk.runctx)
 	defer evtCancel()
 
-	evt := taskrunner{
-		ievt:   ievt.ID(),
-		errc:   make(chan error, len(tsks)),
-		evtctx: evtctx,
-	}
-	for i, tsk := range tsks {
-		ctx := wrk.ctxs[i]
-		ctx.store = evtstore
-		ctx.ctx = evtctx
-		go evt.run(i, ctx, tsk)
+	ctxs := make([]ctxType, len(tsks))
+	for i := range tsks {
+		ctxs[i] = wrk.ctxs[i]
+		ctxs[i].store = evtstore
+		ctxs[i].ctx = evtctx
 	}
-	ndone := 0
-errloop:
-	for {
-		select {
-		case err, ok := <-evt.errc:
-			if !ok {
-				return
-			}
-			ndone++
-			if err != nil {
-				evtstore.close()
-				wrk.msg.flush()
 
-				wrk.errc <- err
-				return
-			}
-			if ndone == len(tsks) {
-				break errloop
-			}
-		case <-evtctx.Done():
-			evtstore.close()
-			wrk.msg.flush()
+	err := wrk.process(ievt.ID(), func() (bool, error) {
+		return runEvent(evtctx, ievt.ID(), ctxs, tsks, wrk.outs, wrk.obs)
+	})
+	if err != nil {
+		evtstore.close()
+		wrk.msg.flush()
+		if evtctx.Err() != nil {
 			return
 		}
+		wrk.errc <- err
+		return
 	}
-	err := evtstore.reset(wrk.keys)
+
+	err = evtstore.reset(wrk.keys)
 	evtstore.close()
 	wrk.msg.flush()
 
@@ -127,17 +121,96 @@ errloop:
 	}
 }
 
+// runEvent runs the tasks on the event ievt and reports whether the event
+// was accepted.
+//
+// Output streams are run once all the other tasks are done, and only if
+// the event was accepted.
+func runEvent(evtctx context.Context, ievt int64, ctxs []ctxType, tsks []Task, outs [][]string, obs observers) (bool, error) {
+	if prof := obs.prof; prof != nil {
+		evt := prof.startEvent(ievt)
+		defer func() {
+			var store *datastore
+			if len(ctxs) > 0 {
+				store, _ = ctxs[0].store.(*datastore)
+			}
+			prof.endEvent(evt, store)
+		}()
+	}
+
+	accepted := true
+	for _, ostreams := range []bool{false, true} {
+		if ostreams && !accepted {
+			break
+		}
+
+		run := taskrunner{
+			ievt:   ievt,
+			errc:   make(chan error, len(tsks)),
+			evtctx: evtctx,
+			obs:    obs,
+		}
+		n := 0
+		for i, tsk := range tsks {
+			if _, ok := tsk.(*OutputStream); ok != ostreams {
+				continue
+			}
+			n++
+			go run.run(ctxs[i], tsk, outs[i])
+		}
+
+		for range n {
+			select {
+			case err := <-run.errc:
+				switch {
+				case err == nil:
+				case errors.Is(err, ErrRejected):
+					accepted = false
+				default:
+					return false, err
+				}
+			case <-evtctx.Done():
+				return false, evtctx.Err()
+			}
+		}
+	}
+	return accepted, nil
+}
+
 type taskrunner struct {
 	errc   chan error
 	evtctx context.Context
 
 	ievt int64
+	obs  observers
 }
 
-func (run taskrunner) run(i int, ctx ctxType, tsk Task) {
+func (run taskrunner) run(ctx ctxType, tsk Task, outs []string) {
 	ctx.id = run.ievt
+	var (
+		err   error
+		start time.Time
+	)
+	if len(run.obs.mons) > 0 {
+		start = time.Now()
+	}
+	switch run.obs.prof {
+	case nil:
+		err = tsk.Process(ctx)
+	default:
+		err = run.obs.prof.process(ctx, tsk)
+	}
+	if len(run.obs.mons) > 0 {
+		dt := time.Since(start)
+		for _, mon := range run.obs.mons {
+			mon.ProcessDone(run.ievt, tsk.Name(), dt, err)
+		}
+	}
+	if errors.Is(err, ErrRejected) {
+		rejectPorts(ctx, outs)
+	}
 	select {
-	case run.errc <- tsk.Process(ctx):
+	case run.errc <- err:
 		// FIXME(sbinet) dont be so eager to flush...
 		ctx.msg.flush()
 	case <-run.evtctx.Done():
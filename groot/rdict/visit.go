@@ -132,6 +132,34 @@ func (v *visitor) visitSE(depth int, se rbytes.StreamerElement) error {
 			}
 			return v.run(depth+1, si)
 
+		case rmeta.STLmap, rmeta.STLmultimap,
+			rmeta.STLunorderedmap, rmeta.STLunorderedmultimap:
+
+			etn := se.ElemTypeName()
+			if len(etn) < 2 {
+				return fmt.Errorf("rdict: invalid std::map<K,V> streamer %#v", se)
+			}
+			// only visit key and value types, not the comparator.
+			for _, etn := range etn[:2] {
+				tname := strings.TrimRight(etn, "*")
+				switch tname {
+				case "TString", "string", "std::string":
+					continue
+				}
+				if _, ok := rmeta.CxxBuiltins[tname]; ok {
+					continue
+				}
+				si, err := v.ctx.StreamerInfo(tname, -1)
+				if err != nil {
+					return fmt.Errorf("could not find std::map<K,V> element %q: %w", tname, err)
+				}
+				err = v.run(depth+1, si)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+
 		default:
 			return fmt.Errorf("rdict: cant visit non-vector-like STL streamers %#v", se)
 		}
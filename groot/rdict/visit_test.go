@@ -64,6 +64,15 @@ func TestVisit(t *testing.T) {
 			}),
 			want: []string{"F32s", "F64s"},
 		},
+		{
+			// only the key and value types of a std::map are visited.
+			si: loadSI(t, "TFormula"),
+			want: []string{
+				"TNamed", "TObject", "fUniqueID", "fBits", "fName", "fTitle",
+				"fClingParameters", "fAllParametersSetted", "fParams", "fFormula",
+				"fNdim", "fNumber", "fLinearParts", "fVectorized",
+			},
+		},
 	} {
 		t.Run(tc.si.Name(), func(t *testing.T) {
 			var got []string
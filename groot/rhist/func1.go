@@ -59,6 +59,67 @@ func newF1() *F1 {
 	}
 }
 
+// NewF1 returns a new ROOT 1-dim function, defined over [xmin, xmax]
+// and with the provided parameters values and names.
+//
+// The values of fct are sampled over 100 points and stored inside the
+// returned TF1, so ROOT can display and evaluate it even without access
+// to the Go definition of fct.
+func NewF1(name, title string, xmin, xmax float64, fct func(x float64) float64, params []float64, names []string) *F1 {
+	const (
+		npx = 100
+		// typ is the ROOT TF1::EFType value for a function defined
+		// through a pointer to a free function.
+		// ROOT falls back on the saved values to evaluate such a function
+		// when it is read back from a file.
+		typ = 1
+	)
+
+	npar := len(params)
+	if names == nil {
+		names = make([]string, npar)
+		for i := range names {
+			names[i] = fmt.Sprintf("p%d", i)
+		}
+	}
+	if len(names) != npar {
+		panic(fmt.Errorf("rhist: mismatch number of parameters (%d) and names (%d)", npar, len(names)))
+	}
+
+	f := newF1()
+	f.named = *rbase.NewNamed(name, title)
+	f.attline.Color = 2
+	f.attline.Width = 2
+	f.attfill = rbase.AttFill{Color: 19, Style: 0}
+	f.xmin = xmin
+	f.xmax = xmax
+	f.npar = int32(npar)
+	f.ndim = 1
+	f.npx = npx
+	f.typ = typ
+	f.fmin = -1111
+	f.fmax = -1111
+	f.parErrs = make([]float64, npar)
+	f.parMin = make([]float64, npar)
+	f.parMax = make([]float64, npar)
+	f.params = &F1Parameters{
+		params: append([]float64(nil), params...),
+		names:  append([]string(nil), names...),
+	}
+
+	if fct != nil {
+		f.save = make([]float64, npx+3)
+		dx := (xmax - xmin) / npx
+		for i := 0; i <= npx; i++ {
+			f.save[i] = fct(xmin + float64(i)*dx)
+		}
+		f.save[npx+1] = xmin
+		f.save[npx+2] = xmax
+	}
+
+	return f
+}
+
 func (*F1) RVersion() int16 {
 	return rvers.F1
 }
@@ -77,6 +138,58 @@ func (f *F1) Title() string {
 	return f.named.Title()
 }
 
+// XMin returns the lower bound of the range of the function.
+func (f *F1) XMin() float64 { return f.xmin }
+
+// XMax returns the upper bound of the range of the function.
+func (f *F1) XMax() float64 { return f.xmax }
+
+// Params returns the values of the parameters of the function, if any.
+func (f *F1) Params() []float64 {
+	if f.params == nil {
+		return nil
+	}
+	return f.params.params
+}
+
+// ParNames returns the names of the parameters of the function, if any.
+func (f *F1) ParNames() []string {
+	if f.params == nil {
+		return nil
+	}
+	return f.params.names
+}
+
+// ParErrors returns the errors on the parameters of the function.
+func (f *F1) ParErrors() []float64 {
+	return f.parErrs
+}
+
+// SetParErrors sets the errors on the parameters of the function.
+func (f *F1) SetParErrors(errs []float64) {
+	if len(errs) != int(f.npar) {
+		panic(fmt.Errorf("rhist: mismatch number of parameters (%d) and errors (%d)", f.npar, len(errs)))
+	}
+	copy(f.parErrs, errs)
+}
+
+// Chi2 returns the chi-square of the fit of the function.
+func (f *F1) Chi2() float64 { return f.chi2 }
+
+// NDF returns the number of degrees of freedom of the fit of the function.
+func (f *F1) NDF() int { return int(f.ndf) }
+
+// NPointsFit returns the number of points used in the fit of the function.
+func (f *F1) NPointsFit() int { return int(f.npfits) }
+
+// SetFitResult sets the chi-square, number of degrees of freedom and
+// number of points used in the fit of the function.
+func (f *F1) SetFitResult(chi2 float64, ndf, npoints int) {
+	f.chi2 = chi2
+	f.ndf = int32(ndf)
+	f.npfits = int32(npoints)
+}
+
 // MarshalROOT implements rbytes.Marshaler
 func (f *F1) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
@@ -193,6 +306,19 @@ func (*F1Parameters) Class() string {
 	return "TF1Parameters"
 }
 
+// MarshalROOT implements rbytes.Marshaler
+func (f *F1Parameters) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(f.Class(), f.RVersion())
+	w.WriteStdVectorF64(f.params)
+	w.WriteStdVectorStrs(f.names)
+
+	return w.SetHeader(hdr)
+}
+
 func (f *F1Parameters) UnmarshalROOT(r *rbytes.RBuffer) error {
 	if r.Err() != nil {
 		return r.Err()
@@ -472,6 +598,7 @@ var (
 	_ rbytes.Unmarshaler = (*F1)(nil)
 
 	_ root.Object        = (*F1Parameters)(nil)
+	_ rbytes.Marshaler   = (*F1Parameters)(nil)
 	_ rbytes.Unmarshaler = (*F1Parameters)(nil)
 
 	_ root.Object        = (*f1Composition)(nil)
@@ -202,4 +202,17 @@ var HistoTestCases = []struct {
 			},
 		},
 	},
+	{
+		Name: "TF1",
+		Want: func() rtests.ROOTer {
+			f := NewF1(
+				"f1", "a+b*x", 0, 10,
+				func(x float64) float64 { return 10 + 2*x },
+				[]float64{10, 2}, []string{"a", "b"},
+			)
+			f.SetParErrors([]float64{0.1, 0.2})
+			f.SetFitResult(0.2, 2, 4)
+			return f
+		}(),
+	},
 }
@@ -0,0 +1,39 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rdict"
+)
+
+func TestFindDepStreamersMap(t *testing.T) {
+	f, err := Create(filepath.Join(t.TempDir(), "deps.root"))
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	// TFormula holds a std::map<TString,int,TFormulaParamOrder>:
+	// only the key and value types are needed, not the comparator.
+	si, err := rdict.StreamerInfos.StreamerInfo("TFormula", -1)
+	if err != nil {
+		t.Fatalf("could not find TFormula streamer: %+v", err)
+	}
+	f.addStreamer(si)
+
+	err = f.findDepStreamers()
+	if err != nil {
+		t.Fatalf("could not find dependent streamers: %+v", err)
+	}
+
+	for _, si := range f.sinfos {
+		if si.Name() == "TFormulaParamOrder" {
+			t.Fatalf("comparator of std::map should not be a dependency")
+		}
+	}
+}
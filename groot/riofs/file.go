@@ -49,6 +49,20 @@ type stater interface {
 	Stat() (os.FileInfo, error)
 }
 
+// Prefetcher is the interface implemented by readers able to fetch
+// many ranges of bytes of a file in a single round trip.
+type Prefetcher interface {
+	// Prefetch fetches the provided ranges of bytes, so they can be
+	// served by subsequent calls to ReadAt.
+	Prefetch(spans []Span) error
+}
+
+// Span describes a range of bytes of a file.
+type Span struct {
+	Off int64 // offset of the range
+	Len int64 // length of the range
+}
+
 // FileOption configures internal states of a ROOT file.
 type FileOption func(f *File) error
 
@@ -267,6 +281,23 @@ func (f *File) Stat() (os.FileInfo, error) {
 	return nil, fmt.Errorf("riofs: underlying file w/o os.FileInfo")
 }
 
+// CanPrefetch returns whether the underlying reader implements Prefetcher.
+func (f *File) CanPrefetch() bool {
+	_, ok := f.r.(Prefetcher)
+	return ok
+}
+
+// Prefetch hints the underlying reader that the provided ranges of bytes
+// will be read shortly.
+// Prefetch is a no-op when the underlying reader does not implement Prefetcher.
+func (f *File) Prefetch(spans []Span) error {
+	pf, ok := f.r.(Prefetcher)
+	if !ok || len(spans) == 0 {
+		return nil
+	}
+	return pf.Prefetch(spans)
+}
+
 // Read implements io.Reader
 func (f *File) Read(p []byte) (int, error) {
 	return f.r.Read(p)
@@ -615,8 +646,15 @@ func (f *File) findDepStreamers() error {
 				deps = append(deps, depsType{se.TypeName(), -1})
 
 			case *rdict.StreamerSTL:
-				for _, etn := range se.ElemTypeName() {
-					deps = append(deps, depsType{etn, -1})
+				etn := se.ElemTypeName()
+				switch se.STLType() {
+				case rmeta.STLmap, rmeta.STLmultimap,
+					rmeta.STLunorderedmap, rmeta.STLunorderedmultimap:
+					// only consider key and value types, not the comparator.
+					etn = etn[:min(len(etn), 2)]
+				}
+				for _, etn := range etn {
+					deps = append(deps, depsType{strings.TrimRight(etn, "*"), -1})
 				}
 			}
 			return nil
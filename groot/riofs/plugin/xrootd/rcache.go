@@ -0,0 +1,153 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdio"
+)
+
+// defaultCacheSize is the default maximal size (in bytes) of the prefetched data.
+const defaultCacheSize = 64 << 20
+
+// rcache is a reader that serves ReadAt calls from the ranges of bytes
+// fetched with vector reads by Prefetch.
+//
+// The prefetched ranges are kept by batches: when the cache grows past its
+// maximal size, the oldest batches are evicted, the latest batch is always kept.
+type rcache struct {
+	*xrdio.File
+
+	mu   sync.RWMutex
+	gen  int     // generation of the latest batch
+	blks []block // prefetched blocks, sorted by offset
+	size int64   // total size of the prefetched blocks
+	max  int64   // maximal size of the prefetched blocks
+}
+
+type block struct {
+	gen  int // generation of the batch holding this block
+	off  int64
+	data []byte
+}
+
+func rcacheOf(f *xrdio.File, max int64) *rcache {
+	return &rcache{File: f, max: max}
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *rcache) ReadAt(p []byte, off int64) (int, error) {
+	if r.load(p, off) {
+		return len(p), nil
+	}
+	return r.File.ReadAt(p, off)
+}
+
+func (r *rcache) load(p []byte, off int64) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	blk, ok := r.find(off, int64(len(p)))
+	if !ok {
+		return false
+	}
+	copy(p, blk.data[off-blk.off:])
+	return true
+}
+
+// find returns the prefetched block holding the n bytes starting at off.
+// find must be called with r.mu held.
+func (r *rcache) find(off, n int64) (block, bool) {
+	// find the last block starting at or before off.
+	i := sort.Search(len(r.blks), func(i int) bool {
+		return r.blks[i].off > off
+	}) - 1
+	if i < 0 {
+		return block{}, false
+	}
+	blk := r.blks[i]
+	if off+n > blk.off+int64(len(blk.data)) {
+		return block{}, false
+	}
+	return blk, true
+}
+
+// Prefetch implements riofs.Prefetcher.
+func (r *rcache) Prefetch(spans []riofs.Span) error {
+	segs := make([]xrdfs.Segment, 0, len(spans))
+	for _, sp := range spans {
+		if sp.Len <= 0 || r.cached(sp) {
+			continue
+		}
+		segs = append(segs, xrdfs.Segment{
+			Offset: sp.Off,
+			Data:   make([]byte, sp.Len),
+		})
+	}
+	if len(segs) == 0 {
+		return nil
+	}
+
+	err := r.File.ReadV(segs)
+	if err != nil {
+		return fmt.Errorf("riofs/xrootd: could not prefetch %d spans: %w", len(segs), err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.gen++
+	for _, seg := range segs {
+		r.blks = append(r.blks, block{gen: r.gen, off: seg.Offset, data: seg.Data})
+		r.size += int64(len(seg.Data))
+	}
+	sort.SliceStable(r.blks, func(i, j int) bool {
+		return r.blks[i].off < r.blks[j].off
+	})
+	r.evict()
+
+	return nil
+}
+
+// cached returns whether the provided span is held by a prefetched block.
+func (r *rcache) cached(sp riofs.Span) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.find(sp.Off, sp.Len)
+	return ok
+}
+
+// evict removes the oldest batches until the cache fits in its maximal size.
+// evict must be called with r.mu held.
+func (r *rcache) evict() {
+	for r.size > r.max {
+		oldest := r.gen
+		for _, blk := range r.blks {
+			oldest = min(oldest, blk.gen)
+		}
+		if oldest == r.gen {
+			return
+		}
+		r.blks = slices.DeleteFunc(r.blks, func(blk block) bool {
+			if blk.gen != oldest {
+				return false
+			}
+			r.size -= int64(len(blk.data))
+			return true
+		})
+	}
+}
+
+var (
+	_ riofs.Reader     = (*rcache)(nil)
+	_ riofs.Prefetcher = (*rcache)(nil)
+)
@@ -0,0 +1,179 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+	xrd "go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/xrdio"
+)
+
+func newTestServer(t *testing.T, fnames ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, fname := range fnames {
+		raw, err := os.ReadFile(fname)
+		if err != nil {
+			t.Fatalf("could not read %q: %+v", fname, err)
+		}
+		err = os.WriteFile(filepath.Join(dir, filepath.Base(fname)), raw, 0644)
+		if err != nil {
+			t.Fatalf("could not copy %q: %+v", fname, err)
+		}
+	}
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %+v", err)
+	}
+
+	srv := xrd.NewServer(xrd.NewFSHandler(dir), func(err error) {
+		t.Errorf("xrd-srv: %+v", err)
+	})
+	go func() {
+		err := srv.Serve(l)
+		if err != nil && err != xrd.ErrServerClosed {
+			t.Errorf("could not serve: %+v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		_ = srv.Shutdown(context.Background())
+	})
+
+	return l.Addr().String()
+}
+
+func TestRCache(t *testing.T) {
+	const fname = "../../../testdata/small-flat-tree.root"
+	addr := newTestServer(t, fname)
+
+	want, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := xrdio.Open("root://" + addr + "//small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open remote file: %+v", err)
+	}
+	defer f.Close()
+
+	r := rcacheOf(f, 300)
+
+	err = r.Prefetch([]riofs.Span{
+		{Off: 100, Len: 100},
+		{Off: 0, Len: 10},
+		{Off: 500, Len: 0},
+	})
+	if err != nil {
+		t.Fatalf("could not prefetch: %+v", err)
+	}
+	if got, want := r.size, int64(110); got != want {
+		t.Fatalf("invalid cache size: got=%d, want=%d", got, want)
+	}
+
+	for _, tc := range []struct {
+		off, len int64
+		cached   bool
+	}{
+		{off: 0, len: 10, cached: true},
+		{off: 2, len: 5, cached: true},
+		{off: 5, len: 10, cached: false},
+		{off: 100, len: 100, cached: true},
+		{off: 150, len: 50, cached: true},
+		{off: 150, len: 51, cached: false},
+		{off: 1000, len: 100, cached: false},
+	} {
+		t.Run(fmt.Sprintf("off=%d-len=%d", tc.off, tc.len), func(t *testing.T) {
+			if got := r.cached(riofs.Span{Off: tc.off, Len: tc.len}); got != tc.cached {
+				t.Fatalf("invalid cache status: got=%v, want=%v", got, tc.cached)
+			}
+			p := make([]byte, tc.len)
+			_, err := r.ReadAt(p, tc.off)
+			if err != nil {
+				t.Fatalf("could not read: %+v", err)
+			}
+			if want := want[tc.off : tc.off+tc.len]; !reflect.DeepEqual(p, want) {
+				t.Fatalf("invalid data")
+			}
+		})
+	}
+
+	// evict the first batch.
+	err = r.Prefetch([]riofs.Span{{Off: 1000, Len: 250}})
+	if err != nil {
+		t.Fatalf("could not prefetch: %+v", err)
+	}
+	if got, want := r.size, int64(250); got != want {
+		t.Fatalf("invalid cache size: got=%d, want=%d", got, want)
+	}
+	if r.cached(riofs.Span{Off: 0, Len: 10}) {
+		t.Fatalf("first batch should have been evicted")
+	}
+
+	// the latest batch is always kept.
+	err = r.Prefetch([]riofs.Span{{Off: 2000, Len: 400}})
+	if err != nil {
+		t.Fatalf("could not prefetch: %+v", err)
+	}
+	if got, want := r.size, int64(400); got != want {
+		t.Fatalf("invalid cache size: got=%d, want=%d", got, want)
+	}
+}
+
+func TestReadTree(t *testing.T) {
+	const fname = "../../../testdata/small-flat-tree.root"
+	addr := newTestServer(t, fname)
+
+	load := func(fname string) []string {
+		f, err := riofs.Open(fname)
+		if err != nil {
+			t.Fatalf("could not open %q: %+v", fname, err)
+		}
+		defer f.Close()
+
+		o, err := riofs.Dir(f).Get("tree")
+		if err != nil {
+			t.Fatalf("could not get tree: %+v", err)
+		}
+		tree := o.(rtree.Tree)
+
+		rvars := rtree.NewReadVars(tree)
+		r, err := rtree.NewReader(tree, rvars)
+		if err != nil {
+			t.Fatalf("could not create tree reader: %+v", err)
+		}
+		defer r.Close()
+
+		var rows []string
+		err = r.Read(func(ctx rtree.RCtx) error {
+			for _, rv := range rvars {
+				rows = append(rows, fmt.Sprintf("%d: %s=%v", ctx.Entry, rv.Name, reflect.ValueOf(rv.Value).Elem().Interface()))
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not read tree: %+v", err)
+		}
+		return rows
+	}
+
+	want := load(fname)
+	got := load("root://" + addr + "//small-flat-tree.root")
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid remote tree content")
+	}
+}
@@ -13,10 +13,16 @@ import (
 func init() {
 	riofs.Register("root", openFile)
 	riofs.Register("xroot", openFile)
+	riofs.Register("roots", openFile)
+	riofs.Register("xroots", openFile)
 }
 
 func openFile(path string) (riofs.Reader, error) {
-	return xrdio.Open(path)
+	f, err := xrdio.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return rcacheOf(f, defaultCacheSize), nil
 }
 
 var (
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"runtime"
+	"sync"
 
 	"go-hep.org/x/hep/groot/riofs"
 )
@@ -25,7 +26,9 @@ type bkreader struct {
 	n      int           // number of in-flight baskets
 	cur    *rbasket      // current buffer being served
 	closed chan struct{} // channel is closed when the async reader shuts down
+	pf     *bkprefetcher // prefetcher of baskets shared by the branches of a tree (may be nil)
 
+	br   Branch
 	name string
 }
 
@@ -34,7 +37,7 @@ type bkReq struct {
 	err error
 }
 
-func newBkReader(b Branch, n int, beg, end int64) *bkreader {
+func newBkReader(b Branch, n int, beg, end int64, pf *bkprefetcher) *bkreader {
 	if n < 0 {
 		n = runtime.NumCPU() + 1
 	}
@@ -55,6 +58,8 @@ func newBkReader(b Branch, n int, beg, end int64) *bkreader {
 		exit:   make(chan struct{}),
 		n:      n,
 		closed: make(chan struct{}),
+		pf:     pf,
+		br:     b,
 		name:   b.Name(),
 	}
 
@@ -140,6 +145,7 @@ func newBkReader(b Branch, n int, beg, end int64) *bkreader {
 		))
 	}
 
+	bkr.pf.register(bkr.br, bkr.spans, ibeg)
 	go bkr.run(base.entryOffsetLen, ibeg, iend)
 
 	return bkr
@@ -171,6 +177,7 @@ func (bkr *bkreader) run(eoff, beg, end int) {
 	for i, span := range bkr.spans[beg:end] {
 		select {
 		case tok := <-bkr.reuse:
+			bkr.pf.fetch(bkr.br, beg+i)
 			tok.err = tok.bkt.inflate(bkr.name, beg+i, span, eoff, bkr.f)
 			bkr.ready <- tok
 		case <-bkr.exit:
@@ -202,6 +209,92 @@ func (bkr *bkreader) close() {
 	}
 }
 
+// bkprefetcher prefetches the baskets of all the branches of a tree,
+// cluster by cluster, so they can be retrieved in a single round trip
+// from files with a riofs.Prefetcher reader.
+type bkprefetcher struct {
+	f *riofs.File
+
+	mu  sync.Mutex
+	brs map[Branch]*bkcursor // prefetch cursors, by branch
+}
+
+type bkcursor struct {
+	spans []rspan
+	next  int             // index of the next basket to prefetch
+	done  []chan struct{} // closed when the prefetch of each basket completes
+}
+
+func newBkPrefetcher(f *riofs.File) *bkprefetcher {
+	if f == nil || !f.CanPrefetch() {
+		return nil
+	}
+	return &bkprefetcher{
+		f:   f,
+		brs: make(map[Branch]*bkcursor),
+	}
+}
+
+// register registers the baskets of the provided branch, starting at basket beg.
+func (pf *bkprefetcher) register(b Branch, spans []rspan, beg int) {
+	if pf == nil {
+		return
+	}
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	pf.brs[b] = &bkcursor{
+		spans: spans,
+		next:  beg,
+		done:  make([]chan struct{}, len(spans)),
+	}
+}
+
+// fetch makes sure the i-th basket of the provided branch has been prefetched.
+// When needed, fetch prefetches all the baskets of all the branches that
+// hold entries before the end of the requested basket.
+// If that basket is being prefetched by another call, fetch waits for it.
+func (pf *bkprefetcher) fetch(b Branch, i int) {
+	if pf == nil {
+		return
+	}
+	pf.mu.Lock()
+	cur, ok := pf.brs[b]
+	if !ok {
+		pf.mu.Unlock()
+		return
+	}
+	if i < cur.next {
+		done := cur.done[i]
+		pf.mu.Unlock()
+		if done != nil {
+			<-done
+		}
+		return
+	}
+
+	var (
+		end   = cur.spans[i].end
+		done  = make(chan struct{})
+		spans []riofs.Span
+	)
+	for _, cur := range pf.brs {
+		for ; cur.next < len(cur.spans) && cur.spans[cur.next].beg < end; cur.next++ {
+			span := cur.spans[cur.next]
+			if span.pos == 0 || span.sz == 0 {
+				continue
+			}
+			cur.done[cur.next] = done
+			spans = append(spans, riofs.Span{Off: span.pos, Len: int64(span.sz)})
+		}
+	}
+	pf.mu.Unlock()
+	defer close(done)
+
+	// errors are reported when the baskets are actually read.
+	_ = pf.f.Prefetch(spans)
+}
+
 type rspan struct {
 	beg int64 // first entry
 	end int64 // last entry
@@ -4,7 +4,17 @@
 
 package rtree
 
-import "testing"
+import (
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/riofs"
+)
 
 func TestBkReaderFindBaskets(t *testing.T) {
 	for _, tc := range []struct {
@@ -65,3 +75,92 @@ func TestBkReaderFindBaskets(t *testing.T) {
 		})
 	}
 }
+
+type blockingPrefetcher struct {
+	*os.File
+
+	started chan struct{}
+	release chan struct{}
+
+	mu    sync.Mutex
+	calls [][]riofs.Span
+}
+
+func (r *blockingPrefetcher) Prefetch(spans []riofs.Span) error {
+	r.mu.Lock()
+	r.calls = append(r.calls, spans)
+	r.mu.Unlock()
+
+	r.started <- struct{}{}
+	<-r.release
+	return nil
+}
+
+func TestBkPrefetcher(t *testing.T) {
+	raw, err := os.Open("../testdata/simple.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &blockingPrefetcher{
+		File:    raw,
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	f, err := riofs.NewReader(r)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	pf := newBkPrefetcher(f)
+	if pf == nil {
+		t.Fatalf("expected a prefetcher")
+	}
+
+	// two distinct branches with the same name, as in different parents.
+	var (
+		b1 = &tbranch{named: *rbase.NewNamed("x", "")}
+		b2 = &tbranch{named: *rbase.NewNamed("x", "")}
+	)
+	pf.register(b1, []rspan{{beg: 0, end: 10, pos: 100, sz: 10}, {beg: 10, end: 20, pos: 300, sz: 10}}, 0)
+	pf.register(b2, []rspan{{beg: 0, end: 10, pos: 200, sz: 20}, {beg: 10, end: 20, pos: 400, sz: 20}}, 0)
+
+	done1 := make(chan struct{})
+	go func() {
+		defer close(done1)
+		pf.fetch(b1, 0)
+	}()
+	<-r.started
+
+	// the lock is released during the prefetch: other branches can
+	// proceed, but wait for the baskets being prefetched.
+	done2 := make(chan struct{})
+	go func() {
+		defer close(done2)
+		pf.fetch(b2, 0)
+	}()
+	select {
+	case <-done2:
+		t.Fatalf("fetch did not wait for in-flight prefetch")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	r.release <- struct{}{}
+	<-done1
+	<-done2
+
+	go func() { <-r.started; r.release <- struct{}{} }()
+	pf.fetch(b2, 1)
+	pf.fetch(b1, 1) // already prefetched.
+
+	want := [][]riofs.Span{
+		{{Off: 100, Len: 10}, {Off: 200, Len: 20}},
+		{{Off: 300, Len: 10}, {Off: 400, Len: 20}},
+	}
+	for _, spans := range r.calls {
+		sort.Slice(spans, func(i, j int) bool { return spans[i].Off < spans[j].Off })
+	}
+	if !reflect.DeepEqual(r.calls, want) {
+		t.Fatalf("invalid prefetched spans:\ngot= %v\nwant=%v", r.calls, want)
+	}
+}
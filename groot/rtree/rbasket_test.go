@@ -88,7 +88,7 @@ func TestReadAheadBasket(t *testing.T) {
 				end  = tree.Entries()
 			)
 
-			ra := newBkReader(b, tc.conc, beg, end)
+			ra := newBkReader(b, tc.conc, beg, end, nil)
 			defer ra.close()
 
 			var got []rspan
@@ -11,10 +11,10 @@ type rbranch struct {
 	leaves []rleaf
 }
 
-func newRBranch(b Branch, n int, beg, end int64, leaves []rleaf, rctx rleafCtx) rbranch {
+func newRBranch(b Branch, n int, beg, end int64, leaves []rleaf, rctx rleafCtx, pf *bkprefetcher) rbranch {
 	rb := rbranch{
 		b:      b,
-		rb:     newBkReader(b, n, beg, end),
+		rb:     newBkReader(b, n, beg, end, pf),
 		leaves: leaves,
 	}
 	return rb
@@ -36,7 +36,7 @@ func (rb *rbranch) stop() error {
 
 func (rb *rbranch) reset() {
 	rb.rb.close()
-	rb.rb = newBkReader(rb.b, rb.rb.n, rb.rb.beg, rb.rb.end)
+	rb.rb = newBkReader(rb.b, rb.rb.n, rb.rb.beg, rb.rb.end, rb.rb.pf)
 }
 
 func (rb *rbranch) read(i int64) error {
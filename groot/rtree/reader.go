@@ -347,10 +347,11 @@ func newRTree(t *ttree, rvars []ReadVar, n int, beg, end int64) *rtree {
 		brs[id] = append(brs[id], leaf)
 	}
 
+	pf := newBkPrefetcher(t.f)
 	r.brs = make([]rbranch, len(brs))
 	for i, leaves := range brs {
 		branch := leaves[0].Leaf().Branch()
-		r.brs[i] = newRBranch(branch, n, beg, end, leaves, r)
+		r.brs[i] = newRBranch(branch, n, beg, end, leaves, r, pf)
 	}
 
 	return r
@@ -7,6 +7,7 @@ package rio
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"reflect"
 	"testing"
 )
@@ -138,3 +139,68 @@ func TestInvalidFile(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendWriter(t *testing.T) {
+	const (
+		nevts = 10
+		split = 4
+	)
+	evts := makeEvents(nevts)
+	name := func(i int) string { return fmt.Sprintf("evt-%03d", i+1) }
+
+	buf := new(bytes.Buffer)
+	w, err := NewWriter(buf)
+	if err != nil {
+		t.Fatalf("error creating new rio Writer: %v", err)
+	}
+	for i := range evts[:split] {
+		err = w.WriteValue(name(i), &evts[i])
+		if err != nil {
+			t.Fatalf("error writing value [%s]: %v", name(i), err)
+		}
+	}
+	err = w.Flush()
+	if err != nil {
+		t.Fatalf("error flushing rio writer: %v", err)
+	}
+
+	// the first writer is abandoned without being closed.
+	out := bytes.NewBuffer(bytes.Clone(buf.Bytes()))
+	w, err = NewAppendWriter(bytes.NewReader(buf.Bytes()), out)
+	if err != nil {
+		t.Fatalf("error creating append rio Writer: %v", err)
+	}
+	for i := split; i < nevts; i++ {
+		err = w.WriteValue(name(i), &evts[i])
+		if err != nil {
+			t.Fatalf("error writing value [%s]: %v", name(i), err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("error closing rio writer: %v", err)
+	}
+
+	f, err := Open(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		t.Fatalf("error opening file: %v", err)
+	}
+	defer f.Close()
+
+	for i := range evts {
+		var evt event
+		err = f.Get(name(i), &evt)
+		if err != nil {
+			t.Fatalf("error reading value [%s]: %v", name(i), err)
+		}
+		if !reflect.DeepEqual(evt, evts[i]) {
+			t.Fatalf("%s differ.\ngot= %v\nwant=%v\n", name(i), evt, evts[i])
+		}
+	}
+
+	// a closed stream can not be appended to.
+	_, err = NewAppendWriter(bytes.NewReader(out.Bytes()), io.Discard)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -7,6 +7,7 @@ package rio
 import (
 	"bufio"
 	"compress/flate"
+	"fmt"
 	"io"
 
 	riobin "codeberg.org/gonuts/binary"
@@ -27,6 +28,17 @@ func (w *cwriter) Flush() error {
 	return w.w.Flush()
 }
 
+type creader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *creader) Read(data []byte) (int, error) {
+	n, err := r.r.Read(data)
+	r.n += int64(n)
+	return n, err
+}
+
 // Writer is a rio write-only stream
 type Writer struct {
 	w *cwriter
@@ -57,6 +69,53 @@ func NewWriter(w io.Writer) (*Writer, error) {
 	}, nil
 }
 
+// NewAppendWriter returns a new write-only rio stream, appending records
+// to an existing rio stream.
+// r reads the records already held by the stream, and w writes the new
+// records after them.
+// The existing stream must not have been closed: it must end with a
+// record, not with the footer written by Writer.Close.
+func NewAppendWriter(r io.Reader, w io.Writer) (*Writer, error) {
+	cr := &creader{r: bufio.NewReader(r)}
+	hdr := [4]byte{}
+	_, err := io.ReadFull(cr, hdr[:])
+	if err != nil {
+		return nil, fmt.Errorf("rio: error reading magic-header: %w", err)
+	}
+	if hdr != rioMagic {
+		return nil, fmt.Errorf("rio: not a rio-stream. magic-header=%q. want=%q",
+			string(hdr[:]),
+			string(rioMagic[:]),
+		)
+	}
+
+	offsets := make(map[string][]Span)
+	for {
+		beg := cr.n
+		var rec rioRecord
+		err = rec.RioUnmarshal(cr)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.CopyN(io.Discard, cr, int64(rioAlignU32(rec.CLen)))
+		if err != nil {
+			return nil, fmt.Errorf("rio: error reading record %q: %w", rec.Name, err)
+		}
+		offsets[rec.Name] = append(offsets[rec.Name], Span{beg, cr.n - beg})
+	}
+
+	return &Writer{
+		w:       &cwriter{bufio.NewWriter(w), cr.n},
+		options: NewOptions(CompressDefault, flate.DefaultCompression, 0),
+		version: 1,
+		recs:    make(map[string]*Record),
+		offsets: offsets,
+	}, nil
+}
+
 // SetCompressor enables compression and sets the compression method.
 func (w *Writer) SetCompressor(compr CompressorKind, lvl int) error {
 	var err error
@@ -80,6 +139,11 @@ func (w *Writer) Record(name string) *Record {
 	return rec
 }
 
+// Flush writes any buffered record to the underlying writer.
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
 // Close finishes writing the rio write-only stream.
 // It does not (and can not) close the underlying writer.
 func (w *Writer) Close() error {
@@ -13,6 +13,7 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto/auth/host"
 	"go-hep.org/x/hep/xrootd/xrdproto/auth/krb5"
 	"go-hep.org/x/hep/xrootd/xrdproto/auth/unix"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth/ztn"
 )
 
 // defaultProviders is the list of authentification providers a xrootd client will use by default.
@@ -20,6 +21,7 @@ var defaultProviders = []auth.Auther{
 	krb5.Default,
 	unix.Default,
 	host.Default,
+	ztn.Default,
 }
 
 func (sess *cliSession) auth(ctx context.Context, securityInformation []byte) error {
@@ -42,6 +44,10 @@ func (sess *cliSession) auth(ctx context.Context, securityInformation []byte) er
 			errs = append(errs, fmt.Errorf("xrootd: could not authorize using %s: provider was not found", provider))
 			continue
 		}
+		if a, ok := auther.(auth.TLSRequirer); ok && a.RequiresTLS() && !sess.tls {
+			errs = append(errs, fmt.Errorf("xrootd: could not authorize using %s: provider requires a TLS connection", provider))
+			continue
+		}
 		r, err := auther.Request(params)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("xrootd: could not authorize using %s: %w", provider, err))
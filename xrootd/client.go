@@ -6,6 +6,7 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"os"
 	"sync"
@@ -18,6 +19,7 @@ import (
 // Concurrent requests are supported.
 // Zero value is invalid, Client should be instantiated using NewClient.
 type Client struct {
+	ctx      context.Context // ctx bounds the lifetime of the sessions of the client.
 	cancel   context.CancelFunc
 	auths    map[string]auth.Auther
 	username string
@@ -30,6 +32,9 @@ type Client struct {
 	sessions         map[string]*cliSession
 
 	maxRedirections int
+
+	tlsConfig  *tls.Config // tlsConfig is the configuration used for TLS connections.
+	requireTLS bool        // requireTLS indicates whether connections must be protected by TLS.
 }
 
 // Option configures an XRootD client.
@@ -44,6 +49,21 @@ func WithAuth(a auth.Auther) Option {
 	}
 }
 
+// WithTLS requires the connections to the XRootD servers to be upgraded to TLS,
+// as for roots:// URLs, using the provided configuration.
+// If cfg is nil, a default configuration is used, verifying the server
+// certificates against the system roots.
+//
+// Without WithTLS, connections are still upgraded to TLS, with the default
+// configuration, when a server requires it.
+func WithTLS(cfg *tls.Config) Option {
+	return func(client *Client) error {
+		client.tlsConfig = cfg
+		client.requireTLS = true
+		return nil
+	}
+}
+
 func (client *Client) addAuth(auth auth.Auther) error {
 	client.auths[auth.Provider()] = auth
 	return nil
@@ -66,6 +86,7 @@ func NewClient(ctx context.Context, address string, username string, opts ...Opt
 	ctx, cancel := context.WithCancel(ctx)
 
 	client := &Client{
+		ctx:             ctx,
 		cancel:          cancel,
 		auths:           make(map[string]auth.Auther),
 		username:        username,
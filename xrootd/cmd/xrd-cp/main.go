@@ -17,9 +17,25 @@
 //	$> xrd-cp root://server.example.com/some/file1.txt - > foo.txt
 //	$> xrd-cp -r root://server.example.com/some/dir .
 //	$> xrd-cp -r root://server.example.com/some/dir outdir
+//	$> xrd-cp -cks=md5 root://server.example.com/some/file1.txt .
+//	$> xrd-cp -cks-readback root://server.example.com/some/file1.txt .
+//
+// Once transferred, the checksum of each file is compared with the checksum
+// computed by the remote server.
+// The checksum is computed from the transferred data, while it is copied.
+// With -cks-readback, it is instead computed by reading back the written
+// file, so errors writing to local storage are detected, at the cost of
+// reading the file a second time.
+// Standard output can not be read back.
+// The verification is skipped, with a warning, if the checksum can not be
+// retrieved from the server.
 //
 // Options:
 //
+//	-cks string
+//	  	checksum type used to verify transfers (adler32, crc32c, md5 or none) (default "adler32")
+//	-cks-readback
+//	  	compute the checksum by reading back the written file
 //	-r	copy directories recursively
 //	-v	enable verbose mode
 package main
@@ -28,10 +44,12 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"hash"
 	"io"
 	"log"
 	"os"
 	stdpath "path"
+	"strings"
 
 	"go-hep.org/x/hep/xrootd"
 	"go-hep.org/x/hep/xrootd/xrdfs"
@@ -54,6 +72,8 @@ Example:
  $> xrd-cp root://server.example.com/some/file1.txt - > foo.txt
  $> xrd-cp -r root://server.example.com/some/dir .
  $> xrd-cp -r root://server.example.com/some/dir outdir
+ $> xrd-cp -cks=md5 root://server.example.com/some/file1.txt .
+ $> xrd-cp -cks-readback root://server.example.com/some/file1.txt .
 
 Options:
 `)
@@ -66,12 +86,24 @@ func main() {
 	log.SetFlags(0)
 
 	var (
+		cksFlag     = flag.String("cks", xrdfs.Adler32, "checksum type used to verify transfers (adler32, crc32c, md5 or none)")
+		backFlag    = flag.Bool("cks-readback", false, "compute the checksum by reading back the written file")
 		recFlag     = flag.Bool("r", false, "copy directories recursively")
 		verboseFlag = flag.Bool("v", false, "enable verbose mode")
 	)
 
 	flag.Parse()
 
+	cks := *cksFlag
+	switch cks {
+	case "none":
+		cks = ""
+	default:
+		if _, err := xrdfs.NewChecksumHash(cks); err != nil {
+			log.Fatalf("invalid checksum type: %v", err)
+		}
+	}
+
 	switch n := flag.NArg(); n {
 	case 0:
 		flag.Usage()
@@ -80,14 +112,14 @@ func main() {
 		flag.Usage()
 		log.Fatalf("missing destination file operand after %q", flag.Arg(0))
 	case 2:
-		err := xrdcopy(flag.Arg(1), flag.Arg(0), *recFlag, *verboseFlag)
+		err := xrdcopy(flag.Arg(1), flag.Arg(0), cks, *backFlag, *recFlag, *verboseFlag)
 		if err != nil {
 			log.Fatalf("could not copy %q to %q: %v", flag.Arg(0), flag.Arg(1), err)
 		}
 	default:
 		dst := flag.Arg(flag.NArg() - 1)
 		for _, src := range flag.Args()[:flag.NArg()-1] {
-			err := xrdcopy(dst, src, *recFlag, *verboseFlag)
+			err := xrdcopy(dst, src, cks, *backFlag, *recFlag, *verboseFlag)
 			if err != nil {
 				log.Fatalf("could not copy %q to %q: %v", src, dst, err)
 			}
@@ -95,7 +127,10 @@ func main() {
 	}
 }
 
-func xrdcopy(dst, srcPath string, recursive, verbose bool) error {
+// xrdcopy copies srcPath to dst.
+// Transferred files are verified with checksums of type cks, unless cks is empty.
+// With readback, checksums are computed by reading back the written files.
+func xrdcopy(dst, srcPath, cks string, readback, recursive, verbose bool) error {
 	cli, src, err := xrdremote(srcPath)
 	if err != nil {
 		return err
@@ -136,9 +171,12 @@ func xrdcopy(dst, srcPath string, recursive, verbose bool) error {
 			}
 		default:
 			jobs.add(job{
-				fs:  fs,
-				src: src,
-				dst: stdpath.Join(root, stdpath.Base(src)),
+				fs:       fs,
+				src:      src,
+				dst:      stdpath.Join(root, stdpath.Base(src)),
+				cks:      cks,
+				readback: readback,
+				verbose:  verbose,
 			})
 		}
 		return nil
@@ -189,9 +227,12 @@ func xrdcopy(dst, srcPath string, recursive, verbose bool) error {
 		}
 
 		jobs.add(job{
-			fs:  fs,
-			src: src,
-			dst: dst,
+			fs:       fs,
+			src:      src,
+			dst:      dst,
+			cks:      cks,
+			readback: readback,
+			verbose:  verbose,
 		})
 	}
 
@@ -208,8 +249,13 @@ func xrdremote(name string) (client *xrootd.Client, path string, err error) {
 		return nil, "", fmt.Errorf("could not parse %q: %w", name, err)
 	}
 
+	var opts []xrootd.Option
+	if url.TLS {
+		opts = append(opts, xrootd.WithTLS(nil))
+	}
+
 	path = url.Path
-	client, err = xrootd.NewClient(context.Background(), url.Addr, url.User)
+	client, err = xrootd.NewClient(context.Background(), url.Addr, url.User, opts...)
 	return client, path, err
 }
 
@@ -217,6 +263,11 @@ type job struct {
 	fs  xrdfs.FileSystem
 	src string
 	dst string
+	cks string // checksum type used to verify the transfer, if any.
+
+	readback bool // whether to compute the checksum from the written file.
+
+	verbose bool
 }
 
 func (j job) run(ctx context.Context) (int, error) {
@@ -244,9 +295,24 @@ func (j job) run(ctx context.Context) (int, error) {
 	}
 	defer f.Close()
 
+	var (
+		w    io.Writer = o
+		hash hash.Hash
+	)
+	if j.cks != "" {
+		hash, err = xrdfs.NewChecksumHash(j.cks)
+		if err != nil {
+			return 0, err
+		}
+		if !j.readback || o == os.Stdout {
+			// standard output can not be read back.
+			w = io.MultiWriter(o, hash)
+		}
+	}
+
 	// TODO(sbinet): make buffer a field of job to reduce memory pressure.
 	// TODO(sbinet): use clever heuristics for buffer size?
-	n, err := io.CopyBuffer(o, f, make([]byte, 16*1024*1024))
+	n, err := io.CopyBuffer(w, f, make([]byte, 16*1024*1024))
 	if err != nil {
 		return int(n), fmt.Errorf("could not copy to output file: %w", err)
 	}
@@ -256,9 +322,53 @@ func (j job) run(ctx context.Context) (int, error) {
 		return int(n), fmt.Errorf("could not close output file: %w", err)
 	}
 
+	if hash != nil {
+		if j.readback && o != os.Stdout {
+			err = j.hashOutput(hash)
+			if err != nil {
+				return int(n), err
+			}
+		}
+		err = j.verify(ctx, xrdfs.NewChecksum(j.cks, hash))
+		if err != nil {
+			return int(n), err
+		}
+	}
+
 	return int(n), nil
 }
 
+// hashOutput feeds the content of the written output file to h.
+func (j job) hashOutput(h hash.Hash) error {
+	f, err := os.Open(j.dst)
+	if err != nil {
+		return fmt.Errorf("could not open output file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return fmt.Errorf("could not read back output file: %w", err)
+	}
+	return nil
+}
+
+// verify compares the checksum of the transferred data with the one computed by the server.
+func (j job) verify(ctx context.Context, got xrdfs.Checksum) error {
+	want, err := j.fs.Checksum(ctx, j.src, j.cks)
+	if err != nil {
+		log.Printf("warning: cannot verify %q: could not retrieve its checksum: %v", j.src, err)
+		return nil
+	}
+	if !strings.EqualFold(got.Type, want.Type) || got.Value != want.Value {
+		return fmt.Errorf("checksum mismatch for %q: got=%v, want=%v", j.src, got, want)
+	}
+	if j.verbose {
+		log.Printf("%s: %v", j.src, got)
+	}
+	return nil
+}
+
 type jobs struct {
 	slice []job
 }
@@ -5,9 +5,18 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
 )
 
 func TestXrdCp(t *testing.T) {
@@ -21,16 +30,136 @@ func TestXrdCp(t *testing.T) {
 	src := "root://ccxrootdgotest.in2p3.fr:9001/tmp/rootio/testdata/chain.1.root"
 
 	const (
+		readback  = false
 		recursive = false
 		verbose   = true
 	)
 
-	err = xrdcopy(dst, src, recursive, verbose)
+	err = xrdcopy(dst, src, xrdfs.Adler32, readback, recursive, verbose)
 	if err != nil {
 		t.Fatalf("could not copy remote file: %v", err)
 	}
 }
 
+// badChecksum is a handler reporting invalid checksums.
+type badChecksum struct {
+	xrootd.Handler
+}
+
+func (badChecksum) Query(sessionID [16]byte, request *query.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	return query.Response{Data: []byte("adler32 00000000")}, xrdproto.Ok
+}
+
+// queryError is a handler failing checksum queries with the provided error code.
+type queryError struct {
+	xrootd.Handler
+	code xrdproto.ServerErrorCode
+}
+
+func (h queryError) Query(sessionID [16]byte, request *query.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	return xrdproto.ServerError{Code: h.code, Message: "Query request failed"}, xrdproto.Error
+}
+
+func TestXrdCpChecksum(t *testing.T) {
+	var (
+		srcDir = t.TempDir()
+		dstDir = t.TempDir()
+		data   = bytes.Repeat([]byte("0123456789"), 10000)
+	)
+	err := os.WriteFile(filepath.Join(srcDir, "file.txt"), data, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serve := func(t *testing.T, handler xrootd.Handler) string {
+		t.Helper()
+		listener, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatalf("could not listen: %+v", err)
+		}
+		srv := xrootd.NewServer(handler, func(err error) {
+			t.Logf("xrd-srv: %+v", err)
+		})
+		go func() { _ = srv.Serve(listener) }()
+		t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+		return listener.Addr().String()
+	}
+
+	const (
+		recursive = false
+		verbose   = true
+	)
+
+	for _, tc := range []struct {
+		name     string
+		handler  xrootd.Handler
+		cks      string
+		readback bool
+		stdout   bool
+		err      string
+	}{
+		{name: "adler32", handler: xrootd.NewFSHandler(srcDir), cks: xrdfs.Adler32},
+		{name: "crc32c", handler: xrootd.NewFSHandler(srcDir), cks: xrdfs.CRC32C},
+		{name: "md5", handler: xrootd.NewFSHandler(srcDir), cks: xrdfs.MD5},
+		{name: "none", handler: badChecksum{xrootd.NewFSHandler(srcDir)}, cks: ""},
+		{name: "readback", handler: xrootd.NewFSHandler(srcDir), cks: xrdfs.Adler32, readback: true},
+		{name: "stdout", handler: xrootd.NewFSHandler(srcDir), cks: xrdfs.Adler32, stdout: true},
+		{name: "stdout-readback", handler: xrootd.NewFSHandler(srcDir), cks: xrdfs.Adler32, readback: true, stdout: true},
+		{name: "invalid-request", handler: queryError{xrootd.NewFSHandler(srcDir), xrdproto.InvalidRequest}, cks: xrdfs.Adler32},
+		{name: "unsupported", handler: queryError{xrootd.NewFSHandler(srcDir), xrdproto.Unsupported}, cks: xrdfs.Adler32},
+		{name: "query-error", handler: queryError{xrootd.NewFSHandler(srcDir), xrdproto.IOError}, cks: xrdfs.Adler32},
+		{
+			name: "stdout-mismatch", handler: badChecksum{xrootd.NewFSHandler(srcDir)}, cks: xrdfs.Adler32, stdout: true,
+			err: `checksum mismatch for "/file.txt"`,
+		},
+		{
+			name: "mismatch", handler: badChecksum{xrootd.NewFSHandler(srcDir)}, cks: xrdfs.Adler32,
+			err: `checksum mismatch for "/file.txt"`,
+		},
+		{
+			name: "readback-mismatch", handler: badChecksum{xrootd.NewFSHandler(srcDir)}, cks: xrdfs.Adler32, readback: true,
+			err: `checksum mismatch for "/file.txt"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			addr := serve(t, tc.handler)
+			dst := filepath.Join(dstDir, tc.name+".txt")
+			out := dst
+			if tc.stdout {
+				f, err := os.Create(dst)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer f.Close()
+				stdout := os.Stdout
+				os.Stdout = f
+				defer func() { os.Stdout = stdout }()
+				out = "-"
+			}
+			err := xrdcopy(out, "root://"+addr+"/file.txt", tc.cks, tc.readback, recursive, verbose)
+			switch {
+			case err != nil && tc.err == "":
+				t.Fatalf("could not copy file: %+v", err)
+			case err != nil && tc.err != "":
+				if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, tc.err)
+				}
+				return
+			case err == nil && tc.err != "":
+				t.Fatalf("expected an error (%s)", tc.err)
+			}
+
+			got, err := os.ReadFile(dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("invalid file content")
+			}
+		})
+	}
+}
+
 func BenchmarkXrdCp_Small(b *testing.B) {
 	benchmarkXrdCp(b, "root://ccxrootdgotest.in2p3.fr:9001/tmp/rootio/testdata/chain.1.root")
 }
@@ -53,6 +182,7 @@ func benchmarkXrdCp(b *testing.B, src string) {
 	dst := filepath.Join(dir, filepath.Base(src))
 
 	const (
+		readback  = false
 		recursive = false
 		verbose   = false
 	)
@@ -60,7 +190,7 @@ func benchmarkXrdCp(b *testing.B, src string) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		os.RemoveAll(dst)
-		err = xrdcopy(dst, src, recursive, verbose)
+		err = xrdcopy(dst, src, xrdfs.Adler32, readback, recursive, verbose)
 		if err != nil {
 			b.Fatalf("could not copy remote file: %v", err)
 		}
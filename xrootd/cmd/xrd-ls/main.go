@@ -93,7 +93,12 @@ func xrdls(name string, long, recursive bool) error {
 
 	ctx := context.Background()
 
-	c, err := xrootd.NewClient(ctx, url.Addr, url.User)
+	var opts []xrootd.Option
+	if url.TLS {
+		opts = append(opts, xrootd.WithTLS(nil))
+	}
+
+	c, err := xrootd.NewClient(ctx, url.Addr, url.User, opts...)
 	if err != nil {
 		return fmt.Errorf("could not create client: %w", err)
 	}
@@ -6,15 +6,21 @@
 package main // import "go-hep.org/x/hep/xrootd/cmd/xrd-srv"
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strings"
 
 	"go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth/unix"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth/ztn"
 )
 
 func init() {
@@ -24,11 +30,33 @@ func init() {
 Usage:
 
  $> xrd-srv [OPTIONS] <base-dir>
+ $> xrd-srv [OPTIONS] -redirect=<addr1>,<addr2>,...
 
 Example:
 
  $> xrd-srv /tmp
  $> xrd-srv -addr=0.0.0.0:1094 /tmp
+ $> xrd-srv -cert=server.crt -key=server.key /tmp
+ $> xrd-srv -ro /tmp
+ $> xrd-srv -addr=0.0.0.0:1094 -redirect=host1:1095,host2:1095
+
+With -redirect, xrd-srv does not serve any data but redirects clients to
+the data servers holding the requested files.
+ $> xrd-srv -cert=server.crt -key=server.key -tokens=tokens.txt -policy=policy.txt /data
+
+The -tokens file lists the bearer tokens accepted by the server (ztn
+authentication, over TLS only), one "<user> <token>" pair per line.
+
+The -policy file lists the authorization rules of the server, one
+"<user> <prefix> <access>" rule per line, where <user> may be "*" to match
+any user and <access> is one of "r", "rw" or "none".
+The access to a path is decided by the first matching rule, and denied if no
+rule matches. For example:
+
+ # users have read-write access to their own area and read access elsewhere.
+ alice /home/alice rw
+ bob   /home/bob   rw
+ *     /           r
 
 Options:
 `)
@@ -40,25 +68,95 @@ func main() {
 	log.SetPrefix("xrd-srv: ")
 	log.SetFlags(0)
 
-	addr := flag.String("addr", "0.0.0.0:1094", "listen to the provided address")
+	var (
+		addr = flag.String("addr", "0.0.0.0:1094", "listen to the provided address")
+		cert = flag.String("cert", "", "path to a PEM certificate file to serve over TLS (roots://)")
+		key  = flag.String("key", "", "path to the PEM private key file of the TLS certificate")
+		toks = flag.String("tokens", "", "path to a file of bearer tokens to authenticate users with")
+		usrs = flag.Bool("unix", false, "authenticate users with the (unverified) user name they report")
+		plcy = flag.String("policy", "", "path to a file of authorization rules")
+		ro   = flag.Bool("ro", false, "serve data in read-only mode")
+		rdir = flag.String("redirect", "", "comma-separated list of data servers to redirect clients to")
+	)
 
 	flag.Parse()
 
-	if flag.NArg() != 1 {
-		flag.Usage()
-		log.Fatalf("missing base dir operand")
+	var handler xrootd.Handler
+	switch {
+	case *rdir != "":
+		if flag.NArg() != 0 {
+			flag.Usage()
+			log.Fatalf("base dir operand and -redirect are mutually exclusive")
+		}
+		redir := xrootd.NewRedirector(strings.Split(*rdir, ","))
+		defer redir.Disconnect()
+		handler = redir
+	default:
+		if flag.NArg() != 1 {
+			flag.Usage()
+			log.Fatalf("missing base dir operand")
+		}
+		handler = xrootd.NewFSHandler(flag.Arg(0))
+	}
+
+	var opts []xrootd.ServerOption
+	if *cert != "" || *key != "" {
+		if *cert == "" || *key == "" {
+			log.Fatalf("both -cert and -key must be provided")
+		}
+		crt, err := tls.LoadX509KeyPair(*cert, *key)
+		if err != nil {
+			log.Fatalf("could not load TLS certificate: %+v", err)
+		}
+		opts = append(opts, xrootd.WithServerTLS(&tls.Config{
+			Certificates: []tls.Certificate{crt},
+		}))
+	}
+
+	var auths []auth.Authenticator
+	if *toks != "" {
+		tokens, err := loadTokens(*toks)
+		if err != nil {
+			log.Fatalf("could not load tokens: %+v", err)
+		}
+		auths = append(auths, &ztn.Authenticator{
+			Validate: func(token string) (string, error) {
+				user, ok := tokens[token]
+				if !ok {
+					return "", fmt.Errorf("unknown token")
+				}
+				return user, nil
+			},
+		})
+	}
+	if *usrs {
+		auths = append(auths, &unix.Authenticator{})
+	}
+	if len(auths) > 0 {
+		opts = append(opts, xrootd.WithServerAuth(auths...))
 	}
 
-	baseDir := flag.Arg(0)
+	switch {
+	case *plcy != "" && *ro:
+		log.Fatalf("-policy and -ro are mutually exclusive")
+	case *plcy != "":
+		policy, err := loadPolicy(*plcy)
+		if err != nil {
+			log.Fatalf("could not load policy: %+v", err)
+		}
+		opts = append(opts, xrootd.WithAuthorizer(policy))
+	case *ro:
+		opts = append(opts, xrootd.WithAuthorizer(xrootd.ReadOnly))
+	}
 
 	listener, err := net.Listen("tcp", *addr)
 	if err != nil {
 		log.Fatalf("could not listen on %q: %v", *addr, err)
 	}
 
-	srv := xrootd.NewServer(xrootd.NewFSHandler(baseDir), func(err error) {
+	srv := xrootd.NewServer(handler, func(err error) {
 		log.Printf("an error occured: %v", err)
-	})
+	}, opts...)
 
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt)
@@ -76,3 +174,63 @@ func main() {
 		log.Fatalf("could not shutdown: %v", err)
 	}
 }
+
+// loadTokens loads the bearer tokens from the named file and
+// returns the names of the users they were issued to, indexed by token.
+func loadTokens(fname string) (map[string]string, error) {
+	tokens := make(map[string]string)
+	err := readLines(fname, func(fields []string) error {
+		if len(fields) != 2 {
+			return fmt.Errorf("invalid token line (want: <user> <token>)")
+		}
+		tokens[fields[1]] = fields[0]
+		return nil
+	})
+	return tokens, err
+}
+
+// loadPolicy loads the authorization rules from the named file.
+func loadPolicy(fname string) (xrootd.Policy, error) {
+	var policy xrootd.Policy
+	err := readLines(fname, func(fields []string) error {
+		if len(fields) != 3 {
+			return fmt.Errorf("invalid rule line (want: <user> <prefix> <access>)")
+		}
+		rule := xrootd.Rule{User: fields[0], Prefix: fields[1]}
+		switch fields[2] {
+		case "r":
+			rule.Access = xrootd.AccessRead
+		case "rw":
+			rule.Access = xrootd.AccessReadWrite
+		case "none":
+			rule.Access = xrootd.AccessNone
+		default:
+			return fmt.Errorf("invalid access %q", fields[2])
+		}
+		policy = append(policy, rule)
+		return nil
+	})
+	return policy, err
+}
+
+// readLines calls fct with the fields of each non-empty, non-comment, line of the named file.
+func readLines(fname string, fct func(fields []string) error) error {
+	f, err := os.Open(fname)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for i := 1; sc.Scan(); i++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		err := fct(strings.Fields(line))
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", fname, i, err)
+		}
+	}
+	return sc.Err()
+}
@@ -8,13 +8,18 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto"
 	"go-hep.org/x/hep/xrootd/xrdproto/dirlist"
 	"go-hep.org/x/hep/xrootd/xrdproto/handshake"
+	"go-hep.org/x/hep/xrootd/xrdproto/locate"
 	"go-hep.org/x/hep/xrootd/xrdproto/login"
 	"go-hep.org/x/hep/xrootd/xrdproto/mkdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/mv"
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgread"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgwrite"
 	"go-hep.org/x/hep/xrootd/xrdproto/ping"
 	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -84,6 +89,12 @@ func (h *defaultHandler) Read(sessionID [16]byte, request *read.Request) (xrdpro
 	return resp, xrdproto.Error
 }
 
+// ReadV implements Handler.ReadV.
+func (h *defaultHandler) ReadV(sessionID [16]byte, request *readv.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	resp := xrdproto.ServerError{Code: xrdproto.InvalidRequest, Message: "ReadV request is not implemented"}
+	return resp, xrdproto.Error
+}
+
 // Write implements Handler.Write.
 func (h *defaultHandler) Write(sessionID [16]byte, request *write.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
 	resp := xrdproto.ServerError{Code: xrdproto.InvalidRequest, Message: "Write request is not implemented"}
@@ -131,3 +142,27 @@ func (h *defaultHandler) RemoveDir(sessionID [16]byte, request *rmdir.Request) (
 	resp := xrdproto.ServerError{Code: xrdproto.InvalidRequest, Message: "RemoveDir request is not implemented"}
 	return resp, xrdproto.Error
 }
+
+// Locate implements Handler.Locate.
+func (h *defaultHandler) Locate(sessionID [16]byte, request *locate.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	resp := xrdproto.ServerError{Code: xrdproto.InvalidRequest, Message: "Locate request is not implemented"}
+	return resp, xrdproto.Error
+}
+
+// Query implements Handler.Query.
+func (h *defaultHandler) Query(sessionID [16]byte, request *query.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	resp := xrdproto.ServerError{Code: xrdproto.InvalidRequest, Message: "Query request is not implemented"}
+	return resp, xrdproto.Error
+}
+
+// PgRead implements Handler.PgRead.
+func (h *defaultHandler) PgRead(sessionID [16]byte, request *pgread.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	resp := xrdproto.ServerError{Code: xrdproto.InvalidRequest, Message: "PgRead request is not implemented"}
+	return resp, xrdproto.Error
+}
+
+// PgWrite implements Handler.PgWrite.
+func (h *defaultHandler) PgWrite(sessionID [16]byte, request *pgwrite.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	resp := xrdproto.ServerError{Code: xrdproto.InvalidRequest, Message: "PgWrite request is not implemented"}
+	return resp, xrdproto.Error
+}
@@ -6,10 +6,14 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"fmt"
 	rsync "sync"
 
 	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgread"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgwrite"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
 	"go-hep.org/x/hep/xrootd/xrdproto/sync"
 	"go-hep.org/x/hep/xrootd/xrdproto/truncate"
@@ -80,6 +84,81 @@ func (f *file) ReadAtContext(ctx context.Context, p []byte, off int64) (n int, e
 	return len(resp.Data), nil
 }
 
+// ReadV reads the provided segments of the file with vector reads.
+// Segments larger than readv.MaxSegmentLength are split, and the segments
+// are sent by batches of readv.MaxSegments.
+// On return, the Data of each segment is resliced to the number of bytes read.
+func (f *file) ReadV(ctx context.Context, segs []xrdfs.Segment) error {
+	// elem is the destination of a segment of a readv request.
+	type elem struct {
+		seg int // index of the segment
+		beg int // offset of the element within the segment data
+	}
+
+	var (
+		ns    = make([]int, len(segs)) // number of bytes read, per segment
+		req   = readv.Request{Segments: make([]readv.Segment, 0, min(len(segs), readv.MaxSegments))}
+		elems = make([]elem, 0, cap(req.Segments))
+		resp  readv.Response
+	)
+
+	flush := func() error {
+		if len(req.Segments) == 0 {
+			return nil
+		}
+		err := f.do(ctx, func(ctx context.Context, sid string) (string, error) {
+			return f.fs.c.sendSession(ctx, sid, &resp, &req)
+		})
+		if err != nil {
+			return err
+		}
+		if len(resp.Chunks) > len(elems) {
+			return fmt.Errorf("xrootd: invalid readv response: got %d chunks, want %d", len(resp.Chunks), len(elems))
+		}
+		for i, chunk := range resp.Chunks {
+			var (
+				seg = req.Segments[i]
+				dst = segs[elems[i].seg].Data[elems[i].beg:][:seg.Length]
+			)
+			if chunk.Handle != seg.Handle || chunk.Offset != seg.Offset || len(chunk.Data) > len(dst) {
+				return fmt.Errorf("xrootd: invalid readv response chunk %d", i)
+			}
+			ns[elems[i].seg] += copy(dst, chunk.Data)
+		}
+		req.Segments = req.Segments[:0]
+		elems = elems[:0]
+		return nil
+	}
+
+	for i, seg := range segs {
+		for beg := 0; beg < len(seg.Data); beg += readv.MaxSegmentLength {
+			end := min(beg+readv.MaxSegmentLength, len(seg.Data))
+			req.Segments = append(req.Segments, readv.Segment{
+				Handle: f.handle,
+				Length: int32(end - beg),
+				Offset: seg.Offset + int64(beg),
+			})
+			elems = append(elems, elem{seg: i, beg: beg})
+			if len(req.Segments) == readv.MaxSegments {
+				err := flush()
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	err := flush()
+	if err != nil {
+		return err
+	}
+
+	for i := range segs {
+		segs[i].Data = segs[i].Data[:ns[i]]
+	}
+	return nil
+}
+
 // ReadAt reads len(p) bytes into p starting at offset off.
 func (f *file) ReadAt(p []byte, off int64) (n int, err error) {
 	return f.ReadAtContext(context.Background(), p, off)
@@ -92,6 +171,33 @@ func (f *file) WriteAtContext(ctx context.Context, p []byte, off int64) error {
 	})
 }
 
+// PgReadAt reads len(p) bytes into p starting at offset off.
+// The CRC-32C checksum of each page of data sent by the server is verified.
+// Servers may reject requests larger than pgread.MaxLength.
+func (f *file) PgReadAt(ctx context.Context, p []byte, off int64) (n int, err error) {
+	resp := pgread.Response{Data: p[:0]}
+	req := &pgread.Request{Handle: f.handle, Offset: off, Length: int32(len(p))}
+	err = f.do(ctx, func(ctx context.Context, sid string) (string, error) {
+		return f.fs.c.sendSession(ctx, sid, &resp, req)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Data) > 0 && resp.Offset != off {
+		return 0, fmt.Errorf("xrootd: invalid pgread offset (got=%d, want=%d)", resp.Offset, off)
+	}
+	return copy(p, resp.Data), nil
+}
+
+// PgWriteAt writes len(p) bytes from p to the file at offset off.
+// Each page of data is sent with its CRC-32C checksum, verified by the server.
+func (f *file) PgWriteAt(ctx context.Context, p []byte, off int64) error {
+	var resp pgwrite.Response
+	return f.do(ctx, func(ctx context.Context, sid string) (string, error) {
+		return f.fs.c.sendSession(ctx, sid, &resp, &pgwrite.Request{Handle: f.handle, Offset: off, Data: p})
+	})
+}
+
 // WriteAt writes len(p) bytes from p to the file at offset off.
 func (f *file) WriteAt(p []byte, off int64) (n int, err error) {
 	err = f.WriteAtContext(context.Background(), p, off)
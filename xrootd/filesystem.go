@@ -7,6 +7,7 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 import (
 	"context"
 	stdpath "path"
+	"strings"
 
 	"go-hep.org/x/hep/xrootd/xrdfs"
 	"go-hep.org/x/hep/xrootd/xrdproto/chmod"
@@ -14,6 +15,7 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto/mkdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/mv"
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -157,6 +159,25 @@ func (fs *fileSystem) Statx(ctx context.Context, paths []string) ([]xrdfs.StatFl
 	return resp.StatFlags, nil
 }
 
+// Checksum returns the checksum of the named file, computed by the server
+// with the provided algorithm, such as xrdfs.Adler32, xrdfs.CRC32C or xrdfs.MD5.
+// An empty type selects the default algorithm of the server.
+func (fs *fileSystem) Checksum(ctx context.Context, path, typ string) (xrdfs.Checksum, error) {
+	if typ != "" {
+		sep := "?"
+		if strings.Contains(path, "?") {
+			sep = "&"
+		}
+		path += sep + "cks.type=" + typ
+	}
+	var resp query.Response
+	_, err := fs.c.Send(ctx, &resp, &query.Request{Query: query.Checksum, Args: []byte(path)})
+	if err != nil {
+		return xrdfs.Checksum{}, err
+	}
+	return xrdfs.ParseChecksum(resp.Data)
+}
+
 var (
 	_ xrdfs.FileSystem = (*fileSystem)(nil)
 )
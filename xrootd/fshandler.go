@@ -8,8 +8,10 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path"
+	"strings"
 	"sync"
 
 	"go-hep.org/x/hep/xrootd/xrdfs"
@@ -18,7 +20,11 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto/mkdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/mv"
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgread"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgwrite"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -53,9 +59,15 @@ func NewFSHandler(basePath string) Handler {
 	}
 }
 
+// path returns the path of name on the backing filesystem.
+// name is interpreted relative to basePath, even if it contains ".." elements.
+func (h *fshandler) path(name string) string {
+	return path.Join(h.basePath, cleanPath(name))
+}
+
 // Dirlist implements server.Handler.Dirlist.
 func (h *fshandler) Dirlist(sessionID [16]byte, request *dirlist.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
-	files, err := os.ReadDir(path.Join(h.basePath, request.Path))
+	files, err := os.ReadDir(h.path(request.Path))
 	if err != nil {
 		return xrdproto.ServerError{
 			Code:    xrdproto.IOError,
@@ -105,7 +117,7 @@ func (h *fshandler) Open(sessionID [16]byte, request *open.Request) (xrdproto.Ma
 		}
 	}
 
-	filePath := path.Join(h.basePath, request.Path)
+	filePath := h.path(request.Path)
 	if request.Options&xrdfs.OpenOptionsMkPath != 0 {
 		if err := os.MkdirAll(path.Dir(filePath), os.FileMode(request.Mode)); err != nil {
 			return xrdproto.ServerError{
@@ -230,6 +242,46 @@ func (h *fshandler) Read(sessionID [16]byte, request *read.Request) (xrdproto.Ma
 	return read.Response{Data: buf[:n]}, xrdproto.Ok
 }
 
+// ReadV implements server.Handler.ReadV.
+func (h *fshandler) ReadV(sessionID [16]byte, request *readv.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if len(request.Segments) > readv.MaxSegments {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Too many readv segments: %d (max=%d)", len(request.Segments), readv.MaxSegments),
+		}, xrdproto.Error
+	}
+
+	resp := readv.Response{Chunks: make([]readv.Chunk, len(request.Segments))}
+	for i, seg := range request.Segments {
+		if seg.Length < 0 || seg.Length > readv.MaxSegmentLength || seg.Offset < 0 {
+			return xrdproto.ServerError{
+				Code:    xrdproto.InvalidRequest,
+				Message: fmt.Sprintf("Invalid readv segment: offset=%d, length=%d", seg.Offset, seg.Length),
+			}, xrdproto.Error
+		}
+
+		file := h.getFile(sessionID, seg.Handle)
+		if file == nil {
+			return xrdproto.ServerError{
+				Code:    xrdproto.InvalidRequest,
+				Message: fmt.Sprintf("Invalid file handle: %v", seg.Handle),
+			}, xrdproto.Error
+		}
+
+		buf := make([]byte, seg.Length)
+		n, err := file.ReadAt(buf, seg.Offset)
+		if err != nil && err != io.EOF {
+			return xrdproto.ServerError{
+				Code:    xrdproto.IOError,
+				Message: fmt.Sprintf("An IO error occurred: %v", err),
+			}, xrdproto.Error
+		}
+		resp.Chunks[i] = readv.Chunk{Handle: seg.Handle, Offset: seg.Offset, Data: buf[:n]}
+	}
+
+	return resp, xrdproto.Ok
+}
+
 // Write implements server.Handler.Write.
 func (h *fshandler) Write(sessionID [16]byte, request *write.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
 	file := h.getFile(sessionID, request.Handle)
@@ -251,6 +303,64 @@ func (h *fshandler) Write(sessionID [16]byte, request *write.Request) (xrdproto.
 	return nil, xrdproto.Ok
 }
 
+// PgRead implements Handler.PgRead.
+func (h *fshandler) PgRead(sessionID [16]byte, request *pgread.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if request.Length < 0 || request.Length > pgread.MaxLength || request.Offset < 0 {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Invalid pgread request: offset=%d, length=%d", request.Offset, request.Length),
+		}, xrdproto.Error
+	}
+
+	file := h.getFile(sessionID, request.Handle)
+	if file == nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Invalid file handle: %v", request.Handle),
+		}, xrdproto.Error
+	}
+
+	buf := make([]byte, request.Length)
+	n, err := file.ReadAt(buf, request.Offset)
+	if err != nil && err != io.EOF {
+		return xrdproto.ServerError{
+			Code:    xrdproto.IOError,
+			Message: fmt.Sprintf("An IO error occurred: %v", err),
+		}, xrdproto.Error
+	}
+
+	return &pgread.Response{Offset: request.Offset, Data: buf[:n]}, xrdproto.Status
+}
+
+// PgWrite implements Handler.PgWrite.
+// Nothing is written if the checksum of any page does not match its content.
+func (h *fshandler) PgWrite(sessionID [16]byte, request *pgwrite.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	file := h.getFile(sessionID, request.Handle)
+	if file == nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Invalid file handle: %v", request.Handle),
+		}, xrdproto.Error
+	}
+
+	if bad := request.Verify(); len(bad) > 0 {
+		return xrdproto.ServerError{
+			Code:    xrdproto.ChecksumError,
+			Message: fmt.Sprintf("Invalid checksum of pages at offsets %v", bad),
+		}, xrdproto.Error
+	}
+
+	_, err := file.WriteAt(request.Data, request.Offset)
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.IOError,
+			Message: fmt.Sprintf("An IO error occurred: %v", err),
+		}, xrdproto.Error
+	}
+
+	return &pgwrite.Response{Offset: request.Offset}, xrdproto.Status
+}
+
 func (h *fshandler) getFile(sessionID [16]byte, handle xrdfs.FileHandle) *os.File {
 	h.mu.RLock()
 	sess, ok := h.sessions[sessionID]
@@ -289,7 +399,7 @@ func (h *fshandler) Stat(sessionID [16]byte, request *stat.Request) (xrdproto.Ma
 		}
 		fi, err = file.Stat()
 	} else {
-		fi, err = os.Stat(path.Join(h.basePath, request.Path))
+		fi, err = os.Stat(h.path(request.Path))
 	}
 
 	if err != nil {
@@ -315,7 +425,7 @@ func (h *fshandler) Truncate(sessionID [16]byte, request *truncate.Request) (xrd
 		}
 		err = file.Truncate(request.Size)
 	} else {
-		err = os.Truncate(path.Join(h.basePath, request.Path), request.Size)
+		err = os.Truncate(h.path(request.Path), request.Size)
 	}
 
 	if err != nil {
@@ -350,7 +460,7 @@ func (h *fshandler) Sync(sessionID [16]byte, request *xrdsync.Request) (xrdproto
 
 // Rename implements server.Handler.Rename.
 func (h *fshandler) Rename(sessionID [16]byte, request *mv.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
-	if err := os.Rename(path.Join(h.basePath, request.OldPath), path.Join(h.basePath, request.NewPath)); err != nil {
+	if err := os.Rename(h.path(request.OldPath), h.path(request.NewPath)); err != nil {
 		return xrdproto.ServerError{
 			Code:    xrdproto.IOError,
 			Message: fmt.Sprintf("An IO error occurred: %v", err),
@@ -367,7 +477,7 @@ func (h *fshandler) Mkdir(sessionID [16]byte, request *mkdir.Request) (xrdproto.
 		mkdirFunc = os.MkdirAll
 	}
 
-	if err := mkdirFunc(path.Join(h.basePath, request.Path), os.FileMode(request.Mode)); err != nil {
+	if err := mkdirFunc(h.path(request.Path), os.FileMode(request.Mode)); err != nil {
 		return xrdproto.ServerError{
 			Code:    xrdproto.IOError,
 			Message: fmt.Sprintf("An IO error occurred: %v", err),
@@ -378,7 +488,7 @@ func (h *fshandler) Mkdir(sessionID [16]byte, request *mkdir.Request) (xrdproto.
 
 // Remove implements server.Handler.Remove.
 func (h *fshandler) Remove(sessionID [16]byte, request *rm.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
-	if err := os.Remove(path.Join(h.basePath, request.Path)); err != nil {
+	if err := os.Remove(h.path(request.Path)); err != nil {
 		return xrdproto.ServerError{
 			Code:    xrdproto.IOError,
 			Message: fmt.Sprintf("An IO error occurred: %v", err),
@@ -389,7 +499,7 @@ func (h *fshandler) Remove(sessionID [16]byte, request *rm.Request) (xrdproto.Ma
 
 // RemoveDir implements server.Handler.RemoveDir.
 func (h *fshandler) RemoveDir(sessionID [16]byte, request *rmdir.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
-	if err := os.Remove(path.Join(h.basePath, request.Path)); err != nil {
+	if err := os.Remove(h.path(request.Path)); err != nil {
 		return xrdproto.ServerError{
 			Code:    xrdproto.IOError,
 			Message: fmt.Sprintf("An IO error occurred: %v", err),
@@ -398,6 +508,58 @@ func (h *fshandler) RemoveDir(sessionID [16]byte, request *rmdir.Request) (xrdpr
 	return nil, xrdproto.Ok
 }
 
+// Query implements Handler.Query.
+// Only the checksum query is supported. The checksum type is selected
+// with the "cks.type" opaque parameter and defaults to adler32.
+func (h *fshandler) Query(sessionID [16]byte, request *query.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if request.Query != query.Checksum {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Query %d is not implemented", request.Query),
+		}, xrdproto.Error
+	}
+
+	name, opaque, _ := strings.Cut(strings.TrimRight(string(request.Args), "\x00"), "?")
+	values, err := url.ParseQuery(opaque)
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Invalid opaque data %q: %v", opaque, err),
+		}, xrdproto.Error
+	}
+	typ := strings.ToLower(values.Get("cks.type"))
+	if typ == "" {
+		typ = xrdfs.Adler32
+	}
+	hash, err := xrdfs.NewChecksumHash(typ)
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Unsupported checksum type %q", typ),
+		}, xrdproto.Error
+	}
+
+	file, err := os.Open(h.path(name))
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.IOError,
+			Message: fmt.Sprintf("An IO error occurred: %v", err),
+		}, xrdproto.Error
+	}
+	defer file.Close()
+
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.IOError,
+			Message: fmt.Sprintf("An IO error occurred: %v", err),
+		}, xrdproto.Error
+	}
+
+	cks := xrdfs.NewChecksum(typ, hash)
+	return query.Response{Data: []byte(cks.String())}, xrdproto.Ok
+}
+
 // CloseSession implements server.Handler.CloseSession.
 func (h *fshandler) CloseSession(sessionID [16]byte) error {
 	h.mu.Lock()
@@ -6,8 +6,12 @@ package xrootd_test // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"crypto/md5"
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"hash/adler32"
+	"hash/crc32"
 	"net"
 	"os"
 	"path"
@@ -18,6 +22,8 @@ import (
 	"go-hep.org/x/hep/xrootd"
 	"go-hep.org/x/hep/xrootd/xrdfs"
 	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgread"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgwrite"
 	"go-hep.org/x/hep/xrootd/xrdproto/ping"
 )
 
@@ -432,6 +438,251 @@ func TestHandler_Read(t *testing.T) {
 	}
 }
 
+func TestHandler_ReadV(t *testing.T) {
+	data := make([]byte, 5*1024*1024)
+	_, err := rand.Read(data)
+	if err != nil {
+		t.Fatalf("could not prepare test data: %v", err)
+	}
+
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer func() {
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	err = os.WriteFile(path.Join(baseDir, "file1.txt"), data, 0777)
+	if err != nil {
+		t.Fatalf("could not create test file: %v", err)
+	}
+
+	cli, err := createClient(addr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	f, err := cli.FS().Open(context.Background(), "file1.txt", xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+	if err != nil {
+		t.Fatalf("could not call Open: %v", err)
+	}
+	defer f.Close(context.Background())
+
+	type segment struct {
+		offset int64
+		length int
+	}
+
+	many := make([]segment, 2500)
+	for i := range many {
+		many[i] = segment{offset: int64(i * 7), length: 10}
+	}
+
+	for _, tc := range []struct {
+		testName string
+		segs     []segment
+	}{
+		{
+			testName: "Single segment",
+			segs:     []segment{{offset: 1, length: 6}},
+		},
+		{
+			testName: "Many segments",
+			segs:     []segment{{offset: 10, length: 20}, {offset: 0, length: 5}, {offset: 1024, length: 0}, {offset: 15, length: 1000}},
+		},
+		{
+			testName: "With EOF",
+			segs:     []segment{{offset: int64(len(data)) - 10, length: 20}, {offset: int64(len(data)) + 10, length: 20}},
+		},
+		{
+			testName: "With segment larger than max segment length",
+			segs:     []segment{{offset: 3, length: 2 * len(data) / 3}, {offset: 0, length: 1}},
+		},
+		{
+			testName: "With more segments than max number of segments",
+			segs:     many,
+		},
+	} {
+		t.Run(tc.testName, func(t *testing.T) {
+			segs := make([]xrdfs.Segment, len(tc.segs))
+			for i, seg := range tc.segs {
+				segs[i] = xrdfs.Segment{Offset: seg.offset, Data: make([]byte, seg.length)}
+			}
+
+			err := f.ReadV(context.Background(), segs)
+			if err != nil {
+				t.Fatalf("could not call ReadV: %v", err)
+			}
+
+			for i, seg := range tc.segs {
+				beg := min(seg.offset, int64(len(data)))
+				end := min(seg.offset+int64(seg.length), int64(len(data)))
+				if want := data[beg:end]; !reflect.DeepEqual(segs[i].Data, want) {
+					t.Fatalf("wrong data for segment %d: got %d bytes, want %d bytes", i, len(segs[i].Data), len(want))
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_PgRead(t *testing.T) {
+	data := make([]byte, 5*pgread.PageSize+123)
+	_, err := rand.Read(data)
+	if err != nil {
+		t.Fatalf("could not prepare test data: %v", err)
+	}
+
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer func() {
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	err = os.WriteFile(path.Join(baseDir, "file1.txt"), data, 0777)
+	if err != nil {
+		t.Fatalf("could not create test file: %v", err)
+	}
+
+	cli, err := createClient(addr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	f, err := cli.FS().Open(context.Background(), "file1.txt", xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+	if err != nil {
+		t.Fatalf("could not call Open: %v", err)
+	}
+	defer f.Close(context.Background())
+
+	for _, tc := range []struct {
+		testName string
+		offset   int64
+		length   int
+	}{
+		{testName: "Single page", offset: 0, length: 10},
+		{testName: "Aligned pages", offset: pgread.PageSize, length: 2 * pgread.PageSize},
+		{testName: "Unaligned pages", offset: 100, length: 3*pgread.PageSize + 7},
+		{testName: "With EOF", offset: int64(len(data)) - 10, length: 20},
+		{testName: "Past EOF", offset: int64(len(data)) + 10, length: 20},
+	} {
+		t.Run(tc.testName, func(t *testing.T) {
+			buf := make([]byte, tc.length)
+			n, err := f.PgReadAt(context.Background(), buf, tc.offset)
+			if err != nil {
+				t.Fatalf("could not call PgReadAt: %v", err)
+			}
+			beg := min(tc.offset, int64(len(data)))
+			end := min(tc.offset+int64(tc.length), int64(len(data)))
+			if want := data[beg:end]; !reflect.DeepEqual(buf[:n], want) {
+				t.Fatalf("wrong data: got %d bytes, want %d bytes", n, len(want))
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		testName string
+		offset   int64
+		length   int
+	}{
+		{testName: "Negative offset", offset: -1, length: 10},
+		{testName: "Larger than max length", offset: 0, length: pgread.MaxLength + 1},
+	} {
+		t.Run(tc.testName, func(t *testing.T) {
+			buf := make([]byte, tc.length)
+			_, err := f.PgReadAt(context.Background(), buf, tc.offset)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			var serr xrdproto.ServerError
+			if !errors.As(err, &serr) || serr.Code != xrdproto.InvalidRequest {
+				t.Fatalf("invalid error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestHandler_PgWrite(t *testing.T) {
+	data := make([]byte, 3*pgread.PageSize+10)
+	_, err := rand.Read(data)
+	if err != nil {
+		t.Fatalf("could not prepare test data: %v", err)
+	}
+
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer func() {
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	cli, err := createClient(addr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	ctx := context.Background()
+	f, err := cli.FS().Open(ctx, "file1.txt", xrdfs.OpenModeOwnerRead|xrdfs.OpenModeOwnerWrite, xrdfs.OpenOptionsNew|xrdfs.OpenOptionsOpenUpdate)
+	if err != nil {
+		t.Fatalf("could not call Open: %v", err)
+	}
+	defer f.Close(ctx)
+
+	err = f.PgWriteAt(ctx, data[:100], 0)
+	if err != nil {
+		t.Fatalf("could not call PgWriteAt: %v", err)
+	}
+	err = f.PgWriteAt(ctx, data[100:], 100)
+	if err != nil {
+		t.Fatalf("could not call PgWriteAt: %v", err)
+	}
+
+	got, err := os.ReadFile(path.Join(baseDir, "file1.txt"))
+	if err != nil {
+		t.Fatalf("could not read test file: %v", err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Fatalf("wrong data: got %d bytes, want %d bytes", len(got), len(data))
+	}
+
+	// a corrupted page is rejected and nothing is written.
+	pages := pgread.Pages(0, data)
+	checksums := make([]uint32, len(pages))
+	for i, page := range pages {
+		checksums[i] = crc32.Checksum(page, xrdproto.CRC32C)
+	}
+	checksums[1] ^= 1
+	corrupted := make([]byte, len(data))
+	_, err = cli.Send(ctx, nil, &pgwrite.Request{Handle: f.Handle(), Data: corrupted, Checksums: checksums})
+	var serr xrdproto.ServerError
+	if !errors.As(err, &serr) || serr.Code != xrdproto.ChecksumError {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	got, err = os.ReadFile(path.Join(baseDir, "file1.txt"))
+	if err != nil {
+		t.Fatalf("could not read test file: %v", err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Fatalf("corrupted data was written")
+	}
+}
+
 func TestHandler_Write(t *testing.T) {
 	bigData := make([]byte, 10*1024)
 	_, err := rand.Read(bigData)
@@ -1040,3 +1291,69 @@ func TestHandler_Ping(t *testing.T) {
 		t.Fatalf("could not call Ping: %v", err)
 	}
 }
+
+func TestHandler_Checksum(t *testing.T) {
+	data := make([]byte, 10*1024+3)
+	_, err := rand.Read(data)
+	if err != nil {
+		t.Fatalf("could not prepare test data: %v", err)
+	}
+
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer func() {
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	err = os.WriteFile(path.Join(baseDir, "file1.txt"), data, 0777)
+	if err != nil {
+		t.Fatalf("could not create test file: %v", err)
+	}
+
+	cli, err := createClient(addr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	var (
+		adler = adler32.Checksum(data)
+		crc   = crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))
+		md    = md5.Sum(data)
+	)
+
+	for _, tc := range []struct {
+		typ  string
+		want xrdfs.Checksum
+	}{
+		{typ: "", want: xrdfs.Checksum{Type: "adler32", Value: fmt.Sprintf("%08x", adler)}},
+		{typ: "adler32", want: xrdfs.Checksum{Type: "adler32", Value: fmt.Sprintf("%08x", adler)}},
+		{typ: "crc32c", want: xrdfs.Checksum{Type: "crc32c", Value: fmt.Sprintf("%08x", crc)}},
+		{typ: "md5", want: xrdfs.Checksum{Type: "md5", Value: fmt.Sprintf("%x", md)}},
+	} {
+		t.Run(tc.typ, func(t *testing.T) {
+			got, err := cli.FS().Checksum(context.Background(), "file1.txt", tc.typ)
+			if err != nil {
+				t.Fatalf("could not call Checksum: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("wrong checksum:\ngot = %v\nwant= %v", got, tc.want)
+			}
+		})
+	}
+
+	_, err = cli.FS().Checksum(context.Background(), "file1.txt", "sha1")
+	if err == nil {
+		t.Fatalf("expected an error for an unsupported checksum type")
+	}
+
+	_, err = cli.FS().Checksum(context.Background(), "missing.txt", "adler32")
+	if err == nil {
+		t.Fatalf("expected an error for a missing file")
+	}
+}
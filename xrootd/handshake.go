@@ -6,38 +6,130 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
 
 	"go-hep.org/x/hep/xrootd/internal/xrdenc"
 	"go-hep.org/x/hep/xrootd/xrdproto"
 	"go-hep.org/x/hep/xrootd/xrdproto/handshake"
+	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
 )
 
-func (sess *cliSession) handshake(ctx context.Context) error {
-	streamID := xrdproto.StreamID{0, 0}
-	responseChannel, err := sess.mux.ClaimWithID(streamID)
-	if err != nil {
-		return err
+// handshake performs the initial handshake with the server and negotiates
+// the protocol, announcing the request expected to be sent next.
+// The connection is upgraded to TLS when the server asks for it.
+//
+// handshake reads the responses directly from the connection, so it must be
+// called before the responses are consumed by sess.consume.
+func (sess *cliSession) handshake(ctx context.Context, expect protocol.Expect) (protocol.Response, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn := sess.conn
+		if err := conn.SetDeadline(deadline); err != nil {
+			return protocol.Response{}, err
+		}
+		defer conn.SetDeadline(time.Time{})
 	}
 
-	req := handshake.NewRequest()
 	var wBuffer xrdenc.WBuffer
-	err = req.MarshalXrd(&wBuffer)
+	if err := handshake.NewRequest().MarshalXrd(&wBuffer); err != nil {
+		return protocol.Response{}, err
+	}
+
+	data, err := sess.roundTrip(wBuffer.Bytes())
 	if err != nil {
-		return err
+		return protocol.Response{}, err
+	}
+
+	var hresp handshake.Response
+	if err = hresp.UnmarshalXrd(xrdenc.NewRBuffer(data)); err != nil {
+		return protocol.Response{}, err
+	}
+	sess.protocolVersion = hresp.ProtocolVersion
+
+	req := protocol.Request{
+		ClientProtocolVersion: max(sess.protocolVersion, protocol.TLSVersion),
+		Options:               protocol.ReturnSecurityRequirements | protocol.AbleTLS,
+		Expect:                expect,
+	}
+	if sess.client.requireTLS {
+		req.Options |= protocol.WantTLS
+	}
+
+	wBuffer = xrdenc.WBuffer{}
+	header := xrdproto.RequestHeader{StreamID: xrdproto.StreamID{0, 1}, RequestID: req.ReqID()}
+	if err = header.MarshalXrd(&wBuffer); err != nil {
+		return protocol.Response{}, err
+	}
+	if err = req.MarshalXrd(&wBuffer); err != nil {
+		return protocol.Response{}, err
 	}
 
-	resp, _, err := sess.send(ctx, streamID, responseChannel, wBuffer.Bytes(), nil, 0)
-	// TODO: should we react somehow to redirection?
+	data, err = sess.roundTrip(wBuffer.Bytes())
 	if err != nil {
-		return err
+		return protocol.Response{}, err
 	}
 
-	var result handshake.Response
-	if err = result.UnmarshalXrd(xrdenc.NewRBuffer(resp)); err != nil {
-		return err
+	var resp protocol.Response
+	if err = resp.UnmarshalXrd(xrdenc.NewRBuffer(data)); err != nil {
+		return protocol.Response{}, err
 	}
 
-	sess.protocolVersion = result.ProtocolVersion
+	switch {
+	case resp.GotoTLS():
+		err = sess.startTLS(ctx)
+		if err != nil {
+			return protocol.Response{}, err
+		}
+	case sess.client.requireTLS:
+		return protocol.Response{}, fmt.Errorf("xrootd: server %s does not support TLS", sess.addr)
+	}
+
+	return resp, nil
+}
+
+// roundTrip sends the raw request to the server and returns the data of the response.
+func (sess *cliSession) roundTrip(req []byte) ([]byte, error) {
+	if _, err := sess.conn.Write(req); err != nil {
+		return nil, err
+	}
+
+	header, data, err := xrdproto.ReadResponse(sess.conn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch header.Status {
+	case xrdproto.Ok:
+		return data, nil
+	case xrdproto.Error:
+		return nil, header.Error(data)
+	default:
+		return nil, fmt.Errorf("xrootd: unexpected response status %d during handshake", header.Status)
+	}
+}
+
+// startTLS upgrades the connection to TLS.
+func (sess *cliSession) startTLS(ctx context.Context) error {
+	cfg := new(tls.Config)
+	if sess.client.tlsConfig != nil {
+		cfg = sess.client.tlsConfig.Clone()
+	}
+	if cfg.ServerName == "" {
+		host, _, err := net.SplitHostPort(sess.addr)
+		if err != nil {
+			return fmt.Errorf("xrootd: could not extract host from %q: %w", sess.addr, err)
+		}
+		cfg.ServerName = host
+	}
+
+	conn := tls.Client(sess.conn, cfg)
+	if err := conn.HandshakeContext(ctx); err != nil {
+		return fmt.Errorf("xrootd: could not perform TLS handshake with %s: %w", sess.addr, err)
+	}
 
+	sess.conn = conn
+	sess.tls = true
 	return nil
 }
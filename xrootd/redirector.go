@@ -0,0 +1,382 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/dirlist"
+	"go-hep.org/x/hep/xrootd/xrdproto/handshake"
+	"go-hep.org/x/hep/xrootd/xrdproto/locate"
+	"go-hep.org/x/hep/xrootd/xrdproto/mv"
+	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
+	"go-hep.org/x/hep/xrootd/xrdproto/rm"
+	"go-hep.org/x/hep/xrootd/xrdproto/stat"
+	"go-hep.org/x/hep/xrootd/xrdproto/truncate"
+)
+
+// Redirector implements Handler by redirecting clients to the data servers
+// holding the requested files.
+//
+// The data servers holding a file are discovered by sending stat requests
+// to all the data servers, and are cached until the file is removed or renamed
+// through the Redirector, or until a client asks for a refresh of the
+// location of the file (see xrdfs.OpenOptionsRefresh and locate.Refresh).
+// New files are created on the data servers in a round-robin fashion.
+//
+// Redirector answers locate, open, stat, rm, mv and truncate requests
+// for paths, and merges the directory listings of the data servers.
+// Other requests must be sent directly to the data servers.
+type Redirector struct {
+	Handler
+
+	servers []string
+	opts    []Option
+	timeout time.Duration // timeout of the requests to the data servers.
+
+	ctx    context.Context // ctx bounds the lifetime of the clients to the data servers.
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	clients map[string]*Client  // clients to the data servers, by address.
+	cache   map[string][]string // data servers holding a path.
+	next    int                 // next is the index of the data server where to create a new file.
+}
+
+// NewRedirector creates a Redirector to the data servers at the provided addresses.
+// The options opts configure the clients used to query the data servers.
+func NewRedirector(servers []string, opts ...Option) *Redirector {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Redirector{
+		Handler: Default(),
+		ctx:     ctx,
+		cancel:  cancel,
+		servers: append([]string(nil), servers...),
+		opts:    opts,
+		timeout: 10 * time.Second,
+		clients: make(map[string]*Client),
+		cache:   make(map[string][]string),
+	}
+}
+
+// Disconnect closes the connections to the data servers.
+func (r *Redirector) Disconnect() error {
+	defer r.cancel()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for addr, cli := range r.clients {
+		errs = append(errs, cli.Close())
+		delete(r.clients, addr)
+	}
+	return errors.Join(errs...)
+}
+
+// Handshake implements Handler.Handshake.
+func (*Redirector) Handshake() (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	resp := handshake.Response{ProtocolVersion: 0x310, ServerType: xrdproto.LoadBalancingServer}
+	return &resp, xrdproto.Ok
+}
+
+// Protocol implements Handler.Protocol.
+func (*Redirector) Protocol(sessionID [16]byte, request *protocol.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	resp := &protocol.Response{BinaryProtocolVersion: 0x310, Flags: protocol.IsManager}
+	return resp, xrdproto.Ok
+}
+
+// Locate implements Handler.Locate.
+func (r *Redirector) Locate(sessionID [16]byte, request *locate.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	name := strings.TrimPrefix(request.Path, "*")
+
+	servers := r.servers
+	if cleanPath(name) != "/" {
+		var err error
+		servers, err = r.locate(name, request.Options&locate.Refresh != 0)
+		if err != nil {
+			return newRedirectorErrorResponse(err)
+		}
+	}
+
+	// Data servers are reported as servers ('S') with read access ('r').
+	locs := make([]string, len(servers))
+	for i, addr := range servers {
+		locs[i] = "Sr" + addr
+	}
+	return &locate.Response{Data: []byte(strings.Join(locs, " "))}, xrdproto.Ok
+}
+
+// Open implements Handler.Open.
+func (r *Redirector) Open(sessionID [16]byte, request *open.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	servers, err := r.locate(request.Path, request.Options&xrdfs.OpenOptionsRefresh != 0)
+	switch {
+	case err == nil:
+		return r.redirect(servers[0])
+	case errors.Is(err, errNotFound) && request.Options&(xrdfs.OpenOptionsNew|xrdfs.OpenOptionsDelete) != 0 && len(r.servers) > 0:
+		r.mu.Lock()
+		addr := r.servers[r.next%len(r.servers)]
+		r.next++
+		r.mu.Unlock()
+		return r.redirect(addr)
+	default:
+		return newRedirectorErrorResponse(err)
+	}
+}
+
+// Stat implements Handler.Stat.
+func (r *Redirector) Stat(sessionID [16]byte, request *stat.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if request.Path == "" {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: "Stat of file handles must be sent to data servers",
+		}, xrdproto.Error
+	}
+	return r.redirectPath(request.Path)
+}
+
+// Dirlist implements Handler.Dirlist.
+// The entries of the directory are merged from all the data servers holding it.
+func (r *Redirector) Dirlist(sessionID [16]byte, request *dirlist.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	// directories may be created on any data server: always refresh their location.
+	servers, err := r.locate(request.Path, true)
+	if err != nil {
+		return newRedirectorErrorResponse(err)
+	}
+
+	ents := make([][]xrdfs.EntryStat, len(servers))
+	errs := r.each(servers, func(ctx context.Context, i int, fs xrdfs.FileSystem) error {
+		var err error
+		ents[i], err = fs.Dirlist(ctx, request.Path)
+		return err
+	})
+	if err := errors.Join(errs...); err != nil {
+		return newRedirectorErrorResponse(err)
+	}
+
+	resp := &dirlist.Response{WithStatInfo: request.Options&dirlist.WithStatInfo != 0}
+	seen := make(map[string]struct{})
+	for _, ents := range ents {
+		for _, ent := range ents {
+			if _, dup := seen[ent.EntryName]; dup {
+				continue
+			}
+			seen[ent.EntryName] = struct{}{}
+			ent.HasStatInfo = resp.WithStatInfo
+			resp.Entries = append(resp.Entries, ent)
+		}
+	}
+	return resp, xrdproto.Ok
+}
+
+// Remove implements Handler.Remove.
+func (r *Redirector) Remove(sessionID [16]byte, request *rm.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	defer r.forget(request.Path)
+	return r.redirectPath(request.Path)
+}
+
+// Rename implements Handler.Rename.
+func (r *Redirector) Rename(sessionID [16]byte, request *mv.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	defer r.forget(request.OldPath)
+	return r.redirectPath(request.OldPath)
+}
+
+// Query implements Handler.Query.
+// Checksum queries are redirected to a data server holding the file.
+func (r *Redirector) Query(sessionID [16]byte, request *query.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if request.Query != query.Checksum {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Query %d is not implemented", request.Query),
+		}, xrdproto.Error
+	}
+	return r.redirectPath(strings.TrimRight(string(request.Args), "\x00"))
+}
+
+// Truncate implements Handler.Truncate.
+func (r *Redirector) Truncate(sessionID [16]byte, request *truncate.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if request.Path == "" {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: "Truncate of file handles must be sent to data servers",
+		}, xrdproto.Error
+	}
+	return r.redirectPath(request.Path)
+}
+
+var errNotFound = errors.New("no such file or directory")
+
+// locate returns the addresses of the data servers holding the named path.
+// Unless refresh is set, the addresses are retrieved from the cache if possible.
+func (r *Redirector) locate(name string, refresh bool) ([]string, error) {
+	name = cleanPath(name)
+
+	if !refresh {
+		r.mu.Lock()
+		servers, ok := r.cache[name]
+		r.mu.Unlock()
+		if ok {
+			return servers, nil
+		}
+	}
+
+	errs := r.each(r.servers, func(ctx context.Context, i int, fs xrdfs.FileSystem) error {
+		_, err := fs.Stat(ctx, name)
+		return err
+	})
+
+	var (
+		servers []string
+		err     error
+	)
+	for i, addr := range r.servers {
+		switch e := errs[i]; {
+		case e == nil:
+			servers = append(servers, addr)
+		case err == nil && !isNotFound(e):
+			err = fmt.Errorf("could not stat %q on %s: %w", name, addr, e)
+		}
+	}
+
+	switch {
+	case len(servers) > 0:
+		r.mu.Lock()
+		r.cache[name] = servers
+		r.mu.Unlock()
+		return servers, nil
+	case err != nil:
+		return nil, err
+	default:
+		return nil, fmt.Errorf("%q: %w", name, errNotFound)
+	}
+}
+
+// forget removes the named path from the cache.
+func (r *Redirector) forget(name string) {
+	r.mu.Lock()
+	delete(r.cache, cleanPath(name))
+	r.mu.Unlock()
+}
+
+// each concurrently runs fct with the filesystems of the provided data servers,
+// and returns the errors of each run.
+func (r *Redirector) each(servers []string, fct func(ctx context.Context, i int, fs xrdfs.FileSystem) error) []error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(servers))
+	)
+	for i, addr := range servers {
+		wg.Add(1)
+		go func(i int, addr string) {
+			defer wg.Done()
+			cli, err := r.client(addr)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			errs[i] = fct(ctx, i, cli.FS())
+		}(i, addr)
+	}
+	wg.Wait()
+	return errs
+}
+
+// client returns the client connected to the data server at addr.
+func (r *Redirector) client(addr string) (*Client, error) {
+	r.mu.Lock()
+	cli, ok := r.clients[addr]
+	r.mu.Unlock()
+	if ok {
+		return cli, nil
+	}
+
+	cli, err := NewClient(r.ctx, addr, "redirector", r.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cur, ok := r.clients[addr]; ok {
+		// another request connected to the data server concurrently.
+		cli.Close()
+		return cur, nil
+	}
+	r.clients[addr] = cli
+	return cli, nil
+}
+
+func (r *Redirector) redirectPath(name string) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	servers, err := r.locate(name, false)
+	if err != nil {
+		return newRedirectorErrorResponse(err)
+	}
+	return r.redirect(servers[0])
+}
+
+func (r *Redirector) redirect(addr string) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.IOError,
+			Message: fmt.Sprintf("Invalid data server address %q: %v", addr, err),
+		}, xrdproto.Error
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.IOError,
+			Message: fmt.Sprintf("Invalid data server port %q: %v", addr, err),
+		}, xrdproto.Error
+	}
+	return xrdproto.RedirectResponse{Host: host, Port: int32(p)}, xrdproto.Redirect
+}
+
+func newRedirectorErrorResponse(err error) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if errors.Is(err, errNotFound) {
+		return xrdproto.ServerError{
+			Code:    xrdproto.NotFound,
+			Message: fmt.Sprintf("Not found: %v", err),
+		}, xrdproto.Error
+	}
+	return xrdproto.ServerError{
+		Code:    xrdproto.IOError,
+		Message: fmt.Sprintf("An IO error occurred: %v", err),
+	}, xrdproto.Error
+}
+
+// isNotFound returns whether err indicates that a path does not exist on a data server.
+func isNotFound(err error) bool {
+	var serr xrdproto.ServerError
+	if !errors.As(err, &serr) {
+		return false
+	}
+	switch serr.Code {
+	case xrdproto.NotFound:
+		return true
+	case xrdproto.IOError:
+		// fshandler reports missing files as IO errors.
+		return strings.Contains(serr.Message, "no such file or directory")
+	}
+	return false
+}
+
+var (
+	_ Handler = (*Redirector)(nil)
+)
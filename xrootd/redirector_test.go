@@ -0,0 +1,244 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd_test // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/crc32"
+	"net"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto/locate"
+)
+
+func serveHandler(t *testing.T, handler xrootd.Handler) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %+v", err)
+	}
+
+	srv := xrootd.NewServer(handler, func(err error) {
+		t.Logf("xrd-srv: %+v", err)
+	})
+
+	go func() {
+		err := srv.Serve(listener)
+		if err != nil && err != xrootd.ErrServerClosed {
+			t.Errorf("could not serve: %+v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		_ = srv.Shutdown(context.Background())
+	})
+
+	return listener.Addr().String()
+}
+
+func TestRedirector(t *testing.T) {
+	var (
+		dirs  = []string{t.TempDir(), t.TempDir()}
+		addrs = make([]string, len(dirs))
+	)
+	for i, dir := range dirs {
+		err := os.Mkdir(path.Join(dir, "data"), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrs[i] = serveHandler(t, xrootd.NewFSHandler(dir))
+	}
+	for _, f := range []struct {
+		srv  int
+		name string
+	}{
+		{0, "data/f0.txt"},
+		{1, "data/f1.txt"},
+		{0, "data/both.txt"},
+		{1, "data/both.txt"},
+	} {
+		err := os.WriteFile(path.Join(dirs[f.srv], f.name), []byte(addrs[f.srv]), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	redir := xrootd.NewRedirector(addrs)
+	defer redir.Disconnect()
+	addr := serveHandler(t, redir)
+
+	ctx := context.Background()
+	cli, err := xrootd.NewClient(ctx, addr, "gopher")
+	if err != nil {
+		t.Fatalf("could not create client: %+v", err)
+	}
+	defer cli.Close()
+	fs := cli.FS()
+
+	t.Run("open", func(t *testing.T) {
+		for i, name := range []string{"/data/f0.txt", "/data/f1.txt"} {
+			f, err := fs.Open(ctx, name, xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+			if err != nil {
+				t.Fatalf("could not open %s: %+v", name, err)
+			}
+			buf := make([]byte, len(addrs[i]))
+			_, err = f.ReadAt(buf, 0)
+			if err != nil {
+				t.Fatalf("could not read %s: %+v", name, err)
+			}
+			if got, want := string(buf), addrs[i]; got != want {
+				t.Fatalf("invalid data for %s: got=%q, want=%q", name, got, want)
+			}
+			err = f.Close(ctx)
+			if err != nil {
+				t.Fatalf("could not close %s: %+v", name, err)
+			}
+		}
+
+		_, err := fs.Open(ctx, "/data/missing.txt", xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+		if err == nil || !strings.Contains(err.Error(), "no such file or directory") {
+			t.Fatalf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("request-context", func(t *testing.T) {
+		cli, err := xrootd.NewClient(ctx, addr, "gopher")
+		if err != nil {
+			t.Fatalf("could not create client: %+v", err)
+		}
+		defer cli.Close()
+
+		// sessions opened while following a redirection outlive the request.
+		octx, cancel := context.WithCancel(ctx)
+		f, err := cli.FS().Open(octx, "/data/f0.txt", xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+		cancel()
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close(ctx)
+
+		buf := make([]byte, len(addrs[0]))
+		_, err = f.ReadAt(buf, 0)
+		if err != nil {
+			t.Fatalf("could not read file: %+v", err)
+		}
+	})
+
+	t.Run("stat", func(t *testing.T) {
+		st, err := fs.Stat(ctx, "/data/f1.txt")
+		if err != nil {
+			t.Fatalf("could not stat file: %+v", err)
+		}
+		if got, want := st.EntrySize, int64(len(addrs[1])); got != want {
+			t.Fatalf("invalid size: got=%d, want=%d", got, want)
+		}
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		cks, err := fs.Checksum(ctx, "/data/f1.txt", xrdfs.CRC32C)
+		if err != nil {
+			t.Fatalf("could not query checksum: %+v", err)
+		}
+		want := fmt.Sprintf("%08x", crc32.Checksum([]byte(addrs[1]), crc32.MakeTable(crc32.Castagnoli)))
+		if cks.Type != xrdfs.CRC32C || cks.Value != want {
+			t.Fatalf("invalid checksum: got=%v, want=%s", cks, want)
+		}
+	})
+
+	t.Run("locate", func(t *testing.T) {
+		for _, tc := range []struct {
+			path string
+			want []string
+		}{
+			{"/data/f0.txt", []string{"Sr" + addrs[0]}},
+			{"/data/f1.txt", []string{"Sr" + addrs[1]}},
+			{"/data/both.txt", []string{"Sr" + addrs[0], "Sr" + addrs[1]}},
+			{"*", []string{"Sr" + addrs[0], "Sr" + addrs[1]}},
+		} {
+			var resp locate.Response
+			_, err := cli.Send(ctx, &resp, &locate.Request{Path: tc.path, Options: locate.Refresh})
+			if err != nil {
+				t.Fatalf("could not locate %s: %+v", tc.path, err)
+			}
+			got := strings.Fields(string(bytes.TrimRight(resp.Data, "\x00")))
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("invalid location of %s:\ngot= %q\nwant=%q", tc.path, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("create", func(t *testing.T) {
+		for _, name := range []string{"/data/new0.txt", "/data/new1.txt"} {
+			f, err := fs.Open(ctx, name, xrdfs.OpenModeOwnerRead|xrdfs.OpenModeOwnerWrite, xrdfs.OpenOptionsNew|xrdfs.OpenOptionsOpenUpdate)
+			if err != nil {
+				t.Fatalf("could not create %s: %+v", name, err)
+			}
+			_, err = f.WriteAt([]byte("hello"), 0)
+			if err != nil {
+				t.Fatalf("could not write %s: %+v", name, err)
+			}
+			err = f.Close(ctx)
+			if err != nil {
+				t.Fatalf("could not close %s: %+v", name, err)
+			}
+		}
+
+		// new files are spread over the data servers.
+		for i, dir := range dirs {
+			ents, err := os.ReadDir(path.Join(dir, "data"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			n := 0
+			for _, ent := range ents {
+				if strings.HasPrefix(ent.Name(), "new") {
+					n++
+				}
+			}
+			if n != 1 {
+				t.Fatalf("invalid number of new files on data server %d: got=%d, want=1", i, n)
+			}
+		}
+	})
+
+	t.Run("dirlist", func(t *testing.T) {
+		ents, err := fs.Dirlist(ctx, "/data")
+		if err != nil {
+			t.Fatalf("could not list dir: %+v", err)
+		}
+		var names []string
+		for _, ent := range ents {
+			names = append(names, ent.Name())
+		}
+		slices.Sort(names)
+		want := []string{"both.txt", "f0.txt", "f1.txt", "new0.txt", "new1.txt"}
+		if !slices.Equal(names, want) {
+			t.Fatalf("invalid entries:\ngot= %q\nwant=%q", names, want)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		err := fs.RemoveFile(ctx, "/data/f1.txt")
+		if err != nil {
+			t.Fatalf("could not remove file: %+v", err)
+		}
+		_, err = os.Stat(path.Join(dirs[1], "data/f1.txt"))
+		if !os.IsNotExist(err) {
+			t.Fatalf("file was not removed: %v", err)
+		}
+		_, err = fs.Stat(ctx, "/data/f1.txt")
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
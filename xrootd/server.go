@@ -7,24 +7,32 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 import (
 	"context"
 	"crypto/rand"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"reflect"
+	"strings"
 	"sync"
 
 	"go-hep.org/x/hep/xrootd/internal/xrdenc"
 	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth"
 	"go-hep.org/x/hep/xrootd/xrdproto/dirlist"
 	"go-hep.org/x/hep/xrootd/xrdproto/handshake"
+	"go-hep.org/x/hep/xrootd/xrdproto/locate"
 	"go-hep.org/x/hep/xrootd/xrdproto/login"
 	"go-hep.org/x/hep/xrootd/xrdproto/mkdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/mv"
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgread"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgwrite"
 	"go-hep.org/x/hep/xrootd/xrdproto/ping"
 	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -57,20 +65,32 @@ type Server struct {
 
 	connMu     sync.Mutex
 	activeConn map[net.Conn]struct{}
+
+	tlsConfig *tls.Config          // tlsConfig is the configuration of TLS connections.
+	auths     []auth.Authenticator // auths are the security providers clients must authenticate with.
+	authz     Authorizer           // authz is the authorization policy of the server.
 }
 
 // NewServer creates a XRootD server which uses specified handler to handle requests
 // and errorHandler to handle errors. If the errorHandler is nil,
 // then a default error handler is used that does nothing.
-func NewServer(handler Handler, errorHandler ErrorHandler) *Server {
+// Options opts configure the server and are applied in the order they were specified.
+func NewServer(handler Handler, errorHandler ErrorHandler, opts ...ServerOption) *Server {
 	if errorHandler == nil {
 		errorHandler = func(error) {}
 	}
-	return &Server{
+	srv := &Server{
 		handler:      handler,
 		errorHandler: errorHandler,
 		activeConn:   make(map[net.Conn]struct{}),
 	}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(srv)
+	}
+	return srv
 }
 
 // Shutdown stops Server and closes all listeners and active connections.
@@ -139,13 +159,13 @@ func (s *Server) handleConnection(conn net.Conn) {
 		s.connMu.Unlock()
 	}()
 
-	var sessionID [16]byte
-	if _, err := rand.Read(sessionID[:]); err != nil {
+	sess := new(srvConn)
+	if _, err := rand.Read(sess.id[:]); err != nil {
 		s.errorHandler(fmt.Errorf("could not read session ID: %w", err))
 	}
 	defer func() {
-		if err := s.handler.CloseSession(sessionID); err != nil {
-			s.errorHandler(fmt.Errorf("could not close session ID %q: %w", sessionID, err))
+		if err := s.handler.CloseSession(sess.id); err != nil {
+			s.errorHandler(fmt.Errorf("could not close session ID %q: %w", sess.id, err))
 		}
 	}()
 
@@ -155,11 +175,15 @@ func (s *Server) handleConnection(conn net.Conn) {
 		return
 	}
 
+	// rw is the connection used to exchange requests and responses.
+	// It differs from conn once the connection has been upgraded to TLS.
+	rw := conn
+
 	for {
-		// We are using conn for read access only in that place
+		// We are using rw for read access only in that place
 		// and only once at time for each conn, so no additional
 		// serialization is needed.
-		reqData, err := xrdproto.ReadRequest(conn)
+		reqData, err := xrdproto.ReadRequest(rw)
 		if err == io.EOF || err == io.ErrClosedPipe {
 			// Client closed the connection.
 			return
@@ -176,11 +200,24 @@ func (s *Server) handleConnection(conn net.Conn) {
 			return
 		}
 
+		// The protocol request is handled synchronously as it
+		// may upgrade the connection to TLS.
+		var reqHeader xrdproto.RequestHeader
+		rBuffer := xrdenc.NewRBuffer(reqData)
+		if err := reqHeader.UnmarshalXrd(rBuffer); err == nil && reqHeader.RequestID == protocol.RequestID {
+			rw, err = s.handleProtocol(rw, sess, reqHeader.StreamID, rBuffer)
+			if err != nil {
+				s.errorHandler(fmt.Errorf("could not handle protocol request: %w", err))
+				return
+			}
+			continue
+		}
+
 		// Performing a request may take some time so we are running it
 		// in the separate goroutine. We follow the XRootD protocol and
 		// write results back with StreamID provided in the request,
 		// so Client will match the responses to the corresponding request calls.
-		go func(req []byte) {
+		go func(conn net.Conn, req []byte) {
 			var (
 				reqHeader xrdproto.RequestHeader
 				resp      xrdproto.Marshaler
@@ -191,7 +228,7 @@ func (s *Server) handleConnection(conn net.Conn) {
 			if err := reqHeader.UnmarshalXrd(rBuffer); err != nil {
 				resp, status = newUnmarshalingErrorResponse(err)
 			} else {
-				resp, status = s.handleRequest(sessionID, reqHeader.RequestID, rBuffer)
+				resp, status = s.handleRequest(sess, reqHeader.RequestID, rBuffer)
 			}
 
 			if err := xrdproto.WriteResponse(conn, reqHeader.StreamID, status, resp); err != nil {
@@ -205,7 +242,7 @@ func (s *Server) handleConnection(conn net.Conn) {
 				// the writing phase because we can't recover from it.
 				return
 			}
-		}(reqData)
+		}(rw, reqData)
 	}
 }
 
@@ -239,7 +276,12 @@ func newUnmarshalingErrorResponse(err error) (xrdproto.Marshaler, xrdproto.Respo
 	return response, xrdproto.Error
 }
 
-func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xrdenc.RBuffer) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+func (s *Server) handleRequest(sess *srvConn, requestID uint16, rBuffer *xrdenc.RBuffer) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if err := s.checkSession(sess, requestID); err != nil {
+		return newNotAuthorizedResponse(err)
+	}
+
+	sessionID := sess.id
 	switch requestID {
 	case login.RequestID:
 		var request login.Request
@@ -247,7 +289,23 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 		if err != nil {
 			return newUnmarshalingErrorResponse(err)
 		}
-		return s.handler.Login(sessionID, &request)
+		resp, status := s.handler.Login(sessionID, &request)
+		if info := s.securityInformation(); info != nil && status == xrdproto.Ok {
+			if v, ok := resp.(login.Response); ok {
+				resp = &v
+			}
+			if r, ok := resp.(*login.Response); ok {
+				r.SecurityInformation = info
+			}
+		}
+		return resp, status
+	case auth.RequestID:
+		var request auth.Request
+		err := request.UnmarshalXrd(rBuffer)
+		if err != nil {
+			return newUnmarshalingErrorResponse(err)
+		}
+		return s.authenticate(sess, &request)
 	case protocol.RequestID:
 		var request protocol.Request
 		err := request.UnmarshalXrd(rBuffer)
@@ -261,6 +319,9 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 		if err != nil {
 			return newUnmarshalingErrorResponse(err)
 		}
+		if err := s.authorize(sess, AccessRead, request.Path); err != nil {
+			return newNotAuthorizedResponse(err)
+		}
 		return s.handler.Dirlist(sessionID, &request)
 	case open.RequestID:
 		var request open.Request
@@ -268,6 +329,9 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 		if err != nil {
 			return newUnmarshalingErrorResponse(err)
 		}
+		if err := s.authorize(sess, openAccess(request.Options), request.Path); err != nil {
+			return newNotAuthorizedResponse(err)
+		}
 		return s.handler.Open(sessionID, &request)
 	case xrdclose.RequestID:
 		var request xrdclose.Request
@@ -283,6 +347,13 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 			return newUnmarshalingErrorResponse(err)
 		}
 		return s.handler.Read(sessionID, &request)
+	case readv.RequestID:
+		var request readv.Request
+		err := request.UnmarshalXrd(rBuffer)
+		if err != nil {
+			return newUnmarshalingErrorResponse(err)
+		}
+		return s.handler.ReadV(sessionID, &request)
 	case write.RequestID:
 		var request write.Request
 		err := request.UnmarshalXrd(rBuffer)
@@ -296,6 +367,11 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 		if err != nil {
 			return newUnmarshalingErrorResponse(err)
 		}
+		if request.Path != "" {
+			if err := s.authorize(sess, AccessRead, request.Path); err != nil {
+				return newNotAuthorizedResponse(err)
+			}
+		}
 		return s.handler.Stat(sessionID, &request)
 	case xrdsync.RequestID:
 		var request xrdsync.Request
@@ -310,6 +386,11 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 		if err != nil {
 			return newUnmarshalingErrorResponse(err)
 		}
+		if request.Path != "" {
+			if err := s.authorize(sess, AccessWrite, request.Path); err != nil {
+				return newNotAuthorizedResponse(err)
+			}
+		}
 		return s.handler.Truncate(sessionID, &request)
 	case mv.RequestID:
 		var request mv.Request
@@ -317,6 +398,9 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 		if err != nil {
 			return newUnmarshalingErrorResponse(err)
 		}
+		if err := s.authorize(sess, AccessWrite, request.OldPath, request.NewPath); err != nil {
+			return newNotAuthorizedResponse(err)
+		}
 		return s.handler.Rename(sessionID, &request)
 	case mkdir.RequestID:
 		var request mkdir.Request
@@ -324,6 +408,9 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 		if err != nil {
 			return newUnmarshalingErrorResponse(err)
 		}
+		if err := s.authorize(sess, AccessWrite, request.Path); err != nil {
+			return newNotAuthorizedResponse(err)
+		}
 		return s.handler.Mkdir(sessionID, &request)
 	case ping.RequestID:
 		var request ping.Request
@@ -338,6 +425,9 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 		if err != nil {
 			return newUnmarshalingErrorResponse(err)
 		}
+		if err := s.authorize(sess, AccessWrite, request.Path); err != nil {
+			return newNotAuthorizedResponse(err)
+		}
 		return s.handler.Remove(sessionID, &request)
 	case rmdir.RequestID:
 		var request rmdir.Request
@@ -345,7 +435,46 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 		if err != nil {
 			return newUnmarshalingErrorResponse(err)
 		}
+		if err := s.authorize(sess, AccessWrite, request.Path); err != nil {
+			return newNotAuthorizedResponse(err)
+		}
 		return s.handler.RemoveDir(sessionID, &request)
+	case locate.RequestID:
+		var request locate.Request
+		err := request.UnmarshalXrd(rBuffer)
+		if err != nil {
+			return newUnmarshalingErrorResponse(err)
+		}
+		if err := s.authorize(sess, AccessRead, strings.TrimPrefix(request.Path, "*")); err != nil {
+			return newNotAuthorizedResponse(err)
+		}
+		return s.handler.Locate(sessionID, &request)
+	case query.RequestID:
+		var request query.Request
+		err := request.UnmarshalXrd(rBuffer)
+		if err != nil {
+			return newUnmarshalingErrorResponse(err)
+		}
+		if request.Query == query.Checksum {
+			if err := s.authorize(sess, AccessRead, string(request.Args)); err != nil {
+				return newNotAuthorizedResponse(err)
+			}
+		}
+		return s.handler.Query(sessionID, &request)
+	case pgread.RequestID:
+		var request pgread.Request
+		err := request.UnmarshalXrd(rBuffer)
+		if err != nil {
+			return newUnmarshalingErrorResponse(err)
+		}
+		return s.handler.PgRead(sessionID, &request)
+	case pgwrite.RequestID:
+		var request pgwrite.Request
+		err := request.UnmarshalXrd(rBuffer)
+		if err != nil {
+			return newUnmarshalingErrorResponse(err)
+		}
+		return s.handler.PgWrite(sessionID, &request)
 	default:
 		response := xrdproto.ServerError{
 			Code:    xrdproto.InvalidRequest,
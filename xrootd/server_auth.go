@@ -0,0 +1,218 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth/ztn"
+	"go-hep.org/x/hep/xrootd/xrdproto/login"
+	"go-hep.org/x/hep/xrootd/xrdproto/ping"
+	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
+)
+
+// ServerOption configures an XRootD server.
+type ServerOption func(*Server)
+
+// WithServerTLS enables TLS on the server, using the provided configuration.
+// Clients able to use TLS are asked to upgrade their connection to TLS
+// during the protocol negotiation, and requests sent over connections
+// that were not upgraded are rejected.
+func WithServerTLS(cfg *tls.Config) ServerOption {
+	return func(srv *Server) {
+		srv.tlsConfig = cfg
+	}
+}
+
+// WithServerAuth requires clients to authenticate with one of the provided
+// security providers, listed in order of preference.
+//
+// Security providers requiring TLS (see auth.TLSRequirer) are only accepted
+// over connections upgraded to TLS, see WithServerTLS.
+func WithServerAuth(auths ...auth.Authenticator) ServerOption {
+	return func(srv *Server) {
+		srv.auths = append(srv.auths, auths...)
+	}
+}
+
+// WithTokenAuth requires clients to authenticate with a bearer token,
+// using the "ztn" security provider.
+// validate checks the provided token and returns the name of the authenticated user.
+//
+// As tokens are sent as is, ztn authentication is only accepted over TLS connections.
+func WithTokenAuth(validate func(token string) (user string, err error)) ServerOption {
+	return WithServerAuth(&ztn.Authenticator{Validate: validate})
+}
+
+// WithAuthorizer applies the provided authorization policy to the paths
+// accessed by clients.
+func WithAuthorizer(authz Authorizer) ServerOption {
+	return func(srv *Server) {
+		srv.authz = authz
+	}
+}
+
+// srvConn holds the state of a client connection to the server.
+type srvConn struct {
+	id [16]byte
+
+	mu     sync.RWMutex
+	tls    bool   // tls indicates whether the connection is protected by TLS.
+	authed bool   // authed indicates whether the client has been authenticated.
+	user   string // user is the name of the authenticated user.
+}
+
+func (sess *srvConn) isTLS() bool {
+	sess.mu.RLock()
+	defer sess.mu.RUnlock()
+	return sess.tls
+}
+
+func (sess *srvConn) isAuthenticated() bool {
+	sess.mu.RLock()
+	defer sess.mu.RUnlock()
+	return sess.authed
+}
+
+func (sess *srvConn) username() string {
+	sess.mu.RLock()
+	defer sess.mu.RUnlock()
+	return sess.user
+}
+
+// handleProtocol handles the protocol request req.
+// If the server supports TLS and the client is able to use it,
+// the connection is upgraded to TLS and the new connection is returned.
+//
+// handleProtocol must not be run concurrently with other reads of conn.
+func (s *Server) handleProtocol(conn net.Conn, sess *srvConn, streamID xrdproto.StreamID, rBuffer *xrdenc.RBuffer) (net.Conn, error) {
+	var (
+		request protocol.Request
+		resp    xrdproto.Marshaler
+		status  xrdproto.ResponseStatus
+		upgrade bool
+	)
+
+	err := request.UnmarshalXrd(rBuffer)
+	switch {
+	case err != nil:
+		resp, status = newUnmarshalingErrorResponse(err)
+	default:
+		resp, status = s.handler.Protocol(sess.id, &request)
+	}
+
+	if s.tlsConfig != nil && status == xrdproto.Ok {
+		if v, ok := resp.(protocol.Response); ok {
+			resp = &v
+		}
+		if r, ok := resp.(*protocol.Response); ok {
+			r.BinaryProtocolVersion = max(r.BinaryProtocolVersion, protocol.TLSVersion)
+			r.Flags |= protocol.HaveTLS | protocol.TLSLogin
+			if request.Options&protocol.AbleTLS != 0 && !sess.isTLS() {
+				r.Flags |= protocol.GotoTLS
+				upgrade = true
+			}
+		}
+	}
+
+	err = xrdproto.WriteResponse(conn, streamID, status, resp)
+	if err != nil || !upgrade {
+		return conn, err
+	}
+
+	tconn := tls.Server(conn, s.tlsConfig)
+	err = tconn.HandshakeContext(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("xrootd: could not perform TLS handshake with %v: %w", conn.RemoteAddr(), err)
+	}
+
+	sess.mu.Lock()
+	sess.tls = true
+	sess.mu.Unlock()
+
+	return tconn, nil
+}
+
+// checkSession checks whether the request requestID can be handled in the session.
+func (s *Server) checkSession(sess *srvConn, requestID uint16) error {
+	switch requestID {
+	case protocol.RequestID:
+		return nil
+	}
+	if s.tlsConfig != nil && !sess.isTLS() {
+		return fmt.Errorf("TLS is required")
+	}
+
+	switch requestID {
+	case login.RequestID, auth.RequestID, ping.RequestID:
+		return nil
+	}
+	if len(s.auths) > 0 && !sess.isAuthenticated() {
+		return fmt.Errorf("authentication is required")
+	}
+	return nil
+}
+
+// securityInformation returns the security information sent back
+// to the client in the login response.
+func (s *Server) securityInformation() []byte {
+	var info []byte
+	for _, a := range s.auths {
+		info = append(info, "&P="+a.Provider()...)
+		for _, p := range a.Params() {
+			info = append(info, ","+p...)
+		}
+	}
+	return info
+}
+
+// authenticate handles the auth request of a client.
+func (s *Server) authenticate(sess *srvConn, request *auth.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	provider := strings.TrimRight(string(request.Type[:]), "\x00")
+
+	var authn auth.Authenticator
+	for _, a := range s.auths {
+		if a.Provider() == provider {
+			authn = a
+			break
+		}
+	}
+	if authn == nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.NotAuthorized,
+			Message: fmt.Sprintf("Unsupported security provider %q", provider),
+		}, xrdproto.Error
+	}
+
+	if r, ok := authn.(auth.TLSRequirer); ok && r.RequiresTLS() && !sess.isTLS() {
+		return xrdproto.ServerError{
+			Code:    xrdproto.NotAuthorized,
+			Message: fmt.Sprintf("%s authentication requires a TLS connection", provider),
+		}, xrdproto.Error
+	}
+
+	user, err := authn.Authenticate(request)
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.NotAuthorized,
+			Message: fmt.Sprintf("Authentication failed: %v", err),
+		}, xrdproto.Error
+	}
+
+	sess.mu.Lock()
+	sess.authed = true
+	sess.user = user
+	sess.mu.Unlock()
+
+	return nil, xrdproto.Ok
+}
@@ -0,0 +1,149 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+)
+
+// Access describes the kind of access to a path requested by a client.
+type Access uint8
+
+const (
+	// AccessRead is the access needed to open a file for reading,
+	// to stat a path or to list a directory.
+	AccessRead Access = 1 << iota
+	// AccessWrite is the access needed to create, modify, rename or remove a path.
+	AccessWrite
+
+	// AccessNone denies any access.
+	AccessNone Access = 0
+	// AccessReadWrite grants read and write access.
+	AccessReadWrite = AccessRead | AccessWrite
+)
+
+func (a Access) String() string {
+	switch a {
+	case AccessNone:
+		return "none"
+	case AccessRead:
+		return "read"
+	case AccessWrite:
+		return "write"
+	case AccessReadWrite:
+		return "read-write"
+	}
+	return fmt.Sprintf("Access(%d)", uint8(a))
+}
+
+// Authorizer is the interface implemented by the authorization policies of a Server.
+type Authorizer interface {
+	// Authorize returns an error if user may not access the named path.
+	// user is empty when the client was not authenticated.
+	// name is an absolute, cleaned, path.
+	Authorize(user, name string, access Access) error
+}
+
+// Rule grants access to a tree of paths to a user.
+type Rule struct {
+	User   string // User is the name of the user the rule applies to, or "*" for all users.
+	Prefix string // Prefix is the root of the tree of paths the rule applies to.
+	Access Access // Access is the access granted by the rule.
+}
+
+func (r Rule) match(user, name string) bool {
+	if r.User != "*" && r.User != user {
+		return false
+	}
+	prefix := cleanPath(r.Prefix)
+	switch {
+	case prefix == "/", prefix == name:
+		return true
+	default:
+		return strings.HasPrefix(name, prefix+"/")
+	}
+}
+
+// Policy is an authorization policy made of a list of rules.
+//
+// The access to a path is decided by the first rule matching the user
+// and the path: the access is granted if it is part of the access of the rule.
+// The access is denied if no rule matches.
+//
+// For example, the following policy grants read-write access to
+// the home directory of each user and read-only access to everything else:
+//
+//	xrootd.Policy{
+//		{User: "alice", Prefix: "/home/alice", Access: xrootd.AccessReadWrite},
+//		{User: "bob", Prefix: "/home/bob", Access: xrootd.AccessReadWrite},
+//		{User: "*", Prefix: "/", Access: xrootd.AccessRead},
+//	}
+type Policy []Rule
+
+// ReadOnly is a policy granting read-only access to all users.
+var ReadOnly = Policy{{User: "*", Prefix: "/", Access: AccessRead}}
+
+// Authorize implements Authorizer.
+func (p Policy) Authorize(user, name string, access Access) error {
+	name = cleanPath(name)
+	for _, r := range p {
+		if !r.match(user, name) {
+			continue
+		}
+		if r.Access&access != access {
+			break
+		}
+		return nil
+	}
+	return fmt.Errorf("%s access to %q denied for user %q", access, name, user)
+}
+
+// cleanPath returns the absolute, cleaned, version of name,
+// stripped of its opaque data.
+func cleanPath(name string) string {
+	if i := strings.Index(name, "?"); i >= 0 {
+		name = name[:i]
+	}
+	return path.Clean("/" + name)
+}
+
+// authorize checks whether the client of sess may access the provided paths.
+func (s *Server) authorize(sess *srvConn, access Access, names ...string) error {
+	if s.authz == nil {
+		return nil
+	}
+	user := sess.username()
+	for _, name := range names {
+		err := s.authz.Authorize(user, cleanPath(name), access)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openAccess returns the access needed to open a file with the provided options.
+func openAccess(opts xrdfs.OpenOptions) Access {
+	const write = xrdfs.OpenOptionsDelete | xrdfs.OpenOptionsNew |
+		xrdfs.OpenOptionsOpenUpdate | xrdfs.OpenOptionsOpenAppend |
+		xrdfs.OpenOptionsMkPath | xrdfs.OpenOptionsReplica
+	if opts&write != 0 {
+		return AccessReadWrite
+	}
+	return AccessRead
+}
+
+func newNotAuthorizedResponse(err error) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	response := xrdproto.ServerError{
+		Code:    xrdproto.NotAuthorized,
+		Message: fmt.Sprintf("Not authorized: %v", err),
+	}
+	return response, xrdproto.Error
+}
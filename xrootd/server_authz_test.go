@@ -0,0 +1,209 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd_test // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth/unix"
+)
+
+func TestPolicy(t *testing.T) {
+	policy := xrootd.Policy{
+		{User: "alice", Prefix: "/home/alice", Access: xrootd.AccessReadWrite},
+		{User: "bob", Prefix: "/home/bob/", Access: xrootd.AccessReadWrite},
+		{User: "*", Prefix: "/secret", Access: xrootd.AccessNone},
+		{User: "*", Prefix: "/", Access: xrootd.AccessRead},
+	}
+
+	for _, tc := range []struct {
+		user   string
+		name   string
+		access xrootd.Access
+		err    string
+	}{
+		{user: "alice", name: "/home/alice", access: xrootd.AccessWrite},
+		{user: "alice", name: "/home/alice/f.root", access: xrootd.AccessReadWrite},
+		{user: "alice", name: "/home/alice/../alice/f.root", access: xrootd.AccessWrite},
+		{user: "bob", name: "/home/bob/f.root", access: xrootd.AccessWrite},
+		{user: "bob", name: "/home/alice/f.root", access: xrootd.AccessRead},
+		{user: "", name: "/data/f.root", access: xrootd.AccessRead},
+		{
+			user: "bob", name: "/home/alice/f.root", access: xrootd.AccessWrite,
+			err: `write access to "/home/alice/f.root" denied for user "bob"`,
+		},
+		{
+			user: "alice", name: "/home/alice2", access: xrootd.AccessWrite,
+			err: `write access to "/home/alice2" denied for user "alice"`,
+		},
+		{
+			user: "alice", name: "/home/alice/../bob/f.root", access: xrootd.AccessWrite,
+			err: `write access to "/home/bob/f.root" denied for user "alice"`,
+		},
+		{
+			user: "alice", name: "/secret/f.root", access: xrootd.AccessRead,
+			err: `read access to "/secret/f.root" denied for user "alice"`,
+		},
+		{
+			user: "", name: "/data", access: xrootd.AccessReadWrite,
+			err: `read-write access to "/data" denied for user ""`,
+		},
+	} {
+		t.Run(tc.user+":"+tc.name, func(t *testing.T) {
+			err := policy.Authorize(tc.user, tc.name, tc.access)
+			switch {
+			case err != nil && tc.err == "":
+				t.Fatalf("could not authorize: %+v", err)
+			case err != nil && tc.err != "":
+				if got, want := err.Error(), tc.err; got != want {
+					t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+				}
+			case err == nil && tc.err != "":
+				t.Fatalf("expected an error (%s)", tc.err)
+			}
+		})
+	}
+
+	if err := (xrootd.Policy{}).Authorize("alice", "/", xrootd.AccessRead); err == nil {
+		t.Fatalf("empty policy should deny access")
+	}
+}
+
+func TestServerAuthorization(t *testing.T) {
+	addr, baseDir := createTLSServer(t,
+		xrootd.WithServerAuth(&unix.Authenticator{Users: []string{"alice", "bob"}}),
+		xrootd.WithAuthorizer(xrootd.Policy{
+			{User: "alice", Prefix: "/alice", Access: xrootd.AccessReadWrite},
+			{User: "*", Prefix: "/", Access: xrootd.AccessRead},
+		}),
+	)
+	err := os.Mkdir(path.Join(baseDir, "alice"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	newClient := func(t *testing.T, user string) *xrootd.Client {
+		t.Helper()
+		cli, err := xrootd.NewClient(ctx, addr, user, xrootd.WithAuth(&unix.Auth{User: user, Group: "users"}))
+		if err != nil {
+			t.Fatalf("could not create client: %+v", err)
+		}
+		t.Cleanup(func() { cli.Close() })
+		return cli
+	}
+
+	t.Run("unknown-user", func(t *testing.T) {
+		_, err := xrootd.NewClient(ctx, addr, "eve", xrootd.WithAuth(&unix.Auth{User: "eve", Group: "users"}))
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+		if got, want := err.Error(), `unix: unknown user "eve"`; !strings.Contains(got, want) {
+			t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+		}
+	})
+
+	t.Run("alice", func(t *testing.T) {
+		fs := newClient(t, "alice").FS()
+
+		f, err := fs.Open(ctx, "/alice/f.txt", xrdfs.OpenModeOwnerRead|xrdfs.OpenModeOwnerWrite, xrdfs.OpenOptionsNew|xrdfs.OpenOptionsOpenUpdate)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		_, err = f.WriteAt([]byte("hello"), 0)
+		if err != nil {
+			t.Fatalf("could not write file: %+v", err)
+		}
+		err = f.Close(ctx)
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+
+		err = fs.Mkdir(ctx, "/alice/dir", xrdfs.OpenModeOwnerRead|xrdfs.OpenModeOwnerWrite|xrdfs.OpenModeOwnerExecute)
+		if err != nil {
+			t.Fatalf("could not create dir: %+v", err)
+		}
+
+		err = fs.Rename(ctx, "/alice/f.txt", "/f.txt")
+		if err == nil || !strings.Contains(err.Error(), `write access to "/f.txt" denied for user "alice"`) {
+			t.Fatalf("invalid rename error: %v", err)
+		}
+
+		err = fs.RemoveFile(ctx, "/alice/../file1.txt")
+		if err == nil || !strings.Contains(err.Error(), `write access to "/file1.txt" denied for user "alice"`) {
+			t.Fatalf("invalid remove error: %v", err)
+		}
+	})
+
+	t.Run("bob", func(t *testing.T) {
+		fs := newClient(t, "bob").FS()
+
+		ents, err := fs.Dirlist(ctx, "/")
+		if err != nil {
+			t.Fatalf("could not list dir: %+v", err)
+		}
+		if got, want := len(ents), 2; got != want {
+			t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+		}
+
+		f, err := fs.Open(ctx, "/alice/f.txt", xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close(ctx)
+
+		buf := make([]byte, 5)
+		_, err = f.ReadAt(buf, 0)
+		if err != nil {
+			t.Fatalf("could not read file: %+v", err)
+		}
+		if got, want := string(buf), "hello"; got != want {
+			t.Fatalf("invalid data: got=%q, want=%q", got, want)
+		}
+
+		_, err = fs.Open(ctx, "/alice/f.txt", xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenUpdate)
+		if err == nil || !strings.Contains(err.Error(), `read-write access to "/alice/f.txt" denied for user "bob"`) {
+			t.Fatalf("invalid open error: %v", err)
+		}
+
+		err = fs.Truncate(ctx, "/alice/f.txt", 0)
+		if err == nil || !strings.Contains(err.Error(), `write access to "/alice/f.txt" denied for user "bob"`) {
+			t.Fatalf("invalid truncate error: %v", err)
+		}
+
+		err = fs.RemoveDir(ctx, "/alice/dir")
+		if err == nil || !strings.Contains(err.Error(), `write access to "/alice/dir" denied for user "bob"`) {
+			t.Fatalf("invalid rmdir error: %v", err)
+		}
+	})
+}
+
+func TestFSHandlerBasePath(t *testing.T) {
+	addr, baseDir := createTLSServer(t)
+	outside := path.Base(baseDir) + ".txt"
+	err := os.WriteFile(path.Join(path.Dir(baseDir), outside), []byte("outside"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Remove(path.Join(path.Dir(baseDir), outside)) })
+
+	ctx := context.Background()
+	cli, err := xrootd.NewClient(ctx, addr, "gopher")
+	if err != nil {
+		t.Fatalf("could not create client: %+v", err)
+	}
+	defer cli.Close()
+
+	_, err = cli.FS().Stat(ctx, "../"+outside)
+	if err == nil {
+		t.Fatalf("file outside of the base path should not be accessible")
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"go-hep.org/x/hep/xrootd/internal/mux"
 	"go-hep.org/x/hep/xrootd/internal/xrdenc"
 	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
 	"go-hep.org/x/hep/xrootd/xrdproto/signing"
 	"go-hep.org/x/hep/xrootd/xrdproto/sigver"
 )
@@ -60,6 +61,7 @@ type cliSession struct {
 	addr      string
 	loginID   [16]byte
 	pathID    xrdproto.PathID
+	tls       bool // indicates whether the connection is protected by TLS.
 }
 
 // pendingRequest is a request that has been sent to the remote server.
@@ -76,8 +78,16 @@ type pendingRequest struct {
 	PathID xrdproto.PathID
 }
 
+// newSession creates a session to the server at address.
+// The provided context bounds the establishment of the session, while the session
+// lives as long as the context of the client (if any).
 func newSession(ctx context.Context, address, username, token string, client *Client) (*cliSession, error) {
-	ctx, cancel := context.WithCancel(ctx)
+	parent := ctx
+	if client != nil && client.ctx != nil {
+		parent = client.ctx
+	}
+	sctx, cancel := context.WithCancel(parent)
+	defer context.AfterFunc(ctx, cancel)()
 
 	var d net.Dialer
 	addr := parseAddr(address)
@@ -88,7 +98,7 @@ func newSession(ctx context.Context, address, username, token string, client *Cl
 	}
 
 	sess := &cliSession{
-		ctx:       ctx,
+		ctx:       sctx,
 		cancel:    cancel,
 		conn:      conn,
 		mux:       mux.New(),
@@ -101,13 +111,14 @@ func newSession(ctx context.Context, address, username, token string, client *Cl
 		maxSubs:   8, // TODO: The value of 8 is just a guess. Change it?
 	}
 
-	go sess.consume()
-
-	if err := sess.handshake(ctx); err != nil {
+	protocolInfo, err := sess.handshake(ctx, protocol.ExpectLogin)
+	if err != nil {
 		sess.Close()
 		return nil, err
 	}
 
+	go sess.consume()
+
 	securityInfo, err := sess.Login(ctx, username, token)
 	if err != nil {
 		sess.Close()
@@ -124,12 +135,6 @@ func newSession(ctx context.Context, address, username, token string, client *Cl
 		}
 	}
 
-	protocolInfo, err := sess.Protocol(ctx)
-	if err != nil {
-		sess.Close()
-		return nil, err
-	}
-
 	sess.signRequirements = signing.New(protocolInfo.SecurityLevel, protocolInfo.SecurityOverrides)
 
 	return sess, nil
@@ -264,13 +269,21 @@ func (sess *cliSession) consume() {
 				// TODO: should we just ignore responses to unclaimed stream IDs?
 			}
 
-			if header.Status != xrdproto.OkSoFar {
+			if header.Status != xrdproto.OkSoFar && !isPartialStatus(header.Status, resp.Data) {
 				sess.cleanupRequest(header.StreamID)
 			}
 		}
 	}
 }
 
+// isPartialStatus returns whether the response is a status response
+// announcing additional responses on the same stream.
+func isPartialStatus(status xrdproto.ResponseStatus, data []byte) bool {
+	const typeOffset = 7 // offset of the response type in a status response
+	return status == xrdproto.Status && len(data) >= xrdproto.StatusResponseLength &&
+		xrdproto.StatusResponseType(data[typeOffset]) == xrdproto.PartialResult
+}
+
 func (sess *cliSession) cleanupRequest(streamID xrdproto.StreamID) {
 	sess.mux.Unclaim(streamID)
 	sess.mu.Lock()
@@ -445,8 +458,12 @@ func (sess *cliSession) sign(streamID xrdproto.StreamID, requestID uint16, data
 	return wBuffer.Bytes(), nil
 }
 
+// newSubSession creates a sub-session of the parent session.
+// The provided context bounds the establishment of the sub-session, while the
+// sub-session lives as long as its parent.
 func newSubSession(ctx context.Context, parent *cliSession) (*cliSession, error) {
-	ctx, cancel := context.WithCancel(ctx)
+	sctx, cancel := context.WithCancel(parent.ctx)
+	defer context.AfterFunc(ctx, cancel)()
 
 	var d net.Dialer
 	conn, err := d.DialContext(ctx, "tcp", parent.addr)
@@ -456,7 +473,7 @@ func newSubSession(ctx context.Context, parent *cliSession) (*cliSession, error)
 	}
 
 	sess := &cliSession{
-		ctx:       ctx,
+		ctx:       sctx,
 		cancel:    cancel,
 		conn:      conn,
 		mux:       parent.mux,
@@ -468,13 +485,13 @@ func newSubSession(ctx context.Context, parent *cliSession) (*cliSession, error)
 		isSub:     true,
 	}
 
-	go sess.consume()
-
-	if err := sess.handshake(ctx); err != nil {
+	if _, err := sess.handshake(ctx, protocol.ExpectBind); err != nil {
 		sess.Close()
 		return nil, err
 	}
 
+	go sess.consume()
+
 	pathID, err := sess.bind(ctx, parent.loginID)
 	if err != nil {
 		sess.Close()
@@ -0,0 +1,308 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd_test // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth/ztn"
+	"go-hep.org/x/hep/xrootd/xrdproto/dirlist"
+	"go-hep.org/x/hep/xrootd/xrdproto/handshake"
+	"go-hep.org/x/hep/xrootd/xrdproto/login"
+	"go-hep.org/x/hep/xrootd/xrdproto/ping"
+)
+
+// newTestCert creates a self-signed certificate for localhost.
+func newTestCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate key: %+v", err)
+	}
+
+	tmpl := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("could not create certificate: %+v", err)
+	}
+
+	crt, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("could not parse certificate: %+v", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(crt)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+func createTLSServer(t *testing.T, opts ...xrootd.ServerOption) (addr, baseDir string) {
+	t.Helper()
+
+	baseDir = t.TempDir()
+	err := os.WriteFile(path.Join(baseDir, "file1.txt"), []byte("hello"), 0644)
+	if err != nil {
+		t.Fatalf("could not create test file: %+v", err)
+	}
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %+v", err)
+	}
+
+	srv := xrootd.NewServer(xrootd.NewFSHandler(baseDir), func(err error) {
+		t.Logf("xrd-srv: %+v", err)
+	}, opts...)
+
+	go func() {
+		err := srv.Serve(listener)
+		if err != nil && err != xrootd.ErrServerClosed {
+			t.Errorf("could not serve: %+v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		_ = srv.Shutdown(context.Background())
+	})
+
+	return listener.Addr().String(), baseDir
+}
+
+func TestTLS(t *testing.T) {
+	crt, pool := newTestCert(t)
+	srvTLS := xrootd.WithServerTLS(&tls.Config{Certificates: []tls.Certificate{crt}})
+	cliTLS := xrootd.WithTLS(&tls.Config{RootCAs: pool})
+
+	validate := func(token string) (string, error) {
+		if token != "s3cr3t" {
+			return "", fmt.Errorf("unknown token")
+		}
+		return "bob", nil
+	}
+
+	for _, tc := range []struct {
+		name string
+		srv  []xrootd.ServerOption
+		cli  []xrootd.Option
+		err  string
+	}{
+		{
+			name: "tls",
+			srv:  []xrootd.ServerOption{srvTLS},
+			cli:  []xrootd.Option{cliTLS},
+		},
+		{
+			name: "tls-ztn",
+			srv:  []xrootd.ServerOption{srvTLS, xrootd.WithTokenAuth(validate)},
+			cli:  []xrootd.Option{cliTLS, xrootd.WithAuth(&ztn.Auth{Token: "s3cr3t"})},
+		},
+		{
+			name: "tls-ztn-invalid-token",
+			srv:  []xrootd.ServerOption{srvTLS, xrootd.WithTokenAuth(validate)},
+			cli:  []xrootd.Option{cliTLS, xrootd.WithAuth(&ztn.Auth{Token: "invalid"})},
+			err:  "Authentication failed: ztn: invalid token: unknown token",
+		},
+		{
+			name: "tls-unknown-authority",
+			srv:  []xrootd.ServerOption{srvTLS},
+			cli:  []xrootd.Option{xrootd.WithTLS(nil)},
+			err:  "could not perform TLS handshake",
+		},
+		{
+			name: "server-without-tls",
+			cli:  []xrootd.Option{cliTLS},
+			err:  "does not support TLS",
+		},
+		{
+			name: "ztn-without-tls",
+			srv:  []xrootd.ServerOption{xrootd.WithTokenAuth(validate)},
+			cli:  []xrootd.Option{xrootd.WithAuth(&ztn.Auth{Token: "s3cr3t"})},
+			err:  "provider requires a TLS connection",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, _ := createTLSServer(t, tc.srv...)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			cli, err := xrootd.NewClient(ctx, addr, "gopher", tc.cli...)
+			switch {
+			case err != nil && tc.err != "":
+				if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, tc.err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not create client: %+v", err)
+			case tc.err != "":
+				_ = cli.Close()
+				t.Fatalf("expected an error containing %q", tc.err)
+			}
+			defer cli.Close()
+
+			f, err := cli.FS().Open(ctx, "file1.txt", xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close(ctx)
+
+			buf := make([]byte, 5)
+			_, err = f.ReadAt(buf, 0)
+			if err != nil {
+				t.Fatalf("could not read file: %+v", err)
+			}
+			if got, want := string(buf), "hello"; got != want {
+				t.Fatalf("invalid data: got=%q, want=%q", got, want)
+			}
+		})
+	}
+}
+
+func TestTLSRequired(t *testing.T) {
+	crt, _ := newTestCert(t)
+	addr, _ := createTLSServer(t, xrootd.WithServerTLS(&tls.Config{Certificates: []tls.Certificate{crt}}))
+
+	// a client unable to use TLS, sending its requests in clear.
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("could not dial: %+v", err)
+	}
+	defer conn.Close()
+
+	err = rawHandshake(conn)
+	if err != nil {
+		t.Fatalf("could not perform handshake: %+v", err)
+	}
+
+	err = rawRequest(conn, login.NewRequest("gopher", ""))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), "Not authorized: TLS is required"; !strings.Contains(got, want) {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+	}
+}
+
+func TestTokenAuthRequired(t *testing.T) {
+	addr, _ := createTLSServer(t, xrootd.WithTokenAuth(func(token string) (string, error) {
+		return "bob", nil
+	}))
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("could not dial: %+v", err)
+	}
+	defer conn.Close()
+
+	err = rawHandshake(conn)
+	if err != nil {
+		t.Fatalf("could not perform handshake: %+v", err)
+	}
+
+	err = rawRequest(conn, login.NewRequest("gopher", ""))
+	if err != nil {
+		t.Fatalf("could not login: %+v", err)
+	}
+
+	err = rawRequest(conn, &ping.Request{})
+	if err != nil {
+		t.Fatalf("could not ping: %+v", err)
+	}
+
+	err = rawRequest(conn, &dirlist.Request{Path: "/"})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), "Not authorized: authentication is required"; !strings.Contains(got, want) {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+	}
+
+	// ztn credentials are refused over a connection without TLS.
+	cred, err := ztn.Credentials("s3cr3t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rawRequest(conn, &auth.Request{Type: ztn.Type, Credentials: cred})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), "ztn authentication requires a TLS connection"; !strings.Contains(got, want) {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+	}
+}
+
+func rawHandshake(conn net.Conn) error {
+	var wBuffer xrdenc.WBuffer
+	err := handshake.NewRequest().MarshalXrd(&wBuffer)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Write(wBuffer.Bytes())
+	if err != nil {
+		return err
+	}
+	_, _, err = xrdproto.ReadResponse(conn)
+	return err
+}
+
+func rawRequest(conn net.Conn, req xrdproto.Request) error {
+	var (
+		wBuffer xrdenc.WBuffer
+		header  = xrdproto.RequestHeader{StreamID: xrdproto.StreamID{0, 1}, RequestID: req.ReqID()}
+	)
+	err := header.MarshalXrd(&wBuffer)
+	if err != nil {
+		return err
+	}
+	err = req.MarshalXrd(&wBuffer)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Write(wBuffer.Bytes())
+	if err != nil {
+		return err
+	}
+	hdr, data, err := xrdproto.ReadResponse(conn)
+	if err != nil {
+		return err
+	}
+	if hdr.Status == xrdproto.Error {
+		return hdr.Error(data)
+	}
+	return nil
+}
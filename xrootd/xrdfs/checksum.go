@@ -0,0 +1,61 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdfs // import "go-hep.org/x/hep/xrootd/xrdfs"
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"strings"
+)
+
+// Checksum types supported by NewChecksumHash.
+const (
+	Adler32 = "adler32"
+	CRC32C  = "crc32c"
+	MD5     = "md5"
+)
+
+// Checksum is the checksum of a file.
+type Checksum struct {
+	Type  string // Type is the name of the checksum algorithm, such as Adler32.
+	Value string // Value is the lowercase hexadecimal representation of the checksum.
+}
+
+func (cks Checksum) String() string {
+	return cks.Type + " " + cks.Value
+}
+
+// NewChecksum returns the checksum of the provided type from the state of h.
+func NewChecksum(typ string, h hash.Hash) Checksum {
+	return Checksum{Type: typ, Value: hex.EncodeToString(h.Sum(nil))}
+}
+
+// ParseChecksum parses the response of a checksum query,
+// made of the checksum type and its value separated by a space.
+func ParseChecksum(data []byte) (Checksum, error) {
+	typ, value, ok := strings.Cut(string(bytes.TrimRight(data, "\x00\n ")), " ")
+	if !ok || typ == "" || value == "" {
+		return Checksum{}, fmt.Errorf("xrootd: invalid checksum %q", data)
+	}
+	return Checksum{Type: typ, Value: strings.ToLower(value)}, nil
+}
+
+// NewChecksumHash returns a hash computing checksums of the provided type.
+func NewChecksumHash(typ string) (hash.Hash, error) {
+	switch strings.ToLower(typ) {
+	case Adler32:
+		return adler32.New(), nil
+	case CRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	case MD5:
+		return md5.New(), nil
+	}
+	return nil, fmt.Errorf("xrootd: unsupported checksum type %q", typ)
+}
@@ -39,9 +39,24 @@ type File interface {
 	// ReadAtContext reads len(p) bytes into p starting at offset off.
 	ReadAtContext(ctx context.Context, p []byte, off int64) (n int, err error)
 
+	// ReadV reads the provided segments of the file with vector reads,
+	// fetching many segments in a single round trip.
+	// On return, the Data of each segment is resliced to the number of bytes read,
+	// which is less than requested for segments extending past the end of the file.
+	ReadV(ctx context.Context, segs []Segment) error
+
 	// WriteAtContext writes len(p) bytes from p to the file at offset off.
 	WriteAtContext(ctx context.Context, p []byte, off int64) error
 
+	// PgReadAt reads len(p) bytes into p starting at offset off.
+	// Each page of data sent by the server is protected by a CRC-32C checksum,
+	// verified by the client.
+	PgReadAt(ctx context.Context, p []byte, off int64) (n int, err error)
+
+	// PgWriteAt writes len(p) bytes from p to the file at offset off.
+	// Each page of data is protected by a CRC-32C checksum, verified by the server.
+	PgWriteAt(ctx context.Context, p []byte, off int64) error
+
 	// Truncate changes the size of the file.
 	Truncate(ctx context.Context, size int64) error
 
@@ -60,6 +75,12 @@ type File interface {
 	VerifyWriteAt(ctx context.Context, p []byte, off int64) error
 }
 
+// Segment describes a chunk of a file to be read with ReadV.
+type Segment struct {
+	Offset int64  // offset of the chunk in the file
+	Data   []byte // buffer receiving the chunk, the length of the chunk is len(Data)
+}
+
 // FileHandle is the file handle, which should be treated as opaque data.
 type FileHandle [4]byte
 
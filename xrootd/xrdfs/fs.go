@@ -58,6 +58,11 @@ type FileSystem interface {
 	// Statx obtains type information for one or more paths.
 	// Only a limited number of flags is meaningful such as StatIsExecutable, StatIsDir, StatIsOther, StatIsOffline.
 	Statx(ctx context.Context, paths []string) ([]StatFlags, error)
+
+	// Checksum returns the checksum of the named file, computed by the server
+	// with the provided algorithm, such as Adler32, CRC32C or MD5.
+	// An empty type selects the default algorithm of the server.
+	Checksum(ctx context.Context, path, typ string) (Checksum, error)
 }
 
 // OpenMode is the mode in which path is to be opened.
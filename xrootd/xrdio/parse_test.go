@@ -78,6 +78,23 @@ func TestParse(t *testing.T) {
 				Path: "/file1.%c.root",
 			},
 		},
+		{
+			name: "roots://bob@example.org:1094//dir/file1.root",
+			want: URL{
+				Addr: "example.org:1094",
+				User: "bob",
+				Path: "/dir/file1.root",
+				TLS:  true,
+			},
+		},
+		{
+			name: "xroots://example.org/file1.root",
+			want: URL{
+				Addr: "example.org",
+				Path: "/file1.root",
+				TLS:  true,
+			},
+		},
 		{
 			name: "root://localhost:1094/file1.root",
 			want: URL{
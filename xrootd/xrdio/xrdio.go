@@ -36,6 +36,7 @@ type File struct {
 
 // Open opens the name file, where name is the absolute location of that file
 // (xrootd server address and path to the file on that server.)
+// The connection to the server is protected by TLS for roots:// URLs.
 //
 // Example:
 //
@@ -46,7 +47,12 @@ func Open(name string) (*File, error) {
 		return nil, fmt.Errorf("could not parse %q: %w", name, err)
 	}
 
-	xrd, err := xrootd.NewClient(context.Background(), urn.Addr, urn.User)
+	var opts []xrootd.Option
+	if urn.TLS {
+		opts = append(opts, xrootd.WithTLS(nil))
+	}
+
+	xrd, err := xrootd.NewClient(context.Background(), urn.Addr, urn.User, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("xrdio: could not connect to xrootd server %q: %w", urn.Addr, err)
 	}
@@ -137,6 +143,12 @@ func (f *File) ReadAt(data []byte, offset int64) (int, error) {
 	return f.f.ReadAt(data, offset)
 }
 
+// ReadV reads the provided segments of the file with vector reads.
+// See xrdfs.File.ReadV for details.
+func (f *File) ReadV(segs []xrdfs.Segment) error {
+	return f.f.ReadV(context.Background(), segs)
+}
+
 // Write implements io.Writer.
 func (f *File) Write(data []byte) (int, error) {
 	n, err := f.f.WriteAt(data, f.pos)
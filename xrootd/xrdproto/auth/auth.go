@@ -47,3 +47,16 @@ type Auther interface {
 	Provider() string                          // Provider returns the name of the security provider.
 	Request(params []string) (*Request, error) // Request forms an authorization Request according to passed parameters.
 }
+
+// Authenticator is the interface that must be implemented by the server side of a security provider.
+type Authenticator interface {
+	Provider() string                          // Provider returns the name of the security provider.
+	Params() []string                          // Params returns the parameters sent to clients along with the provider name.
+	Authenticate(req *Request) (string, error) // Authenticate verifies the credentials of an authorization Request and returns the name of the authenticated user.
+}
+
+// TLSRequirer is the interface implemented by security providers that
+// must only be used over connections protected by TLS.
+type TLSRequirer interface {
+	RequiresTLS() bool // RequiresTLS returns whether the provider requires a TLS connection.
+}
@@ -6,7 +6,9 @@
 package unix // import "go-hep.org/x/hep/xrootd/xrdproto/auth/unix"
 
 import (
+	"fmt"
 	"os/user"
+	"strings"
 
 	"go-hep.org/x/hep/xrootd/xrdproto/auth"
 )
@@ -46,6 +48,60 @@ func (a *Auth) Request(params []string) (*auth.Request, error) {
 	return &auth.Request{Type: Type, Credentials: "unix\000" + a.User + " " + a.Group + "\000"}, nil
 }
 
+// Authenticator implements the server side of the unix security provider.
+//
+// The unix security provider does not verify the identity of clients:
+// the user name they report is trusted as is.
+// It should only be used within trusted networks.
+type Authenticator struct {
+	// Users is the list of accepted user names.
+	// If empty, any user name is accepted.
+	Users []string
+}
+
+// Provider implements auth.Authenticator
+func (*Authenticator) Provider() string {
+	return "unix"
+}
+
+// Params implements auth.Authenticator
+func (*Authenticator) Params() []string { return nil }
+
+// Authenticate implements auth.Authenticator
+func (a *Authenticator) Authenticate(req *auth.Request) (string, error) {
+	if req.Type != Type {
+		return "", fmt.Errorf("unix: invalid request type %q", req.Type[:])
+	}
+	usr, _, err := ParseCredentials(req.Credentials)
+	if err != nil {
+		return "", err
+	}
+	if len(a.Users) == 0 {
+		return usr, nil
+	}
+	for _, u := range a.Users {
+		if u == usr {
+			return usr, nil
+		}
+	}
+	return "", fmt.Errorf("unix: unknown user %q", usr)
+}
+
+// ParseCredentials extracts the user and group names from the provided unix credentials.
+func ParseCredentials(cred string) (user, group string, err error) {
+	const prefix = "unix\000"
+	if !strings.HasPrefix(cred, prefix) {
+		return "", "", fmt.Errorf("unix: invalid credentials protocol")
+	}
+	cred = strings.TrimSuffix(cred[len(prefix):], "\000")
+	user, group, _ = strings.Cut(cred, " ")
+	if user == "" {
+		return "", "", fmt.Errorf("unix: missing user name")
+	}
+	return user, group, nil
+}
+
 var (
-	_ auth.Auther = (*Auth)(nil)
+	_ auth.Auther        = (*Auth)(nil)
+	_ auth.Authenticator = (*Authenticator)(nil)
 )
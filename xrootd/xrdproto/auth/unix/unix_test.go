@@ -36,3 +36,60 @@ func TestUnix(t *testing.T) {
 		t.Fatalf("invalid type: got=%q, want=%q", got.Type, want.Type)
 	}
 }
+
+func TestAuthenticator(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		users []string
+		cred  string
+		user  string
+		err   string
+	}{
+		{
+			name: "any",
+			cred: "unix\000gopher golang\000",
+			user: "gopher",
+		},
+		{
+			name:  "known",
+			users: []string{"alice", "gopher"},
+			cred:  "unix\000gopher golang\000",
+			user:  "gopher",
+		},
+		{
+			name:  "unknown",
+			users: []string{"alice"},
+			cred:  "unix\000gopher golang\000",
+			err:   `unix: unknown user "gopher"`,
+		},
+		{
+			name: "no-user",
+			cred: "unix\000 golang\000",
+			err:  "unix: missing user name",
+		},
+		{
+			name: "invalid",
+			cred: "host\000gopher\000",
+			err:  "unix: invalid credentials protocol",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := unix.Authenticator{Users: tc.users}
+			user, err := srv.Authenticate(&auth.Request{Type: unix.Type, Credentials: tc.cred})
+			switch {
+			case err != nil && tc.err == "":
+				t.Fatalf("could not authenticate: %+v", err)
+			case err != nil && tc.err != "":
+				if got, want := err.Error(), tc.err; got != want {
+					t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+				}
+				return
+			case err == nil && tc.err != "":
+				t.Fatalf("expected an error (%s)", tc.err)
+			}
+			if user != tc.user {
+				t.Fatalf("invalid user: got=%q, want=%q", user, tc.user)
+			}
+		})
+	}
+}
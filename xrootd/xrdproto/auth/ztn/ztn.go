@@ -0,0 +1,199 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ztn contains the implementation of the "ztn" security provider,
+// which authenticates clients with a bearer token (e.g. a WLCG or SciToken).
+//
+// As the token is sent as is to the server, the ztn security provider
+// is only used over connections protected by TLS.
+package ztn // import "go-hep.org/x/hep/xrootd/xrdproto/auth/ztn"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go-hep.org/x/hep/xrootd/xrdproto/auth"
+)
+
+// Default is a ztn security provider using the bearer token discovered
+// from the environment when authenticating. See Token for details.
+var Default auth.Auther = &Auth{}
+
+// Auth implements the ztn security provider.
+type Auth struct {
+	// Token is the bearer token sent to the server.
+	// If empty, the token is discovered from the environment
+	// at each authentication. See the Token function for details.
+	Token string
+}
+
+// Provider implements auth.Auther
+func (*Auth) Provider() string {
+	return "ztn"
+}
+
+// RequiresTLS implements auth.TLSRequirer
+func (*Auth) RequiresTLS() bool { return true }
+
+// Type indicates that ztn authentication protocol is used.
+var Type = [4]byte{'z', 't', 'n', 0}
+
+// MaxTokenSize is the maximal size of a token.
+const MaxTokenSize = 1<<16 - 2
+
+const (
+	version  = 0   // version of the ztn credentials format.
+	sndToken = 'T' // operation code for sending a token.
+	hdrLen   = 10  // length of the credentials header, including the token length.
+)
+
+// Request implements auth.Auther
+func (a *Auth) Request(params []string) (*auth.Request, error) {
+	tok := a.Token
+	if tok == "" {
+		var err error
+		tok, err = Token()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cred, err := Credentials(tok)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Request{Type: Type, Credentials: cred}, nil
+}
+
+// Credentials returns the ztn credentials holding the provided token.
+//
+// The credentials are made of the "ztn\0" protocol identifier,
+// a version byte, an operation byte ('T'), 2 reserved bytes,
+// the big-endian uint16 length of the token (including its trailing NUL)
+// and the NUL-terminated token.
+func Credentials(token string) (string, error) {
+	if token == "" {
+		return "", fmt.Errorf("ztn: empty token")
+	}
+	if len(token) > MaxTokenSize {
+		return "", fmt.Errorf("ztn: token too large (%d > %d)", len(token), MaxTokenSize)
+	}
+	buf := make([]byte, hdrLen, hdrLen+len(token)+1)
+	copy(buf, Type[:])
+	buf[4] = version
+	buf[5] = sndToken
+	binary.BigEndian.PutUint16(buf[8:], uint16(len(token)+1))
+	buf = append(buf, token...)
+	buf = append(buf, 0)
+	return string(buf), nil
+}
+
+// ParseCredentials extracts the token from the provided ztn credentials.
+func ParseCredentials(cred string) (string, error) {
+	if len(cred) < hdrLen {
+		return "", fmt.Errorf("ztn: credentials too short")
+	}
+	if cred[:4] != string(Type[:]) {
+		return "", fmt.Errorf("ztn: invalid credentials protocol %q", cred[:4])
+	}
+	if v := cred[4]; v != version {
+		return "", fmt.Errorf("ztn: unsupported credentials version %d", v)
+	}
+	if op := cred[5]; op != sndToken {
+		return "", fmt.Errorf("ztn: unsupported credentials operation %q", op)
+	}
+	n := int(binary.BigEndian.Uint16([]byte(cred[8:hdrLen])))
+	tok := cred[hdrLen:]
+	if n < 2 || n != len(tok) || tok[n-1] != 0 {
+		return "", fmt.Errorf("ztn: invalid token length")
+	}
+	return tok[:n-1], nil
+}
+
+// Authenticator implements the server side of the ztn security provider.
+type Authenticator struct {
+	// Validate checks the bearer token sent by a client and returns
+	// the name of the user it was issued to.
+	Validate func(token string) (user string, err error)
+}
+
+// Provider implements auth.Authenticator
+func (*Authenticator) Provider() string {
+	return "ztn"
+}
+
+// RequiresTLS implements auth.TLSRequirer
+func (*Authenticator) RequiresTLS() bool { return true }
+
+// Params implements auth.Authenticator
+func (*Authenticator) Params() []string {
+	return []string{strconv.Itoa(version) + ":" + strconv.Itoa(MaxTokenSize) + ":"}
+}
+
+// Authenticate implements auth.Authenticator
+func (a *Authenticator) Authenticate(req *auth.Request) (string, error) {
+	if req.Type != Type {
+		return "", fmt.Errorf("ztn: invalid request type %q", req.Type[:])
+	}
+	tok, err := ParseCredentials(req.Credentials)
+	if err != nil {
+		return "", err
+	}
+	if a.Validate == nil {
+		return "", fmt.Errorf("ztn: no token validator")
+	}
+	user, err := a.Validate(tok)
+	if err != nil {
+		return "", fmt.Errorf("ztn: invalid token: %w", err)
+	}
+	return user, nil
+}
+
+// Token discovers the bearer token to use, following the WLCG bearer token
+// discovery specification. The token is taken from the first of:
+//   - the BEARER_TOKEN environment variable,
+//   - the file named by the BEARER_TOKEN_FILE environment variable,
+//   - the $XDG_RUNTIME_DIR/bt_u<uid> file,
+//   - the /tmp/bt_u<uid> file.
+func Token() (string, error) {
+	if tok := strings.TrimSpace(os.Getenv("BEARER_TOKEN")); tok != "" {
+		return tok, nil
+	}
+
+	var fnames []string
+	if fname := os.Getenv("BEARER_TOKEN_FILE"); fname != "" {
+		fnames = append(fnames, fname)
+	}
+	if uid := os.Getuid(); uid >= 0 {
+		name := "bt_u" + strconv.Itoa(uid)
+		if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+			fnames = append(fnames, filepath.Join(dir, name))
+		}
+		fnames = append(fnames, filepath.Join("/tmp", name))
+	}
+
+	for _, fname := range fnames {
+		raw, err := os.ReadFile(fname)
+		if err != nil {
+			continue
+		}
+		if tok := strings.TrimSpace(string(raw)); tok != "" {
+			return tok, nil
+		}
+	}
+
+	return "", fmt.Errorf("ztn: could not find a bearer token")
+}
+
+var (
+	_ auth.Auther      = (*Auth)(nil)
+	_ auth.TLSRequirer = (*Auth)(nil)
+
+	_ auth.Authenticator = (*Authenticator)(nil)
+	_ auth.TLSRequirer   = (*Authenticator)(nil)
+)
@@ -0,0 +1,192 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ztn_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd/xrdproto/auth/ztn"
+)
+
+func TestAuthZTN(t *testing.T) {
+	zauth := ztn.Auth{Token: "s3cr3t"}
+	if got, want := zauth.Provider(), "ztn"; got != want {
+		t.Fatalf("invalid auth type: got=%q, want=%q", got, want)
+	}
+	if !zauth.RequiresTLS() {
+		t.Fatalf("ztn should require TLS")
+	}
+
+	req, err := zauth.Request(nil)
+	if err != nil {
+		t.Fatalf("got err=%v", err)
+	}
+
+	if req.Type != ztn.Type {
+		t.Fatalf("invalid request type: got=%q, want=%q", req.Type, ztn.Type)
+	}
+
+	want := "ztn\x00\x00T\x00\x00\x00\x07s3cr3t\x00"
+	if req.Credentials != want {
+		t.Fatalf("invalid credentials:\ngot= %q\nwant=%q", req.Credentials, want)
+	}
+
+	tok, err := ztn.ParseCredentials(req.Credentials)
+	if err != nil {
+		t.Fatalf("could not parse credentials: %+v", err)
+	}
+	if tok != zauth.Token {
+		t.Fatalf("invalid token: got=%q, want=%q", tok, zauth.Token)
+	}
+}
+
+func TestAuthenticator(t *testing.T) {
+	srv := ztn.Authenticator{
+		Validate: func(token string) (string, error) {
+			if token != "s3cr3t" {
+				return "", fmt.Errorf("unknown token")
+			}
+			return "bob", nil
+		},
+	}
+	if got, want := srv.Provider(), "ztn"; got != want {
+		t.Fatalf("invalid provider: got=%q, want=%q", got, want)
+	}
+	if got, want := srv.Params(), []string{"0:65534:"}; len(got) != 1 || got[0] != want[0] {
+		t.Fatalf("invalid params: got=%q, want=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		token string
+		user  string
+		err   string
+	}{
+		{token: "s3cr3t", user: "bob"},
+		{token: "invalid", err: "ztn: invalid token: unknown token"},
+	} {
+		t.Run(tc.token, func(t *testing.T) {
+			req, err := (&ztn.Auth{Token: tc.token}).Request(nil)
+			if err != nil {
+				t.Fatalf("could not create request: %+v", err)
+			}
+			user, err := srv.Authenticate(req)
+			switch {
+			case err != nil && tc.err == "":
+				t.Fatalf("could not authenticate: %+v", err)
+			case err != nil && tc.err != "":
+				if got, want := err.Error(), tc.err; got != want {
+					t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+				}
+				return
+			case err == nil && tc.err != "":
+				t.Fatalf("expected an error (%s)", tc.err)
+			}
+			if user != tc.user {
+				t.Fatalf("invalid user: got=%q, want=%q", user, tc.user)
+			}
+		})
+	}
+}
+
+func TestParseCredentialsInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cred string
+	}{
+		{name: "empty", cred: ""},
+		{name: "short", cred: "ztn\x00\x00T"},
+		{name: "protocol", cred: "unix\x00T\x00\x00\x00\x02a\x00"},
+		{name: "version", cred: "ztn\x00\x01T\x00\x00\x00\x02a\x00"},
+		{name: "operation", cred: "ztn\x00\x00X\x00\x00\x00\x02a\x00"},
+		{name: "length", cred: "ztn\x00\x00T\x00\x00\x00\x05a\x00"},
+		{name: "nul", cred: "ztn\x00\x00T\x00\x00\x00\x02ab"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ztn.ParseCredentials(tc.cred)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestCredentialsInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "too-large", token: strings.Repeat("a", ztn.MaxTokenSize+1)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ztn.Credentials(tc.token)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "token")
+	err := os.WriteFile(fname, []byte(" from-file\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("BEARER_TOKEN", "")
+	t.Setenv("BEARER_TOKEN_FILE", "")
+	t.Setenv("XDG_RUNTIME_DIR", dir)
+
+	xdg := filepath.Join(dir, "bt_u"+strconv.Itoa(os.Getuid()))
+	err = os.WriteFile(xdg, []byte("from-xdg\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok, err := ztn.Token()
+	if err != nil {
+		t.Fatalf("could not discover token: %+v", err)
+	}
+	if got, want := tok, "from-xdg"; got != want {
+		t.Fatalf("invalid token: got=%q, want=%q", got, want)
+	}
+
+	t.Setenv("BEARER_TOKEN_FILE", fname)
+	tok, err = ztn.Token()
+	if err != nil {
+		t.Fatalf("could not discover token: %+v", err)
+	}
+	if got, want := tok, "from-file"; got != want {
+		t.Fatalf("invalid token: got=%q, want=%q", got, want)
+	}
+
+	t.Setenv("BEARER_TOKEN", "from-env")
+	tok, err = ztn.Token()
+	if err != nil {
+		t.Fatalf("could not discover token: %+v", err)
+	}
+	if got, want := tok, "from-env"; got != want {
+		t.Fatalf("invalid token: got=%q, want=%q", got, want)
+	}
+
+	req, err := (&ztn.Auth{}).Request(nil)
+	if err != nil {
+		t.Fatalf("could not create request: %+v", err)
+	}
+	tok, err = ztn.ParseCredentials(req.Credentials)
+	if err != nil {
+		t.Fatalf("could not parse credentials: %+v", err)
+	}
+	if got, want := tok, "from-env"; got != want {
+		t.Fatalf("invalid token: got=%q, want=%q", got, want)
+	}
+}
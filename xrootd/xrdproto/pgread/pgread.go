@@ -0,0 +1,165 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pgread contains the structures describing request and response for pgread request.
+// The pgread request reads data from a file, each page of data being protected by a CRC-32C checksum.
+// See xrootd protocol specification v5.0 (kXR_pgread) for details.
+package pgread // import "go-hep.org/x/hep/xrootd/xrdproto/pgread"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+)
+
+// RequestID is the id of the request, it is sent as part of message.
+// See xrootd protocol specification for details: http://xrootd.org/doc/dev45/XRdv310.pdf, 2.3 Client Request Format.
+const RequestID uint16 = 3030
+
+const (
+	// PageSize is the size of a page of data.
+	// Pages are aligned on multiples of PageSize in the file.
+	PageSize = 4096
+
+	// ChecksumSize is the size of the checksum preceding each page of data.
+	ChecksumSize = 4
+
+	// MaxLength is the maximal length of the data of a single pgread request.
+	MaxLength = 2097152
+
+	infoLength = 8 // length of the information of the status response
+)
+
+// Request holds pgread request parameters.
+type Request struct {
+	Handle xrdfs.FileHandle
+	Offset int64
+	Length int32
+}
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Request) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	wBuffer.WriteBytes(o.Handle[:])
+	wBuffer.WriteI64(o.Offset)
+	wBuffer.WriteI32(o.Length)
+	wBuffer.WriteLen(0)
+	return nil
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+func (o *Request) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	rBuffer.ReadBytes(o.Handle[:])
+	o.Offset = rBuffer.ReadI64()
+	o.Length = rBuffer.ReadI32()
+	// skip the optional arguments (path id and flags.)
+	rBuffer.Skip(rBuffer.ReadLen())
+	return nil
+}
+
+// ReqID implements xrdproto.Request.ReqID.
+func (req *Request) ReqID() uint16 { return RequestID }
+
+// ShouldSign implements xrdproto.Request.ShouldSign.
+func (req *Request) ShouldSign() bool { return false }
+
+// Response is the response issued by the server to a pgread request.
+//
+// On the wire, the response is made of one or more status responses,
+// each of them holding the offset of its data and the pages of data,
+// each page being preceded by its checksum.
+// The checksums are computed by MarshalXrd and verified by UnmarshalXrd.
+type Response struct {
+	StreamID xrdproto.StreamID
+	Offset   int64
+	Data     []byte
+}
+
+// RespID implements xrdproto.Response.RespID.
+func (*Response) RespID() uint16 { return RequestID }
+
+// SetStreamID implements xrdproto.StreamIDSetter.
+func (o *Response) SetStreamID(id xrdproto.StreamID) { o.StreamID = id }
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Response) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	info := make([]byte, infoLength)
+	binary.BigEndian.PutUint64(info, uint64(o.Offset))
+
+	resp := xrdproto.StatusResponse{
+		StreamID:  o.StreamID,
+		RequestID: RequestID,
+		Type:      xrdproto.FinalResult,
+		Info:      info,
+		Data:      make([]byte, 0, len(o.Data)+ChecksumSize*(len(o.Data)/PageSize+2)),
+	}
+	for _, page := range Pages(o.Offset, o.Data) {
+		resp.Data = binary.BigEndian.AppendUint32(resp.Data, crc32.Checksum(page, xrdproto.CRC32C))
+		resp.Data = append(resp.Data, page...)
+	}
+	return resp.MarshalXrd(wBuffer)
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+// The pages of all the status responses are concatenated into Data.
+// An error is returned if the checksum of a page does not match its content.
+func (o *Response) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	o.Data = o.Data[:0]
+	first := true
+	for rBuffer.Len() > 0 {
+		var resp xrdproto.StatusResponse
+		err := resp.UnmarshalStatus(rBuffer, infoLength)
+		if err != nil {
+			return err
+		}
+		offset := int64(binary.BigEndian.Uint64(resp.Info))
+		switch {
+		case first:
+			o.StreamID = resp.StreamID
+			o.Offset = offset
+			first = false
+		case offset != o.Offset+int64(len(o.Data)):
+			return fmt.Errorf("xrootd: non-contiguous pgread response (offset=%d, want=%d)", offset, o.Offset+int64(len(o.Data)))
+		}
+
+		data := resp.Data
+		for len(data) > 0 {
+			if len(data) <= ChecksumSize {
+				return fmt.Errorf("xrootd: truncated pgread page at offset %d", offset)
+			}
+			n := min(PageSize-int(offset%PageSize), len(data)-ChecksumSize)
+			var (
+				crc  = binary.BigEndian.Uint32(data)
+				page = data[ChecksumSize : ChecksumSize+n]
+			)
+			if got := crc32.Checksum(page, xrdproto.CRC32C); got != crc {
+				return xrdproto.ServerError{
+					Code:    xrdproto.ChecksumError,
+					Message: fmt.Sprintf("Invalid checksum of page at offset %d (got=0x%08x, want=0x%08x)", offset, got, crc),
+				}
+			}
+			o.Data = append(o.Data, page...)
+			offset += int64(n)
+			data = data[ChecksumSize+n:]
+		}
+	}
+	return nil
+}
+
+// Pages splits data read or written at the provided offset into pages.
+// All the pages are aligned on multiples of PageSize in the file,
+// except possibly the first and the last ones.
+func Pages(offset int64, data []byte) [][]byte {
+	var pages [][]byte
+	for len(data) > 0 {
+		n := min(PageSize-int(offset%PageSize), len(data))
+		pages = append(pages, data[:n])
+		offset += int64(n)
+		data = data[n:]
+	}
+	return pages
+}
@@ -0,0 +1,142 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pgread_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgread"
+)
+
+func TestRequest(t *testing.T) {
+	want := pgread.Request{Handle: [4]byte{1, 2, 3, 4}, Offset: 1 << 40, Length: 42}
+	if want.ReqID() != pgread.RequestID {
+		t.Fatalf("invalid request ID: got=%d want=%d", want.ReqID(), pgread.RequestID)
+	}
+	if want.ShouldSign() {
+		t.Fatalf("invalid")
+	}
+
+	var (
+		w   = new(xrdenc.WBuffer)
+		got pgread.Request
+	)
+	err := want.MarshalXrd(w)
+	if err != nil {
+		t.Fatalf("could not marshal request: %v", err)
+	}
+	err = got.UnmarshalXrd(xrdenc.NewRBuffer(w.Bytes()))
+	if err != nil {
+		t.Fatalf("could not unmarshal request: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip failed:\ngot = %#v\nwant= %#v\n", got, want)
+	}
+}
+
+func TestResponse(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 1000)
+	for _, want := range []pgread.Response{
+		{StreamID: xrdproto.StreamID{1, 2}, Offset: 0, Data: []byte{}},
+		{StreamID: xrdproto.StreamID{1, 2}, Offset: 0, Data: data},
+		{StreamID: xrdproto.StreamID{1, 2}, Offset: 4000, Data: data},
+		{StreamID: xrdproto.StreamID{1, 2}, Offset: 2 * pgread.PageSize, Data: data[:pgread.PageSize]},
+	} {
+		t.Run("", func(t *testing.T) {
+			var (
+				w   = new(xrdenc.WBuffer)
+				got = pgread.Response{Data: []byte{}}
+			)
+			err := want.MarshalXrd(w)
+			if err != nil {
+				t.Fatalf("could not marshal response: %v", err)
+			}
+			err = got.UnmarshalXrd(xrdenc.NewRBuffer(w.Bytes()))
+			if err != nil {
+				t.Fatalf("could not unmarshal response: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("round trip failed:\ngot = %#v\nwant= %#v\n", got, want)
+			}
+
+			if len(want.Data) == 0 {
+				return
+			}
+			raw := w.Bytes()
+			raw[len(raw)-1] ^= 0xff
+			err = got.UnmarshalXrd(xrdenc.NewRBuffer(raw))
+			var serr xrdproto.ServerError
+			if !errors.As(err, &serr) || serr.Code != xrdproto.ChecksumError {
+				t.Fatalf("invalid error: %v", err)
+			}
+		})
+	}
+}
+
+func TestResponseBlocks(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 1000)
+	w := new(xrdenc.WBuffer)
+	for _, resp := range []pgread.Response{
+		{Offset: 10, Data: data[:5000]},
+		{Offset: 5010, Data: data[5000:]},
+	} {
+		err := resp.MarshalXrd(w)
+		if err != nil {
+			t.Fatalf("could not marshal response: %v", err)
+		}
+	}
+
+	var got pgread.Response
+	err := got.UnmarshalXrd(xrdenc.NewRBuffer(w.Bytes()))
+	if err != nil {
+		t.Fatalf("could not unmarshal response: %v", err)
+	}
+	if got.Offset != 10 || !bytes.Equal(got.Data, data) {
+		t.Fatalf("invalid response: offset=%d, len=%d", got.Offset, len(got.Data))
+	}
+
+	w = new(xrdenc.WBuffer)
+	for _, resp := range []pgread.Response{
+		{Offset: 10, Data: data[:5000]},
+		{Offset: 6000, Data: data[5000:]},
+	} {
+		err := resp.MarshalXrd(w)
+		if err != nil {
+			t.Fatalf("could not marshal response: %v", err)
+		}
+	}
+	err = got.UnmarshalXrd(xrdenc.NewRBuffer(w.Bytes()))
+	if err == nil {
+		t.Fatalf("expected an error for non-contiguous blocks")
+	}
+}
+
+func TestPages(t *testing.T) {
+	for _, tc := range []struct {
+		offset int64
+		n      int
+		want   []int
+	}{
+		{0, 0, nil},
+		{0, 10, []int{10}},
+		{0, pgread.PageSize, []int{pgread.PageSize}},
+		{0, pgread.PageSize + 1, []int{pgread.PageSize, 1}},
+		{4000, 200, []int{96, 104}},
+		{4000, 2 * pgread.PageSize, []int{96, pgread.PageSize, pgread.PageSize - 96}},
+	} {
+		var got []int
+		for _, page := range pgread.Pages(tc.offset, make([]byte, tc.n)) {
+			got = append(got, len(page))
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("invalid pages for offset=%d, n=%d: got=%v, want=%v", tc.offset, tc.n, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pgwrite contains the structures describing request and response for pgwrite request.
+// The pgwrite request writes data to a file, each page of data being protected by a CRC-32C checksum.
+// It supersedes the verifyw request and shares its request id.
+// See xrootd protocol specification v5.0 (kXR_pgwrite) for details.
+package pgwrite // import "go-hep.org/x/hep/xrootd/xrdproto/pgwrite"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgread"
+)
+
+// RequestID is the id of the request, it is sent as part of message.
+// See xrootd protocol specification for details: http://xrootd.org/doc/dev45/XRdv310.pdf, 2.3 Client Request Format.
+const RequestID uint16 = 3026
+
+// Flags are the flags of a pgwrite request.
+type Flags uint8
+
+const (
+	// Retry indicates that the request retransmits pages whose checksum was invalid.
+	Retry Flags = 1
+)
+
+const infoLength = 8 // length of the information of the status response
+
+// Request holds pgwrite request parameters.
+//
+// On the wire, each page of data is preceded by its checksum.
+// If Checksums is nil, the checksums are computed from Data by MarshalXrd.
+type Request struct {
+	Handle    xrdfs.FileHandle
+	Offset    int64
+	PathID    xrdproto.PathID
+	Flags     Flags
+	_         [2]uint8
+	Data      []byte
+	Checksums []uint32 // Checksums are the checksums of the pages of Data, as split by pgread.Pages.
+}
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Request) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	pages := pgread.Pages(o.Offset, o.Data)
+	if o.Checksums != nil && len(o.Checksums) != len(pages) {
+		return fmt.Errorf("xrootd: invalid number of pgwrite checksums (got=%d, want=%d)", len(o.Checksums), len(pages))
+	}
+
+	wBuffer.WriteBytes(o.Handle[:])
+	wBuffer.WriteI64(o.Offset)
+	wBuffer.WriteU8(uint8(o.PathID))
+	wBuffer.WriteU8(uint8(o.Flags))
+	wBuffer.Next(2)
+	wBuffer.WriteLen(len(o.Data) + pgread.ChecksumSize*len(pages))
+	for i, page := range pages {
+		var crc uint32
+		switch {
+		case o.Checksums != nil:
+			crc = o.Checksums[i]
+		default:
+			crc = crc32.Checksum(page, xrdproto.CRC32C)
+		}
+		wBuffer.WriteI32(int32(crc))
+		wBuffer.WriteBytes(page)
+	}
+	return nil
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+// The checksums are not verified, see Request.Verify.
+func (o *Request) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	rBuffer.ReadBytes(o.Handle[:])
+	o.Offset = rBuffer.ReadI64()
+	o.PathID = xrdproto.PathID(rBuffer.ReadU8())
+	o.Flags = Flags(rBuffer.ReadU8())
+	rBuffer.Skip(2)
+	n := rBuffer.ReadLen()
+	if n < 0 || n > rBuffer.Len() {
+		return fmt.Errorf("xrootd: invalid pgwrite data length %d", n)
+	}
+
+	o.Data = make([]byte, 0, n)
+	o.Checksums = o.Checksums[:0]
+	offset := o.Offset
+	for n > 0 {
+		if n <= pgread.ChecksumSize {
+			return fmt.Errorf("xrootd: truncated pgwrite page at offset %d", offset)
+		}
+		size := min(pgread.PageSize-int(offset%pgread.PageSize), n-pgread.ChecksumSize)
+		o.Checksums = append(o.Checksums, uint32(rBuffer.ReadI32()))
+		o.Data = append(o.Data, rBuffer.Bytes()[:size]...)
+		rBuffer.Skip(size)
+		offset += int64(size)
+		n -= pgread.ChecksumSize + size
+	}
+	return nil
+}
+
+// Verify returns the offsets of the pages whose checksum does not match their content.
+func (o *Request) Verify() []int64 {
+	var (
+		bad    []int64
+		offset = o.Offset
+	)
+	for i, page := range pgread.Pages(o.Offset, o.Data) {
+		if i >= len(o.Checksums) || crc32.Checksum(page, xrdproto.CRC32C) != o.Checksums[i] {
+			bad = append(bad, offset)
+		}
+		offset += int64(len(page))
+	}
+	return bad
+}
+
+// ReqID implements xrdproto.Request.ReqID.
+func (req *Request) ReqID() uint16 { return RequestID }
+
+// ShouldSign implements xrdproto.Request.ShouldSign.
+func (req *Request) ShouldSign() bool { return false }
+
+// Response is the response issued by the server to a pgwrite request.
+type Response struct {
+	StreamID xrdproto.StreamID
+	Offset   int64 // Offset is the offset of the data written by the request.
+}
+
+// RespID implements xrdproto.Response.RespID.
+func (*Response) RespID() uint16 { return RequestID }
+
+// SetStreamID implements xrdproto.StreamIDSetter.
+func (o *Response) SetStreamID(id xrdproto.StreamID) { o.StreamID = id }
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Response) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	info := make([]byte, infoLength)
+	binary.BigEndian.PutUint64(info, uint64(o.Offset))
+	resp := xrdproto.StatusResponse{
+		StreamID:  o.StreamID,
+		RequestID: RequestID,
+		Type:      xrdproto.FinalResult,
+		Info:      info,
+	}
+	return resp.MarshalXrd(wBuffer)
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+func (o *Response) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	var resp xrdproto.StatusResponse
+	err := resp.UnmarshalStatus(rBuffer, infoLength)
+	if err != nil {
+		return err
+	}
+	o.StreamID = resp.StreamID
+	o.Offset = int64(binary.BigEndian.Uint64(resp.Info))
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pgwrite_test
+
+import (
+	"bytes"
+	"hash/crc32"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgwrite"
+)
+
+func TestRequest(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 1000)
+	for _, tc := range []struct {
+		req pgwrite.Request
+		bad []int64
+	}{
+		{
+			req: pgwrite.Request{Handle: [4]byte{1, 2, 3, 4}, Offset: 0, Data: []byte{}},
+		},
+		{
+			req: pgwrite.Request{Handle: [4]byte{1, 2, 3, 4}, Offset: 4000, PathID: 2, Data: data},
+		},
+		{
+			req: pgwrite.Request{
+				Handle: [4]byte{1, 2, 3, 4}, Offset: 4000, Flags: pgwrite.Retry, Data: data,
+				Checksums: []uint32{
+					crc32.Checksum(data[:96], xrdproto.CRC32C),
+					0xdeadbeef,
+					crc32.Checksum(data[96+4096:96+2*4096], xrdproto.CRC32C),
+					crc32.Checksum(data[96+2*4096:], xrdproto.CRC32C),
+				},
+			},
+			bad: []int64{4096},
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			want := tc.req
+			if want.ReqID() != pgwrite.RequestID {
+				t.Fatalf("invalid request ID: got=%d want=%d", want.ReqID(), pgwrite.RequestID)
+			}
+			if want.ShouldSign() {
+				t.Fatalf("invalid")
+			}
+
+			var (
+				w   = new(xrdenc.WBuffer)
+				got pgwrite.Request
+			)
+			err := want.MarshalXrd(w)
+			if err != nil {
+				t.Fatalf("could not marshal request: %v", err)
+			}
+			err = got.UnmarshalXrd(xrdenc.NewRBuffer(w.Bytes()))
+			if err != nil {
+				t.Fatalf("could not unmarshal request: %v", err)
+			}
+
+			if got.Handle != want.Handle || got.Offset != want.Offset ||
+				got.PathID != want.PathID || got.Flags != want.Flags ||
+				!bytes.Equal(got.Data, want.Data) {
+				t.Fatalf("round trip failed:\ngot = %#v\nwant= %#v\n", got, want)
+			}
+			if got, want := got.Verify(), tc.bad; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid pages: got=%v, want=%v", got, want)
+			}
+		})
+	}
+
+	err := (pgwrite.Request{Data: data, Checksums: []uint32{1}}).MarshalXrd(new(xrdenc.WBuffer))
+	if err == nil {
+		t.Fatalf("expected an error for an invalid number of checksums")
+	}
+}
+
+func TestResponse(t *testing.T) {
+	want := pgwrite.Response{StreamID: xrdproto.StreamID{1, 2}, Offset: 1 << 40}
+	var (
+		w   = new(xrdenc.WBuffer)
+		got pgwrite.Response
+	)
+	err := want.MarshalXrd(w)
+	if err != nil {
+		t.Fatalf("could not marshal response: %v", err)
+	}
+	err = got.UnmarshalXrd(xrdenc.NewRBuffer(w.Bytes()))
+	if err != nil {
+		t.Fatalf("could not unmarshal response: %v", err)
+	}
+	if got != want {
+		t.Fatalf("round trip failed:\ngot = %#v\nwant= %#v\n", got, want)
+	}
+}
@@ -26,6 +26,9 @@ import (
 // See xrootd protocol specification for details: http://xrootd.org/doc/dev45/XRdv310.pdf, 2.3 Client Request Format.
 const RequestID uint16 = 3006
 
+// TLSVersion is the first version of the protocol supporting TLS.
+const TLSVersion int32 = 0x500
+
 // Flags are the Flags that define xrootd server type. See xrootd protocol specification for further info.
 type Flags int32
 
@@ -35,6 +38,11 @@ const (
 	IsMeta       Flags = 0x00000100 // IsMeta indicates whether this server has meta attribute.
 	IsProxy      Flags = 0x00000200 // IsProxy indicates whether this server has proxy attribute.
 	IsSupervisor Flags = 0x00000400 // IsSupervisor indicates whether this server has supervisor attribute.
+	TLSData      Flags = 0x01000000 // TLSData indicates that the server requires TLS for data connections.
+	TLSLogin     Flags = 0x04000000 // TLSLogin indicates that the server requires TLS for login.
+	TLSSession   Flags = 0x08000000 // TLSSession indicates that the server requires TLS after login.
+	GotoTLS      Flags = 0x40000000 // GotoTLS indicates that the connection must be upgraded to TLS after the response.
+	HaveTLS      Flags = -1 << 31   // HaveTLS indicates that the server supports TLS (0x80000000).
 )
 
 // SecurityOptions are the security-related options.
@@ -55,14 +63,29 @@ const (
 	RequestOptionsNone RequestOptions = 0
 	// ReturnSecurityRequirements specifies that security requirements should be returned
 	// if that's supported by the server.
-	ReturnSecurityRequirements RequestOptions = 1
+	ReturnSecurityRequirements RequestOptions = 0x01
+	// AbleTLS specifies that the client is able to upgrade the connection to TLS.
+	AbleTLS RequestOptions = 0x02
+	// WantTLS specifies that the client wants the connection to be upgraded to TLS.
+	WantTLS RequestOptions = 0x04
+)
+
+// Expect specifies which request the client is going to send after the protocol request.
+// The server uses it to decide whether the connection must be upgraded to TLS.
+type Expect byte
+
+const (
+	ExpectNone  Expect = 0x00 // ExpectNone indicates that no specific request is expected.
+	ExpectBind  Expect = 0x01 // ExpectBind indicates that a bind request is expected.
+	ExpectLogin Expect = 0x03 // ExpectLogin indicates that a login request is expected.
 )
 
 // Request holds protocol request parameters.
 type Request struct {
 	ClientProtocolVersion int32
 	Options               RequestOptions
-	_                     [11]byte
+	Expect                Expect
+	_                     [10]byte
 	_                     int32
 }
 
@@ -82,7 +105,8 @@ func (req *Request) ReqID() uint16 { return RequestID }
 func (o Request) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
 	wBuffer.WriteI32(o.ClientProtocolVersion)
 	wBuffer.WriteU8(byte(o.Options))
-	wBuffer.Next(15)
+	wBuffer.WriteU8(byte(o.Expect))
+	wBuffer.Next(14)
 	return nil
 }
 
@@ -90,7 +114,8 @@ func (o Request) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
 func (o *Request) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
 	o.ClientProtocolVersion = rBuffer.ReadI32()
 	o.Options = RequestOptions(rBuffer.ReadU8())
-	rBuffer.Skip(15)
+	o.Expect = Expect(rBuffer.ReadU8())
+	rBuffer.Skip(14)
 	return nil
 }
 
@@ -132,6 +157,17 @@ func (resp *Response) IsSupervisor() bool {
 	return resp.Flags&IsSupervisor != 0
 }
 
+// HaveTLS indicates whether the server supports TLS.
+func (resp *Response) HaveTLS() bool {
+	return resp.Flags&HaveTLS != 0
+}
+
+// GotoTLS indicates whether the connection must be upgraded to TLS
+// right after this response.
+func (resp *Response) GotoTLS() bool {
+	return resp.Flags&GotoTLS != 0
+}
+
 // ForceSecurity indicates whether signing is required even if the authentication
 // protocol does not support generic encryption.
 func (resp *Response) ForceSecurity() bool {
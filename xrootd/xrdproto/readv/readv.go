@@ -0,0 +1,159 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package readv contains the structures describing request and response for readv request.
+// See xrootd protocol specification (http://xrootd.org/doc/dev45/XRdv310.pdf, kXR_readv) for details.
+package readv // import "go-hep.org/x/hep/xrootd/xrdproto/readv"
+
+import (
+	"fmt"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+)
+
+// RequestID is the id of the request, it is sent as part of message.
+// See xrootd protocol specification for details: http://xrootd.org/doc/dev45/XRdv310.pdf, 2.3 Client Request Format.
+const RequestID uint16 = 3025
+
+const (
+	// MaxSegments is the maximal number of segments of a single readv request.
+	MaxSegments = 1024
+
+	// MaxSegmentLength is the maximal length of a single segment of a readv request.
+	MaxSegmentLength = 2097136
+
+	segmentLength = 16 // length of an encoded segment
+)
+
+// Segment describes a chunk of data to be read.
+type Segment struct {
+	Handle xrdfs.FileHandle
+	Length int32
+	Offset int64
+}
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Segment) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	wBuffer.WriteBytes(o.Handle[:])
+	wBuffer.WriteI32(o.Length)
+	wBuffer.WriteI64(o.Offset)
+	return nil
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+func (o *Segment) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	rBuffer.ReadBytes(o.Handle[:])
+	o.Length = rBuffer.ReadI32()
+	o.Offset = rBuffer.ReadI64()
+	return nil
+}
+
+// Request holds readv request parameters.
+type Request struct {
+	// PathID is the path id returned by bind request.
+	// The response data is sent to this path, if possible.
+	PathID   xrdproto.PathID
+	Segments []Segment
+}
+
+// ReqID implements xrdproto.Request.ReqID.
+func (req *Request) ReqID() uint16 { return RequestID }
+
+// ShouldSign implements xrdproto.Request.ShouldSign.
+func (req *Request) ShouldSign() bool { return false }
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Request) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	wBuffer.Next(15)
+	wBuffer.WriteU8(uint8(o.PathID))
+	wBuffer.WriteLen(len(o.Segments) * segmentLength)
+	for _, seg := range o.Segments {
+		err := seg.MarshalXrd(wBuffer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+func (o *Request) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	rBuffer.Skip(15)
+	o.PathID = xrdproto.PathID(rBuffer.ReadU8())
+	alen := rBuffer.ReadLen()
+	if alen%segmentLength != 0 || alen > rBuffer.Len() {
+		return fmt.Errorf("xrootd: invalid readv list length %d", alen)
+	}
+	o.Segments = make([]Segment, alen/segmentLength)
+	for i := range o.Segments {
+		err := o.Segments[i].UnmarshalXrd(rBuffer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Chunk is the data read for a segment of a readv request.
+type Chunk struct {
+	Handle xrdfs.FileHandle
+	Offset int64
+	Data   []byte
+}
+
+// Response is a response for the readv request, which contains the read data
+// of each segment of the request, in the order of the request.
+type Response struct {
+	Chunks []Chunk
+}
+
+// RespID implements xrdproto.Response.RespID.
+func (resp *Response) RespID() uint16 { return RequestID }
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Response) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	for _, chunk := range o.Chunks {
+		seg := Segment{Handle: chunk.Handle, Length: int32(len(chunk.Data)), Offset: chunk.Offset}
+		err := seg.MarshalXrd(wBuffer)
+		if err != nil {
+			return err
+		}
+		wBuffer.WriteBytes(chunk.Data)
+	}
+	return nil
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+// The data of the chunks refers to the underlying buffer of rBuffer.
+func (o *Response) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	o.Chunks = o.Chunks[:0]
+	for rBuffer.Len() > 0 {
+		if rBuffer.Len() < segmentLength {
+			return fmt.Errorf("xrootd: truncated readv response header")
+		}
+		var seg Segment
+		err := seg.UnmarshalXrd(rBuffer)
+		if err != nil {
+			return err
+		}
+		n := int(seg.Length)
+		if n < 0 || n > rBuffer.Len() {
+			return fmt.Errorf("xrootd: invalid readv response chunk length %d", seg.Length)
+		}
+		o.Chunks = append(o.Chunks, Chunk{
+			Handle: seg.Handle,
+			Offset: seg.Offset,
+			Data:   rBuffer.Bytes()[:n:n],
+		})
+		rBuffer.Skip(n)
+	}
+	return nil
+}
+
+var (
+	_ xrdproto.Request  = (*Request)(nil)
+	_ xrdproto.Response = (*Response)(nil)
+)
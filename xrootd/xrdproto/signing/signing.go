@@ -14,7 +14,10 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto/mkdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/mv"
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgread"
+	"go-hep.org/x/hep/xrootd/xrdproto/pgwrite"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -71,13 +74,16 @@ func New(level xrdproto.SecurityLevel, overrides []xrdproto.SecurityOverride) Re
 	if level >= xrdproto.Intense {
 		// TODO: set requirements
 		sr.requirements[xrdclose.RequestID] = xrdproto.SignNeeded
+		sr.requirements[pgwrite.RequestID] = xrdproto.SignNeeded
 		sr.requirements[verifyw.RequestID] = xrdproto.SignNeeded
 		sr.requirements[write.RequestID] = xrdproto.SignNeeded
 	}
 	if level >= xrdproto.Pedantic {
 		// TODO: set requirements
 		sr.requirements[dirlist.RequestID] = xrdproto.SignNeeded
+		sr.requirements[pgread.RequestID] = xrdproto.SignNeeded
 		sr.requirements[read.RequestID] = xrdproto.SignNeeded
+		sr.requirements[readv.RequestID] = xrdproto.SignNeeded
 		sr.requirements[stat.RequestID] = xrdproto.SignNeeded
 		sr.requirements[statx.RequestID] = xrdproto.SignNeeded
 		sr.requirements[sync.RequestID] = xrdproto.SignNeeded
@@ -0,0 +1,109 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdproto // import "go-hep.org/x/hep/xrootd/xrdproto"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+)
+
+// StatusResponseType is the type of a StatusResponse.
+type StatusResponseType uint8
+
+const (
+	FinalResult   StatusResponseType = 0 // FinalResult indicates that no additional responses will be forthcoming.
+	PartialResult StatusResponseType = 1 // PartialResult indicates that additional responses will follow on the same stream.
+	ProgressInfo  StatusResponseType = 2 // ProgressInfo indicates that the response only reports the progress of the request.
+)
+
+const (
+	// StatusResponseLength is the length of the fixed part of a StatusResponse.
+	StatusResponseLength = 16
+
+	// firstRequestID is the smallest request id, the request id of a StatusResponse is relative to it.
+	firstRequestID = 3000
+)
+
+// CRC32C is the table of the CRC-32C (Castagnoli) checksum used by the protocol.
+var CRC32C = crc32.MakeTable(crc32.Castagnoli)
+
+// StatusResponse is the body of a response with the Status status.
+// It holds request-specific information, protected by a CRC-32C checksum,
+// followed by request-specific data.
+// See xrootd protocol specification v5.0 (kXR_status) for details.
+type StatusResponse struct {
+	StreamID  StreamID           // StreamID is the stream id of the request.
+	RequestID uint16             // RequestID is the id of the request.
+	Type      StatusResponseType // Type is the type of the response.
+	Info      []byte             // Info holds request-specific information.
+	Data      []byte             // Data holds request-specific data.
+}
+
+// StreamIDSetter is implemented by the responses that embed the stream id of the request.
+// WriteResponse calls SetStreamID before marshaling such a response.
+type StreamIDSetter interface {
+	SetStreamID(id StreamID)
+}
+
+// SetStreamID implements StreamIDSetter.
+func (o *StatusResponse) SetStreamID(id StreamID) { o.StreamID = id }
+
+// MarshalXrd implements Marshaler.
+func (o StatusResponse) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	var hdr xrdenc.WBuffer
+	hdr.WriteBytes(o.StreamID[:])
+	hdr.WriteU8(uint8(o.RequestID - firstRequestID))
+	hdr.WriteU8(uint8(o.Type))
+	hdr.Next(4)
+	hdr.WriteLen(len(o.Data))
+	hdr.WriteBytes(o.Info)
+
+	wBuffer.WriteI32(int32(crc32.Checksum(hdr.Bytes(), CRC32C)))
+	wBuffer.WriteBytes(hdr.Bytes())
+	wBuffer.WriteBytes(o.Data)
+	return nil
+}
+
+// UnmarshalXrd implements Unmarshaler.
+// All the bytes between the fixed part of the response and its data are considered as information.
+func (o *StatusResponse) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	if rBuffer.Len() < StatusResponseLength {
+		return fmt.Errorf("xrootd: status response too short (len=%d)", rBuffer.Len())
+	}
+	n := int(int32(binary.BigEndian.Uint32(rBuffer.Bytes()[12:])))
+	return o.UnmarshalStatus(rBuffer, rBuffer.Len()-StatusResponseLength-n)
+}
+
+// UnmarshalStatus decodes a StatusResponse holding infoLen bytes of information.
+// The checksum of the response is verified.
+// Data references the content of rBuffer.
+func (o *StatusResponse) UnmarshalStatus(rBuffer *xrdenc.RBuffer, infoLen int) error {
+	if infoLen < 0 || rBuffer.Len() < StatusResponseLength+infoLen {
+		return fmt.Errorf("xrootd: status response too short (len=%d)", rBuffer.Len())
+	}
+	var (
+		raw = rBuffer.Bytes()[4 : StatusResponseLength+infoLen]
+		crc = uint32(rBuffer.ReadI32())
+	)
+	if got := crc32.Checksum(raw, CRC32C); got != crc {
+		return fmt.Errorf("xrootd: invalid status response checksum (got=0x%08x, want=0x%08x)", got, crc)
+	}
+	rBuffer.ReadBytes(o.StreamID[:])
+	o.RequestID = uint16(rBuffer.ReadU8()) + firstRequestID
+	o.Type = StatusResponseType(rBuffer.ReadU8())
+	rBuffer.Skip(4)
+	n := int(rBuffer.ReadI32())
+	o.Info = make([]byte, infoLen)
+	rBuffer.ReadBytes(o.Info)
+	if n < 0 || n > rBuffer.Len() {
+		return fmt.Errorf("xrootd: invalid status response data length %d", n)
+	}
+	o.Data = rBuffer.Bytes()[:n]
+	rBuffer.Skip(n)
+	return nil
+}
@@ -71,6 +71,8 @@ const (
 	Redirect ResponseStatus = 4004
 	// Wait indicates that the client must wait the indicated number of seconds and retry the request.
 	Wait ResponseStatus = 4005
+	// Status indicates that the response body is a StatusResponse, followed by request-specific data.
+	Status ResponseStatus = 4007
 )
 
 // WaitResponse is the response indicating that the client must wait and retry the request.
@@ -91,6 +93,47 @@ func (o *WaitResponse) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
 	return nil
 }
 
+// RedirectResponse is the response indicating that the client must re-issue the request to another server.
+// See http://xrootd.org/doc/dev45/XRdv310.pdf, p. 33 for details.
+type RedirectResponse struct {
+	Host   string // Host is the name of the server to which the client must connect.
+	Port   int32  // Port is the port of the server to which the client must connect.
+	Opaque string // Opaque is the data that must be added to the file name of the re-issued request.
+	Token  string // Token is the data that must be sent to the new server as part of the login request.
+}
+
+// MarshalXrd implements Marshaler.
+func (o RedirectResponse) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	wBuffer.WriteI32(o.Port)
+	url := o.Host
+	if o.Opaque != "" || o.Token != "" {
+		url += "?" + o.Opaque
+	}
+	if o.Token != "" {
+		url += "?" + o.Token
+	}
+	wBuffer.WriteBytes([]byte(url))
+	return nil
+}
+
+// UnmarshalXrd implements Unmarshaler.
+func (o *RedirectResponse) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	o.Port = rBuffer.ReadI32()
+	url := make([]byte, rBuffer.Len())
+	rBuffer.ReadBytes(url)
+	parts := strings.SplitN(string(url), "?", 3)
+	o.Host = parts[0]
+	o.Opaque = ""
+	o.Token = ""
+	if len(parts) > 1 {
+		o.Opaque = parts[1]
+	}
+	if len(parts) > 2 {
+		o.Token = parts[2]
+	}
+	return nil
+}
+
 // ServerError is the error returned by the XRootD server as part of response to the request.
 type ServerError struct {
 	Code    ServerErrorCode
@@ -105,6 +148,8 @@ const (
 	IOError        ServerErrorCode = 3007 // IOError indicates that an IO error has occurred on the server side.
 	NotAuthorized  ServerErrorCode = 3010 // NotAuthorized indicates that user was not authorized for operation.
 	NotFound       ServerErrorCode = 3011 // NotFound indicates that path was not found on the remote server.
+	Unsupported    ServerErrorCode = 3013 // Unsupported indicates that the request is not supported by the server.
+	ChecksumError  ServerErrorCode = 3019 // ChecksumError indicates that the checksum of the transferred data is invalid.
 )
 
 func (err ServerError) Error() string {
@@ -356,6 +401,10 @@ func ReadRequest(r io.Reader) ([]byte, error) {
 // WriteResponse writes all data to the w as single Write call, so no
 // serialization is required.
 func WriteResponse(w io.Writer, streamID StreamID, status ResponseStatus, resp Marshaler) error {
+	if s, ok := resp.(StreamIDSetter); ok {
+		s.SetStreamID(streamID)
+	}
+
 	var respWBuffer xrdenc.WBuffer
 	if resp != nil {
 		if err := resp.MarshalXrd(&respWBuffer); err != nil {
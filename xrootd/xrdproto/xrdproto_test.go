@@ -194,6 +194,38 @@ func TestWaitResponse(t *testing.T) {
 	}
 }
 
+func TestRedirectResponse(t *testing.T) {
+	for _, want := range []RedirectResponse{
+		{Host: "localhost", Port: 1094},
+		{Host: "example.org", Port: 1095, Opaque: "foo=bar"},
+		{Host: "example.org", Port: 1095, Token: "s3cr3t"},
+		{Host: "example.org", Port: 1095, Opaque: "foo=bar&v=1", Token: "s3cr3t"},
+	} {
+		t.Run("", func(t *testing.T) {
+			var (
+				err error
+				w   = new(xrdenc.WBuffer)
+				got RedirectResponse
+			)
+
+			err = want.MarshalXrd(w)
+			if err != nil {
+				t.Fatalf("could not marshal response: %v", err)
+			}
+
+			r := xrdenc.NewRBuffer(w.Bytes())
+			err = got.UnmarshalXrd(r)
+			if err != nil {
+				t.Fatalf("could not unmarshal response: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("round trip failed\ngot = %#v\nwant= %#v\n", got, want)
+			}
+		})
+	}
+}
+
 func TestServerError(t *testing.T) {
 	for _, want := range []ServerError{
 		{Code: IOError, Message: ""},
@@ -387,3 +419,41 @@ func TestSetOpaque(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusResponse(t *testing.T) {
+	for _, want := range []StatusResponse{
+		{StreamID: StreamID{1, 2}, RequestID: 3030, Type: FinalResult, Info: []byte{}, Data: []byte{}},
+		{StreamID: StreamID{1, 2}, RequestID: 3030, Type: PartialResult, Info: []byte{0, 0, 0, 0, 0, 0, 0, 42}, Data: []byte("data")},
+		{StreamID: StreamID{3, 4}, RequestID: 3026, Type: FinalResult, Info: []byte{0, 0, 0, 0, 0, 0, 0, 42}, Data: []byte{}},
+	} {
+		t.Run("", func(t *testing.T) {
+			var (
+				err error
+				w   = new(xrdenc.WBuffer)
+				got StatusResponse
+			)
+
+			err = want.MarshalXrd(w)
+			if err != nil {
+				t.Fatalf("could not marshal response: %v", err)
+			}
+
+			r := xrdenc.NewRBuffer(w.Bytes())
+			err = got.UnmarshalXrd(r)
+			if err != nil {
+				t.Fatalf("could not unmarshal response: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("round trip failed\ngot = %#v\nwant= %#v\n", got, want)
+			}
+
+			raw := w.Bytes()
+			raw[6] ^= 0xff
+			err = got.UnmarshalXrd(xrdenc.NewRBuffer(raw))
+			if err == nil {
+				t.Fatalf("expected a checksum error")
+			}
+		})
+	}
+}
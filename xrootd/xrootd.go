@@ -23,8 +23,28 @@
 //		// handle error
 //	}
 //
+// Connections are upgraded to TLS when the server requires it, or when
+// the WithTLS option is provided (as for roots:// URLs).
+//
 // The NewServer function creates a server:
 //
 //	srv := xrootd.NewServer(xrootd.Default(), nil)
 //	err := srv.Serve(listener)
+//
+// The WithServerTLS and WithTokenAuth options serve data over TLS
+// and require clients to authenticate with a bearer token.
+// More security providers may be configured with WithServerAuth,
+// and the access of authenticated users to paths restricted with
+// WithAuthorizer (see Policy).
+//
+// A Redirector handler redirects clients to the data servers holding
+// the requested files:
+//
+//	redir := xrootd.NewRedirector([]string{"host1:1095", "host2:1095"})
+//	defer redir.Disconnect()
+//	srv := xrootd.NewServer(redir, nil)
+//
+// The checksum of a remote file is computed by the server with
+// xrdfs.FileSystem.Checksum, and data may be transferred with per-page
+// CRC-32C verification with xrdfs.File.PgReadAt and xrdfs.File.PgWriteAt.
 package xrootd // import "go-hep.org/x/hep/xrootd"